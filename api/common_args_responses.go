@@ -53,6 +53,14 @@ type JSONFromAddrs struct {
 	From []string `json:"from"`
 }
 
+// JSONMemo is the memo of a transaction
+type JSONMemo struct {
+	Memo string `json:"memo"`
+	// If provided, the memo is encrypted to this CB58 encoded secp256k1 public
+	// key, which must belong to a recipient of the transaction
+	MemoPublicKey string `json:"memoPublicKey"`
+}
+
 // JSONSpendHeader is 3 arguments to a method that spends (including those with tx fees)
 // 1) The username/password
 // 2) The addresses used in the method
@@ -114,3 +122,39 @@ type GetUTXOsReply struct {
 	// Encoding specifies the encoding format the UTXOs are returned in
 	Encoding formatting.Encoding `json:"encoding"`
 }
+
+// The verification steps reported by SimulateTxReply
+const (
+	SignaturesCheck = "signatures"
+	SyntacticCheck  = "syntactic"
+	SemanticCheck   = "semantic"
+)
+
+// SimulatedUTXO is a UTXO consumed or produced by a simulated transaction
+type SimulatedUTXO struct {
+	UTXOID  string      `json:"utxoID"`
+	AssetID ids.ID      `json:"assetID"`
+	Amount  json.Uint64 `json:"amount"`
+}
+
+// BurnedAsset is the amount of an asset burned by a simulated transaction
+type BurnedAsset struct {
+	AssetID ids.ID      `json:"assetID"`
+	Amount  json.Uint64 `json:"amount"`
+}
+
+// SimulateTxReply is the result of verifying a transaction against the current
+// preferred state, without issuing it
+type SimulateTxReply struct {
+	TxID ids.ID `json:"txID"`
+	// True if the transaction passed every check
+	Valid bool `json:"valid"`
+	// The check that the transaction failed, if any, and why it failed
+	FailedCheck string `json:"failedCheck,omitempty"`
+	Error       string `json:"error,omitempty"`
+	// The UTXOs the transaction consumes and produces
+	Consumed []SimulatedUTXO `json:"consumed"`
+	Produced []SimulatedUTXO `json:"produced"`
+	// The amounts of the assets the transaction burns, including the fee
+	Burned []BurnedAsset `json:"burned"`
+}
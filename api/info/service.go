@@ -212,7 +212,10 @@ type GetTxFeeResponse struct {
 	TxFee         json.Uint64 `json:"txFee"`
 }
 
-// GetTxFee returns the transaction fee in nDJTX.
+// GetTxFee returns the transaction fee in nDJTX. The other assets the X-chain
+// accepts to pay fees aren't reported here, as they are part of the X-chain's
+// upgrade config and depend on its chain time. They are reported by the
+// X-chain's avm.getFees.
 func (service *Info) GetTxFee(_ *http.Request, args *struct{}, reply *GetTxFeeResponse) error {
 	reply.CreationTxFee = json.Uint64(service.creationTxFee)
 	reply.TxFee = json.Uint64(service.txFee)
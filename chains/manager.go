@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/state"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -131,9 +132,10 @@ type ManagerConfig struct {
 	DecisionEvents            *triggers.EventDispatcher
 	ConsensusEvents           *triggers.EventDispatcher
 	DBManager                 dbManager.Manager
-	Router                    router.Router    // Routes incoming messages to the appropriate chain
-	Net                       network.Network  // Sends consensus messages to other validators
-	ConsensusParams           avcon.Parameters // The consensus parameters (alpha, beta, etc.) for new chains
+	Router                    router.Router         // Routes incoming messages to the appropriate chain
+	Net                       network.Network       // Sends consensus messages to other validators
+	ConsensusParams           avcon.Parameters      // The consensus parameters (alpha, beta, etc.) for new chains
+	SnowmanEarlyTermination   poll.EarlyTermination // Strategy used to terminate polls of Snowman chains early
 	EpochFirstTransition      time.Time
 	EpochDuration             time.Duration
 	Validators                validators.Manager // Validators validating on this chain
@@ -168,6 +170,10 @@ type ManagerConfig struct {
 	// This node will only consider the first [MultiputMaxContainersReceived]
 	// containers in a multiput it receives.
 	BootstrapMultiputMaxContainersReceived int
+	// Shares message handling time between the chains. May be nil.
+	Scheduler *router.Scheduler
+	// alias -> scheduling weight of the chain
+	ChainSchedulerWeights map[string]uint64
 }
 
 type manager struct {
@@ -326,6 +332,18 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 		return nil, fmt.Errorf("error while creating chain's log %w", err)
 	}
 
+	// The validators of this blockchain
+	var vdrs validators.Set // Validators validating this blockchain
+	var ok bool
+	if m.StakingEnabled {
+		vdrs, ok = m.Validators.GetValidators(chainParams.SubnetID)
+	} else { // Staking is disabled. Every peer validates every subnet.
+		vdrs, ok = m.Validators.GetValidators(constants.PrimaryNetworkID)
+	}
+	if !ok {
+		return nil, fmt.Errorf("couldn't get validator set of subnet with ID %s. The subnet may not exist", chainParams.SubnetID)
+	}
+
 	ctx := &snow.Context{
 		NetworkID:            m.NetworkID,
 		SubnetID:             chainParams.SubnetID,
@@ -344,6 +362,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 		Metrics:              m.ConsensusParams.Metrics,
 		EpochFirstTransition: m.EpochFirstTransition,
 		EpochDuration:        m.EpochDuration,
+		Validators:           vdrs,
 	}
 
 	// Get a factory for the vm we want to use on our chain
@@ -387,16 +406,8 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 	consensusParams := m.ConsensusParams
 	consensusParams.Namespace = fmt.Sprintf("%s_%s", constants.PlatformName, primaryAlias)
 
-	// The validators of this blockchain
-	var vdrs validators.Set // Validators validating this blockchain
-	var ok bool
-	if m.StakingEnabled {
-		vdrs, ok = m.Validators.GetValidators(chainParams.SubnetID)
-	} else { // Staking is disabled. Every peer validates every subnet.
-		vdrs, ok = m.Validators.GetValidators(constants.PrimaryNetworkID)
-	}
-	if !ok {
-		return nil, fmt.Errorf("couldn't get validator set of subnet with ID %s. The subnet may not exist", chainParams.SubnetID)
+	if m.Scheduler != nil {
+		m.Scheduler.SetWeight(chainParams.ID, m.getChainSchedulerWeight(chainParams.ID))
 	}
 
 	beacons := vdrs
@@ -406,6 +417,11 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 
 	bootstrapWeight := beacons.Weight()
 
+	vm, err = linearize(vm)
+	if err != nil {
+		return nil, err
+	}
+
 	var chain *chain
 	switch vm := vm.(type) {
 	case vertex.DAGVM:
@@ -522,6 +538,11 @@ func (m *manager) createAvalancheChain(
 		return nil, fmt.Errorf("couldn't initialize sender: %w", err)
 	}
 
+	// Allow the VM to gossip application level messages
+	if appVM, ok := vm.(common.AppVM); ok {
+		appVM.SetAppSender(&sender)
+	}
+
 	sampleK := consensusParams.K
 	if uint64(sampleK) > bootstrapWeight {
 		sampleK = int(bootstrapWeight)
@@ -586,6 +607,7 @@ func (m *manager) createAvalancheChain(
 		engine,
 		validators,
 		msgChan,
+		m.Scheduler,
 		fmt.Sprintf("%s_handler", consensusParams.Namespace),
 		consensusParams.Metrics,
 	)
@@ -656,6 +678,11 @@ func (m *manager) createSnowmanChain(
 		return nil, fmt.Errorf("couldn't initialize sender: %w", err)
 	}
 
+	// Allow the VM to gossip application level messages
+	if appVM, ok := vm.(common.AppVM); ok {
+		appVM.SetAppSender(&sender)
+	}
+
 	sampleK := consensusParams.K
 	if uint64(sampleK) > bootstrapWeight {
 		sampleK = int(bootstrapWeight)
@@ -693,8 +720,9 @@ func (m *manager) createSnowmanChain(
 			VM:           vm,
 			Bootstrapped: m.unblockChains,
 		},
-		Params:    consensusParams,
-		Consensus: &smcon.Topological{},
+		Params:           consensusParams,
+		Consensus:        &smcon.Topological{},
+		EarlyTermination: m.SnowmanEarlyTermination,
 	}); err != nil {
 		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
 	}
@@ -703,6 +731,7 @@ func (m *manager) createSnowmanChain(
 		engine,
 		validators,
 		msgChan,
+		m.Scheduler,
 		fmt.Sprintf("%s_handler", consensusParams.Namespace),
 		consensusParams.Metrics,
 	)
@@ -785,6 +814,24 @@ func (m *manager) isChainWithAlias(aliases ...string) (string, bool) {
 	return "", false
 }
 
+// linearize returns the ChainVM that runs [vm] on a linear chain in place of
+// its default consensus engine, if [vm] supports it and the network has
+// activated it. Otherwise, [vm] is returned as it is.
+func linearize(vm interface{}) (interface{}, error) {
+	linearizable, ok := vm.(block.Linearizable)
+	if !ok {
+		return vm, nil
+	}
+	chainVM, linearized, err := linearizable.Linearize()
+	if err != nil {
+		return nil, fmt.Errorf("error while linearizing vm: %w", err)
+	}
+	if linearized {
+		return chainVM, nil
+	}
+	return vm, nil
+}
+
 // getChainConfig returns value of a entry by looking at ID key and alias key
 // it first searches ID key, then falls back to it's corresponding primary alias
 func (m *manager) getChainConfig(id ids.ID) ChainConfig {
@@ -800,3 +847,18 @@ func (m *manager) getChainConfig(id ids.ID) ChainConfig {
 
 	return ChainConfig{}
 }
+
+// getChainSchedulerWeight returns the scheduling weight of the chain by looking
+// at ID key and alias key. If no weight was configured, the default weight is
+// returned.
+func (m *manager) getChainSchedulerWeight(id ids.ID) uint64 {
+	if weight, ok := m.ManagerConfig.ChainSchedulerWeights[id.String()]; ok {
+		return weight
+	}
+	for _, alias := range m.Aliases(id) {
+		if weight, ok := m.ManagerConfig.ChainSchedulerWeights[alias]; ok {
+			return weight
+		}
+	}
+	return router.DefaultChainWeight
+}
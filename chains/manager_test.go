@@ -0,0 +1,54 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/stretchr/testify/assert"
+)
+
+// linearizableVM runs on [chainVM] once [activated]
+type linearizableVM struct {
+	chainVM   block.ChainVM
+	activated bool
+	err       error
+}
+
+func (vm *linearizableVM) Linearize() (block.ChainVM, bool, error) {
+	if vm.err != nil {
+		return nil, false, vm.err
+	}
+	if !vm.activated {
+		return nil, false, nil
+	}
+	return vm.chainVM, true, nil
+}
+
+func TestLinearize(t *testing.T) {
+	assert := assert.New(t)
+
+	chainVM := &block.TestVM{}
+	vm := &linearizableVM{chainVM: chainVM, activated: true}
+
+	linearized, err := linearize(vm)
+	assert.NoError(err)
+	assert.Equal(chainVM, linearized, "the VM should run on a linear chain once the network has activated it")
+
+	vm.activated = false
+	linearized, err = linearize(vm)
+	assert.NoError(err)
+	assert.Equal(vm, linearized, "the VM should run as it is before the network has activated a linear chain")
+
+	other := &block.TestVM{}
+	linearized, err = linearize(other)
+	assert.NoError(err)
+	assert.Equal(other, linearized, "a VM that can't be linearized should run as it is")
+
+	vm.err = errors.New("invalid config")
+	_, err = linearize(vm)
+	assert.True(errors.Is(err, vm.err), "the linearization error should be returned")
+}
@@ -42,6 +42,10 @@ type Manager interface {
 	// by this codec manager
 	SetMaxSize(int)
 
+	// MaxSize returns the maximum size, in bytes, of something
+	// serialized/deserialized by this codec manager
+	MaxSize() int
+
 	// Marshal the given value using the codec with the given version.
 	// RegisterCodec must have been called with that version.
 	Marshal(version uint16, source interface{}) (destination []byte, err error)
@@ -89,6 +93,14 @@ func (m *manager) SetMaxSize(size int) {
 	m.lock.Unlock()
 }
 
+// MaxSize of bytes allowed
+func (m *manager) MaxSize() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.maxSize
+}
+
 // To marshal an interface, [value] must be a pointer to the interface.
 func (m *manager) Marshal(version uint16, value interface{}) ([]byte, error) {
 	if value == nil {
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils"
@@ -126,8 +128,22 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	nodeConfig.ConsensusParams.OptimalProcessing = v.GetInt(SnowOptimalProcessingKey)
 	nodeConfig.ConsensusParams.MaxOutstandingItems = v.GetInt(SnowMaxProcessingKey)
 	nodeConfig.ConsensusParams.MaxItemProcessingTime = v.GetDuration(SnowMaxTimeProcessingKey)
+	earlyTermination, err := poll.ParseEarlyTermination(v.GetString(SnowPollEarlyTerminationKey))
+	if err != nil {
+		return node.Config{}, err
+	}
+	nodeConfig.SnowmanEarlyTermination = earlyTermination
 	nodeConfig.ConsensusGossipFrequency = v.GetDuration(ConsensusGossipFrequencyKey)
 	nodeConfig.ConsensusShutdownTimeout = v.GetDuration(ConsensusShutdownTimeoutKey)
+	nodeConfig.ConsensusSchedulerMaxConcurrent = int(v.GetUint(ConsensusSchedulerMaxConcurrentKey))
+	if nodeConfig.ConsensusSchedulerMaxConcurrent == 0 {
+		return node.Config{}, fmt.Errorf("%s must be > 0", ConsensusSchedulerMaxConcurrentKey)
+	}
+	chainWeights, err := parseChainWeights(v.GetString(ConsensusSchedulerChainWeightsKey))
+	if err != nil {
+		return node.Config{}, err
+	}
+	nodeConfig.ConsensusSchedulerChainWeights = chainWeights
 	nodeConfig.ConsensusGossipAcceptedFrontierSize = uint(v.GetUint32(ConsensusGossipAcceptedFrontierSizeKey))
 	nodeConfig.ConsensusGossipOnAcceptSize = uint(v.GetUint32(ConsensusGossipOnAcceptSizeKey))
 
@@ -445,27 +461,17 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 		maxValidatorStake := v.GetUint64(MaxValidatorStakeKey)
 		minDelegatorStake := v.GetUint64(MinDelegatorStakeKey)
 		minDelegationFee := v.GetUint64(MinDelegatorFeeKey)
-		if minValidatorStake > maxValidatorStake {
-			return node.Config{}, errors.New("minimum validator stake can't be greater than maximum validator stake")
+		if minDelegationFee > genesis.MaxDelegationFee {
+			return node.Config{}, fmt.Errorf("delegation fee must be in the range [0, %d]", genesis.MaxDelegationFee)
 		}
 
 		nodeConfig.MinValidatorStake = minValidatorStake
 		nodeConfig.MaxValidatorStake = maxValidatorStake
 		nodeConfig.MinDelegatorStake = minDelegatorStake
-
-		if minDelegationFee > 1_000_000 {
-			return node.Config{}, errors.New("delegation fee must be in the range [0, 1000000]")
-		}
 		nodeConfig.MinDelegationFee = uint32(minDelegationFee)
 
-		if nodeConfig.MinStakeDuration == 0 {
-			return node.Config{}, errors.New("min stake duration can't be zero")
-		}
-		if nodeConfig.MaxStakeDuration < nodeConfig.MinStakeDuration {
-			return node.Config{}, errors.New("max stake duration can't be less than min stake duration")
-		}
-		if nodeConfig.StakeMintingPeriod < nodeConfig.MaxStakeDuration {
-			return node.Config{}, errors.New("stake minting period can't be less than max stake duration")
+		if err := genesis.ValidateParams(&nodeConfig.Params); err != nil {
+			return node.Config{}, err
 		}
 
 		nodeConfig.EpochFirstTransition = time.Unix(v.GetInt64(SnowEpochFirstTransition), 0)
@@ -633,6 +639,31 @@ func initBootstrapPeers(v *viper.Viper, config *node.Config) error {
 	return nil
 }
 
+// parseChainWeights parses a comma separated list of chain:weight pairs into a
+// map from the chain's ID or alias to its scheduling weight
+func parseChainWeights(s string) (map[string]uint64, error) {
+	chainWeights := make(map[string]uint64)
+	for _, pair := range strings.Split(s, ",") {
+		if pair == "" {
+			continue
+		}
+		sepIndex := strings.LastIndex(pair, ":")
+		if sepIndex <= 0 {
+			return nil, fmt.Errorf("couldn't parse chain weight %q: expected chain:weight", pair)
+		}
+		chain := pair[:sepIndex]
+		weight, err := strconv.ParseUint(pair[sepIndex+1:], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse weight of chain %s: %w", chain, err)
+		}
+		if weight == 0 {
+			return nil, fmt.Errorf("weight of chain %s must be > 0", chain)
+		}
+		chainWeights[chain] = weight
+	}
+	return chainWeights, nil
+}
+
 // ReadsChainConfigs reads chain config files from static directories and returns map with contents,
 // if successful.
 func readChainConfigDirs(chainDirs []string) (map[string]chains.ChainConfig, error) {
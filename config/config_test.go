@@ -364,3 +364,50 @@ func setupViper(configFilePath string) *viper.Viper {
 	}
 	return v
 }
+
+func TestParseChainWeights(t *testing.T) {
+	tests := map[string]struct {
+		given      string
+		expected   map[string]uint64
+		errMessage string
+	}{
+		"empty": {
+			given:    "",
+			expected: map[string]uint64{},
+		},
+		"aliases and ids": {
+			given: "P:4,X:2,2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm:3",
+			expected: map[string]uint64{
+				"P": 4,
+				"X": 2,
+				"2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm": 3,
+			},
+		},
+		"missing weight": {
+			given:      "P",
+			errMessage: "expected chain:weight",
+		},
+		"invalid weight": {
+			given:      "P:heavy",
+			errMessage: "couldn't parse weight of chain P",
+		},
+		"zero weight": {
+			given:      "P:0",
+			errMessage: "weight of chain P must be > 0",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			chainWeights, err := parseChainWeights(test.given)
+			if len(test.errMessage) > 0 {
+				assert.Error(err)
+				assert.Contains(err.Error(), test.errMessage)
+			} else {
+				assert.NoError(err)
+				assert.Equal(test.expected, chainWeights)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"time"
 
 	"github.com/kardianos/osext"
@@ -17,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/rocksdb"
 	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ulimit"
 	"github.com/ava-labs/avalanchego/utils/units"
@@ -152,6 +154,8 @@ func addNodeFlags(fs *flag.FlagSet) {
 	// Router
 	fs.Duration(ConsensusGossipFrequencyKey, 10*time.Second, "Frequency of gossiping accepted frontiers.")
 	fs.Duration(ConsensusShutdownTimeoutKey, 5*time.Second, "Timeout before killing an unresponsive chain.")
+	fs.Uint(ConsensusSchedulerMaxConcurrentKey, uint(runtime.NumCPU()), "Maximum number of chains that may handle a consensus message at the same time.")
+	fs.String(ConsensusSchedulerChainWeightsKey, "", "Comma separated list of chain:weight pairs. A chain with twice the weight of another chain gets twice the message handling time when they compete. Chains default to a weight of 1. Example: P:4,X:2")
 	fs.Uint(ConsensusGossipAcceptedFrontierSizeKey, 35, "Number of peers to gossip to when gossiping accepted frontier")
 	fs.Uint(ConsensusGossipOnAcceptSizeKey, 20, "Number of peers to gossip to each accepted container to")
 
@@ -242,6 +246,7 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Int(SnowOptimalProcessingKey, 50, "Optimal number of processing vertices in consensus")
 	fs.Int(SnowMaxProcessingKey, 1024, "Maximum number of processing items to be considered healthy")
 	fs.Duration(SnowMaxTimeProcessingKey, 2*time.Minute, "Maximum amount of time an item should be processing and still be healthy")
+	fs.String(SnowPollEarlyTerminationKey, string(poll.EarlyTermNoTraversal), fmt.Sprintf("Strategy used to terminate polls of Snowman (linear) chains early. One of: %q, %q, %q. Avalanche (DAG) chains always use %q", poll.NoEarlyTerm, poll.EarlyTermNoTraversal, poll.EarlyTermTraversal, poll.EarlyTermNoTraversal))
 	fs.Int64(SnowEpochFirstTransition, 1636700400, "Unix timestamp of the first epoch transaction, in seconds. Defaults to 12/10/2020 @ 7:00pm (UTC)")
 	fs.Duration(SnowEpochDuration, 6*time.Hour, "Duration of each epoch")
 
@@ -82,6 +82,7 @@ const (
 	SnowOptimalProcessingKey                  = "snow-optimal-processing"
 	SnowMaxProcessingKey                      = "snow-max-processing"
 	SnowMaxTimeProcessingKey                  = "snow-max-time-processing"
+	SnowPollEarlyTerminationKey               = "snow-poll-early-termination"
 	SnowEpochFirstTransition                  = "snow-epoch-first-transition"
 	SnowEpochDuration                         = "snow-epoch-duration"
 	WhitelistedSubnetsKey                     = "whitelisted-subnets"
@@ -98,6 +99,8 @@ const (
 	ConsensusGossipAcceptedFrontierSizeKey    = "consensus-accepted-frontier-gossip-size"
 	ConsensusGossipOnAcceptSizeKey            = "consensus-on-accept-gossip-size"
 	ConsensusShutdownTimeoutKey               = "consensus-shutdown-timeout"
+	ConsensusSchedulerMaxConcurrentKey        = "consensus-scheduler-max-concurrent"
+	ConsensusSchedulerChainWeightsKey         = "consensus-scheduler-chain-weights"
 	FdLimitKey                                = "fd-limit"
 	CorethConfigKey                           = "coreth-config"
 	IndexEnabledKey                           = "index-enabled"
@@ -8,6 +8,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/evm"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
@@ -67,5 +68,6 @@ func GetVMAliases() map[ids.ID][]string {
 		secp256k1fx.ID: {"secp256k1fx"},
 		nftfx.ID:       {"nftfx"},
 		propertyfx.ID:  {"propertyfx"},
+		htlcfx.ID:      {"htlcfx"},
 	}
 }
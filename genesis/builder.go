@@ -0,0 +1,138 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+// MaxDelegationFee is the largest delegation fee that can be charged. It
+// represents a fee of 100%.
+const MaxDelegationFee = 1_000_000
+
+var (
+	errMinStakeGreaterThanMax = errors.New("minimum validator stake can't be greater than maximum validator stake")
+	errInvalidDelegationFee   = fmt.Errorf("delegation fee must be in the range [0, %d]", MaxDelegationFee)
+	errZeroMinStakeDuration   = errors.New("min stake duration can't be zero")
+	errMaxLessThanMinDuration = errors.New("max stake duration can't be less than min stake duration")
+	errMintingLessThanMaxDur  = errors.New("stake minting period can't be less than max stake duration")
+)
+
+// ValidateParams returns an error if the provided *Params are not considered
+// valid for a network.
+func ValidateParams(params *Params) error {
+	switch {
+	case params.MinValidatorStake > params.MaxValidatorStake:
+		return errMinStakeGreaterThanMax
+	case params.MinDelegationFee > MaxDelegationFee:
+		return errInvalidDelegationFee
+	case params.MinStakeDuration == 0:
+		return errZeroMinStakeDuration
+	case params.MaxStakeDuration < params.MinStakeDuration:
+		return errMaxLessThanMinDuration
+	case params.StakeMintingPeriod < params.MaxStakeDuration:
+		return errMintingLessThanMaxDur
+	default:
+		return nil
+	}
+}
+
+// Chain describes a blockchain that is created in the genesis of a network
+type Chain struct {
+	Name string `json:"name"`
+	ID   ids.ID `json:"id"`
+	VMID ids.ID `json:"vmID"`
+}
+
+// Built is the genesis of a custom network
+type Built struct {
+	// Config the genesis was built from
+	Config UnparsedConfig
+	// Byte representation of the genesis state of the platform chain
+	Bytes []byte
+	// Asset ID of DJTX
+	DJTXAssetID ids.ID
+	// Blockchains created in the genesis
+	Chains []Chain
+}
+
+// Build validates the genesis [config] of a custom network, and the economic
+// [params] the network will be run with, and returns the genesis of the
+// network. The same [config] always results in the same genesis.
+func Build(config *Config, params *Params) (*Built, error) {
+	switch config.NetworkID {
+	case constants.MainnetID, constants.TestnetID, constants.LocalID:
+		return nil, fmt.Errorf(
+			"cannot build genesis for standard network %s (%d)",
+			constants.NetworkName(config.NetworkID),
+			config.NetworkID,
+		)
+	}
+
+	if err := ValidateParams(params); err != nil {
+		return nil, fmt.Errorf("params validation failed: %w", err)
+	}
+	if err := validateConfig(config.NetworkID, config); err != nil {
+		return nil, fmt.Errorf("genesis config validation failed: %w", err)
+	}
+	for _, staker := range config.InitialStakers {
+		if staker.DelegationFee > MaxDelegationFee {
+			return nil, fmt.Errorf(
+				"initial staker %s has delegation fee %d but it must be in the range [0, %d]",
+				staker.NodeID.PrefixedString(constants.NodeIDPrefix),
+				staker.DelegationFee,
+				MaxDelegationFee,
+			)
+		}
+	}
+
+	unparsedConfig, err := config.Unparse()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't unparse genesis config: %w", err)
+	}
+
+	genesisBytes, djtxAssetID, err := FromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't build genesis: %w", err)
+	}
+
+	chains, err := Chains(genesisBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Built{
+		Config:      unparsedConfig,
+		Bytes:       genesisBytes,
+		DJTXAssetID: djtxAssetID,
+		Chains:      chains,
+	}, nil
+}
+
+// Chains returns the blockchains created in the genesis of the platform chain
+func Chains(genesisBytes []byte) ([]Chain, error) {
+	genesis := platformvm.Genesis{}
+	if _, err := platformvm.GenesisCodec.Unmarshal(genesisBytes, &genesis); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal genesis bytes due to: %w", err)
+	}
+	if err := genesis.Initialize(); err != nil {
+		return nil, err
+	}
+
+	chains := make([]Chain, len(genesis.Chains))
+	for i, chain := range genesis.Chains {
+		uChain := chain.UnsignedTx.(*platformvm.UnsignedCreateChainTx)
+		chains[i] = Chain{
+			Name: uChain.ChainName,
+			ID:   chain.ID(),
+			VMID: uChain.VMID,
+		}
+	}
+	return chains, nil
+}
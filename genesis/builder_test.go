@@ -0,0 +1,173 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/evm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestValidateParams(t *testing.T) {
+	tests := map[string]struct {
+		params func() Params
+		err    error
+	}{
+		"local": {
+			params: func() Params { return LocalParams },
+		},
+		"min stake greater than max stake": {
+			params: func() Params {
+				params := LocalParams
+				params.MinValidatorStake = params.MaxValidatorStake + 1
+				return params
+			},
+			err: errMinStakeGreaterThanMax,
+		},
+		"delegation fee too large": {
+			params: func() Params {
+				params := LocalParams
+				params.MinDelegationFee = MaxDelegationFee + 1
+				return params
+			},
+			err: errInvalidDelegationFee,
+		},
+		"zero min stake duration": {
+			params: func() Params {
+				params := LocalParams
+				params.MinStakeDuration = 0
+				return params
+			},
+			err: errZeroMinStakeDuration,
+		},
+		"max stake duration less than min": {
+			params: func() Params {
+				params := LocalParams
+				params.MaxStakeDuration = params.MinStakeDuration - time.Second
+				return params
+			},
+			err: errMaxLessThanMinDuration,
+		},
+		"minting period less than max stake duration": {
+			params: func() Params {
+				params := LocalParams
+				params.StakeMintingPeriod = params.MaxStakeDuration - time.Second
+				return params
+			},
+			err: errMintingLessThanMaxDur,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := test.params()
+			assert.Equal(t, test.err, ValidateParams(&params))
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	assert := assert.New(t)
+
+	config := *GetConfig(1337)
+	built, err := Build(&config, &LocalParams)
+	assert.NoError(err)
+
+	genesisBytes, djtxAssetID, err := FromConfig(&config)
+	assert.NoError(err)
+	assert.Equal(genesisBytes, built.Bytes)
+	assert.Equal(djtxAssetID, built.DJTXAssetID)
+	assert.Equal(config.NetworkID, built.Config.NetworkID)
+
+	// The genesis is deterministic
+	rebuilt, err := Build(&config, &LocalParams)
+	assert.NoError(err)
+	assert.Equal(built, rebuilt)
+
+	assert.Len(built.Chains, 2)
+	for _, chain := range built.Chains {
+		expected, err := VMGenesis(built.Bytes, chain.VMID)
+		assert.NoError(err)
+		assert.Equal(expected.ID(), chain.ID)
+	}
+	vmIDs := []interface{}{built.Chains[0].VMID, built.Chains[1].VMID}
+	assert.Contains(vmIDs, avm.ID)
+	assert.Contains(vmIDs, evm.ID)
+}
+
+func TestBuildChains(t *testing.T) {
+	assert := assert.New(t)
+
+	vmID := ids.ID{'t', 'e', 's', 't', 'v', 'm'}
+	config := *GetConfig(1337)
+	config.Chains = []ChainConfig{{
+		Name:        "Test-Chain",
+		VMID:        vmID,
+		FxIDs:       []ids.ID{secp256k1fx.ID},
+		GenesisData: "test genesis",
+	}}
+	built, err := Build(&config, &LocalParams)
+	assert.NoError(err)
+	assert.Equal(config.Chains, built.Config.Chains)
+	assert.Len(built.Chains, 3)
+
+	chainTx, err := VMGenesis(built.Bytes, vmID)
+	assert.NoError(err)
+	chain := chainTx.UnsignedTx.(*platformvm.UnsignedCreateChainTx)
+	assert.Equal("Test-Chain", chain.ChainName)
+	assert.Equal(constants.PrimaryNetworkID, chain.SubnetID)
+	assert.Equal([]ids.ID{secp256k1fx.ID}, chain.FxIDs)
+	assert.Equal([]byte("test genesis"), chain.GenesisData)
+	assert.Contains(built.Chains, Chain{Name: "Test-Chain", ID: chainTx.ID(), VMID: vmID})
+
+	// The chain is created by nodes that parse the built config
+	parsedConfig, err := built.Config.Parse()
+	assert.NoError(err)
+	genesisBytes, _, err := FromConfig(&parsedConfig)
+	assert.NoError(err)
+	assert.Equal(built.Bytes, genesisBytes)
+
+	config.Chains = []ChainConfig{{VMID: vmID}}
+	_, err = Build(&config, &LocalParams)
+	assert.Error(err, "should have failed because the chain has no name")
+
+	config.Chains = []ChainConfig{{Name: "Test-Chain"}}
+	_, err = Build(&config, &LocalParams)
+	assert.Error(err, "should have failed because the chain has no VM")
+
+	config.Chains = []ChainConfig{{Name: "Test-Chain", VMID: vmID, SubnetID: ids.GenerateTestID()}}
+	_, err = Build(&config, &LocalParams)
+	assert.Error(err, "should have failed because the subnet doesn't exist at genesis")
+}
+
+func TestBuildInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := Build(&LocalConfig, &LocalParams)
+	assert.Error(err, "shouldn't build the genesis of a standard network")
+
+	config := *GetConfig(1337)
+	params := LocalParams
+	params.MinStakeDuration = 0
+	_, err = Build(&config, &params)
+	assert.Error(err, "should have failed params validation")
+
+	config.InitialStakers = []Staker{}
+	_, err = Build(&config, &LocalParams)
+	assert.Error(err, "should have failed genesis config validation")
+
+	config = *GetConfig(1337)
+	config.InitialStakers = append([]Staker(nil), config.InitialStakers...)
+	config.InitialStakers[0].DelegationFee = MaxDelegationFee + 1
+	_, err = Build(&config, &LocalParams)
+	assert.Error(err, "should have failed delegation fee validation")
+}
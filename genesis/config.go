@@ -64,6 +64,19 @@ func (s Staker) Unparse(networkID uint32) (UnparsedStaker, error) {
 	}, err
 }
 
+// ChainConfig describes a blockchain, other than the X-Chain and C-Chain, that
+// is created in the genesis of a network
+type ChainConfig struct {
+	Name string `json:"name"`
+	// Subnet the chain is validated by. Only the primary network exists at
+	// genesis.
+	SubnetID ids.ID   `json:"subnetID"`
+	VMID     ids.ID   `json:"vmID"`
+	FxIDs    []ids.ID `json:"fxIDs"`
+	// Genesis data of the chain, passed to the VM as is
+	GenesisData string `json:"genesisData"`
+}
+
 // Config contains the genesis addresses used to construct a genesis
 type Config struct {
 	NetworkID uint32 `json:"networkID"`
@@ -78,6 +91,8 @@ type Config struct {
 
 	CChainGenesis string `json:"cChainGenesis"`
 
+	Chains []ChainConfig `json:"chains,omitempty"`
+
 	Message string `json:"message"`
 }
 
@@ -91,6 +106,7 @@ func (c Config) Unparse() (UnparsedConfig, error) {
 		InitialStakedFunds:         make([]string, len(c.InitialStakedFunds)),
 		InitialStakers:             make([]UnparsedStaker, len(c.InitialStakers)),
 		CChainGenesis:              c.CChainGenesis,
+		Chains:                     c.Chains,
 		Message:                    c.Message,
 	}
 	for i, a := range c.Allocations {
@@ -21,6 +21,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/evm"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
@@ -153,6 +154,21 @@ func validateConfig(networkID uint32, config *Config) error {
 		return errors.New("C-Chain genesis cannot be empty")
 	}
 
+	for _, chain := range config.Chains {
+		switch {
+		case len(chain.Name) == 0:
+			return errors.New("chain name cannot be empty")
+		case chain.VMID == ids.Empty:
+			return fmt.Errorf("chain %q must specify a VM ID", chain.Name)
+		case chain.SubnetID != constants.PrimaryNetworkID:
+			return fmt.Errorf(
+				"chain %q is validated by subnet %s but only the primary network exists at genesis",
+				chain.Name,
+				chain.SubnetID,
+			)
+		}
+	}
+
 	return nil
 }
 
@@ -386,6 +402,7 @@ func FromConfig(config *Config) ([]byte, ids.ID, error) {
 				secp256k1fx.ID,
 				nftfx.ID,
 				propertyfx.ID,
+				htlcfx.ID,
 			},
 			Name: "X-Chain",
 		},
@@ -396,6 +413,19 @@ func FromConfig(config *Config) ([]byte, ids.ID, error) {
 			Name:        "C-Chain",
 		},
 	}
+	for _, chain := range config.Chains {
+		chainGenesisStr, err := formatting.Encode(defaultEncoding, []byte(chain.GenesisData))
+		if err != nil {
+			return nil, ids.Empty, fmt.Errorf("couldn't encode genesis of chain %q: %w", chain.Name, err)
+		}
+		platformvmArgs.Chains = append(platformvmArgs.Chains, platformvm.APIChain{
+			GenesisData: chainGenesisStr,
+			SubnetID:    chain.SubnetID,
+			VMID:        chain.VMID,
+			FxIDs:       chain.FxIDs,
+			Name:        chain.Name,
+		})
+	}
 
 	platformvmReply := platformvm.BuildGenesisReply{}
 	platformvmSS := platformvm.StaticService{}
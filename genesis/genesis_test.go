@@ -16,7 +16,11 @@ import (
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/evm"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 func TestAliases(t *testing.T) {
@@ -409,6 +413,24 @@ func TestVMGenesis(t *testing.T) {
 	}
 }
 
+func TestXChainFxs(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, _, err := Genesis(constants.LocalID, "")
+	assert.NoError(err)
+
+	genesisTx, err := VMGenesis(genesisBytes, avm.ID)
+	assert.NoError(err)
+
+	// The index of each fx is part of the X-chain's codec, so the fxs must
+	// keep their order
+	createChainTx := genesisTx.UnsignedTx.(*platformvm.UnsignedCreateChainTx)
+	assert.Equal(
+		[]ids.ID{secp256k1fx.ID, nftfx.ID, propertyfx.ID, htlcfx.ID},
+		createChainTx.FxIDs,
+	)
+}
+
 func TestDJTXAssetID(t *testing.T) {
 	tests := []struct {
 		networkID  uint32
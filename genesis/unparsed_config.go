@@ -95,6 +95,8 @@ type UnparsedConfig struct {
 
 	CChainGenesis string `json:"cChainGenesis"`
 
+	Chains []ChainConfig `json:"chains,omitempty"`
+
 	Message string `json:"message"`
 }
 
@@ -108,6 +110,7 @@ func (uc UnparsedConfig) Parse() (Config, error) {
 		InitialStakedFunds:         make([]ids.ShortID, len(uc.InitialStakedFunds)),
 		InitialStakers:             make([]Staker, len(uc.InitialStakers)),
 		CChainGenesis:              uc.CChainGenesis,
+		Chains:                     uc.Chains,
 		Message:                    uc.Message,
 	}
 	for i, ua := range uc.Allocations {
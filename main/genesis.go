@@ -0,0 +1,201 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/perms"
+)
+
+const (
+	genesisCommand      = "genesis"
+	genesisBuildCommand = "build"
+
+	genesisConfigFileKey       = "config-file"
+	cChainGenesisFileKey       = "c-chain-genesis-file"
+	generateStakersKey         = "generate-stakers"
+	stakingDirKey              = "staking-dir"
+	stakerRewardAddressKey     = "staker-reward-address"
+	stakerDelegationFeeKey     = "staker-delegation-fee"
+	genesisOutputFileKey       = "output-file"
+	defaultStakerDelegationFee = genesis.MaxDelegationFee
+)
+
+var (
+	errMissingGenesisConfig  = fmt.Errorf("--%s must be provided", genesisConfigFileKey)
+	errMissingRewardAddress  = fmt.Errorf("--%s must be provided when generating stakers", stakerRewardAddressKey)
+	errUnknownGenesisCommand = fmt.Errorf("usage: %s %s %s [flags]", constants.AppName, genesisCommand, genesisBuildCommand)
+)
+
+// builtGenesis is the output of the genesis build command
+type builtGenesis struct {
+	// Genesis config of the network. Passed to the nodes with --genesis.
+	Config genesis.UnparsedConfig `json:"config"`
+	// Hex encoding of the genesis state of the platform chain
+	Genesis     string          `json:"genesis"`
+	DJTXAssetID ids.ID          `json:"djtxAssetID"`
+	Chains      []genesis.Chain `json:"chains"`
+	// Node flags that the network must be run with
+	Flags map[string]interface{} `json:"flags"`
+}
+
+// runGenesis runs the genesis subcommand with the provided [args] and returns
+// the exit code of the process.
+func runGenesis(args []string) int {
+	if len(args) == 0 || args[0] != genesisBuildCommand {
+		fmt.Println(errUnknownGenesisCommand)
+		return 1
+	}
+	if err := buildGenesis(args[1:]); err != nil {
+		fmt.Printf("couldn't build genesis: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// buildGenesis builds the genesis of a custom network. The allocations, initial
+// stakers, C-Chain genesis and any other chains created at genesis are read
+// from a genesis config file. Initial stakers, and their staking certs, can be
+// generated. The economics of the network are provided as the same flags that
+// the nodes are run with.
+func buildGenesis(args []string) error {
+	params := genesis.LocalParams
+
+	fs := pflag.NewFlagSet(genesisBuildCommand, pflag.ContinueOnError)
+	configFile := fs.String(genesisConfigFileKey, "", "Genesis config file that specifies the allocations, initial stakers and chains of the network")
+	cChainGenesisFile := fs.String(cChainGenesisFileKey, "", "File containing the C-Chain genesis. Overrides the C-Chain genesis in the genesis config")
+	numStakers := fs.Uint(generateStakersKey, 0, "Number of initial stakers to generate staking certs for")
+	stakingDir := fs.String(stakingDirKey, "staking", "Directory to write the staking certs of the generated stakers to")
+	rewardAddress := fs.String(stakerRewardAddressKey, "", "X-Chain address that the generated stakers are rewarded to")
+	delegationFee := fs.Uint32(stakerDelegationFeeKey, defaultStakerDelegationFee, "Delegation fee of the generated stakers, in the range [0, 1000000]")
+	outputFile := fs.String(genesisOutputFileKey, "", "File to write the genesis config to. If empty, the genesis config is only printed")
+	fs.Uint64Var(&params.TxFee, config.TxFeeKey, params.TxFee, "Transaction fee, in nDJTX")
+	fs.Uint64Var(&params.CreationTxFee, config.CreationTxFeeKey, params.CreationTxFee, "Transaction fee, in nDJTX, for transactions that create new state")
+	fs.Float64Var(&params.UptimeRequirement, config.UptimeRequirementKey, params.UptimeRequirement, "Fraction of time a validator must be online to receive rewards")
+	fs.Uint64Var(&params.MinValidatorStake, config.MinValidatorStakeKey, params.MinValidatorStake, "Minimum stake, in nDJTX, required to validate the primary network")
+	fs.Uint64Var(&params.MaxValidatorStake, config.MaxValidatorStakeKey, params.MaxValidatorStake, "Maximum stake, in nDJTX, that can be placed on a validator on the primary network")
+	fs.Uint64Var(&params.MinDelegatorStake, config.MinDelegatorStakeKey, params.MinDelegatorStake, "Minimum stake, in nDJTX, that can be delegated on the primary network")
+	fs.Uint32Var(&params.MinDelegationFee, config.MinDelegatorFeeKey, params.MinDelegationFee, "Minimum delegation fee, in the range [0, 1000000], that can be charged for delegation on the primary network")
+	fs.DurationVar(&params.MinStakeDuration, config.MinStakeDurationKey, params.MinStakeDuration, "Minimum staking duration")
+	fs.DurationVar(&params.MaxStakeDuration, config.MaxStakeDurationKey, params.MaxStakeDuration, "Maximum staking duration")
+	fs.DurationVar(&params.StakeMintingPeriod, config.StakeMintingPeriodKey, params.StakeMintingPeriod, "Consumption period of the staking function")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *configFile == "" {
+		return errMissingGenesisConfig
+	}
+	configBytes, err := ioutil.ReadFile(filepath.Clean(*configFile))
+	if err != nil {
+		return fmt.Errorf("unable to load file %s: %w", *configFile, err)
+	}
+	unparsedConfig := genesis.UnparsedConfig{}
+	if err := json.Unmarshal(configBytes, &unparsedConfig); err != nil {
+		return fmt.Errorf("could not unmarshal JSON: %w", err)
+	}
+
+	if *cChainGenesisFile != "" {
+		cChainGenesis, err := ioutil.ReadFile(filepath.Clean(*cChainGenesisFile))
+		if err != nil {
+			return fmt.Errorf("unable to load file %s: %w", *cChainGenesisFile, err)
+		}
+		unparsedConfig.CChainGenesis = string(cChainGenesis)
+	}
+
+	if *numStakers > 0 {
+		if *rewardAddress == "" {
+			return errMissingRewardAddress
+		}
+		if err := os.MkdirAll(*stakingDir, perms.ReadWriteExecute); err != nil {
+			return fmt.Errorf("couldn't create staking directory: %w", err)
+		}
+	}
+	for i := uint(0); i < *numStakers; i++ {
+		cert, err := staking.NewTLSCert()
+		if err != nil {
+			return fmt.Errorf("couldn't generate staking cert: %w", err)
+		}
+		keyPath := filepath.Join(*stakingDir, fmt.Sprintf("staker%d.key", i+1))
+		certPath := filepath.Join(*stakingDir, fmt.Sprintf("staker%d.crt", i+1))
+		if err := staking.WriteTLSCert(cert, keyPath, certPath); err != nil {
+			return err
+		}
+
+		nodeID, err := ids.ToShortID(hashing.PubkeyBytesToAddress(cert.Leaf.Raw))
+		if err != nil {
+			return err
+		}
+		unparsedConfig.InitialStakers = append(unparsedConfig.InitialStakers, genesis.UnparsedStaker{
+			NodeID:        nodeID.PrefixedString(constants.NodeIDPrefix),
+			RewardAddress: *rewardAddress,
+			DelegationFee: *delegationFee,
+		})
+	}
+
+	genesisConfig, err := unparsedConfig.Parse()
+	if err != nil {
+		return fmt.Errorf("unable to parse genesis config: %w", err)
+	}
+	built, err := genesis.Build(&genesisConfig, &params)
+	if err != nil {
+		return err
+	}
+
+	genesisStr, err := formatting.Encode(formatting.Hex, built.Bytes)
+	if err != nil {
+		return err
+	}
+	output := builtGenesis{
+		Config:      built.Config,
+		Genesis:     genesisStr,
+		DJTXAssetID: built.DJTXAssetID,
+		Chains:      built.Chains,
+		Flags: map[string]interface{}{
+			config.NetworkNameKey:        built.Config.NetworkID,
+			config.TxFeeKey:              params.TxFee,
+			config.CreationTxFeeKey:      params.CreationTxFee,
+			config.UptimeRequirementKey:  params.UptimeRequirement,
+			config.MinValidatorStakeKey:  params.MinValidatorStake,
+			config.MaxValidatorStakeKey:  params.MaxValidatorStake,
+			config.MinDelegatorStakeKey:  params.MinDelegatorStake,
+			config.MinDelegatorFeeKey:    params.MinDelegationFee,
+			config.MinStakeDurationKey:   params.MinStakeDuration.String(),
+			config.MaxStakeDurationKey:   params.MaxStakeDuration.String(),
+			config.StakeMintingPeriodKey: params.StakeMintingPeriod.String(),
+		},
+	}
+
+	if *outputFile != "" {
+		configJSON, err := json.MarshalIndent(built.Config, "", "\t")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(*outputFile, configJSON, perms.ReadWrite); err != nil {
+			return fmt.Errorf("couldn't write genesis config: %w", err)
+		}
+		output.Flags[config.GenesisConfigFileKey] = *outputFile
+	}
+
+	outputJSON, err := json.MarshalIndent(output, "", "\t")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(outputJSON))
+	return nil
+}
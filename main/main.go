@@ -17,6 +17,10 @@ import (
 
 // main is the entry point to AvalancheGo.
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == genesisCommand {
+		os.Exit(runGenesis(os.Args[2:]))
+	}
+
 	fs := config.BuildFlagSet()
 	v, err := config.BuildViper(fs, os.Args[1:])
 	if err != nil {
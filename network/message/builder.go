@@ -113,6 +113,11 @@ type Builder interface {
 		requestID uint32,
 		containerIDs []ids.ID,
 	) (Message, error)
+
+	AppGossip(
+		chainID ids.ID,
+		msg []byte,
+	) (Message, error)
 }
 
 type builder struct{ c Codec }
@@ -419,3 +424,18 @@ func (b *builder) Chits(
 		Chits.Compressable(),
 	)
 }
+
+func (b *builder) AppGossip(
+	chainID ids.ID,
+	msg []byte,
+) (Message, error) {
+	return b.c.Pack(
+		AppGossip,
+		map[Field]interface{}{
+			ChainID:  chainID[:],
+			AppBytes: msg,
+		},
+		AppGossip.Compressable(), // AppGossip messages can't be compressed
+		AppGossip.Compressable(),
+	)
+}
@@ -492,3 +492,23 @@ func TestBuildMultiPut(t *testing.T) {
 		assert.Equal(t, containers, parsedMsg.Get(MultiContainerBytes))
 	}
 }
+
+func TestBuildAppGossip(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	appBytes := []byte("app gossip")
+
+	msg, err := TestBuilder.AppGossip(chainID, appBytes)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, AppGossip, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, appBytes, msg.Get(AppBytes))
+
+	parsedMsg, err := TestCodec.Parse(msg.Bytes(), true)
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, AppGossip, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, appBytes, parsedMsg.Get(AppBytes))
+	assert.EqualValues(t, msg.Bytes(), parsedMsg.Bytes())
+}
@@ -28,6 +28,7 @@ const (
 	SigBytes                         // Used in handshake / peer gossiping
 	VersionTime                      // Used in handshake / peer gossiping
 	SignedPeers                      // Used in peer gossiping
+	AppBytes                         // Used for application level messages
 )
 
 // Packer returns the packer function that can be used to pack this field.
@@ -65,6 +66,8 @@ func (f Field) Packer() func(*wrappers.Packer, interface{}) {
 		return wrappers.TryPackLong
 	case SignedPeers:
 		return wrappers.TryPackIPCertList
+	case AppBytes:
+		return wrappers.TryPackBytes
 	default:
 		return nil
 	}
@@ -105,6 +108,8 @@ func (f Field) Unpacker() func(*wrappers.Packer) interface{} {
 		return wrappers.TryUnpackLong
 	case SignedPeers:
 		return wrappers.TryUnpackIPCertList
+	case AppBytes:
+		return wrappers.TryUnpackBytes
 	default:
 		return nil
 	}
@@ -144,6 +149,8 @@ func (f Field) String() string {
 		return "VersionTime"
 	case SignedPeers:
 		return "SignedPeers"
+	case AppBytes:
+		return "AppBytes"
 	default:
 		return "Unknown Field"
 	}
@@ -32,6 +32,8 @@ const (
 	// Handshake / peer gossiping
 	Version
 	PeerList
+	// Application level:
+	AppGossip
 )
 
 var (
@@ -54,6 +56,7 @@ var (
 		Chits,
 		Version,
 		PeerList,
+		AppGossip,
 	}
 
 	// Defines the messages that can be sent/received with this network
@@ -78,6 +81,8 @@ var (
 		PushQuery: {ChainID, RequestID, Deadline, ContainerID, ContainerBytes},
 		PullQuery: {ChainID, RequestID, Deadline, ContainerID},
 		Chits:     {ChainID, RequestID, ContainerIDs},
+		// Application level:
+		AppGossip: {ChainID, AppBytes},
 	}
 )
 
@@ -126,6 +131,8 @@ func (op Op) String() string {
 		return "pull_query"
 	case Chits:
 		return "chits"
+	case AppGossip:
+		return "app_gossip"
 	default:
 		return "Unknown Op"
 	}
@@ -74,7 +74,8 @@ type metrics struct {
 	getAccepted, accepted,
 	getAncestors, multiPut,
 	get, put,
-	pushQuery, pullQuery, chits messageMetrics
+	pushQuery, pullQuery, chits,
+	appGossip messageMetrics
 }
 
 func (m *metrics) initialize(namespace string, registerer prometheus.Registerer) error {
@@ -159,6 +160,7 @@ func (m *metrics) initialize(namespace string, registerer prometheus.Registerer)
 		m.pushQuery.initialize(message.PushQuery, namespace, registerer),
 		m.pullQuery.initialize(message.PullQuery, namespace, registerer),
 		m.chits.initialize(message.Chits, namespace, registerer),
+		m.appGossip.initialize(message.AppGossip, namespace, registerer),
 	)
 	return errs.Err
 }
@@ -199,6 +201,8 @@ func (m *metrics) message(msgType message.Op) *messageMetrics {
 		return &m.pullQuery
 	case message.Chits:
 		return &m.chits
+	case message.AppGossip:
+		return &m.appGossip
 	default:
 		return nil
 	}
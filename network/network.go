@@ -837,6 +837,53 @@ func (n *network) Gossip(chainID, containerID ids.ID, container []byte) {
 	}
 }
 
+// AppGossip attempts to gossip the application level message to the network.
+// It is sent to as many peers as accepted containers are gossiped to.
+// Assumes [n.stateLock] is not held.
+func (n *network) AppGossip(chainID ids.ID, appGossipBytes []byte) {
+	now := n.clock.Time()
+
+	msg, err := n.b.AppGossip(chainID, appGossipBytes)
+	if err != nil {
+		n.log.Error("failed to build AppGossip(%s): %s. len(appGossipBytes): %d",
+			chainID,
+			err,
+			len(appGossipBytes))
+		n.sendFailRateCalculator.Observe(1, now)
+		return
+	}
+	msgLen := len(msg.Bytes())
+
+	allPeers := n.getAllPeers()
+
+	numToGossip := int(n.gossipOnAcceptSize)
+	if numToGossip > len(allPeers) {
+		numToGossip = len(allPeers)
+	}
+
+	s := sampler.NewUniform()
+	if err := s.Initialize(uint64(len(allPeers))); err != nil {
+		n.log.Error("failed to sample peers for AppGossip(%s): %s", chainID, err)
+		return
+	}
+	indices, err := s.Sample(numToGossip)
+	if err != nil {
+		n.log.Error("failed to sample peers for AppGossip(%s): %s", chainID, err)
+		return
+	}
+	for _, index := range indices {
+		peer := allPeers[int(index)]
+		if peer.Send(msg, false) {
+			n.appGossip.numSent.Inc()
+			n.appGossip.sentBytes.Add(float64(msgLen))
+			n.sendFailRateCalculator.Observe(0, now)
+		} else {
+			n.appGossip.numFailed.Inc()
+			n.sendFailRateCalculator.Observe(1, now)
+		}
+	}
+}
+
 // Accept is called after every consensus decision
 // Assumes [n.stateLock] is not held.
 func (n *network) Accept(ctx *snow.Context, containerID ids.ID, container []byte) error {
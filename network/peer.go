@@ -481,6 +481,8 @@ func (p *peer) handle(msg message.Message, onFinishedHandling func()) {
 		p.handlePullQuery(msg, onFinishedHandling)
 	case message.Chits:
 		p.handleChits(msg, onFinishedHandling)
+	case message.AppGossip:
+		p.handleAppGossip(msg, onFinishedHandling)
 	default:
 		p.net.log.Debug("dropping an unknown message from %s%s at %s with op %s", constants.NodeIDPrefix, p.nodeID, p.getIP(), op)
 		onFinishedHandling()
@@ -1173,6 +1175,20 @@ func (p *peer) handleChits(msg message.Message, onFinishedHandling func()) {
 	)
 }
 
+// assumes the [stateLock] is not held
+func (p *peer) handleAppGossip(msg message.Message, onFinishedHandling func()) {
+	chainID, err := ids.ToID(msg.Get(message.ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	appGossipBytes := msg.Get(message.AppBytes).([]byte)
+
+	p.net.router.AppGossip(
+		p.nodeID,
+		chainID,
+		appGossipBytes,
+		onFinishedHandling,
+	)
+}
+
 // assumes the [stateLock] is held
 func (p *peer) tryMarkFinishedHandshake() {
 	if !p.finishedHandshake.GetValue() && // not already marked as finished with handshake
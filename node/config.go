@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/utils"
@@ -106,6 +107,9 @@ type Config struct {
 
 	// Consensus configuration
 	ConsensusParams avalanche.Parameters
+	// Strategy used to terminate polls of Snowman chains early. Avalanche
+	// chains always use poll.EarlyTermNoTraversal.
+	SnowmanEarlyTermination poll.EarlyTermination
 
 	// IPC configuration
 	IPCAPIEnabled      bool
@@ -120,6 +124,10 @@ type Config struct {
 	RouterHealthConfig       router.HealthConfig
 	ConsensusShutdownTimeout time.Duration
 	ConsensusGossipFrequency time.Duration
+	// Maximum number of chains that may handle a message at the same time
+	ConsensusSchedulerMaxConcurrent int
+	// alias -> weight of the chain when sharing message handling time
+	ConsensusSchedulerChainWeights map[string]uint64
 	// Number of peers to gossip to when gossiping accepted frontier
 	ConsensusGossipAcceptedFrontierSize uint
 	// Number of peers to gossip each accepted container to
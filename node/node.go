@@ -51,6 +51,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/evm"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
@@ -629,6 +630,9 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 		return fmt.Errorf("couldn't initialize chain router: %w", err)
 	}
 
+	// Shares message handling time between the chains
+	scheduler := router.NewScheduler(n.Log, n.Config.ConsensusSchedulerMaxConcurrent)
+
 	fetchOnlyFrom := validators.NewSet()
 	for _, peerID := range n.Config.BootstrapIDs {
 		if err := fetchOnlyFrom.AddWeight(peerID, 1); err != nil {
@@ -649,6 +653,7 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 		Router:                                 n.Config.ConsensusRouter,
 		Net:                                    n.Net,
 		ConsensusParams:                        n.Config.ConsensusParams,
+		SnowmanEarlyTermination:                n.Config.SnowmanEarlyTermination,
 		EpochFirstTransition:                   n.Config.EpochFirstTransition,
 		EpochDuration:                          n.Config.EpochDuration,
 		Validators:                             n.vdrs,
@@ -671,6 +676,8 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 		BootstrapMaxTimeGetAncestors:           n.Config.BootstrapMaxTimeGetAncestors,
 		BootstrapMultiputMaxContainersSent:     n.Config.BootstrapMultiputMaxContainersSent,
 		BootstrapMultiputMaxContainersReceived: n.Config.BootstrapMultiputMaxContainersReceived,
+		Scheduler:                              scheduler,
+		ChainSchedulerWeights:                  n.Config.ConsensusSchedulerChainWeights,
 	})
 
 	vdrs := n.vdrs
@@ -700,14 +707,17 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 			MinStakeDuration:   n.Config.MinStakeDuration,
 			MaxStakeDuration:   n.Config.MaxStakeDuration,
 			StakeMintingPeriod: n.Config.StakeMintingPeriod,
+			ApricotPhase3Time:  version.GetApricotPhase3Time(n.Config.NetworkID),
 		}),
 		n.vmManager.RegisterFactory(avm.ID, &avm.Factory{
-			CreationFee: n.Config.CreationTxFee,
-			Fee:         n.Config.TxFee,
+			CreationFee:       n.Config.CreationTxFee,
+			Fee:               n.Config.TxFee,
+			ApricotPhase3Time: version.GetApricotPhase3Time(n.Config.NetworkID),
 		}),
 		n.vmManager.RegisterFactory(secp256k1fx.ID, &secp256k1fx.Factory{}),
 		n.vmManager.RegisterFactory(nftfx.ID, &nftfx.Factory{}),
 		n.vmManager.RegisterFactory(propertyfx.ID, &propertyfx.Factory{}),
+		n.vmManager.RegisterFactory(htlcfx.ID, &htlcfx.Factory{}),
 	)
 	if errs.Errored() {
 		return errs.Err
@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
 	"github.com/ava-labs/avalanchego/utils/sampler"
 )
 
@@ -51,6 +52,8 @@ var (
 		ErrorOnRejectSiblingTest,
 		ErrorOnTransitiveRejectionTest,
 		RandomizedConsistencyTest,
+		RandomizedConsistencyEarlyTermNoTraversalTest,
+		RandomizedConsistencyEarlyTermTraversalTest,
 	}
 )
 
@@ -1458,6 +1461,24 @@ func ErrorOnTransitiveRejectionTest(t *testing.T, factory Factory) {
 }
 
 func RandomizedConsistencyTest(t *testing.T, factory Factory) {
+	randomizedConsistencyTest(t, factory, nil)
+}
+
+func RandomizedConsistencyEarlyTermNoTraversalTest(t *testing.T, factory Factory) {
+	randomizedConsistencyTest(t, factory, func(alpha int, _ poll.ParentGetter) poll.Factory {
+		return poll.NewEarlyTermNoTraversalFactory(alpha)
+	})
+}
+
+func RandomizedConsistencyEarlyTermTraversalTest(t *testing.T, factory Factory) {
+	randomizedConsistencyTest(t, factory, poll.NewEarlyTermTraversalFactory)
+}
+
+func randomizedConsistencyTest(
+	t *testing.T,
+	factory Factory,
+	newPollFactory func(alpha int, parent poll.ParentGetter) poll.Factory,
+) {
 	numColors := 50
 	numNodes := 100
 	params := snowball.Parameters{
@@ -1477,6 +1498,11 @@ func RandomizedConsistencyTest(t *testing.T, factory Factory) {
 
 	n := Network{}
 	n.Initialize(params, numColors)
+	if newPollFactory != nil {
+		n.newPollFactory = func(parent poll.ParentGetter) poll.Factory {
+			return newPollFactory(params.Alpha, parent)
+		}
+	}
 
 	for i := 0; i < numNodes; i++ {
 		if err := n.AddNode(factory.New()); err != nil {
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
 	"github.com/ava-labs/avalanchego/utils/sampler"
 )
 
@@ -19,6 +20,12 @@ type Network struct {
 	params         snowball.Parameters
 	colors         []*TestBlock
 	nodes, running []Consensus
+
+	// If [newPollFactory] is non-nil, votes are collected through a poll that
+	// may terminate before all the sampled nodes have responded.
+	newPollFactory func(parent poll.ParentGetter) poll.Factory
+	nodeIDs        []ids.ShortID
+	pollFactories  map[Consensus]poll.Factory
 }
 
 func (n *Network) shuffleColors() {
@@ -67,6 +74,8 @@ func (n *Network) AddNode(sm Consensus) error {
 
 	n.shuffleColors()
 	deps := map[ids.ID]Block{}
+	blocks := map[ids.ID]*TestBlock{}
+	parentIDs := map[ids.ID]ids.ID{}
 	for _, blk := range n.colors {
 		myDep, found := deps[blk.ParentV.ID()]
 		if !found {
@@ -86,7 +95,25 @@ func (n *Network) AddNode(sm Consensus) error {
 			return err
 		}
 		deps[myVtx.ID()] = myDep
+		blocks[myVtx.ID()] = myVtx
+		parentIDs[myVtx.ID()] = blk.ParentV.ID()
+	}
+	if n.newPollFactory != nil {
+		if n.pollFactories == nil {
+			n.pollFactories = make(map[Consensus]poll.Factory)
+		}
+		n.pollFactories[sm] = n.newPollFactory(func(blkID ids.ID) (ids.ID, bool) {
+			if blk, ok := blocks[blkID]; !ok || blk.Status() != choices.Processing {
+				return ids.ID{}, false
+			}
+			parentID := parentIDs[blkID]
+			if parent, ok := blocks[parentID]; !ok || parent.Status() != choices.Processing {
+				return ids.ID{}, false
+			}
+			return parentID, true
+		})
 	}
+	n.nodeIDs = append(n.nodeIDs, ids.GenerateTestShortID())
 	n.nodes = append(n.nodes, sm)
 	n.running = append(n.running, sm)
 	return nil
@@ -106,9 +133,25 @@ func (n *Network) Round() error {
 	_ = s.Initialize(uint64(len(n.nodes)))
 	indices, _ := s.Sample(n.params.K)
 	sampledColors := ids.Bag{}
-	for _, index := range indices {
-		peer := n.nodes[int(index)]
-		sampledColors.Add(peer.Preference())
+	if factory, ok := n.pollFactories[running]; ok {
+		vdrs := ids.ShortBag{}
+		for _, index := range indices {
+			vdrs.Add(n.nodeIDs[int(index)])
+		}
+		p := factory.New(vdrs)
+		for _, index := range indices {
+			if p.Finished() {
+				break
+			}
+			peer := n.nodes[int(index)]
+			p.Vote(n.nodeIDs[int(index)], peer.Preference())
+		}
+		sampledColors = p.Result()
+	} else {
+		for _, index := range indices {
+			peer := n.nodes[int(index)]
+			sampledColors.Add(peer.Preference())
+		}
 	}
 
 	if err := running.RecordPoll(sampledColors); err != nil {
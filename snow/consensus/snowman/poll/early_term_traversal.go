@@ -0,0 +1,103 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// ParentGetter returns the parent of the processing block [blkID]. If either
+// [blkID] or its parent isn't currently processing, false should be returned.
+type ParentGetter func(blkID ids.ID) (ids.ID, bool)
+
+type earlyTermTraversalFactory struct {
+	alpha  int
+	parent ParentGetter
+}
+
+// NewEarlyTermTraversalFactory returns a factory that returns polls with
+// early termination, using [parent] to traverse the processing tree
+func NewEarlyTermTraversalFactory(alpha int, parent ParentGetter) Factory {
+	return &earlyTermTraversalFactory{
+		alpha:  alpha,
+		parent: parent,
+	}
+}
+
+func (f *earlyTermTraversalFactory) New(vdrs ids.ShortBag) Poll {
+	return &earlyTermTraversalPoll{
+		polled: vdrs,
+		alpha:  f.alpha,
+		parent: f.parent,
+	}
+}
+
+// earlyTermTraversalPoll finishes when any remaining validators can't change
+// the result of the poll for any block in the processing tree. A vote for a
+// block is treated as a vote for all of its processing ancestors.
+type earlyTermTraversalPoll struct {
+	votes  ids.Bag
+	polled ids.ShortBag
+	alpha  int
+	parent ParentGetter
+}
+
+// Vote registers a response for this poll
+func (p *earlyTermTraversalPoll) Vote(vdr ids.ShortID, vote ids.ID) {
+	count := p.polled.Count(vdr)
+	// make sure that a validator can't respond multiple times
+	p.polled.Remove(vdr)
+
+	// track the votes the validator responded with
+	p.votes.AddCount(vote, count)
+}
+
+// Drop any future response for this poll
+func (p *earlyTermTraversalPoll) Drop(vdr ids.ShortID) {
+	p.polled.Remove(vdr)
+}
+
+// Finished returns true when all validators have voted or when none of the
+// remaining validators can change whether any block receives an alpha
+// majority of transitive votes
+func (p *earlyTermTraversalPoll) Finished() bool {
+	remaining := p.polled.Len()
+	received := p.votes.Len()
+	switch {
+	case remaining == 0: // All k nodes responded
+		return true
+	case received+remaining < p.alpha: // An alpha majority can never return
+		return true
+	case remaining >= p.alpha: // The remaining nodes could form an alpha majority for any block
+		return false
+	}
+
+	transitiveVotes := ids.Bag{}
+	for _, vote := range p.votes.List() {
+		count := p.votes.Count(vote)
+		for blkID, ok := vote, true; ok; blkID, ok = p.parent(blkID) {
+			transitiveVotes.AddCount(blkID, count)
+		}
+	}
+	for _, blkID := range transitiveVotes.List() {
+		count := transitiveVotes.Count(blkID)
+		// If the remaining nodes could push this block over the alpha
+		// threshold, the poll must keep waiting.
+		if count < p.alpha && count+remaining >= p.alpha {
+			return false
+		}
+	}
+	return true
+}
+
+// Result returns the result of this poll
+func (p *earlyTermTraversalPoll) Result() ids.Bag { return p.votes }
+
+func (p *earlyTermTraversalPoll) PrefixedString(prefix string) string {
+	return fmt.Sprintf("waiting on %s", p.polled.PrefixedString(prefix))
+}
+
+func (p *earlyTermTraversalPoll) String() string { return p.PrefixedString("") }
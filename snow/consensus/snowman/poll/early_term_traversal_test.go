@@ -0,0 +1,241 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// newTestParentGetter returns a ParentGetter where every block in [parents] is
+// processing and has the provided parent
+func newTestParentGetter(parents map[ids.ID]ids.ID) ParentGetter {
+	return func(blkID ids.ID) (ids.ID, bool) {
+		parentID, ok := parents[blkID]
+		if !ok {
+			return ids.ID{}, false
+		}
+		if _, ok := parents[parentID]; !ok {
+			return ids.ID{}, false
+		}
+		return parentID, true
+	}
+}
+
+func TestEarlyTermTraversalResults(t *testing.T) {
+	alpha := 1
+
+	blkID := ids.ID{1}
+
+	vdr1 := ids.ShortID{1} // k = 1
+
+	vdrs := ids.ShortBag{}
+	vdrs.Add(vdr1)
+
+	factory := NewEarlyTermTraversalFactory(alpha, newTestParentGetter(nil))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate after receiving k votes")
+	}
+
+	result := poll.Result()
+	if list := result.List(); len(list) != 1 {
+		t.Fatalf("Wrong number of blocks returned")
+	} else if retBlkID := list[0]; retBlkID != blkID {
+		t.Fatalf("Wrong block returned")
+	} else if result.Count(blkID) != 1 {
+		t.Fatalf("Wrong number of votes returned")
+	}
+}
+
+func TestEarlyTermTraversalString(t *testing.T) {
+	alpha := 2
+
+	blkID := ids.ID{1}
+
+	vdr1 := ids.ShortID{1}
+	vdr2 := ids.ShortID{2} // k = 2
+
+	vdrs := ids.ShortBag{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+	)
+
+	factory := NewEarlyTermTraversalFactory(alpha, newTestParentGetter(nil))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID)
+
+	expected := "waiting on Bag: (Size = 1)\n" +
+		"    ID[BaMPFdqMUQ46BV8iRcwbVfsam55kMqcp]: Count = 1"
+	if result := poll.String(); expected != result {
+		t.Fatalf("Poll should have returned %s but returned %s", expected, result)
+	}
+}
+
+func TestEarlyTermTraversalDropsDuplicatedVotes(t *testing.T) {
+	alpha := 2
+
+	blkID := ids.ID{1}
+
+	vdr1 := ids.ShortID{1}
+	vdr2 := ids.ShortID{2} // k = 2
+
+	vdrs := ids.ShortBag{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+	)
+
+	factory := NewEarlyTermTraversalFactory(alpha, newTestParentGetter(nil))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID)
+	if poll.Finished() {
+		t.Fatalf("Poll finished after less than alpha votes")
+	}
+	poll.Vote(vdr1, blkID)
+	if poll.Finished() {
+		t.Fatalf("Poll finished after getting a duplicated vote")
+	}
+	poll.Vote(vdr2, blkID)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate after receiving k votes")
+	}
+}
+
+func TestEarlyTermTraversalTerminatesEarly(t *testing.T) {
+	alpha := 3
+
+	// blkID1 <- blkID2
+	// blkID3
+	blkID0 := ids.ID{0}
+	blkID1 := ids.ID{1}
+	blkID2 := ids.ID{2}
+	blkID3 := ids.ID{3}
+	parents := map[ids.ID]ids.ID{
+		blkID1: blkID0,
+		blkID2: blkID1,
+		blkID3: blkID0,
+	}
+
+	vdr1 := ids.ShortID{1}
+	vdr2 := ids.ShortID{2}
+	vdr3 := ids.ShortID{3}
+	vdr4 := ids.ShortID{4}
+	vdr5 := ids.ShortID{5} // k = 5
+
+	vdrs := ids.ShortBag{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+		vdr3,
+		vdr4,
+		vdr5,
+	)
+
+	factory := NewEarlyTermTraversalFactory(alpha, newTestParentGetter(parents))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	poll.Vote(vdr2, blkID1)
+	if poll.Finished() {
+		t.Fatalf("Poll finished while the remaining validators could form an alpha majority")
+	}
+	poll.Vote(vdr3, blkID2)
+	if poll.Finished() {
+		t.Fatalf("Poll finished while the remaining validators could change the result for %s", blkID2)
+	}
+	poll.Vote(vdr4, blkID3)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate after no block could change its result")
+	}
+
+	result := poll.Result()
+	if count := result.Count(blkID1); count != 2 {
+		t.Fatalf("Expected 2 direct votes for %s but got %d", blkID1, count)
+	}
+	if count := result.Count(blkID2); count != 1 {
+		t.Fatalf("Expected 1 direct vote for %s but got %d", blkID2, count)
+	}
+}
+
+func TestEarlyTermTraversalWaitsForChild(t *testing.T) {
+	alpha := 3
+
+	// blkID1 <- blkID2
+	blkID0 := ids.ID{0}
+	blkID1 := ids.ID{1}
+	blkID2 := ids.ID{2}
+	parents := map[ids.ID]ids.ID{
+		blkID1: blkID0,
+		blkID2: blkID1,
+	}
+
+	vdr1 := ids.ShortID{1}
+	vdr2 := ids.ShortID{2}
+	vdr3 := ids.ShortID{3}
+	vdr4 := ids.ShortID{4}
+	vdr5 := ids.ShortID{5} // k = 5
+
+	vdrs := ids.ShortBag{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+		vdr3,
+		vdr4,
+		vdr5,
+	)
+
+	factory := NewEarlyTermTraversalFactory(alpha, newTestParentGetter(parents))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID2)
+	poll.Vote(vdr2, blkID2)
+	poll.Vote(vdr3, blkID1)
+	poll.Vote(vdr4, blkID1)
+	if poll.Finished() {
+		t.Fatalf("Poll finished while the last validator could give %s an alpha majority", blkID2)
+	}
+	poll.Vote(vdr5, blkID2)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate after receiving k votes")
+	}
+}
+
+func TestEarlyTermTraversalDropWithWeightedResponses(t *testing.T) {
+	alpha := 2
+
+	blkID := ids.ID{1}
+
+	vdr1 := ids.ShortID{1}
+	vdr2 := ids.ShortID{2} // k = 3
+
+	vdrs := ids.ShortBag{}
+	vdrs.Add(
+		vdr1,
+		vdr1,
+		vdr2,
+	)
+
+	factory := NewEarlyTermTraversalFactory(alpha, newTestParentGetter(nil))
+	poll := factory.New(vdrs)
+
+	poll.Drop(vdr2)
+	if poll.Finished() {
+		t.Fatalf("Poll finished while an alpha majority could still return")
+	}
+	poll.Vote(vdr1, blkID)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate after receiving an alpha majority")
+	}
+	result := poll.Result()
+	if count := result.Count(blkID); count != 2 {
+		t.Fatalf("Expected the weighted vote to count twice but got %d", count)
+	}
+}
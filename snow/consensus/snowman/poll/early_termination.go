@@ -0,0 +1,50 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"fmt"
+)
+
+// EarlyTermination describes when a poll is allowed to finish before every
+// polled validator has responded
+type EarlyTermination string
+
+const (
+	// NoEarlyTerm polls only finish once every polled validator has responded
+	// or the query has failed
+	NoEarlyTerm EarlyTermination = "none"
+	// EarlyTermNoTraversal polls finish once a single block can't change
+	// whether it receives an alpha majority
+	EarlyTermNoTraversal EarlyTermination = "no-traversal"
+	// EarlyTermTraversal polls finish once no block in the processing tree can
+	// change whether it receives an alpha majority of transitive votes
+	EarlyTermTraversal EarlyTermination = "traversal"
+)
+
+// ParseEarlyTermination returns the EarlyTermination described by [s]
+func ParseEarlyTermination(s string) (EarlyTermination, error) {
+	switch earlyTerm := EarlyTermination(s); earlyTerm {
+	case NoEarlyTerm, EarlyTermNoTraversal, EarlyTermTraversal:
+		return earlyTerm, nil
+	default:
+		return "", fmt.Errorf("unknown poll early termination %q", s)
+	}
+}
+
+// NewFactory returns the poll factory described by [earlyTerm]. [parent] is
+// only used by EarlyTermTraversal. The empty EarlyTermination defaults to
+// EarlyTermNoTraversal.
+func (earlyTerm EarlyTermination) NewFactory(alpha int, parent ParentGetter) (Factory, error) {
+	switch earlyTerm {
+	case NoEarlyTerm:
+		return NewNoEarlyTermFactory(), nil
+	case EarlyTermNoTraversal, "":
+		return NewEarlyTermNoTraversalFactory(alpha), nil
+	case EarlyTermTraversal:
+		return NewEarlyTermTraversalFactory(alpha, parent), nil
+	default:
+		return nil, fmt.Errorf("unknown poll early termination %q", earlyTerm)
+	}
+}
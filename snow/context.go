@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
@@ -65,6 +66,10 @@ type Context struct {
 	EpochDuration        time.Duration
 	Clock                timer.Clock
 
+	// Validators of the subnet this chain is validated by. VMs can register
+	// to be notified of the changes to the set.
+	Validators validators.Set
+
 	// Non-zero iff this chain bootstrapped. Should only be accessed atomically.
 	bootstrapped uint32
 }
@@ -19,6 +19,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 const (
@@ -393,9 +395,18 @@ func (b *Bootstrapper) ForceAccepted(acceptedContainerIDs []ids.ID) error {
 			err)
 	}
 
-	b.NumFetched = 0
+	// Vertices that were fetched before a restart are still on the job queue,
+	// so they count towards the fetched total.
+	b.NumFetched = uint32(safemath.Min64(b.VtxBlocked.PendingJobs(), math.MaxUint32))
 
 	pendingContainerIDs := b.VtxBlocked.MissingIDs()
+	if !b.Restarted && (b.NumFetched > 0 || len(pendingContainerIDs) > 0) {
+		b.Ctx.Log.Info("resuming bootstrapping with %d fetched vertices, %d fetched transactions and %d missing vertices",
+			b.NumFetched,
+			b.TxBlocked.PendingJobs(),
+			len(pendingContainerIDs),
+		)
+	}
 	// Append the list of accepted container IDs to pendingContainerIDs to ensure
 	// we iterate over every container that must be traversed.
 	pendingContainerIDs = append(pendingContainerIDs, acceptedContainerIDs...)
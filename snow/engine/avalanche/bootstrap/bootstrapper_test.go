@@ -863,6 +863,185 @@ func TestBootstrapperIncompleteMultiPut(t *testing.T) {
 	}
 }
 
+// Test that a bootstrapper that is recreated on top of the same database
+// resumes fetching the missing vertices without refetching vertices that were
+// already fetched.
+func TestBootstrapperResumeAfterRestart(t *testing.T) {
+	config, peerID, sender, manager, vm := newConfig(t)
+
+	db := memdb.New()
+	newQueues := func() (*queue.JobsWithMissing, *queue.Jobs) {
+		vtxBlocker, err := queue.NewWithMissing(prefixdb.New([]byte("vtx"), db), "vtx", prometheus.NewRegistry())
+		if err != nil {
+			t.Fatal(err)
+		}
+		txBlocker, err := queue.New(prefixdb.New([]byte("tx"), db), "tx", prometheus.NewRegistry())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return vtxBlocker, txBlocker
+	}
+	config.VtxBlocked, config.TxBlocked = newQueues()
+
+	vtxID0 := ids.Empty.Prefix(0)
+	vtxID1 := ids.Empty.Prefix(1)
+	vtxID2 := ids.Empty.Prefix(2)
+
+	vtxBytes0 := []byte{0}
+	vtxBytes1 := []byte{1}
+	vtxBytes2 := []byte{2}
+
+	vtx0 := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     vtxID0,
+			StatusV: choices.Unknown,
+		},
+		HeightV: 0,
+		BytesV:  vtxBytes0,
+	}
+	vtx1 := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     vtxID1,
+			StatusV: choices.Unknown,
+		},
+		ParentsV: []avalanche.Vertex{vtx0},
+		HeightV:  1,
+		BytesV:   vtxBytes1,
+	}
+	vtx2 := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     vtxID2,
+			StatusV: choices.Processing,
+		},
+		ParentsV: []avalanche.Vertex{vtx1},
+		HeightV:  2,
+		BytesV:   vtxBytes2,
+	}
+
+	manager.GetVtxF = func(vtxID ids.ID) (avalanche.Vertex, error) {
+		switch vtxID {
+		case vtxID0:
+			if vtx0.StatusV == choices.Unknown {
+				return nil, errUnknownVertex
+			}
+			return vtx0, nil
+		case vtxID1:
+			if vtx1.StatusV == choices.Unknown {
+				return nil, errUnknownVertex
+			}
+			return vtx1, nil
+		case vtxID2:
+			return vtx2, nil
+		default:
+			t.Fatal(errUnknownVertex)
+			panic(errUnknownVertex)
+		}
+	}
+	manager.ParseVtxF = func(vtxBytes []byte) (avalanche.Vertex, error) {
+		switch {
+		case bytes.Equal(vtxBytes, vtxBytes0):
+			vtx0.StatusV = choices.Processing
+			return vtx0, nil
+		case bytes.Equal(vtxBytes, vtxBytes1):
+			vtx1.StatusV = choices.Processing
+			return vtx1, nil
+		case bytes.Equal(vtxBytes, vtxBytes2):
+			return vtx2, nil
+		}
+		t.Fatal(errParsedUnknownVertex)
+		return nil, errParsedUnknownVertex
+	}
+	reqIDPtr := new(uint32)
+	requested := ids.Empty
+	sender.GetAncestorsF = func(vdr ids.ShortID, reqID uint32, vtxID ids.ID) {
+		if vdr != peerID {
+			t.Fatalf("Should have requested vertex from %s, requested from %s", peerID, vdr)
+		}
+		switch vtxID {
+		case vtxID1, vtxID0:
+		default:
+			t.Fatal(errUnknownVertex)
+		}
+		*reqIDPtr = reqID
+		requested = vtxID
+	}
+
+	vm.CantBootstrapping = false
+
+	bs := Bootstrapper{}
+	err := bs.Initialize(
+		config,
+		func() error { return nil },
+		fmt.Sprintf("%s_%s_bs", constants.PlatformName, config.Ctx.ChainID),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bs.ForceAccepted([]ids.ID{vtxID2}); err != nil { // should request vtx1
+		t.Fatal(err)
+	} else if requested != vtxID1 {
+		t.Fatal("requested wrong vtx")
+	}
+
+	if err := bs.MultiPut(peerID, *reqIDPtr, [][]byte{vtxBytes1}); err != nil { // should request vtx0
+		t.Fatal(err)
+	} else if requested != vtxID0 {
+		t.Fatal("should have requested vtx0")
+	}
+
+	// Simulate a restart by creating a new bootstrapper using the same
+	// database.
+	config.VtxBlocked, config.TxBlocked = newQueues()
+	if pending := config.VtxBlocked.PendingJobs(); pending != 2 {
+		t.Fatalf("expected 2 pending vertex jobs but got %d", pending)
+	}
+	if missing := config.VtxBlocked.MissingIDs(); len(missing) != 1 || missing[0] != vtxID0 {
+		t.Fatalf("expected only %s to be missing but got %v", vtxID0, missing)
+	}
+
+	requested = ids.Empty
+	finished := new(bool)
+	bs = Bootstrapper{}
+	err = bs.Initialize(
+		config,
+		func() error { *finished = true; return nil },
+		fmt.Sprintf("%s_%s_bs", constants.PlatformName, config.Ctx.ChainID),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bs.ForceAccepted([]ids.ID{vtxID2}); err != nil { // should request vtx0
+		t.Fatal(err)
+	} else if requested != vtxID0 {
+		t.Fatal("should have resumed by requesting vtx0")
+	} else if bs.NumFetched != 2 {
+		t.Fatalf("expected to resume with 2 fetched vertices but got %d", bs.NumFetched)
+	}
+
+	vm.CantBootstrapped = false
+
+	err = bs.MultiPut(peerID, *reqIDPtr, [][]byte{vtxBytes0})
+	switch {
+	case err != nil: // Provide vtx0; can finish now
+		t.Fatal(err)
+	case !*finished:
+		t.Fatal("should have finished")
+	case vtx0.Status() != choices.Accepted:
+		t.Fatal("should be accepted")
+	case vtx1.Status() != choices.Accepted:
+		t.Fatal("should be accepted")
+	case vtx2.Status() != choices.Accepted:
+		t.Fatal("should be accepted")
+	}
+	if pending := bs.VtxBlocked.PendingJobs(); pending != 0 {
+		t.Fatalf("expected no pending vertex jobs but got %d", pending)
+	}
+}
+
 func TestBootstrapperFinalized(t *testing.T) {
 	config, peerID, sender, manager, vm := newConfig(t)
 
@@ -48,6 +48,20 @@ func (_m *Engine) AcceptedFrontier(validatorID ids.ShortID, requestID uint32, co
 	return r0
 }
 
+// AppGossip provides a mock function with given fields: nodeID, msg
+func (_m *Engine) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	ret := _m.Called(nodeID, msg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ids.ShortID, []byte) error); ok {
+		r0 = rf(nodeID, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Chits provides a mock function with given fields: validatorID, requestID, containerIDs
 func (_m *Engine) Chits(validatorID ids.ShortID, requestID uint32, containerIDs []ids.ID) error {
 	ret := _m.Called(validatorID, requestID, containerIDs)
@@ -137,6 +137,16 @@ func (t *Transitive) Gossip() error {
 	return nil
 }
 
+// AppGossip implements the Engine interface
+func (t *Transitive) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	appVM, ok := t.VM.(common.AppHandler)
+	if !ok {
+		t.Ctx.Log.Verbo("dropping AppGossip from %s as the VM doesn't handle application level messages", nodeID)
+		return nil
+	}
+	return appVM.AppGossip(nodeID, msg)
+}
+
 // Shutdown implements the Engine interface
 func (t *Transitive) Shutdown() error {
 	t.Ctx.Log.Info("shutting down consensus engine")
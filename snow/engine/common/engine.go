@@ -40,6 +40,7 @@ type ExternalHandler interface {
 	AcceptedHandler
 	FetchHandler
 	QueryHandler
+	AppHandler
 }
 
 // FrontierHandler defines how a consensus engine reacts to frontier messages
@@ -280,6 +281,19 @@ type QueryHandler interface {
 	QueryFailed(validatorID ids.ShortID, requestID uint32) error
 }
 
+// AppHandler defines how a consensus engine reacts to application level
+// messages from other nodes. Returned errors should be treated as fatal and
+// require the chain to shutdown.
+type AppHandler interface {
+	// Notify this engine of an application level message gossiped by another
+	// node.
+	//
+	// This function can be called by any node. The nodeID is assumed to be
+	// authenticated, but the contents of [msg] are not. Invalid messages
+	// should be dropped rather than returning an error.
+	AppGossip(nodeID ids.ShortID, msg []byte) error
+}
+
 // InternalHandler defines how this consensus engine reacts to messages from
 // other components of this validator. Functions only return fatal errors if
 // they occur.
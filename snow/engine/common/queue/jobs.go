@@ -52,6 +52,10 @@ func (j *Jobs) SetParser(parser Parser) error { j.state.parser = parser; return
 
 func (j *Jobs) Has(jobID ids.ID) (bool, error) { return j.state.HasJob(jobID) }
 
+// PendingJobs returns the number of jobs that have been pushed but not yet
+// executed. This includes jobs that were pushed before a restart.
+func (j *Jobs) PendingJobs() uint64 { return j.state.NumJobs() }
+
 // Push adds a new job to the queue. Returns true if [job] was added to the queue and false
 // if [job] was already in the queue.
 func (j *Jobs) Push(job Job) (bool, error) {
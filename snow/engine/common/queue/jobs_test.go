@@ -453,3 +453,96 @@ func TestHandleJobWithMissingDependencyOnRunnableStack(t *testing.T) {
 	assert.Equal(2, count)
 	assert.True(executed1)
 }
+
+// Test that the number of pending jobs is persisted across restarts and is
+// cleared once all the jobs have been executed.
+func TestPendingJobs(t *testing.T) {
+	assert := assert.New(t)
+
+	parser := &TestParser{T: t}
+	db := memdb.New()
+
+	jobs, err := New(db, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := jobs.SetParser(parser); err != nil {
+		t.Fatal(err)
+	}
+
+	jobID0 := ids.GenerateTestID()
+	jobID1 := ids.GenerateTestID()
+	executed0 := new(bool)
+	job0 := &TestJob{
+		T: t,
+
+		IDF:                     func() ids.ID { return jobID0 },
+		MissingDependenciesF:    func() (ids.Set, error) { return ids.Set{}, nil },
+		HasMissingDependenciesF: func() (bool, error) { return false, nil },
+		ExecuteF:                func() error { *executed0 = true; return nil },
+		BytesF:                  func() []byte { return []byte{0} },
+	}
+	job1 := &TestJob{
+		T: t,
+
+		IDF: func() ids.ID { return jobID1 },
+		MissingDependenciesF: func() (ids.Set, error) {
+			if *executed0 {
+				return ids.Set{}, nil
+			}
+			s := ids.Set{}
+			s.Add(jobID0)
+			return s, nil
+		},
+		HasMissingDependenciesF: func() (bool, error) { return !*executed0, nil },
+		ExecuteF:                func() error { return nil },
+		BytesF:                  func() []byte { return []byte{1} },
+	}
+
+	assert.Zero(jobs.PendingJobs())
+
+	pushed, err := jobs.Push(job1)
+	assert.NoError(err)
+	assert.True(pushed)
+
+	pushed, err = jobs.Push(job1)
+	assert.NoError(err)
+	assert.False(pushed)
+	assert.Equal(uint64(1), jobs.PendingJobs())
+
+	pushed, err = jobs.Push(job0)
+	assert.NoError(err)
+	assert.True(pushed)
+	assert.Equal(uint64(2), jobs.PendingJobs())
+
+	err = jobs.Commit()
+	assert.NoError(err)
+
+	jobs, err = New(db, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	if err := jobs.SetParser(parser); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(uint64(2), jobs.PendingJobs())
+
+	parser.ParseF = func(b []byte) (Job, error) {
+		switch {
+		case bytes.Equal(b, []byte{0}):
+			return job0, nil
+		case bytes.Equal(b, []byte{1}):
+			return job1, nil
+		default:
+			assert.FailNow("Unknown job")
+			return nil, nil
+		}
+	}
+
+	count, err := jobs.ExecuteAll(snow.DefaultContextTest(), &common.Halter{}, false)
+	assert.NoError(err)
+	assert.Equal(2, count)
+	assert.Zero(jobs.PendingJobs())
+
+	dbSize, err := database.Size(db)
+	assert.NoError(err)
+	assert.Zero(dbSize)
+}
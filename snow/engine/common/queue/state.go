@@ -41,6 +41,10 @@ type state struct {
 	// made.
 	dependentsCache cache.Cacher
 	missingJobIDs   linkeddb.LinkedDB
+	// Number of jobs that have been pushed but not yet executed. It is counted
+	// from the jobs in the database when the queue is loaded and is only
+	// tracked in memory afterwards.
+	numJobs uint64
 }
 
 func newState(
@@ -53,7 +57,7 @@ func newState(
 	if err != nil {
 		return nil, fmt.Errorf("couldn't create metered cache: %s", err)
 	}
-	return &state{
+	s := &state{
 		runnableJobIDs:  linkeddb.NewDefault(prefixdb.New(runnableJobIDsKey, db)),
 		cachingEnabled:  true,
 		jobsCache:       jobsCache,
@@ -61,7 +65,23 @@ func newState(
 		dependencies:    prefixdb.New(dependenciesKey, db),
 		dependentsCache: &cache.LRU{Size: dependentsCacheSize},
 		missingJobIDs:   linkeddb.NewDefault(prefixdb.New(missingJobIDsKey, db)),
-	}, nil
+	}
+	if err := s.countJobs(); err != nil {
+		return nil, fmt.Errorf("couldn't count the pending jobs: %w", err)
+	}
+	return s, nil
+}
+
+// countJobs sets the number of jobs to the number of jobs in the queue
+func (s *state) countJobs() error {
+	iterator := s.jobs.NewIterator()
+	defer iterator.Release()
+
+	s.numJobs = 0
+	for iterator.Next() {
+		s.numJobs++
+	}
+	return iterator.Error()
 }
 
 // AddRunnableJob adds [jobID] to the runnable queue
@@ -93,18 +113,33 @@ func (s *state) RemoveRunnableJob() (Job, error) {
 	if err != nil {
 		return nil, err
 	}
-	return job, s.jobs.Delete(jobIDBytes)
+	if err := s.jobs.Delete(jobIDBytes); err != nil {
+		return nil, err
+	}
+	if s.numJobs > 0 {
+		s.numJobs--
+	}
+	return job, nil
 }
 
-// PutJob adds the job to the queue
+// PutJob adds the job to the queue. It is assumed that [job] isn't already in
+// the queue.
 func (s *state) PutJob(job Job) error {
 	id := job.ID()
 	if s.cachingEnabled {
 		s.jobsCache.Put(id, job)
 	}
-	return s.jobs.Put(id[:], job.Bytes())
+	if err := s.jobs.Put(id[:], job.Bytes()); err != nil {
+		return err
+	}
+	s.numJobs++
+	return nil
 }
 
+// NumJobs returns the number of jobs that have been pushed onto the queue but
+// not yet executed
+func (s *state) NumJobs() uint64 { return s.numJobs }
+
 // HasJob returns true if the job [id] is in the queue
 func (s *state) HasJob(id ids.ID) (bool, error) {
 	if s.cachingEnabled {
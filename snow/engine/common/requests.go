@@ -47,6 +47,12 @@ func (r *Requests) Add(vdr ids.ShortID, requestID uint32, containerID ids.ID) {
 	}
 }
 
+// Get the containerID the request is expecting and if the request exists.
+func (r *Requests) Get(vdr ids.ShortID, requestID uint32) (ids.ID, bool) {
+	containerID, ok := r.reqsToID[vdr][requestID]
+	return containerID, ok
+}
+
 // Remove attempts to abandon a requestID sent to a validator. If the request is
 // currently outstanding, the requested ID will be returned along with true. If
 // the request isn't currently outstanding, false will be returned.
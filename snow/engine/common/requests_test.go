@@ -31,6 +31,13 @@ func TestRequests(t *testing.T) {
 	length = req.Len()
 	assert.Equal(t, 1, length, "should have had one outstanding request")
 
+	containerID, exists := req.Get(ids.ShortEmpty, 0)
+	assert.Equal(t, ids.Empty, containerID, "should have returned the requested ID")
+	assert.True(t, exists, "should have found the request")
+
+	_, exists = req.Get(ids.ShortEmpty, 1)
+	assert.False(t, exists, "shouldn't have found the request")
+
 	_, removed = req.Remove(ids.ShortEmpty, 1)
 	assert.False(t, removed, "shouldn't have removed the request")
 
@@ -15,6 +15,7 @@ type Sender interface {
 	FetchSender
 	QuerySender
 	Gossiper
+	AppSender
 }
 
 // FrontierSender defines how a consensus engine sends frontier messages to
@@ -86,3 +87,11 @@ type Gossiper interface {
 	// Gossip gossips the provided container throughout the network
 	Gossip(containerID ids.ID, container []byte)
 }
+
+// AppSender defines how a VM sends application level messages to the instances
+// of the VM running on other nodes
+type AppSender interface {
+	// SendAppGossip gossips the provided application level message to a
+	// random subset of the network
+	SendAppGossip(appGossipBytes []byte) error
+}
@@ -46,6 +46,8 @@ type EngineTest struct {
 	CantQueryFailed,
 	CantChits,
 
+	CantAppGossip,
+
 	CantConnected,
 	CantDisconnected,
 
@@ -64,6 +66,7 @@ type EngineTest struct {
 	AcceptedFrontierF, GetAcceptedF, AcceptedF, ChitsF func(validatorID ids.ShortID, requestID uint32, containerIDs []ids.ID) error
 	GetAcceptedFrontierF, GetFailedF, GetAncestorsFailedF,
 	QueryFailedF, GetAcceptedFrontierFailedF, GetAcceptedFailedF func(validatorID ids.ShortID, requestID uint32) error
+	AppGossipF                func(nodeID ids.ShortID, msg []byte) error
 	ConnectedF, DisconnectedF func(validatorID ids.ShortID) error
 	HealthF                   func() (interface{}, error)
 	GetVtxF                   func() (avalanche.Vertex, error)
@@ -104,6 +107,8 @@ func (e *EngineTest) Default(cant bool) {
 	e.CantQueryFailed = cant
 	e.CantChits = cant
 
+	e.CantAppGossip = cant
+
 	e.CantConnected = cant
 	e.CantDisconnected = cant
 
@@ -391,6 +396,19 @@ func (e *EngineTest) Chits(validatorID ids.ShortID, requestID uint32, containerI
 	return errors.New("unexpectedly called Chits")
 }
 
+func (e *EngineTest) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	if e.AppGossipF != nil {
+		return e.AppGossipF(nodeID, msg)
+	}
+	if !e.CantAppGossip {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called AppGossip")
+	}
+	return errors.New("unexpectedly called AppGossip")
+}
+
 func (e *EngineTest) Connected(validatorID ids.ShortID) error {
 	if e.ConnectedF != nil {
 		return e.ConnectedF(validatorID)
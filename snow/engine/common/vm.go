@@ -88,3 +88,16 @@ type VM interface {
 	// information about their accounts.
 	CreateHandlers() (map[string]*HTTPHandler, error)
 }
+
+// AppVM is an optional interface for VMs that exchange application level
+// messages with the instances of the VM running on other nodes.
+type AppVM interface {
+	// AppGossip is called when another node gossips an application level
+	// message to this chain.
+	AppHandler
+
+	// SetAppSender gives the VM the sender it uses to send application level
+	// messages. It is called once, after Initialize, before the chain starts
+	// bootstrapping.
+	SetAppSender(appSender AppSender)
+}
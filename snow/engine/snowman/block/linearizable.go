@@ -0,0 +1,13 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+// Linearizable is a VM that can be run on a linear chain in place of its
+// default consensus engine
+type Linearizable interface {
+	// Linearize returns the ChainVM that runs this VM on a linear chain if
+	// the network has activated it. Otherwise, false is returned and the VM
+	// should be run as it is.
+	Linearize() (ChainVM, bool, error)
+}
@@ -0,0 +1,668 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+)
+
+const (
+	// simBlockLen is the length of the serialized blocks used in the
+	// simulation: the block ID, the parent ID and the height.
+	simBlockLen = 2*32 + 8
+
+	// simMaxLatency is the maximum delay of a message in the simulation
+	simMaxLatency = 10
+
+	// simRequestTimeout is the delay after which an unanswered request fails
+	simRequestTimeout = 20 * simMaxLatency
+
+	// simMaxSteps bounds the number of messages delivered in a single run. If
+	// the network hasn't quiesced by then, liveness has been violated.
+	simMaxSteps = 1000000
+
+	// simMaxCraftedBlocks bounds the number of blocks a byzantine node may
+	// issue, so that a byzantine node can't extend the chain forever.
+	simMaxCraftedBlocks = 3
+)
+
+var errGarbageBlock = errors.New("unexpected block length")
+
+type simOp int
+
+const (
+	simBuild simOp = iota
+	simGet
+	simPut
+	simPushQuery
+	simPullQuery
+	simChits
+	simGetFailed
+	simQueryFailed
+	simTimedOut
+)
+
+// simMessage is a message that is delivered to node [to] at [time]
+type simMessage struct {
+	time uint64
+	seq  uint64
+
+	op          simOp
+	from, to    int
+	requestID   uint32
+	containerID ids.ID
+	container   []byte
+	votes       []ids.ID
+
+	// timedOut is the request that failed, if this is a simTimedOut message
+	timedOut simRequest
+}
+
+// simRequest is a request sent by [from] to [to] that expects a response
+type simRequest struct {
+	from, to  int
+	requestID uint32
+	query     bool
+}
+
+type simQueue []*simMessage
+
+func (q simQueue) Len() int { return len(q) }
+func (q simQueue) Less(i, j int) bool {
+	if q[i].time != q[j].time {
+		return q[i].time < q[j].time
+	}
+	return q[i].seq < q[j].seq
+}
+func (q simQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *simQueue) Push(x interface{}) { *q = append(*q, x.(*simMessage)) }
+func (q *simQueue) Pop() interface{} {
+	old := *q
+	msg := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return msg
+}
+
+// byzantineBehavior is invoked for every message that is delivered to a
+// byzantine node. It may respond by sending arbitrary messages through [n].
+type byzantineBehavior func(n *simNetwork, self int, msg *simMessage)
+
+// simNode is either an honest node running a Transitive engine or a byzantine
+// node running a scripted behavior
+type simNode struct {
+	nodeID ids.ShortID
+
+	// honest nodes
+	engine *Transitive
+	blocks map[ids.ID]*snowman.TestBlock
+	pref   ids.ID
+
+	// byzantine nodes
+	behavior byzantineBehavior
+	crafted  int
+}
+
+// simNetwork delivers messages between the nodes in an order determined by a
+// seeded source of randomness, so that every run can be reproduced.
+type simNetwork struct {
+	t   *testing.T
+	rng *rand.Rand
+
+	genesis *snowman.TestBlock
+	nodes   []*simNode
+	indices map[ids.ShortID]int
+
+	// knownBlocks are the serialized blocks that have been created by any node
+	knownBlocks [][]byte
+
+	queue       simQueue
+	now, seq    uint64
+	outstanding map[simRequest]struct{}
+}
+
+func newSimNetwork(t *testing.T, seed int64, numHonest int, behaviors []byzantineBehavior) *simNetwork {
+	n := &simNetwork{
+		t:           t,
+		rng:         rand.New(rand.NewSource(seed)), // #nosec G404
+		indices:     make(map[ids.ShortID]int),
+		outstanding: make(map[simRequest]struct{}),
+	}
+	n.genesis = &snowman.TestBlock{TestDecidable: choices.TestDecidable{
+		IDV:     n.newID(),
+		StatusV: choices.Accepted,
+	}}
+	n.genesis.BytesV = encodeSimBlock(n.genesis.IDV, ids.Empty, 0)
+	n.knownBlocks = append(n.knownBlocks, n.genesis.BytesV)
+
+	numNodes := numHonest + len(behaviors)
+	vdrs := validators.NewSet()
+	for i := 0; i < numNodes; i++ {
+		node := &simNode{}
+		id := n.newID()
+		copy(node.nodeID[:], id[:])
+		if err := vdrs.AddWeight(node.nodeID, 1); err != nil {
+			t.Fatal(err)
+		}
+		n.indices[node.nodeID] = i
+		n.nodes = append(n.nodes, node)
+	}
+
+	// Mix the byzantine nodes in with the honest nodes
+	for i, index := range n.rng.Perm(numNodes)[:len(behaviors)] {
+		n.nodes[index].behavior = behaviors[i]
+	}
+
+	// An alpha majority must be able to be sampled from half of the honest
+	// nodes, otherwise an even split of the honest nodes could never be
+	// resolved.
+	k := numNodes/3 + 1
+	params := snowball.Parameters{
+		K:                     k,
+		Alpha:                 k/2 + 1,
+		BetaVirtuous:          5,
+		BetaRogue:             10,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     100,
+		MaxOutstandingItems:   100,
+		MaxItemProcessingTime: time.Hour,
+	}
+	for i, node := range n.nodes {
+		if node.behavior != nil {
+			continue
+		}
+		n.initHonest(i, vdrs, params)
+	}
+	return n
+}
+
+func (n *simNetwork) initHonest(i int, vdrs validators.Set, params snowball.Parameters) {
+	node := n.nodes[i]
+	node.blocks = make(map[ids.ID]*snowman.TestBlock)
+	genesis := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     n.genesis.IDV,
+			StatusV: choices.Accepted,
+		},
+		BytesV: n.genesis.BytesV,
+	}
+	node.blocks[genesis.IDV] = genesis
+	node.pref = genesis.IDV
+
+	config := DefaultConfig()
+	config.Ctx.NodeID = node.nodeID
+	config.Validators = vdrs
+	config.Sender = &simSender{n: n, self: i}
+	config.Params = params
+	config.Params.Metrics = prometheus.NewRegistry()
+	config.EarlyTermination = poll.EarlyTermTraversal
+
+	vm := &block.TestVM{}
+	vm.Default(false)
+	vm.LastAcceptedF = func() (ids.ID, error) { return genesis.IDV, nil }
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		blk, ok := node.blocks[blkID]
+		if !ok || blk.StatusV == choices.Unknown {
+			return nil, errUnknownBlock
+		}
+		return blk, nil
+	}
+	vm.ParseBlockF = func(b []byte) (snowman.Block, error) { return n.parse(node, b) }
+	vm.SetPreferenceF = func(blkID ids.ID) error {
+		node.pref = blkID
+		return nil
+	}
+	vm.BuildBlockF = func() (snowman.Block, error) {
+		parent := node.blocks[node.pref]
+		blkID := n.newID()
+		blkBytes := encodeSimBlock(blkID, parent.IDV, parent.HeightV+1)
+		n.knownBlocks = append(n.knownBlocks, blkBytes)
+		return n.parse(node, blkBytes)
+	}
+	config.VM = vm
+
+	node.engine = &Transitive{}
+	if err := node.engine.Initialize(config); err != nil {
+		n.t.Fatal(err)
+	}
+}
+
+// parse returns the node-local block that [b] describes. Unknown parents are
+// recorded as placeholders that are filled in once they are parsed.
+func (n *simNetwork) parse(node *simNode, b []byte) (snowman.Block, error) {
+	blkID, parentID, height, err := decodeSimBlock(b)
+	if err != nil {
+		return nil, err
+	}
+	blk := node.getOrCreate(blkID)
+	if blk.StatusV == choices.Unknown {
+		blk.StatusV = choices.Processing
+		blk.ParentV = node.getOrCreate(parentID)
+		blk.HeightV = height
+		blk.BytesV = b
+	}
+	return blk, nil
+}
+
+func (node *simNode) getOrCreate(blkID ids.ID) *snowman.TestBlock {
+	blk, ok := node.blocks[blkID]
+	if !ok {
+		blk = &snowman.TestBlock{TestDecidable: choices.TestDecidable{
+			IDV:     blkID,
+			StatusV: choices.Unknown,
+		}}
+		node.blocks[blkID] = blk
+	}
+	return blk
+}
+
+func encodeSimBlock(blkID, parentID ids.ID, height uint64) []byte {
+	b := make([]byte, simBlockLen)
+	copy(b, blkID[:])
+	copy(b[32:], parentID[:])
+	binary.BigEndian.PutUint64(b[64:], height)
+	return b
+}
+
+func decodeSimBlock(b []byte) (ids.ID, ids.ID, uint64, error) {
+	if len(b) != simBlockLen {
+		return ids.ID{}, ids.ID{}, 0, errGarbageBlock
+	}
+	blkID, parentID := ids.ID{}, ids.ID{}
+	copy(blkID[:], b)
+	copy(parentID[:], b[32:])
+	return blkID, parentID, binary.BigEndian.Uint64(b[64:]), nil
+}
+
+func (n *simNetwork) newID() ids.ID {
+	id := ids.ID{}
+	_, _ = n.rng.Read(id[:])
+	return id
+}
+
+// send schedules [msg] to be delivered after a random delay
+func (n *simNetwork) send(msg *simMessage) {
+	n.sendAfter(msg, 1+uint64(n.rng.Intn(simMaxLatency)))
+}
+
+func (n *simNetwork) sendAfter(msg *simMessage, delay uint64) {
+	n.seq++
+	msg.time = n.now + delay
+	msg.seq = n.seq
+	heap.Push(&n.queue, msg)
+}
+
+// request sends [msg] and schedules the failure of the request if it hasn't
+// been answered before the timeout
+func (n *simNetwork) request(msg *simMessage, query bool) {
+	req := simRequest{
+		from:      msg.from,
+		to:        msg.to,
+		requestID: msg.requestID,
+		query:     query,
+	}
+	n.outstanding[req] = struct{}{}
+	n.send(msg)
+	n.sendAfter(&simMessage{
+		op:       simTimedOut,
+		to:       msg.from,
+		timedOut: req,
+	}, simRequestTimeout)
+}
+
+// respond returns true if [msg] answers an outstanding request. Like the chain
+// router, responses to requests that don't exist are dropped.
+func (n *simNetwork) respond(msg *simMessage) bool {
+	req := simRequest{
+		from:      msg.to,
+		to:        msg.from,
+		requestID: msg.requestID,
+		query:     msg.op == simChits,
+	}
+	if _, ok := n.outstanding[req]; !ok {
+		return false
+	}
+	delete(n.outstanding, req)
+	return true
+}
+
+// run delivers messages until the network quiesces. Returns false if the
+// network didn't quiesce within simMaxSteps.
+func (n *simNetwork) run() (bool, error) {
+	for steps := 0; n.queue.Len() > 0; steps++ {
+		if steps >= simMaxSteps {
+			return false, nil
+		}
+		msg := heap.Pop(&n.queue).(*simMessage)
+		n.now = msg.time
+		if err := n.deliver(msg); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (n *simNetwork) deliver(msg *simMessage) error {
+	switch msg.op {
+	case simPut:
+		if msg.requestID != constants.GossipMsgRequestID && !n.respond(msg) {
+			return nil
+		}
+	case simChits:
+		if !n.respond(msg) {
+			return nil
+		}
+	case simTimedOut:
+		if _, ok := n.outstanding[msg.timedOut]; !ok {
+			return nil
+		}
+		delete(n.outstanding, msg.timedOut)
+		msg.from = msg.timedOut.to
+		msg.requestID = msg.timedOut.requestID
+		msg.op = simGetFailed
+		if msg.timedOut.query {
+			msg.op = simQueryFailed
+		}
+	}
+
+	node := n.nodes[msg.to]
+	if node.behavior != nil {
+		node.behavior(n, msg.to, msg)
+		return nil
+	}
+
+	engine := node.engine
+	from := n.nodes[msg.from].nodeID
+	switch msg.op {
+	case simBuild:
+		return engine.Notify(common.PendingTxs)
+	case simGet:
+		return engine.Get(from, msg.requestID, msg.containerID)
+	case simPut:
+		return engine.Put(from, msg.requestID, msg.containerID, msg.container)
+	case simPushQuery:
+		return engine.PushQuery(from, msg.requestID, msg.containerID, msg.container)
+	case simPullQuery:
+		return engine.PullQuery(from, msg.requestID, msg.containerID)
+	case simChits:
+		return engine.Chits(from, msg.requestID, msg.votes)
+	case simGetFailed:
+		return engine.GetFailed(from, msg.requestID)
+	case simQueryFailed:
+		return engine.QueryFailed(from, msg.requestID)
+	default:
+		return fmt.Errorf("unexpected message type %d", msg.op)
+	}
+}
+
+// check verifies that no two honest nodes accepted conflicting blocks and that
+// every honest node finalized the same chain
+func (n *simNetwork) check() error {
+	accepted := make(map[uint64]ids.ID)
+	var lastAccepted *snowman.TestBlock
+	for i, node := range n.nodes {
+		if node.behavior != nil {
+			continue
+		}
+		if numProcessing := node.engine.Consensus.NumProcessing(); numProcessing != 0 {
+			return fmt.Errorf("node %d has %d processing blocks after quiescing", i, numProcessing)
+		}
+
+		var tip *snowman.TestBlock
+		for blkID, blk := range node.blocks {
+			if blk.StatusV != choices.Accepted {
+				continue
+			}
+			if acceptedID, ok := accepted[blk.HeightV]; ok && acceptedID != blkID {
+				return fmt.Errorf("node %d accepted %s at height %d but %s was also accepted", i, blkID, blk.HeightV, acceptedID)
+			}
+			accepted[blk.HeightV] = blkID
+			if tip == nil || blk.HeightV > tip.HeightV {
+				tip = blk
+			}
+		}
+
+		if lastAccepted != nil && tip.IDV != lastAccepted.IDV {
+			return fmt.Errorf("node %d finalized %s at height %d but %s was finalized at height %d", i, tip.IDV, tip.HeightV, lastAccepted.IDV, lastAccepted.HeightV)
+		}
+		lastAccepted = tip
+	}
+	return nil
+}
+
+// simSender sends the messages of an honest engine into the network
+type simSender struct {
+	n    *simNetwork
+	self int
+}
+
+func (s *simSender) GetAcceptedFrontier(ids.ShortSet, uint32)       {}
+func (s *simSender) AcceptedFrontier(ids.ShortID, uint32, []ids.ID) {}
+func (s *simSender) GetAccepted(ids.ShortSet, uint32, []ids.ID)     {}
+func (s *simSender) Accepted(ids.ShortID, uint32, []ids.ID)         {}
+func (s *simSender) GetAncestors(ids.ShortID, uint32, ids.ID)       {}
+func (s *simSender) MultiPut(ids.ShortID, uint32, [][]byte)         {}
+func (s *simSender) Gossip(containerID ids.ID, container []byte)    {}
+func (s *simSender) SendAppGossip([]byte) error                     { return nil }
+func (s *simSender) to(validatorID ids.ShortID) int                 { return s.n.indices[validatorID] }
+func (s *simSender) Chits(vdr ids.ShortID, requestID uint32, votes []ids.ID) {
+	s.n.send(&simMessage{op: simChits, from: s.self, to: s.to(vdr), requestID: requestID, votes: votes})
+}
+
+// sorted returns the validators in a deterministic order, so that a run can be
+// reproduced from its seed
+func (s *simSender) sorted(vdrs ids.ShortSet) []ids.ShortID {
+	vdrList := vdrs.List()
+	ids.SortShortIDs(vdrList)
+	return vdrList
+}
+
+func (s *simSender) Get(vdr ids.ShortID, requestID uint32, containerID ids.ID) {
+	s.n.request(&simMessage{op: simGet, from: s.self, to: s.to(vdr), requestID: requestID, containerID: containerID}, false)
+}
+
+func (s *simSender) Put(vdr ids.ShortID, requestID uint32, containerID ids.ID, container []byte) {
+	s.n.send(&simMessage{op: simPut, from: s.self, to: s.to(vdr), requestID: requestID, containerID: containerID, container: container})
+}
+
+func (s *simSender) PushQuery(vdrs ids.ShortSet, requestID uint32, containerID ids.ID, container []byte) {
+	for _, vdr := range s.sorted(vdrs) {
+		s.n.request(&simMessage{op: simPushQuery, from: s.self, to: s.to(vdr), requestID: requestID, containerID: containerID, container: container}, true)
+	}
+}
+
+func (s *simSender) PullQuery(vdrs ids.ShortSet, requestID uint32, containerID ids.ID) {
+	for _, vdr := range s.sorted(vdrs) {
+		s.n.request(&simMessage{op: simPullQuery, from: s.self, to: s.to(vdr), requestID: requestID, containerID: containerID}, true)
+	}
+}
+
+// randomKnownBlock returns a random block that has been created by any node
+func (n *simNetwork) randomKnownBlock() (ids.ID, []byte) {
+	blkBytes := n.knownBlocks[n.rng.Intn(len(n.knownBlocks))]
+	blkID, _, _, _ := decodeSimBlock(blkBytes)
+	return blkID, blkBytes
+}
+
+// craftBlock returns a new block built on a random known block, or nil if the
+// node has already crafted its maximum number of blocks
+func (n *simNetwork) craftBlock(self int) (ids.ID, []byte) {
+	node := n.nodes[self]
+	if node.crafted >= simMaxCraftedBlocks {
+		return ids.ID{}, nil
+	}
+	node.crafted++
+
+	_, parentBytes := n.randomKnownBlock()
+	parentID, _, height, _ := decodeSimBlock(parentBytes)
+	blkID := n.newID()
+	blkBytes := encodeSimBlock(blkID, parentID, height+1)
+	n.knownBlocks = append(n.knownBlocks, blkBytes)
+	return blkID, blkBytes
+}
+
+// answerGet responds to a Get with the requested block, if it exists
+func (n *simNetwork) answerGet(self int, msg *simMessage) {
+	for _, blkBytes := range n.knownBlocks {
+		if blkID, _, _, _ := decodeSimBlock(blkBytes); blkID == msg.containerID {
+			n.send(&simMessage{op: simPut, from: self, to: msg.from, requestID: msg.requestID, containerID: blkID, container: blkBytes})
+			return
+		}
+	}
+}
+
+func isQuery(msg *simMessage) bool { return msg.op == simPushQuery || msg.op == simPullQuery }
+
+// withholdingBehavior never responds to any message
+func withholdingBehavior(*simNetwork, int, *simMessage) {}
+
+// equivocatingBehavior responds to every query with a vote for a random known
+// block, sometimes voting for multiple blocks at once
+func equivocatingBehavior(n *simNetwork, self int, msg *simMessage) {
+	switch {
+	case isQuery(msg):
+		numVotes := 1
+		if n.rng.Intn(4) == 0 {
+			numVotes = 2
+		}
+		votes := make([]ids.ID, numVotes)
+		for i := range votes {
+			votes[i], _ = n.randomKnownBlock()
+		}
+		n.send(&simMessage{op: simChits, from: self, to: msg.from, requestID: msg.requestID, votes: votes})
+	case msg.op == simGet:
+		n.answerGet(self, msg)
+	}
+}
+
+// unknownIDBehavior votes for blocks that don't exist and answers requests for
+// blocks with garbage or mislabeled containers
+func unknownIDBehavior(n *simNetwork, self int, msg *simMessage) {
+	switch {
+	case isQuery(msg):
+		n.send(&simMessage{op: simChits, from: self, to: msg.from, requestID: msg.requestID, votes: []ids.ID{n.newID()}})
+	case msg.op == simGet:
+		container := make([]byte, n.rng.Intn(2*simBlockLen))
+		_, _ = n.rng.Read(container)
+		if n.rng.Intn(2) == 0 {
+			_, container = n.randomKnownBlock()
+		}
+		n.send(&simMessage{op: simPut, from: self, to: msg.from, requestID: msg.requestID, containerID: msg.containerID, container: container})
+	}
+}
+
+// floodingBehavior gossips conflicting blocks to random nodes in response to
+// every query and votes for the blocks it crafted
+func floodingBehavior(n *simNetwork, self int, msg *simMessage) {
+	if msg.op == simGet {
+		n.answerGet(self, msg)
+		return
+	}
+	if !isQuery(msg) {
+		return
+	}
+
+	blkID, blkBytes := n.craftBlock(self)
+	if blkBytes == nil {
+		blkID, blkBytes = n.randomKnownBlock()
+	}
+	for i := 0; i < 3; i++ {
+		n.send(&simMessage{
+			op:          simPut,
+			from:        self,
+			to:          n.rng.Intn(len(n.nodes)),
+			requestID:   constants.GossipMsgRequestID,
+			containerID: blkID,
+			container:   blkBytes,
+		})
+	}
+	n.send(&simMessage{op: simChits, from: self, to: msg.from, requestID: msg.requestID, votes: []ids.ID{blkID}})
+}
+
+var byzantineBehaviors = []struct {
+	name     string
+	behavior byzantineBehavior
+}{
+	{"withholding", withholdingBehavior},
+	{"equivocating", equivocatingBehavior},
+	{"unknownIDs", unknownIDBehavior},
+	{"flooding", floodingBehavior},
+}
+
+// runByzantine runs a single simulation where the honest nodes build
+// [numBlocks] blocks while the nodes running [behaviors] attempt to break
+// consensus
+func runByzantine(t *testing.T, seed int64, numHonest, numBlocks int, behaviors []byzantineBehavior) {
+	sampler.Seed(seed)
+	n := newSimNetwork(t, seed, numHonest, behaviors)
+	for i := 0; i < numBlocks; i++ {
+		builder := n.rng.Intn(len(n.nodes))
+		for n.nodes[builder].behavior != nil {
+			builder = n.rng.Intn(len(n.nodes))
+		}
+		n.sendAfter(&simMessage{op: simBuild, from: builder, to: builder}, uint64(n.rng.Intn(10*simRequestTimeout)))
+	}
+
+	quiesced, err := n.run()
+	switch {
+	case err != nil:
+		t.Fatalf("seed %d: engine errored: %s", seed, err)
+	case !quiesced:
+		t.Fatalf("seed %d: network didn't quiesce after %d messages", seed, simMaxSteps)
+	}
+	if err := n.check(); err != nil {
+		t.Fatalf("seed %d: %s", seed, err)
+	}
+}
+
+func TestByzantineBehaviors(t *testing.T) {
+	numRuns := 100
+	if testing.Short() {
+		numRuns = 10
+	}
+	for _, test := range byzantineBehaviors {
+		behavior := test.behavior
+		t.Run(test.name, func(t *testing.T) {
+			for seed := int64(0); seed < int64(numRuns); seed++ {
+				runByzantine(t, seed, 8, 5, []byzantineBehavior{behavior, behavior})
+			}
+		})
+	}
+}
+
+func TestByzantineFuzz(t *testing.T) {
+	numRuns := 1000
+	if testing.Short() {
+		numRuns = 50
+	}
+	for seed := int64(0); seed < int64(numRuns); seed++ {
+		rng := rand.New(rand.NewSource(seed)) // #nosec G404
+		numHonest := 4 + rng.Intn(8)
+		// Keep the byzantine nodes below a third of the network
+		numByzantine := rng.Intn((numHonest-1)/2 + 1)
+		runBehaviors := make([]byzantineBehavior, numByzantine)
+		for i := range runBehaviors {
+			runBehaviors[i] = byzantineBehaviors[rng.Intn(len(byzantineBehaviors))].behavior
+		}
+		runByzantine(t, seed, numHonest, 1+rng.Intn(10), runBehaviors)
+	}
+}
@@ -6,6 +6,7 @@ package snowman
 import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/bootstrap"
 )
 
@@ -15,4 +16,8 @@ type Config struct {
 
 	Params    snowball.Parameters
 	Consensus snowman.Consensus
+
+	// EarlyTermination determines when polls may finish before every polled
+	// validator has responded. Defaults to poll.EarlyTermNoTraversal.
+	EarlyTermination poll.EarlyTermination
 }
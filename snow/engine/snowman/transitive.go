@@ -66,7 +66,10 @@ func (t *Transitive) Initialize(config Config) error {
 	t.Params = config.Params
 	t.Consensus = config.Consensus
 
-	factory := poll.NewEarlyTermNoTraversalFactory(config.Params.Alpha)
+	factory, err := config.EarlyTermination.NewFactory(config.Params.Alpha, t.processingParent)
+	if err != nil {
+		return err
+	}
 	t.polls = poll.NewSet(factory,
 		config.Ctx.Log,
 		config.Params.Namespace,
@@ -145,6 +148,16 @@ func (t *Transitive) Gossip() error {
 	return nil
 }
 
+// AppGossip implements the Engine interface
+func (t *Transitive) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	appVM, ok := t.VM.(common.AppHandler)
+	if !ok {
+		t.Ctx.Log.Verbo("dropping AppGossip from %s as the VM doesn't handle application level messages", nodeID)
+		return nil
+	}
+	return appVM.AppGossip(nodeID, msg)
+}
+
 // Shutdown implements the Engine interface
 func (t *Transitive) Shutdown() error {
 	t.Ctx.Log.Info("shutting down consensus engine")
@@ -225,6 +238,18 @@ func (t *Transitive) Put(vdr ids.ShortID, requestID uint32, blkID ids.ID, blkByt
 		return t.GetFailed(vdr, requestID)
 	}
 
+	actualBlkID := blk.ID()
+	expectedBlkID, ok := t.blkReqs.Get(vdr, requestID)
+	// If the provided block is not the requested block, we need to explicitly
+	// mark the request as failed to avoid having a dangling dependency.
+	if ok && actualBlkID != expectedBlkID {
+		t.Ctx.Log.Debug("incorrect block returned in Put(%s, %d, %s). Expected %s",
+			vdr, requestID, actualBlkID, expectedBlkID)
+		// We assume that [blk] is useless because it doesn't match what we
+		// expected.
+		return t.GetFailed(vdr, requestID)
+	}
+
 	// issue the block into consensus. If the block has already been issued,
 	// this will be a noop. If this block has missing dependencies, vdr will
 	// receive requests to fill the ancestry. dependencies that have already
@@ -750,6 +775,26 @@ func (t *Transitive) HealthCheck() (interface{}, error) {
 	return intf, fmt.Errorf("vm: %s ; consensus: %s", vmErr, consensusErr)
 }
 
+// processingParent returns the ID of the parent of [blkID] if both [blkID] and
+// its parent are currently processing in consensus
+func (t *Transitive) processingParent(blkID ids.ID) (ids.ID, bool) {
+	blk, err := t.GetBlock(blkID)
+	if err != nil || !t.processing(blk) {
+		return ids.ID{}, false
+	}
+	parent := blk.Parent()
+	if !t.processing(parent) {
+		return ids.ID{}, false
+	}
+	return parent.ID(), true
+}
+
+// processing returns true if [blk] has been issued to consensus and hasn't
+// been decided yet
+func (t *Transitive) processing(blk snowman.Block) bool {
+	return blk.Status() == choices.Processing && t.Consensus.AcceptedOrProcessing(blk)
+}
+
 // GetBlock implements the snowman.Engine interface
 func (t *Transitive) GetBlock(blkID ids.ID) (snowman.Block, error) {
 	return t.VM.GetBlock(blkID)
@@ -1122,6 +1122,68 @@ func TestEngineAbandonChit(t *testing.T) {
 	}
 }
 
+func TestEngineAbandonChitWithUnexpectedPutBlock(t *testing.T) {
+	vdr, _, sender, vm, te, gBlk := setup(t)
+
+	sender.Default(true)
+
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: gBlk,
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+
+	sender.CantPushQuery = false
+
+	if err := te.issue(blk); err != nil {
+		t.Fatal(err)
+	}
+
+	fakeBlkID := ids.GenerateTestID()
+	vm.GetBlockF = func(id ids.ID) (snowman.Block, error) {
+		switch id {
+		case fakeBlkID:
+			return nil, errUnknownBlock
+		default:
+			t.Fatalf("Loaded unknown block")
+			panic("Should have failed")
+		}
+	}
+
+	reqID := new(uint32)
+	sender.GetF = func(_ ids.ShortID, requestID uint32, _ ids.ID) {
+		*reqID = requestID
+	}
+
+	if err := te.Chits(vdr, 0, []ids.ID{fakeBlkID}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(te.blocked) != 1 {
+		t.Fatalf("Should have blocked on request")
+	}
+
+	vm.ParseBlockF = func(b []byte) (snowman.Block, error) {
+		if !bytes.Equal(b, gBlk.Bytes()) {
+			t.Fatalf("Wrong bytes")
+		}
+		return gBlk, nil
+	}
+
+	// Respond with a block that wasn't requested
+	if err := te.Put(vdr, *reqID, fakeBlkID, gBlk.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(te.blocked) != 0 {
+		t.Fatalf("Should have removed request")
+	}
+}
+
 func TestEngineBlockingChitRequest(t *testing.T) {
 	vdr, _, sender, vm, te, gBlk := setup(t)
 
@@ -763,6 +763,30 @@ func (cr *ChainRouter) Chits(
 	chain.Chits(validatorID, requestID, votes, onFinishedHandling)
 }
 
+// AppGossip routes an incoming AppGossip message from the node with ID
+// [nodeID] to the consensus engine working on the chain with ID [chainID]
+func (cr *ChainRouter) AppGossip(
+	nodeID ids.ShortID,
+	chainID ids.ID,
+	appGossipBytes []byte,
+	onFinishedHandling func(),
+) {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	// Get the chain, if it exists
+	chain, exists := cr.chains[chainID]
+	if !exists {
+		cr.log.Debug("AppGossip(%s, %s) dropped due to unknown chain", nodeID, chainID)
+		cr.log.Verbo("app gossip:\n%s", formatting.DumpBytes{Bytes: appGossipBytes})
+		onFinishedHandling()
+		return
+	}
+
+	// Pass the message to the chain
+	chain.AppGossip(nodeID, appGossipBytes, onFinishedHandling)
+}
+
 // QueryFailed routes an incoming QueryFailed message from the validator with ID [validatorID]
 // to the consensus engine working on the chain with ID [chainID]
 func (cr *ChainRouter) QueryFailed(
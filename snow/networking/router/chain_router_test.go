@@ -62,6 +62,7 @@ func TestShutdown(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -137,6 +138,7 @@ func TestShutdownTimesOut(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -238,6 +240,7 @@ func TestRouterTimeout(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -307,6 +310,7 @@ func TestRouterClearTimeouts(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
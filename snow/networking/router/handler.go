@@ -38,6 +38,9 @@ type Handler struct {
 	msgFromVMChan <-chan common.Message
 	// Tracks CPU time spent processing messages from each node
 	cpuTracker tracker.TimeTracker
+	// Shares execution time with the other chains on this node.
+	// May be nil.
+	scheduler *Scheduler
 	// Called in a goroutine when this handler/engine shuts down.
 	// May be nil.
 	onCloseF            func()
@@ -50,10 +53,13 @@ type Handler struct {
 
 // Initialize this consensus handler
 // [engine] must be initialized before initializing this handler
+// If [scheduler] is nil, this handler doesn't share execution time with
+// other chains.
 func (h *Handler) Initialize(
 	engine common.Engine,
 	validators validators.Set,
 	msgFromVMChan <-chan common.Message,
+	scheduler *Scheduler,
 	metricsNamespace string,
 	metricsRegisterer prometheus.Registerer,
 ) error {
@@ -67,7 +73,15 @@ func (h *Handler) Initialize(
 	h.validators = validators
 	var lock sync.Mutex
 	h.unprocessedMsgsCond = sync.NewCond(&lock)
-	h.cpuTracker = tracker.NewCPUTracker(uptime.IntervalFactory{}, defaultCPUInterval)
+	h.scheduler = scheduler
+	if scheduler != nil {
+		if err := scheduler.register(h.ctx.ChainID, metricsNamespace, metricsRegisterer); err != nil {
+			return err
+		}
+		h.cpuTracker = scheduler.cpuTracker
+	} else {
+		h.cpuTracker = tracker.NewCPUTracker(uptime.IntervalFactory{}, defaultCPUInterval)
+	}
 	var err error
 	h.unprocessedMsgs, err = newUnprocessedMsgs(h.ctx.Log, h.validators, h.cpuTracker, metricsNamespace, metricsRegisterer)
 	return err
@@ -143,6 +157,16 @@ func (h *Handler) handleMsg(msg message) error {
 	h.ctx.Lock.Lock()
 	defer h.ctx.Lock.Unlock()
 
+	// The execution slot is only taken once the chain's lock is held so that
+	// time spent waiting for the lock isn't charged against the other chains.
+	if h.scheduler != nil {
+		h.scheduler.acquire(h.ctx.ChainID)
+		acquiredTime := h.clock.Time()
+		defer func() {
+			h.scheduler.release(h.ctx.ChainID, acquiredTime, h.clock.Time())
+		}()
+	}
+
 	var err error
 	switch msg.messageType {
 	case constants.NotifyMsg:
@@ -209,6 +233,8 @@ func (h *Handler) handleConsensusMsg(msg message) error {
 		err = h.engine.QueryFailed(msg.nodeID, msg.requestID)
 	case constants.ChitsMsg:
 		err = h.engine.Chits(msg.nodeID, msg.requestID, msg.containerIDs)
+	case constants.AppGossipMsg:
+		err = h.engine.AppGossip(msg.nodeID, msg.appMsgBytes)
 	case constants.ConnectedMsg:
 		err = h.engine.Connected(msg.nodeID)
 	case constants.DisconnectedMsg:
@@ -463,6 +489,18 @@ func (h *Handler) Chits(nodeID ids.ShortID, requestID uint32, votes []ids.ID, on
 	})
 }
 
+// AppGossip passes an application level message gossiped by another node to
+// the consensus engine.
+func (h *Handler) AppGossip(nodeID ids.ShortID, appGossipBytes []byte, onDoneHandling func()) {
+	h.push(message{
+		messageType:    constants.AppGossipMsg,
+		nodeID:         nodeID,
+		appMsgBytes:    appGossipBytes,
+		received:       h.clock.Time(),
+		onDoneHandling: onDoneHandling,
+	})
+}
+
 // QueryFailed passes a QueryFailed message received from the network to the consensus engine.
 func (h *Handler) QueryFailed(nodeID ids.ShortID, requestID uint32) {
 	h.push(message{
@@ -538,6 +576,9 @@ func (h *Handler) shutdown() {
 	}
 	endTime := h.clock.Time()
 	h.metrics.shutdown.Observe(float64(endTime.Sub(startTime)))
+	if h.scheduler != nil {
+		h.scheduler.deregister(h.ctx.ChainID)
+	}
 	close(h.closed)
 }
 
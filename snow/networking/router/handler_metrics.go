@@ -32,6 +32,7 @@ type handlerMetrics struct {
 	getAncestors, multiPut, getAncestorsFailed,
 	get, put, getFailed,
 	pushQuery, pullQuery, chits, queryFailed,
+	appGossip,
 	connected, disconnected,
 	timeout,
 	notify,
@@ -68,6 +69,7 @@ func (m *handlerMetrics) Initialize(namespace string, reg prometheus.Registerer)
 	m.pullQuery = initAverager(namespace, "pull_query", reg, &errs)
 	m.chits = initAverager(namespace, "chits", reg, &errs)
 	m.queryFailed = initAverager(namespace, "query_failed", reg, &errs)
+	m.appGossip = initAverager(namespace, "app_gossip", reg, &errs)
 	m.connected = initAverager(namespace, "connected", reg, &errs)
 	m.disconnected = initAverager(namespace, "disconnected", reg, &errs)
 	m.timeout = initAverager(namespace, "timeout", reg, &errs)
@@ -121,6 +123,8 @@ func (m *handlerMetrics) getMSGHistogram(msg constants.MsgType) metric.Averager
 		return m.queryFailed
 	case constants.ChitsMsg:
 		return m.chits
+	case constants.AppGossipMsg:
+		return m.appGossip
 	case constants.ConnectedMsg:
 		return m.connected
 	case constants.DisconnectedMsg:
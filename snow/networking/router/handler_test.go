@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
 func TestHandlerDropsTimedOutMessages(t *testing.T) {
@@ -43,6 +44,7 @@ func TestHandlerDropsTimedOutMessages(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -85,6 +87,7 @@ func TestHandlerDoesntDrop(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -121,6 +124,7 @@ func TestHandlerClosesOnError(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -165,6 +169,7 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -205,6 +210,7 @@ func TestHandlerDispatchInternal(t *testing.T) {
 		&engine,
 		vdrs,
 		msgFromVMChan,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -219,3 +225,67 @@ func TestHandlerDispatchInternal(t *testing.T) {
 	case <-calledNotify:
 	}
 }
+
+// Test that a chain waiting for its own lock doesn't hold an execution slot
+// that another chain needs
+func TestHandlerDoesntHoldSlotWhileWaitingForLock(t *testing.T) {
+	scheduler := NewScheduler(logging.NoLog{}, 1)
+
+	startHandler := func(ctx *snow.Context, notified chan struct{}) chan common.Message {
+		engine := common.EngineTest{T: t}
+		engine.Default(false)
+		engine.ContextF = func() *snow.Context { return ctx }
+		engine.NotifyF = func(common.Message) error {
+			notified <- struct{}{}
+			return nil
+		}
+
+		handler := &Handler{}
+		msgFromVMChan := make(chan common.Message, 1)
+		vdrs := validators.NewSet()
+		err := vdrs.AddWeight(ids.GenerateTestShortID(), 1)
+		assert.NoError(t, err)
+		err = handler.Initialize(
+			&engine,
+			vdrs,
+			msgFromVMChan,
+			scheduler,
+			"",
+			prometheus.NewRegistry(),
+		)
+		assert.NoError(t, err)
+
+		go handler.Dispatch()
+		return msgFromVMChan
+	}
+
+	ctxA := snow.DefaultContextTest()
+	ctxA.ChainID = ids.GenerateTestID()
+	notifiedA := make(chan struct{}, 1)
+	msgFromVMChanA := startHandler(ctxA, notifiedA)
+
+	ctxB := snow.DefaultContextTest()
+	ctxB.ChainID = ids.GenerateTestID()
+	notifiedB := make(chan struct{}, 1)
+	msgFromVMChanB := startHandler(ctxB, notifiedB)
+
+	// Chain A can't handle its message until its lock is released
+	ctxA.Lock.Lock()
+	msgFromVMChanA <- 0
+	// Give chain A time to start waiting for its lock
+	time.Sleep(10 * time.Millisecond)
+	msgFromVMChanB <- 0
+
+	select {
+	case <-time.After(time.Second):
+		t.Fatalf("chain B should have been able to handle its message")
+	case <-notifiedB:
+	}
+
+	ctxA.Lock.Unlock()
+	select {
+	case <-time.After(time.Second):
+		t.Fatalf("chain A should have handled its message")
+	case <-notifiedA:
+	}
+}
@@ -23,6 +23,7 @@ type message struct {
 	container      []byte
 	containers     [][]byte
 	containerIDs   []ids.ID
+	appMsgBytes    []byte
 	notification   common.Message
 	received       time.Time // Time this message was received
 	deadline       time.Time // Time this message must be responded to
@@ -60,6 +61,8 @@ func (m message) String() string {
 		sb.WriteString(fmt.Sprintf(", NumContainers: %d)", len(m.containers)))
 	case constants.NotifyMsg:
 		sb.WriteString(fmt.Sprintf(", Notification: %s)", m.notification))
+	case constants.AppGossipMsg:
+		sb.WriteString(fmt.Sprintf(", NumBytes: %d)", len(m.appMsgBytes)))
 	default:
 		sb.WriteString(")")
 	}
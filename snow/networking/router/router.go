@@ -131,6 +131,12 @@ type ExternalRouter interface {
 		votes []ids.ID,
 		onFinishedHandling func(),
 	)
+	AppGossip(
+		nodeID ids.ShortID,
+		chainID ids.ID,
+		appGossipBytes []byte,
+		onFinishedHandling func(),
+	)
 }
 
 // InternalRouter deals with messages internal to this node
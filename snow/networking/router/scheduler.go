@@ -0,0 +1,212 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/metric"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/uptime"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// DefaultChainWeight is the scheduling weight of a chain that wasn't
+	// given an explicit weight
+	DefaultChainWeight uint64 = 1
+)
+
+// Scheduler shares the time spent handling messages between all the chains
+// running on this node. At most [maxConcurrent] handlers may handle a message
+// at the same time. When more chains are waiting to handle a message, the chain
+// that recently used the least execution time relative to its weight goes
+// next, so one busy chain can't delay the messages of the other chains.
+//
+// Scheduler also tracks the CPU time spent handling each node's messages across
+// all chains, so that a node can't use more than its share of this node's CPU
+// by spreading its messages over multiple chains.
+type Scheduler struct {
+	log logging.Logger
+	// Useful for faking time in tests
+	clock         timer.Clock
+	maxConcurrent int
+	// Tracks CPU utilization of each node across all chains
+	cpuTracker tracker.TimeTracker
+
+	lock sync.Mutex
+	// Number of handlers currently handling a message
+	running int
+	// Chain ID --> Scheduling state of the chain
+	chains map[ids.ID]*scheduledChain
+	// Chain ID --> Weight of the chain
+	weights map[ids.ID]uint64
+}
+
+type scheduledChain struct {
+	weight uint64
+	// Tracks the portion of time this chain has recently spent handling
+	// messages
+	meter uptime.Meter
+	// Closed when the chain is allowed to handle its next message. Nil if the
+	// chain isn't waiting.
+	ready   chan struct{}
+	metrics schedulerMetrics
+}
+
+// NewScheduler returns a scheduler that allows at most [maxConcurrent] chains
+// to handle a message at the same time
+func NewScheduler(log logging.Logger, maxConcurrent int) *Scheduler {
+	return &Scheduler{
+		log:           log,
+		maxConcurrent: maxConcurrent,
+		cpuTracker:    tracker.NewCPUTracker(uptime.IntervalFactory{}, defaultCPUInterval),
+		chains:        make(map[ids.ID]*scheduledChain),
+		weights:       make(map[ids.ID]uint64),
+	}
+}
+
+// SetWeight sets the scheduling weight of [chainID]. A chain with twice the
+// weight of another chain is allowed to spend twice as much time handling
+// messages when the chains are competing for execution time. Must be called
+// before the chain's handler is initialized.
+func (s *Scheduler) SetWeight(chainID ids.ID, weight uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.weights[chainID] = weight
+}
+
+// register [chainID] with this scheduler
+func (s *Scheduler) register(chainID ids.ID, metricsNamespace string, metricsRegisterer prometheus.Registerer) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, exists := s.chains[chainID]; exists {
+		return fmt.Errorf("chain %s is already registered with the scheduler", chainID)
+	}
+
+	weight, ok := s.weights[chainID]
+	if !ok || weight == 0 {
+		weight = DefaultChainWeight
+	}
+	chain := &scheduledChain{
+		weight: weight,
+		meter:  uptime.IntervalFactory{}.New(defaultCPUInterval),
+	}
+	if err := chain.metrics.initialize(metricsNamespace, metricsRegisterer); err != nil {
+		return err
+	}
+	s.chains[chainID] = chain
+	return nil
+}
+
+// deregister [chainID] from this scheduler. Assumes the chain isn't waiting.
+func (s *Scheduler) deregister(chainID ids.ID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.chains, chainID)
+}
+
+// acquire blocks until [chainID] is allowed to handle a message. Each call to
+// acquire must be followed by a call to release. Assumes that there is at most
+// one outstanding call to acquire per chain.
+func (s *Scheduler) acquire(chainID ids.ID) {
+	startTime := s.clock.Time()
+
+	s.lock.Lock()
+	chain := s.chains[chainID]
+	if s.running < s.maxConcurrent {
+		s.running++
+		s.lock.Unlock()
+		chain.metrics.wait.Observe(0)
+		return
+	}
+
+	ready := make(chan struct{})
+	chain.ready = ready
+	s.lock.Unlock()
+
+	<-ready
+	chain.metrics.wait.Observe(float64(s.clock.Time().Sub(startTime)))
+}
+
+// release marks that [chainID] handled a message from [startTime] to
+// [endTime] and allows the next chain to handle a message
+func (s *Scheduler) release(chainID ids.ID, startTime, endTime time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	chain := s.chains[chainID]
+	chain.meter.Start(startTime)
+	chain.meter.Stop(endTime)
+	chain.metrics.utilization.Set(chain.meter.Read(endTime))
+
+	next := s.next(endTime)
+	if next == nil {
+		s.running--
+		return
+	}
+	// Hand the execution slot directly to the next chain
+	close(next.ready)
+	next.ready = nil
+}
+
+// next returns the waiting chain that has used the least execution time
+// relative to its weight, or nil if no chains are waiting.
+// Assumes [s.lock] is held.
+func (s *Scheduler) next(currentTime time.Time) *scheduledChain {
+	var (
+		next        *scheduledChain
+		minWeighted float64
+		numWaiting  int
+	)
+	for _, chain := range s.chains {
+		if chain.ready == nil {
+			continue
+		}
+		numWaiting++
+		weighted := chain.meter.Read(currentTime) / float64(chain.weight)
+		if next == nil || weighted < minWeighted {
+			next = chain
+			minWeighted = weighted
+		}
+	}
+	if numWaiting > 1 {
+		s.log.Verbo("%d chains are waiting to handle a message", numWaiting)
+	}
+	return next
+}
+
+type schedulerMetrics struct {
+	wait        metric.Averager
+	utilization prometheus.Gauge
+}
+
+func (m *schedulerMetrics) initialize(metricsNamespace string, metricsRegisterer prometheus.Registerer) error {
+	namespace := fmt.Sprintf("%s_%s", metricsNamespace, "scheduler")
+	errs := wrappers.Errs{}
+	m.wait = metric.NewAveragerWithErrs(
+		namespace,
+		"wait",
+		"time (in ns) spent waiting for other chains before handling a message",
+		metricsRegisterer,
+		&errs,
+	)
+	m.utilization = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "utilization",
+		Help:      "Recent portion of time spent handling messages of this chain",
+	})
+	errs.Add(metricsRegisterer.Register(m.utilization))
+	return errs.Err
+}
@@ -0,0 +1,159 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// waitForWaiters blocks until [chainIDs] are waiting to handle a message
+func waitForWaiters(s *Scheduler, chainIDs ...ids.ID) {
+	for {
+		s.lock.Lock()
+		waiting := true
+		for _, chainID := range chainIDs {
+			waiting = waiting && s.chains[chainID].ready != nil
+		}
+		s.lock.Unlock()
+		if waiting {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// acquireAsync calls acquire in a goroutine and returns a channel that is
+// closed once [chainID] is allowed to handle a message
+func acquireAsync(s *Scheduler, chainID ids.ID) chan struct{} {
+	acquired := make(chan struct{})
+	go func() {
+		s.acquire(chainID)
+		close(acquired)
+	}()
+	return acquired
+}
+
+func TestSchedulerPrefersLeastUtilizedChain(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewScheduler(logging.NoLog{}, 1)
+	now := time.Now()
+	s.clock.Set(now)
+
+	chainA := ids.GenerateTestID()
+	chainB := ids.GenerateTestID()
+	chainC := ids.GenerateTestID()
+	for _, chainID := range []ids.ID{chainA, chainB, chainC} {
+		assert.NoError(s.register(chainID, "", prometheus.NewRegistry()))
+	}
+
+	// chainB has recently used a lot more time than chainC
+	s.acquire(chainB)
+	s.release(chainB, now.Add(-10*time.Second), now)
+	s.acquire(chainC)
+	s.release(chainC, now.Add(-10*time.Second), now.Add(-9*time.Second))
+
+	s.acquire(chainA)
+	acquiredB := acquireAsync(s, chainB)
+	acquiredC := acquireAsync(s, chainC)
+	waitForWaiters(s, chainB, chainC)
+
+	s.release(chainA, now, now)
+	<-acquiredC
+	select {
+	case <-acquiredB:
+		t.Fatal("more utilized chain was allowed to run concurrently")
+	default:
+	}
+
+	s.release(chainC, now, now)
+	<-acquiredB
+	s.release(chainB, now, now)
+	assert.Zero(s.running)
+}
+
+func TestSchedulerWeights(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewScheduler(logging.NoLog{}, 1)
+	now := time.Now()
+	s.clock.Set(now)
+
+	chainA := ids.GenerateTestID()
+	chainB := ids.GenerateTestID()
+	chainC := ids.GenerateTestID()
+	s.SetWeight(chainB, 20)
+	for _, chainID := range []ids.ID{chainA, chainB, chainC} {
+		assert.NoError(s.register(chainID, "", prometheus.NewRegistry()))
+	}
+
+	// chainB has used more time than chainC, but it has a much larger weight
+	s.acquire(chainB)
+	s.release(chainB, now.Add(-10*time.Second), now)
+	s.acquire(chainC)
+	s.release(chainC, now.Add(-10*time.Second), now.Add(-9*time.Second))
+
+	s.acquire(chainA)
+	acquiredB := acquireAsync(s, chainB)
+	acquiredC := acquireAsync(s, chainC)
+	waitForWaiters(s, chainB, chainC)
+
+	s.release(chainA, now, now)
+	<-acquiredB
+	select {
+	case <-acquiredC:
+		t.Fatal("chain was allowed to run concurrently")
+	default:
+	}
+
+	s.release(chainB, now, now)
+	<-acquiredC
+	s.release(chainC, now, now)
+	assert.Zero(s.running)
+}
+
+func TestSchedulerMaxConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewScheduler(logging.NoLog{}, 2)
+	now := time.Now()
+
+	chainA := ids.GenerateTestID()
+	chainB := ids.GenerateTestID()
+	chainC := ids.GenerateTestID()
+	for _, chainID := range []ids.ID{chainA, chainB, chainC} {
+		assert.NoError(s.register(chainID, "", prometheus.NewRegistry()))
+	}
+
+	s.acquire(chainA)
+	s.acquire(chainB)
+	acquiredC := acquireAsync(s, chainC)
+	waitForWaiters(s, chainC)
+
+	s.release(chainB, now, now)
+	<-acquiredC
+	assert.Equal(2, s.running)
+
+	s.release(chainA, now, now)
+	s.release(chainC, now, now)
+	assert.Zero(s.running)
+}
+
+func TestSchedulerRegisterTwice(t *testing.T) {
+	s := NewScheduler(logging.NoLog{}, 1)
+	chainID := ids.GenerateTestID()
+	if err := s.register(chainID, "", prometheus.NewRegistry()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.register(chainID, "", prometheus.NewRegistry()); err == nil {
+		t.Fatal("should have errored due to registering the chain twice")
+	}
+}
@@ -38,4 +38,8 @@ type ExternalSender interface {
 	Chits(validatorID ids.ShortID, chainID ids.ID, requestID uint32, votes []ids.ID)
 
 	Gossip(chainID ids.ID, containerID ids.ID, container []byte)
+
+	// AppGossip sends an application level message of chain [chainID] to a
+	// random subset of peers.
+	AppGossip(chainID ids.ID, appGossipBytes []byte)
 }
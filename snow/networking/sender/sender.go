@@ -393,3 +393,11 @@ func (s *Sender) Gossip(containerID ids.ID, container []byte) {
 	s.ctx.Log.Verbo("Gossiping %s", containerID)
 	s.sender.Gossip(s.ctx.ChainID, containerID, container)
 }
+
+// SendAppGossip sends an application level message to a random subset of the
+// network
+func (s *Sender) SendAppGossip(appGossipBytes []byte) error {
+	s.ctx.Log.Verbo("Sending AppGossip of %d bytes", len(appGossipBytes))
+	s.sender.AppGossip(s.ctx.ChainID, appGossipBytes)
+	return nil
+}
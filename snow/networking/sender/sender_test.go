@@ -94,6 +94,7 @@ func TestTimeout(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -170,6 +171,7 @@ func TestReliableMessages(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -255,6 +257,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
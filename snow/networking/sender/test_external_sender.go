@@ -20,7 +20,7 @@ type ExternalSenderTest struct {
 	CantGetAncestors, CantMultiPut,
 	CantGet, CantPut,
 	CantPullQuery, CantPushQuery, CantChits,
-	CantGossip bool
+	CantGossip, CantAppGossip bool
 
 	GetAcceptedFrontierF func(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Duration) []ids.ShortID
 	AcceptedFrontierF    func(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerIDs []ids.ID)
@@ -38,7 +38,8 @@ type ExternalSenderTest struct {
 	PullQueryF func(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Duration, containerID ids.ID) []ids.ShortID
 	ChitsF     func(validatorID ids.ShortID, chainID ids.ID, requestID uint32, votes []ids.ID)
 
-	GossipF func(chainID ids.ID, containerID ids.ID, container []byte)
+	GossipF    func(chainID ids.ID, containerID ids.ID, container []byte)
+	AppGossipF func(chainID ids.ID, appGossipBytes []byte)
 }
 
 // Default set the default callable value to [cant]
@@ -60,6 +61,7 @@ func (s *ExternalSenderTest) Default(cant bool) {
 	s.CantChits = cant
 
 	s.CantGossip = cant
+	s.CantAppGossip = cant
 }
 
 // GetAcceptedFrontier calls GetAcceptedFrontierF if it was initialized. If it
@@ -235,3 +237,17 @@ func (s *ExternalSenderTest) Gossip(chainID ids.ID, containerID ids.ID, containe
 		s.B.Fatalf("Unexpectedly called Gossip")
 	}
 }
+
+// AppGossip calls AppGossipF if it was initialized. If it wasn't initialized
+// and this function shouldn't be called and testing was initialized, then
+// testing will fail.
+func (s *ExternalSenderTest) AppGossip(chainID ids.ID, appGossipBytes []byte) {
+	switch {
+	case s.AppGossipF != nil:
+		s.AppGossipF(chainID, appGossipBytes)
+	case s.CantAppGossip && s.T != nil:
+		s.T.Fatalf("Unexpectedly called AppGossip")
+	case s.CantAppGossip && s.B != nil:
+		s.B.Fatalf("Unexpectedly called AppGossip")
+	}
+}
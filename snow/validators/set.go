@@ -69,6 +69,24 @@ type Set interface {
 	// RevealValidator ensures the named validator is not hidden from future
 	// samplings
 	RevealValidator(ids.ShortID) error
+
+	// RegisterCallbackListener notifies [listener] of the changes to the set.
+	// [listener] is first notified of the validators currently in the set.
+	RegisterCallbackListener(listener SetCallbackListener)
+
+	// UnregisterCallbackListener stops notifying [listener] of the changes to
+	// the set.
+	UnregisterCallbackListener(listener SetCallbackListener)
+}
+
+// SetCallbackListener is notified of the changes to a validator set, in the
+// order they were made. The callbacks are called after the set is unlocked,
+// but before the next change is released, so they should return quickly and
+// must not call back into the set.
+type SetCallbackListener interface {
+	OnValidatorAdded(validatorID ids.ShortID, weight uint64)
+	OnValidatorRemoved(validatorID ids.ShortID, weight uint64)
+	OnValidatorWeightChanged(validatorID ids.ShortID, oldWeight, newWeight uint64)
 }
 
 // NewSet returns a new, empty set of validators.
@@ -91,26 +109,51 @@ func NewBestSet(expectedSampleSize int) Set {
 // update a validators weight, one should ensure to call add with the updated
 // validator.
 type set struct {
-	initialized      bool
-	lock             sync.RWMutex
-	vdrMap           map[ids.ShortID]int
-	vdrSlice         []*validator
-	vdrWeights       []uint64
-	vdrMaskedWeights []uint64
-	sampler          sampler.WeightedWithoutReplacement
-	totalWeight      uint64
-	maskedVdrs       ids.ShortSet
+	initialized       bool
+	lock              sync.RWMutex
+	vdrMap            map[ids.ShortID]int
+	vdrSlice          []*validator
+	vdrWeights        []uint64
+	vdrMaskedWeights  []uint64
+	sampler           sampler.WeightedWithoutReplacement
+	totalWeight       uint64
+	maskedVdrs        ids.ShortSet
+	callbackListeners []SetCallbackListener
+
+	// pendingChanges are the changes to report to [callbackListeners] once
+	// [lock] is released.
+	pendingChanges []validatorChange
+	// callbackLock is held while the listeners are notified, so that the
+	// changes are reported in the order they were made.
+	callbackLock sync.Mutex
+}
+
+// validatorChange is a change to the weight of a validator. A validator that
+// was added has an [oldWeight] of 0 and a validator that was removed has a
+// [newWeight] of 0.
+type validatorChange struct {
+	vdrID     ids.ShortID
+	oldWeight uint64
+	newWeight uint64
 }
 
 // Set implements the Set interface.
 func (s *set) Set(vdrs []Validator) error {
 	s.lock.Lock()
-	defer s.lock.Unlock()
+	defer s.unlockAndNotify()
 
 	return s.set(vdrs)
 }
 
 func (s *set) set(vdrs []Validator) error {
+	var oldWeights map[ids.ShortID]uint64
+	if len(s.callbackListeners) > 0 {
+		oldWeights = make(map[ids.ShortID]uint64, len(s.vdrSlice))
+		for i, vdr := range s.vdrSlice {
+			oldWeights[vdr.nodeID] = s.vdrWeights[i]
+		}
+	}
+
 	lenVdrs := len(vdrs)
 	// If the underlying arrays are much larger than necessary, resize them to
 	// allow garbage collection of unused memory
@@ -161,6 +204,24 @@ func (s *set) set(vdrs []Validator) error {
 		}
 		s.totalWeight = newTotalWeight
 	}
+
+	if len(s.callbackListeners) == 0 {
+		return nil
+	}
+	for i, vdr := range s.vdrSlice {
+		newWeight := s.vdrWeights[i]
+		oldWeight, existed := oldWeights[vdr.nodeID]
+		switch {
+		case !existed:
+			s.queueValidatorAdded(vdr.nodeID, newWeight)
+		case oldWeight != newWeight:
+			s.queueWeightChanged(vdr.nodeID, oldWeight, newWeight)
+		}
+		delete(oldWeights, vdr.nodeID)
+	}
+	for vdrID, oldWeight := range oldWeights {
+		s.queueValidatorRemoved(vdrID, oldWeight)
+	}
 	return nil
 }
 
@@ -170,7 +231,7 @@ func (s *set) AddWeight(vdrID ids.ShortID, weight uint64) error {
 		return nil // This validator would never be sampled anyway
 	}
 	s.lock.Lock()
-	defer s.lock.Unlock()
+	defer s.unlockAndNotify()
 
 	return s.addWeight(vdrID, weight)
 }
@@ -191,9 +252,16 @@ func (s *set) addWeight(vdrID ids.ShortID, weight uint64) error {
 		vdr = s.vdrSlice[i]
 	}
 
+	oldWeight := s.vdrWeights[i]
 	s.vdrWeights[i] += weight
 	vdr.addWeight(weight)
 
+	if oldWeight == 0 {
+		s.queueValidatorAdded(vdrID, s.vdrWeights[i])
+	} else {
+		s.queueWeightChanged(vdrID, oldWeight, s.vdrWeights[i])
+	}
+
 	if s.maskedVdrs.Contains(vdrID) {
 		return nil
 	}
@@ -249,7 +317,7 @@ func (s *set) RemoveWeight(vdrID ids.ShortID, weight uint64) error {
 		return nil
 	}
 	s.lock.Lock()
-	defer s.lock.Unlock()
+	defer s.unlockAndNotify()
 
 	return s.removeWeight(vdrID, weight)
 }
@@ -263,7 +331,8 @@ func (s *set) removeWeight(vdrID ids.ShortID, weight uint64) error {
 	// Validator exists
 	vdr := s.vdrSlice[i]
 
-	weight = safemath.Min64(s.vdrWeights[i], weight)
+	oldWeight := s.vdrWeights[i]
+	weight = safemath.Min64(oldWeight, weight)
 	s.vdrWeights[i] -= weight
 	vdr.removeWeight(weight)
 	if !s.maskedVdrs.Contains(vdrID) {
@@ -272,9 +341,12 @@ func (s *set) removeWeight(vdrID ids.ShortID, weight uint64) error {
 	}
 
 	if vdr.Weight() == 0 {
+		s.queueValidatorRemoved(vdrID, oldWeight)
 		if err := s.remove(vdrID); err != nil {
 			return err
 		}
+	} else {
+		s.queueWeightChanged(vdrID, oldWeight, s.vdrWeights[i])
 	}
 	s.initialized = false
 	return nil
@@ -497,3 +569,104 @@ func (s *set) revealValidator(vdrID ids.ShortID) error {
 
 	return nil
 }
+
+// RegisterCallbackListener implements the Set interface.
+func (s *set) RegisterCallbackListener(callbackListener SetCallbackListener) {
+	s.lock.Lock()
+	callbackListeners := make([]SetCallbackListener, len(s.callbackListeners), len(s.callbackListeners)+1)
+	copy(callbackListeners, s.callbackListeners)
+	s.callbackListeners = append(callbackListeners, callbackListener)
+
+	currentVdrs := make([]validatorChange, len(s.vdrSlice))
+	for i, vdr := range s.vdrSlice {
+		currentVdrs[i] = validatorChange{
+			vdrID:     vdr.nodeID,
+			newWeight: s.vdrWeights[i],
+		}
+	}
+
+	// The current validators are reported outside of [s.lock], but before any
+	// later change is reported.
+	s.callbackLock.Lock()
+	s.lock.Unlock()
+	defer s.callbackLock.Unlock()
+
+	for _, change := range currentVdrs {
+		callbackListener.OnValidatorAdded(change.vdrID, change.newWeight)
+	}
+}
+
+// UnregisterCallbackListener implements the Set interface.
+func (s *set) UnregisterCallbackListener(callbackListener SetCallbackListener) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// [s.callbackListeners] is never modified in place, as the listeners may
+	// still be being notified of the previous changes.
+	callbackListeners := make([]SetCallbackListener, 0, len(s.callbackListeners))
+	for _, listener := range s.callbackListeners {
+		if listener != callbackListener {
+			callbackListeners = append(callbackListeners, listener)
+		}
+	}
+	s.callbackListeners = callbackListeners
+}
+
+// unlockAndNotify releases [s.lock] and then notifies the listeners of the
+// changes made while it was held. [s.callbackLock] is grabbed before [s.lock]
+// is released, so that the changes are reported in the order they were made.
+// Assumes [s.lock] is held
+func (s *set) unlockAndNotify() {
+	if len(s.pendingChanges) == 0 {
+		s.lock.Unlock()
+		return
+	}
+
+	changes := s.pendingChanges
+	callbackListeners := s.callbackListeners
+	s.pendingChanges = nil
+
+	s.callbackLock.Lock()
+	s.lock.Unlock()
+	defer s.callbackLock.Unlock()
+
+	for _, change := range changes {
+		for _, callbackListener := range callbackListeners {
+			switch {
+			case change.oldWeight == 0:
+				callbackListener.OnValidatorAdded(change.vdrID, change.newWeight)
+			case change.newWeight == 0:
+				callbackListener.OnValidatorRemoved(change.vdrID, change.oldWeight)
+			default:
+				callbackListener.OnValidatorWeightChanged(change.vdrID, change.oldWeight, change.newWeight)
+			}
+		}
+	}
+}
+
+// Assumes [s.lock] is held
+func (s *set) queueWeightChanged(vdrID ids.ShortID, oldWeight, newWeight uint64) {
+	s.addPendingChange(vdrID, oldWeight, newWeight)
+}
+
+// Assumes [s.lock] is held
+func (s *set) queueValidatorAdded(vdrID ids.ShortID, weight uint64) {
+	s.addPendingChange(vdrID, 0, weight)
+}
+
+// Assumes [s.lock] is held
+func (s *set) queueValidatorRemoved(vdrID ids.ShortID, weight uint64) {
+	s.addPendingChange(vdrID, weight, 0)
+}
+
+// Assumes [s.lock] is held
+func (s *set) addPendingChange(vdrID ids.ShortID, oldWeight, newWeight uint64) {
+	if len(s.callbackListeners) == 0 {
+		return
+	}
+	s.pendingChanges = append(s.pendingChanges, validatorChange{
+		vdrID:     vdrID,
+		oldWeight: oldWeight,
+		newWeight: newWeight,
+	})
+}
@@ -255,3 +255,95 @@ func TestSamplerMasked(t *testing.T) {
 		assert.Equal(t, expected, result, "wrong string returned")
 	}
 }
+
+type callbackEvent struct {
+	vdrID     ids.ShortID
+	oldWeight uint64
+	newWeight uint64
+}
+
+type callbackListener struct {
+	added   []callbackEvent
+	removed []callbackEvent
+	changed []callbackEvent
+}
+
+func (c *callbackListener) OnValidatorAdded(vdrID ids.ShortID, weight uint64) {
+	c.added = append(c.added, callbackEvent{vdrID: vdrID, newWeight: weight})
+}
+
+func (c *callbackListener) OnValidatorRemoved(vdrID ids.ShortID, weight uint64) {
+	c.removed = append(c.removed, callbackEvent{vdrID: vdrID, oldWeight: weight})
+}
+
+func (c *callbackListener) OnValidatorWeightChanged(vdrID ids.ShortID, oldWeight, newWeight uint64) {
+	c.changed = append(c.changed, callbackEvent{vdrID: vdrID, oldWeight: oldWeight, newWeight: newWeight})
+}
+
+func (c *callbackListener) reset() {
+	c.added = nil
+	c.removed = nil
+	c.changed = nil
+}
+
+func TestSetRegisterCallbackListener(t *testing.T) {
+	vdr0 := ids.ShortID{1}
+	vdr1 := ids.ShortID{2}
+	vdr2 := ids.ShortID{3}
+
+	s := NewSet()
+	err := s.AddWeight(vdr0, 1)
+	assert.NoError(t, err)
+
+	// The listener is notified of the current validators
+	listener := &callbackListener{}
+	s.RegisterCallbackListener(listener)
+	assert.Equal(t, []callbackEvent{{vdrID: vdr0, newWeight: 1}}, listener.added)
+	listener.reset()
+
+	err = s.AddWeight(vdr1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []callbackEvent{{vdrID: vdr1, newWeight: 10}}, listener.added)
+
+	err = s.AddWeight(vdr1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []callbackEvent{{vdrID: vdr1, oldWeight: 10, newWeight: 15}}, listener.changed)
+	listener.reset()
+
+	err = s.RemoveWeight(vdr1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []callbackEvent{{vdrID: vdr1, oldWeight: 15, newWeight: 10}}, listener.changed)
+
+	err = s.RemoveWeight(vdr1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []callbackEvent{{vdrID: vdr1, oldWeight: 10}}, listener.removed)
+	listener.reset()
+
+	// Masking a validator doesn't change its weight
+	err = s.MaskValidator(vdr0)
+	assert.NoError(t, err)
+	assert.Empty(t, listener.added)
+	assert.Empty(t, listener.removed)
+	assert.Empty(t, listener.changed)
+
+	// Setting the validators notifies the listener of the difference
+	err = s.AddWeight(vdr1, 1)
+	assert.NoError(t, err)
+	listener.reset()
+
+	err = s.Set([]Validator{
+		NewValidator(vdr0, 2),
+		NewValidator(vdr2, 3),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []callbackEvent{{vdrID: vdr2, newWeight: 3}}, listener.added)
+	assert.Equal(t, []callbackEvent{{vdrID: vdr1, oldWeight: 1}}, listener.removed)
+	assert.Equal(t, []callbackEvent{{vdrID: vdr0, oldWeight: 1, newWeight: 2}}, listener.changed)
+	listener.reset()
+
+	// An unregistered listener is no longer notified
+	s.UnregisterCallbackListener(listener)
+	err = s.AddWeight(vdr1, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, listener.added)
+}
@@ -7,6 +7,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -29,7 +30,32 @@ func InitNodeStakingKeyPair(keyPath, certPath string) error {
 	if err != nil {
 		return err
 	}
+	return writeKeyPair(certBytes, keyBytes, keyPath, certPath)
+}
+
+// WriteTLSCert writes the key and cert of [cert] to [keyPath] and [certPath],
+// respectively, in the format expected by LoadTLSCert.
+func WriteTLSCert(cert *tls.Certificate, keyPath, certPath string) error {
+	if len(cert.Certificate) == 0 {
+		return errors.New("certificate is empty")
+	}
+	var certBuff bytes.Buffer
+	if err := pem.Encode(&certBuff, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}); err != nil {
+		return fmt.Errorf("couldn't write cert file: %w", err)
+	}
+
+	privBytes, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal private key: %w", err)
+	}
+	var keyBuff bytes.Buffer
+	if err := pem.Encode(&keyBuff, &pem.Block{Type: "PRIVATE KEY", Bytes: privBytes}); err != nil {
+		return fmt.Errorf("couldn't write private key: %w", err)
+	}
+	return writeKeyPair(certBuff.Bytes(), keyBuff.Bytes(), keyPath, certPath)
+}
 
+func writeKeyPair(certBytes, keyBytes []byte, keyPath, certPath string) error {
 	// Ensure directory where key/cert will live exist
 	if err := os.MkdirAll(filepath.Dir(certPath), perms.ReadWriteExecute); err != nil {
 		return fmt.Errorf("couldn't create path for cert: %w", err)
@@ -4,6 +4,9 @@ import (
 	"crypto"
 	"crypto/rand"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -26,3 +29,24 @@ func TestMakeKeys(t *testing.T) {
 	err = cert.Leaf.CheckSignature(cert.Leaf.SignatureAlgorithm, msg, sig)
 	assert.NoError(err)
 }
+
+func TestWriteTLSCert(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "staking")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	cert, err := NewTLSCert()
+	assert.NoError(err)
+
+	keyPath := filepath.Join(dir, "staker.key")
+	certPath := filepath.Join(dir, "staker.crt")
+	err = WriteTLSCert(cert, keyPath, certPath)
+	assert.NoError(err)
+
+	loadedCert, err := LoadTLSCert(keyPath, certPath)
+	assert.NoError(err)
+	assert.Equal(cert.Leaf.Raw, loadedCert.Leaf.Raw)
+	assert.Equal(cert.PrivateKey, loadedCert.PrivateKey)
+}
@@ -30,6 +30,7 @@ const (
 	MultiPutMsg
 	GetAncestorsFailedMsg
 	TimeoutMsg
+	AppGossipMsg
 )
 
 func (t MsgType) String() string {
@@ -78,6 +79,8 @@ func (t MsgType) String() string {
 		return "Notify"
 	case GossipMsg:
 		return "Gossip"
+	case AppGossipMsg:
+		return "App Gossip"
 	default:
 		return fmt.Sprintf("Unknown Message Type: %d", t)
 	}
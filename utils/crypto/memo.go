@@ -0,0 +1,117 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+
+	secp256k1 "github.com/decred/dcrd/dcrec/secp256k1/v3"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// An encrypted memo is the envelope:
+//
+//	EncryptedMemoPrefix   (4 bytes)
+//	Ephemeral public key  (33 bytes, compressed)
+//	Nonce                 (12 bytes)
+//	Ciphertext            (len(plaintext) bytes)
+//	Tag                   (16 bytes)
+//
+// The plaintext is encrypted with AES-256-GCM. The key is the SHA256 hash of
+// the ECDH shared secret of the ephemeral key and the recipient's key,
+// followed by the ephemeral public key. The prefix and the ephemeral public
+// key are authenticated as additional data.
+const (
+	memoNonceLen = 12
+	memoTagLen   = 16
+
+	// EncryptedMemoOverhead is the number of bytes an encrypted memo is longer
+	// than its plaintext
+	EncryptedMemoOverhead = 4 + SECP256K1RPKLen + memoNonceLen + memoTagLen
+)
+
+var (
+	// EncryptedMemoPrefix flags a memo that is encrypted. The last byte is the
+	// version of the envelope.
+	EncryptedMemoPrefix = []byte{0x00, 'e', 'm', 0x01}
+
+	errNotEncryptedMemo     = errors.New("memo isn't encrypted")
+	errInvalidEncryptedMemo = errors.New("invalid encrypted memo")
+	errWrongMemoKey         = errors.New("memo wasn't encrypted to this key")
+)
+
+// IsEncryptedMemo returns true if [memo] is flagged as encrypted
+func IsEncryptedMemo(memo []byte) bool {
+	return bytes.HasPrefix(memo, EncryptedMemoPrefix)
+}
+
+// EncryptMemo returns the envelope of [plaintext] encrypted to [recipient]
+func EncryptMemo(recipient *PublicKeySECP256K1R, plaintext []byte) ([]byte, error) {
+	ephemeral, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	ephemeralPK := ephemeral.PubKey().SerializeCompressed()
+
+	aead, err := newMemoAEAD(secp256k1.GenerateSharedSecret(ephemeral, recipient.pk), ephemeralPK)
+	if err != nil {
+		return nil, err
+	}
+
+	memo := make([]byte, 0, EncryptedMemoOverhead+len(plaintext))
+	memo = append(memo, EncryptedMemoPrefix...)
+	memo = append(memo, ephemeralPK...)
+	header := memo
+	nonce := make([]byte, memoNonceLen)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	memo = append(memo, nonce...)
+	return aead.Seal(memo, nonce, plaintext, header), nil
+}
+
+// DecryptMemo returns the plaintext of the envelope [memo], which must have
+// been encrypted to the public key of [recipient]
+func DecryptMemo(recipient *PrivateKeySECP256K1R, memo []byte) ([]byte, error) {
+	if !IsEncryptedMemo(memo) {
+		return nil, errNotEncryptedMemo
+	}
+	if len(memo) < EncryptedMemoOverhead {
+		return nil, errInvalidEncryptedMemo
+	}
+
+	headerLen := len(EncryptedMemoPrefix) + SECP256K1RPKLen
+	header := memo[:headerLen]
+	ephemeralPK, err := secp256k1.ParsePubKey(header[len(EncryptedMemoPrefix):])
+	if err != nil {
+		return nil, errInvalidEncryptedMemo
+	}
+
+	aead, err := newMemoAEAD(secp256k1.GenerateSharedSecret(recipient.sk, ephemeralPK), header[len(EncryptedMemoPrefix):])
+	if err != nil {
+		return nil, err
+	}
+	nonce := memo[headerLen : headerLen+memoNonceLen]
+	plaintext, err := aead.Open(nil, nonce, memo[headerLen+memoNonceLen:], header)
+	if err != nil {
+		return nil, errWrongMemoKey
+	}
+	return plaintext, nil
+}
+
+// newMemoAEAD returns the cipher that encrypts a memo with the ephemeral
+// public key [ephemeralPK] and the shared secret [secret]
+func newMemoAEAD(secret []byte, ephemeralPK []byte) (cipher.AEAD, error) {
+	key := hashing.ComputeHash256(append(secret, ephemeralPK...))
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
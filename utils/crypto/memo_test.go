@@ -0,0 +1,72 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncryptedMemo(t *testing.T) {
+	f := FactorySECP256K1R{}
+	newKey := func() *PrivateKeySECP256K1R {
+		key, err := f.NewPrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key.(*PrivateKeySECP256K1R)
+	}
+	recipient, other := newKey(), newKey()
+
+	plaintext := []byte("invoice #1234")
+	memo, err := EncryptMemo(recipient.PublicKey().(*PublicKeySECP256K1R), plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncryptedMemo(memo) {
+		t.Fatal("memo should be flagged as encrypted")
+	}
+	if len(memo) != len(plaintext)+EncryptedMemoOverhead {
+		t.Fatalf("expected the memo to be %d bytes but it's %d", len(plaintext)+EncryptedMemoOverhead, len(memo))
+	}
+	if bytes.Contains(memo, plaintext) {
+		t.Fatal("memo shouldn't contain the plaintext")
+	}
+
+	decrypted, err := DecryptMemo(recipient, memo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("expected %q but got %q", plaintext, decrypted)
+	}
+
+	if _, err := DecryptMemo(other, memo); err != errWrongMemoKey {
+		t.Fatalf("expected %s but got %v", errWrongMemoKey, err)
+	}
+
+	tampered := append([]byte(nil), memo...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := DecryptMemo(recipient, tampered); err != errWrongMemoKey {
+		t.Fatalf("expected %s but got %v", errWrongMemoKey, err)
+	}
+
+	if _, err := DecryptMemo(recipient, plaintext); err != errNotEncryptedMemo {
+		t.Fatalf("expected %s but got %v", errNotEncryptedMemo, err)
+	}
+	if _, err := DecryptMemo(recipient, memo[:EncryptedMemoOverhead-1]); err != errInvalidEncryptedMemo {
+		t.Fatalf("expected %s but got %v", errInvalidEncryptedMemo, err)
+	}
+
+	// An empty memo can be encrypted
+	memo, err = EncryptMemo(recipient.PublicKey().(*PublicKeySECP256K1R), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decrypted, err := DecryptMemo(recipient, memo); err != nil {
+		t.Fatal(err)
+	} else if len(decrypted) != 0 {
+		t.Fatalf("expected an empty plaintext but got %q", decrypted)
+	}
+}
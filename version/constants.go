@@ -44,6 +44,13 @@ var (
 		constants.FujiID:    time.Date(2021, time.May, 5, 14, 0, 0, 0, time.UTC),
 	}
 	ApricotPhase2DefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// ApricotPhase3 hasn't been scheduled on the public networks yet
+	ApricotPhase3Times = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		constants.FujiID:    time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	ApricotPhase3DefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
 )
 
 func init() {
@@ -81,6 +88,13 @@ func GetApricotPhase2Time(networkID uint32) time.Time {
 	return ApricotPhase2DefaultTime
 }
 
+func GetApricotPhase3Time(networkID uint32) time.Time {
+	if upgradeTime, exists := ApricotPhase3Times[networkID]; exists {
+		return upgradeTime
+	}
+	return ApricotPhase3DefaultTime
+}
+
 func GetCompatibility(networkID uint32) Compatibility {
 	return NewCompatibility(
 		CurrentApp,
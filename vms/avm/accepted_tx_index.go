@@ -0,0 +1,107 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+)
+
+// Number of index entries removed, or of transactions indexed, between two
+// commits when an index is removed or rebuilt
+const indexCommitBatchSize = 1024
+
+var indexedKey = []byte("indexed")
+
+// acceptedTxIndex is the database of an optional index that is built from the
+// accepted transactions. It's marked as indexed while it's in sync with them.
+type acceptedTxIndex struct {
+	db database.Database
+}
+
+// isIndexed returns true if the index is in sync with the accepted
+// transactions.
+func (i *acceptedTxIndex) isIndexed() (bool, error) {
+	return i.db.Has(indexedKey)
+}
+
+func (i *acceptedTxIndex) setIndexed() error {
+	return i.db.Put(indexedKey, nil)
+}
+
+// clear removes every entry from the index. The index is first marked as not
+// indexed, and the entries are then removed in batches, calling [commit] after
+// each batch, so that an interrupted removal never leaves a partial index
+// marked as indexed.
+func (i *acceptedTxIndex) clear(commit func() error) error {
+	if err := i.db.Delete(indexedKey); err != nil {
+		return err
+	}
+	if err := commit(); err != nil {
+		return err
+	}
+	for {
+		iter := i.db.NewIterator()
+		keys := [][]byte(nil)
+		for len(keys) < indexCommitBatchSize && iter.Next() {
+			keys = append(keys, append([]byte(nil), iter.Key()...))
+		}
+		err := iter.Error()
+		iter.Release()
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if err := i.db.Delete(key); err != nil {
+				return err
+			}
+		}
+		if err := commit(); err != nil {
+			return err
+		}
+		if len(keys) < indexCommitBatchSize {
+			return nil
+		}
+	}
+}
+
+// initIndex rebuilds [index] with [indexTx] if it was just enabled, and removes
+// it if it was just disabled. [name] describes the index in the logs.
+func (vm *VM) initIndex(index *acceptedTxIndex, enabled bool, name string, indexTx func(*Tx) error) error {
+	indexed, err := index.isIndexed()
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		if indexed {
+			vm.ctx.Log.Info("removing the index of %s", name)
+			return index.clear(vm.db.Commit)
+		}
+		return nil
+	}
+	if indexed {
+		return nil
+	}
+	vm.ctx.Log.Info("building the index of %s", name)
+	return vm.rebuildIndex(index, indexTx)
+}
+
+// rebuildIndex clears [index] and passes every transaction that has been
+// accepted to [indexTx]. As the acceptance order isn't persisted, the
+// transactions are indexed in an order that respects their dependencies, which
+// may differ from the order they were accepted in. The index is committed in
+// batches and is only marked as indexed once every transaction has been
+// indexed.
+func (vm *VM) rebuildIndex(index *acceptedTxIndex, indexTx func(*Tx) error) error {
+	if err := index.clear(vm.db.Commit); err != nil {
+		return err
+	}
+	if err := vm.forEachAcceptedTxInBatches(indexTx); err != nil {
+		return err
+	}
+	if err := index.setIndexed(); err != nil {
+		return err
+	}
+	return vm.db.Commit()
+}
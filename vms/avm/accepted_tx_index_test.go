@@ -0,0 +1,48 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+)
+
+func TestAcceptedTxIndexClearInBatches(t *testing.T) {
+	index := &acceptedTxIndex{db: memdb.New()}
+	for j := uint64(0); j <= indexCommitBatchSize; j++ {
+		if err := index.db.Put(database.PackUInt64(j), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := index.setIndexed(); err != nil {
+		t.Fatal(err)
+	}
+
+	numCommits := 0
+	err := index.clear(func() error {
+		// The index must never be committed while marked as indexed
+		if indexed, err := index.isIndexed(); err != nil {
+			return err
+		} else if indexed {
+			t.Fatal("a partially removed index shouldn't be marked as indexed")
+		}
+		numCommits++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The marker, then a full batch of entries, then the remaining entry
+	if numCommits != 3 {
+		t.Fatalf("expected 3 commits but got %d", numCommits)
+	}
+
+	iter := index.db.NewIterator()
+	defer iter.Release()
+	if iter.Next() {
+		t.Fatalf("expected the index to be empty but found key %x", iter.Key())
+	}
+}
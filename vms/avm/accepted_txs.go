@@ -0,0 +1,122 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+)
+
+// getProducedUTXO returns the UTXO [utxoID] as it was produced by its accepted
+// transaction, regardless of whether it has since been spent.
+func (vm *VM) getProducedUTXO(utxoID *djtx.UTXOID) (*djtx.UTXO, error) {
+	txID, index := utxoID.InputSource()
+	tx, err := vm.state.GetTx(txID)
+	if err != nil {
+		return nil, err
+	}
+	utxos := tx.UTXOs()
+	if uint32(len(utxos)) <= index {
+		return nil, errInvalidUTXO
+	}
+	return utxos[index], nil
+}
+
+// forEachAcceptedTxInBatches calls [f] with every transaction that has been
+// accepted, like forEachAcceptedTx, and commits the database after every
+// [indexCommitBatchSize] transactions.
+func (vm *VM) forEachAcceptedTxInBatches(f func(*Tx) error) error {
+	numTxs := 0
+	return vm.forEachAcceptedTx(func(tx *Tx) error {
+		if err := f(tx); err != nil {
+			return err
+		}
+		numTxs++
+		if numTxs%indexCommitBatchSize != 0 {
+			return nil
+		}
+		return vm.db.Commit()
+	})
+}
+
+// forEachAcceptedTx calls [f] with every transaction that has been accepted.
+// As the acceptance order isn't persisted, the transactions are passed in an
+// order that respects their dependencies.
+func (vm *VM) forEachAcceptedTx(f func(*Tx) error) error {
+	txIDs := []ids.ID(nil)
+	accepted := ids.Set{}
+	iter := prefixdb.New(txStatePrefix, vm.db).NewIterator()
+	for iter.Next() {
+		txID, err := ids.ToID(iter.Key())
+		if err != nil {
+			iter.Release()
+			return err
+		}
+		status, err := vm.state.GetStatus(txID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			iter.Release()
+			return err
+		}
+		if status == choices.Accepted {
+			txIDs = append(txIDs, txID)
+			accepted.Add(txID)
+		}
+	}
+	err := iter.Error()
+	iter.Release()
+	if err != nil {
+		return err
+	}
+
+	numParents := make(map[ids.ID]int, len(txIDs))
+	children := map[ids.ID][]ids.ID{}
+	for _, txID := range txIDs {
+		tx, err := vm.state.GetTx(txID)
+		if err != nil {
+			return err
+		}
+		parents := ids.Set{}
+		for _, utxoID := range tx.InputUTXOs() {
+			parentID, _ := utxoID.InputSource()
+			if utxoID.Symbolic() || !accepted.Contains(parentID) || parents.Contains(parentID) {
+				continue
+			}
+			parents.Add(parentID)
+			children[parentID] = append(children[parentID], txID)
+		}
+		numParents[txID] = parents.Len()
+	}
+
+	queue := []ids.ID(nil)
+	for _, txID := range txIDs {
+		if numParents[txID] == 0 {
+			queue = append(queue, txID)
+		}
+	}
+	for len(queue) > 0 {
+		txID := queue[0]
+		queue = queue[1:]
+
+		tx, err := vm.state.GetTx(txID)
+		if err != nil {
+			return err
+		}
+		if err := f(tx); err != nil {
+			return err
+		}
+		for _, childID := range children[txID] {
+			numParents[childID]--
+			if numParents[childID] == 0 {
+				queue = append(queue, childID)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	// Max number of transactions returned by a single call to GetAddressTxs
+	maxAddressTxsPageSize = 1024
+
+	addressTxEntrySize = hashing.HashLen + 2*wrappers.LongLen
+)
+
+var (
+	addressTxsPrefix   = []byte("addressTxs")
+	addressTxsCountKey = []byte("count")
+)
+
+// addressTx is an entry in the history of an address for an asset
+type addressTx struct {
+	TxID ids.ID
+	// Amount of the asset the address received in the transaction
+	In uint64
+	// Amount of the asset the address spent in the transaction
+	Out uint64
+}
+
+// addressTxIndex maintains, for every (address, asset) pair, the list of the
+// accepted transactions that consumed or produced UTXOs of the asset owned by
+// the address. Transactions accepted while the index is enabled are listed in
+// the order they were accepted. Transactions accepted before the index was
+// (re)built are listed in an order that respects their dependencies, which may
+// differ from the order they were accepted in.
+type addressTxIndex struct {
+	acceptedTxIndex
+}
+
+func newAddressTxIndex(db database.Database) *addressTxIndex {
+	return &addressTxIndex{
+		acceptedTxIndex: acceptedTxIndex{
+			db: prefixdb.New(addressTxsPrefix, db),
+		},
+	}
+}
+
+func (i *addressTxIndex) listDB(addr ids.ShortID, assetID ids.ID) database.Database {
+	prefix := make([]byte, len(addr)+len(assetID))
+	copy(prefix, addr[:])
+	copy(prefix[len(addr):], assetID[:])
+	return prefixdb.NewNested(prefix, i.db)
+}
+
+func (i *addressTxIndex) count(listDB database.Database) (uint64, error) {
+	count, err := database.GetUInt64(listDB, addressTxsCountKey)
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return count, err
+}
+
+// add appends [tx] to the history of [addr] for [assetID]
+func (i *addressTxIndex) add(addr ids.ShortID, assetID ids.ID, tx *addressTx) error {
+	listDB := i.listDB(addr, assetID)
+	count, err := i.count(listDB)
+	if err != nil {
+		return err
+	}
+
+	p := wrappers.Packer{Bytes: make([]byte, addressTxEntrySize)}
+	p.PackFixedBytes(tx.TxID[:])
+	p.PackLong(tx.In)
+	p.PackLong(tx.Out)
+	if err := listDB.Put(database.PackUInt64(count), p.Bytes); err != nil {
+		return err
+	}
+	return database.PutUInt64(listDB, addressTxsCountKey, count+1)
+}
+
+// read returns at most [pageSize] entries of the history of [addr] for
+// [assetID], starting at the [cursor]th entry. The returned cursor is the
+// index of the entry following the last one returned.
+func (i *addressTxIndex) read(addr ids.ShortID, assetID ids.ID, cursor uint64, pageSize uint64) ([]addressTx, uint64, error) {
+	listDB := i.listDB(addr, assetID)
+	count, err := i.count(listDB)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	txs := []addressTx(nil)
+	for ; cursor < count && uint64(len(txs)) < pageSize; cursor++ {
+		entryBytes, err := listDB.Get(database.PackUInt64(cursor))
+		if err != nil {
+			return nil, 0, err
+		}
+		p := wrappers.Packer{Bytes: entryBytes}
+		txID, err := ids.ToID(p.UnpackFixedBytes(hashing.HashLen))
+		if err != nil {
+			return nil, 0, err
+		}
+		tx := addressTx{
+			TxID: txID,
+			In:   p.UnpackLong(),
+			Out:  p.UnpackLong(),
+		}
+		if p.Errored() {
+			return nil, 0, p.Err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, cursor, nil
+}
+
+// indexTx adds the accepted [tx] to the history of every address that owned
+// one of the UTXOs consumed or produced by [tx].
+func (vm *VM) indexTx(tx *Tx) error {
+	txID := tx.ID()
+	entries := map[ids.ShortID]map[ids.ID]*addressTx{}
+	record := func(utxo *djtx.UTXO, spent bool) error {
+		addressable, ok := utxo.Out.(djtx.Addressable)
+		if !ok {
+			return nil
+		}
+		amount := uint64(0)
+		if amounter, ok := utxo.Out.(djtx.Amounter); ok {
+			amount = amounter.Amount()
+		}
+		assetID := utxo.AssetID()
+		for _, addrBytes := range addressable.Addresses() {
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				return err
+			}
+			assetEntries, ok := entries[addr]
+			if !ok {
+				assetEntries = map[ids.ID]*addressTx{}
+				entries[addr] = assetEntries
+			}
+			entry, ok := assetEntries[assetID]
+			if !ok {
+				entry = &addressTx{TxID: txID}
+				assetEntries[assetID] = entry
+			}
+			if spent {
+				entry.Out, err = safemath.Add64(entry.Out, amount)
+			} else {
+				entry.In, err = safemath.Add64(entry.In, amount)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, utxoID := range tx.InputUTXOs() {
+		if utxoID.Symbolic() {
+			// Imported UTXOs were owned by addresses of another chain
+			continue
+		}
+		utxo, err := vm.getProducedUTXO(utxoID)
+		if err != nil {
+			return err
+		}
+		if err := record(utxo, true); err != nil {
+			return err
+		}
+	}
+	for _, utxo := range tx.UTXOs() {
+		if err := record(utxo, false); err != nil {
+			return err
+		}
+	}
+
+	for addr, assetEntries := range entries {
+		for assetID, entry := range assetEntries {
+			if err := vm.addressTxs.add(addr, assetID, entry); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+func TestGetAddressTxs(t *testing.T) {
+	_, vm, s, _, genesisTx := setupWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := genesisTx.ID()
+	fromAddrStr, err := vm.FormatLocalAddress(addrs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	toAddrStr, err := vm.FormatLocalAddress(addrs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.GetAddressTxs(nil, &GetAddressTxsArgs{
+		Address: fromAddrStr,
+		AssetID: assetID.String(),
+	}, &GetAddressTxsReply{})
+	if err != errIndexingDisabled {
+		t.Fatalf("expected %s but got %v", errIndexingDisabled, err)
+	}
+
+	// Enabling the index indexes the genesis transactions
+	if err := vm.initAddressTxIndex(true); err != nil {
+		t.Fatal(err)
+	}
+
+	reply := &api.JSONTxIDChangeAddr{}
+	vm.timer.Cancel()
+	err = s.Send(nil, &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs:  api.JSONFromAddrs{From: []string{fromAddrStr}},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		},
+		SendOutput: SendOutput{
+			Amount:  1000,
+			AssetID: assetID.String(),
+			To:      toAddrStr,
+		},
+	}, reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sendTx := UniqueTx{
+		vm:   vm,
+		txID: reply.TxID,
+	}
+	if err := sendTx.Accept(); err != nil {
+		t.Fatal(err)
+	}
+
+	checkHistory := func(addrStr string, expected []AddressTx) {
+		for i, expectedTx := range expected {
+			txsReply := &GetAddressTxsReply{}
+			err := s.GetAddressTxs(nil, &GetAddressTxsArgs{
+				Address:  addrStr,
+				AssetID:  assetID.String(),
+				Cursor:   json.Uint64(i),
+				PageSize: 1,
+			}, txsReply)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(txsReply.Txs) != 1 {
+				t.Fatalf("expected 1 tx but got %d", len(txsReply.Txs))
+			}
+			if txsReply.Txs[0] != expectedTx {
+				t.Fatalf("expected %+v but got %+v", expectedTx, txsReply.Txs[0])
+			}
+			if txsReply.Cursor != json.Uint64(i+1) {
+				t.Fatalf("expected cursor %d but got %d", i+1, txsReply.Cursor)
+			}
+		}
+
+		txsReply := &GetAddressTxsReply{}
+		err := s.GetAddressTxs(nil, &GetAddressTxsArgs{
+			Address: addrStr,
+			AssetID: assetID.String(),
+			Cursor:  json.Uint64(len(expected)),
+		}, txsReply)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(txsReply.Txs) != 0 {
+			t.Fatalf("expected no more txs but got %d", len(txsReply.Txs))
+		}
+	}
+	checkHistory(fromAddrStr, []AddressTx{
+		{TxID: assetID, In: json.Uint64(startBalance)},
+		{TxID: reply.TxID, Out: json.Uint64(startBalance)},
+	})
+	checkHistory(toAddrStr, []AddressTx{
+		{TxID: assetID, In: json.Uint64(startBalance)},
+		{TxID: reply.TxID, In: 1000},
+	})
+	checkHistory(changeAddrStr, []AddressTx{
+		{TxID: reply.TxID, In: json.Uint64(startBalance - 1000 - vm.txFee)},
+	})
+
+	// Rebuilding the index results in the same histories
+	if err := vm.rebuildIndex(&vm.addressTxs.acceptedTxIndex, vm.indexTx); err != nil {
+		t.Fatal(err)
+	}
+	checkHistory(fromAddrStr, []AddressTx{
+		{TxID: assetID, In: json.Uint64(startBalance)},
+		{TxID: reply.TxID, Out: json.Uint64(startBalance)},
+	})
+
+	// Disabling the index removes it
+	if err := vm.initAddressTxIndex(false); err != nil {
+		t.Fatal(err)
+	}
+	if indexed, err := vm.addressTxs.isIndexed(); err != nil {
+		t.Fatal(err)
+	} else if indexed {
+		t.Fatal("index should have been removed")
+	}
+	txs, _, err := vm.addressTxs.read(addrs[0], assetID, 0, maxAddressTxsPageSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 0 {
+		t.Fatalf("expected the index to be empty but got %d txs", len(txs))
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	config, err := parseConfig(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.IndexTransactions {
+		t.Fatal("transactions shouldn't be indexed by default")
+	}
+
+	config, err = parseConfig([]byte(`{"indexTransactions":true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !config.IndexTransactions {
+		t.Fatal("transactions should be indexed")
+	}
+
+	if _, err := parseConfig([]byte(`{`)); err == nil {
+		t.Fatal("should have failed to parse the config")
+	}
+}
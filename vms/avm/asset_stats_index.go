@@ -0,0 +1,367 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"math"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	// Max number of holders returned by a single call to GetAssetHolders
+	maxAssetHoldersPageSize = 1024
+
+	assetStatsSize = 3 * wrappers.LongLen
+	// A holder key is the inverted balance of the holder followed by its
+	// address, so that holders are iterated by decreasing balance
+	assetHolderKeySize = wrappers.LongLen + 20
+)
+
+var (
+	assetStatsPrefix = []byte("assetStats")
+
+	assetStatsStatsPrefix    = []byte("stats")
+	assetStatsBalancesPrefix = []byte("balances")
+	assetStatsHoldersPrefix  = []byte("holders")
+	assetStatsMintersPrefix  = []byte("minters")
+)
+
+// assetStats are the supply statistics of an asset
+type assetStats struct {
+	// Amount of the asset that has been created
+	Minted uint64
+	// Amount of the asset that has been destroyed
+	Burned uint64
+	// Number of addresses that own a positive amount of the asset
+	Holders uint64
+}
+
+// assetStatsIndex maintains the supply statistics, the balance of each holder
+// and the mint outputs of every asset. Balances are calculated like the
+// balances returned by GetBalance, so a UTXO with several owners counts
+// towards the balance of each of them. Only outputs with an amount count
+// towards the supply and the balances.
+type assetStatsIndex struct {
+	acceptedTxIndex
+	// Asset ID --> stats of the asset
+	statsDB database.Database
+	// Asset ID + address --> balance of the address
+	balancesDB database.Database
+	// Asset ID + holder key --> nil
+	holdersDB database.Database
+	// Asset ID + UTXO ID --> nil
+	mintersDB database.Database
+}
+
+func newAssetStatsIndex(db database.Database) *assetStatsIndex {
+	indexDB := prefixdb.New(assetStatsPrefix, db)
+	return &assetStatsIndex{
+		acceptedTxIndex: acceptedTxIndex{
+			db: indexDB,
+		},
+		statsDB:    prefixdb.NewNested(assetStatsStatsPrefix, indexDB),
+		balancesDB: prefixdb.NewNested(assetStatsBalancesPrefix, indexDB),
+		holdersDB:  prefixdb.NewNested(assetStatsHoldersPrefix, indexDB),
+		mintersDB:  prefixdb.NewNested(assetStatsMintersPrefix, indexDB),
+	}
+}
+
+// stats returns the statistics of [assetID]
+func (i *assetStatsIndex) stats(assetID ids.ID) (*assetStats, error) {
+	statsBytes, err := i.statsDB.Get(assetID[:])
+	if err == database.ErrNotFound {
+		return &assetStats{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := wrappers.Packer{Bytes: statsBytes}
+	stats := &assetStats{
+		Minted:  p.UnpackLong(),
+		Burned:  p.UnpackLong(),
+		Holders: p.UnpackLong(),
+	}
+	return stats, p.Err
+}
+
+func (i *assetStatsIndex) putStats(assetID ids.ID, stats *assetStats) error {
+	p := wrappers.Packer{Bytes: make([]byte, assetStatsSize)}
+	p.PackLong(stats.Minted)
+	p.PackLong(stats.Burned)
+	p.PackLong(stats.Holders)
+	return i.statsDB.Put(assetID[:], p.Bytes)
+}
+
+func (i *assetStatsIndex) assetDB(db database.Database, assetID ids.ID) database.Database {
+	return prefixdb.NewNested(assetID[:], db)
+}
+
+func (i *assetStatsIndex) balance(assetID ids.ID, addr ids.ShortID) (uint64, error) {
+	balance, err := database.GetUInt64(i.assetDB(i.balancesDB, assetID), addr[:])
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return balance, err
+}
+
+func holderKey(balance uint64, addr ids.ShortID) []byte {
+	p := wrappers.Packer{Bytes: make([]byte, assetHolderKeySize)}
+	p.PackLong(math.MaxUint64 - balance)
+	p.PackFixedBytes(addr[:])
+	return p.Bytes
+}
+
+// setBalance sets the balance of [addr] in [assetID], and returns the change
+// to the number of holders of the asset
+func (i *assetStatsIndex) setBalance(assetID ids.ID, addr ids.ShortID, balance uint64) (int, error) {
+	oldBalance, err := i.balance(assetID, addr)
+	if err != nil {
+		return 0, err
+	}
+	if oldBalance == balance {
+		return 0, nil
+	}
+
+	balancesDB := i.assetDB(i.balancesDB, assetID)
+	holdersDB := i.assetDB(i.holdersDB, assetID)
+	holdersChange := 0
+	if oldBalance > 0 {
+		if err := holdersDB.Delete(holderKey(oldBalance, addr)); err != nil {
+			return 0, err
+		}
+		holdersChange--
+	}
+	if balance == 0 {
+		return holdersChange, balancesDB.Delete(addr[:])
+	}
+	if err := holdersDB.Put(holderKey(balance, addr), nil); err != nil {
+		return 0, err
+	}
+	return holdersChange + 1, database.PutUInt64(balancesDB, addr[:], balance)
+}
+
+// holders returns at most [pageSize] of the holders of [assetID] and their
+// balances, by decreasing balance, starting at the holder key [start]. The
+// returned key is the key of the next holder, or nil if there are no more.
+func (i *assetStatsIndex) holders(assetID ids.ID, start []byte, pageSize int) ([]ids.ShortID, []uint64, []byte, error) {
+	iter := i.assetDB(i.holdersDB, assetID).NewIteratorWithStart(start)
+	defer iter.Release()
+
+	addrs := []ids.ShortID(nil)
+	balances := []uint64(nil)
+	for iter.Next() {
+		key := iter.Key()
+		if len(addrs) == pageSize {
+			return addrs, balances, append([]byte(nil), key...), nil
+		}
+
+		p := wrappers.Packer{Bytes: key}
+		balance := math.MaxUint64 - p.UnpackLong()
+		addr, err := ids.ToShortID(p.UnpackFixedBytes(20))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if p.Errored() {
+			return nil, nil, nil, p.Err
+		}
+		addrs = append(addrs, addr)
+		balances = append(balances, balance)
+	}
+	return addrs, balances, nil, iter.Error()
+}
+
+// minters returns the IDs of the unspent mint outputs of [assetID]
+func (i *assetStatsIndex) minters(assetID ids.ID) ([]ids.ID, error) {
+	iter := i.assetDB(i.mintersDB, assetID).NewIterator()
+	defer iter.Release()
+
+	utxoIDs := []ids.ID(nil)
+	for iter.Next() {
+		utxoID, err := ids.ToID(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		utxoIDs = append(utxoIDs, utxoID)
+	}
+	return utxoIDs, iter.Error()
+}
+
+// mintOwners returns the owners of [out] if it's a mint output
+func mintOwners(out verify.State) (*secp256k1fx.OutputOwners, bool) {
+	switch out := out.(type) {
+	case *secp256k1fx.MintOutput:
+		return &out.OutputOwners, true
+	case *nftfx.MintOutput:
+		return &out.OutputOwners, true
+	case *propertyfx.MintOutput:
+		return &out.OutputOwners, true
+	default:
+		return nil, false
+	}
+}
+
+// indexAssetStats updates the statistics of the assets moved by the accepted
+// [tx]. Funds that are imported or exported count as being consumed or
+// produced, so funds that were exported to another chain are still counted as
+// circulating.
+func (vm *VM) indexAssetStats(tx *Tx) error {
+	// Asset ID --> amount of the asset consumed by the tx
+	consumed := map[ids.ID]uint64{}
+	// Asset ID --> amount of the asset produced by the tx
+	produced := map[ids.ID]uint64{}
+	// Asset ID --> address --> amount of the asset the address received
+	received := map[ids.ID]map[ids.ShortID]uint64{}
+	// Asset ID --> address --> amount of the asset the address spent
+	spent := map[ids.ID]map[ids.ShortID]uint64{}
+
+	record := func(assetID ids.ID, out verify.State, totals map[ids.ID]uint64, changes map[ids.ID]map[ids.ShortID]uint64) error {
+		amounter, ok := out.(djtx.Amounter)
+		if !ok {
+			return nil
+		}
+		amount := amounter.Amount()
+		total, err := safemath.Add64(totals[assetID], amount)
+		if err != nil {
+			return err
+		}
+		totals[assetID] = total
+
+		addressable, ok := out.(djtx.Addressable)
+		if !ok {
+			return nil
+		}
+		assetChanges, ok := changes[assetID]
+		if !ok {
+			assetChanges = map[ids.ShortID]uint64{}
+			changes[assetID] = assetChanges
+		}
+		for _, addrBytes := range addressable.Addresses() {
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				return err
+			}
+			change, err := safemath.Add64(assetChanges[addr], amount)
+			if err != nil {
+				return err
+			}
+			assetChanges[addr] = change
+		}
+		return nil
+	}
+
+	for _, utxoID := range tx.InputUTXOs() {
+		if utxoID.Symbolic() {
+			// Imported UTXOs are recorded below
+			continue
+		}
+		utxo, err := vm.getProducedUTXO(utxoID)
+		if err != nil {
+			return err
+		}
+		assetID := utxo.AssetID()
+		if err := record(assetID, utxo.Out, consumed, spent); err != nil {
+			return err
+		}
+		if _, ok := mintOwners(utxo.Out); ok {
+			inputID := utxo.InputID()
+			if err := vm.assetStats.assetDB(vm.assetStats.mintersDB, assetID).Delete(inputID[:]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, utxo := range tx.UTXOs() {
+		assetID := utxo.AssetID()
+		if err := record(assetID, utxo.Out, produced, received); err != nil {
+			return err
+		}
+		if _, ok := mintOwners(utxo.Out); ok {
+			inputID := utxo.InputID()
+			if err := vm.assetStats.assetDB(vm.assetStats.mintersDB, assetID).Put(inputID[:], nil); err != nil {
+				return err
+			}
+		}
+	}
+	switch utx := tx.UnsignedTx.(type) {
+	case *ImportTx:
+		for _, in := range utx.ImportedIns {
+			total, err := safemath.Add64(consumed[in.AssetID()], in.Input().Amount())
+			if err != nil {
+				return err
+			}
+			consumed[in.AssetID()] = total
+		}
+	case *ExportTx:
+		for _, out := range utx.ExportedOuts {
+			if err := record(out.AssetID(), out.Out, produced, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	assetIDs := ids.Set{}
+	for assetID := range consumed {
+		assetIDs.Add(assetID)
+	}
+	for assetID := range produced {
+		assetIDs.Add(assetID)
+	}
+	for assetID := range assetIDs {
+		stats, err := vm.assetStats.stats(assetID)
+		if err != nil {
+			return err
+		}
+
+		if produced[assetID] > consumed[assetID] {
+			stats.Minted, err = safemath.Add64(stats.Minted, produced[assetID]-consumed[assetID])
+		} else {
+			stats.Burned, err = safemath.Add64(stats.Burned, consumed[assetID]-produced[assetID])
+		}
+		if err != nil {
+			return err
+		}
+
+		addrs := ids.ShortSet{}
+		for addr := range received[assetID] {
+			addrs.Add(addr)
+		}
+		for addr := range spent[assetID] {
+			addrs.Add(addr)
+		}
+		for addr := range addrs {
+			balance, err := vm.assetStats.balance(assetID, addr)
+			if err != nil {
+				return err
+			}
+			balance, err = safemath.Add64(balance, received[assetID][addr])
+			if err != nil {
+				return err
+			}
+			balance, err = safemath.Sub64(balance, spent[assetID][addr])
+			if err != nil {
+				return err
+			}
+			holdersChange, err := vm.assetStats.setBalance(assetID, addr, balance)
+			if err != nil {
+				return err
+			}
+			stats.Holders = uint64(int64(stats.Holders) + int64(holdersChange))
+		}
+
+		if err := vm.assetStats.putStats(assetID, stats); err != nil {
+			return err
+		}
+	}
+	return nil
+}
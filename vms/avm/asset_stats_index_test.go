@@ -0,0 +1,187 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+func TestGetAssetStats(t *testing.T) {
+	genesisBytes, vm, s, _, genesisTx := setupWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := genesisTx.ID()
+	varCapAssetID := GetCreateTxFromGenesisTest(t, genesisBytes, "myVarCapAsset").ID()
+	addrStrs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		addrStr, err := vm.FormatLocalAddress(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addrStrs[i] = addrStr
+	}
+	changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.GetAssetStats(nil, &GetAssetStatsArgs{
+		AssetID: assetID.String(),
+	}, &GetAssetStatsReply{})
+	if err != errAssetIndexingDisabled {
+		t.Fatalf("expected %s but got %v", errAssetIndexingDisabled, err)
+	}
+
+	// Enabling the index indexes the genesis transactions
+	if err := vm.initAssetStatsIndex(true); err != nil {
+		t.Fatal(err)
+	}
+
+	checkStats := func(assetID string, minted, burned, holders uint64, numMinterSets int) {
+		reply := &GetAssetStatsReply{}
+		if err := s.GetAssetStats(nil, &GetAssetStatsArgs{AssetID: assetID}, reply); err != nil {
+			t.Fatal(err)
+		}
+		if uint64(reply.Minted) != minted {
+			t.Fatalf("expected %d to be minted but got %d", minted, reply.Minted)
+		}
+		if uint64(reply.Burned) != burned {
+			t.Fatalf("expected %d to be burned but got %d", burned, reply.Burned)
+		}
+		if uint64(reply.Circulating) != minted-burned {
+			t.Fatalf("expected %d to be circulating but got %d", minted-burned, reply.Circulating)
+		}
+		if uint64(reply.Holders) != holders {
+			t.Fatalf("expected %d holders but got %d", holders, reply.Holders)
+		}
+		if len(reply.MinterSets) != numMinterSets {
+			t.Fatalf("expected %d minter sets but got %d", numMinterSets, len(reply.MinterSets))
+		}
+	}
+	checkHolders := func(assetID string, expected []Holder) {
+		cursor := ""
+		for i, expectedHolder := range expected {
+			reply := &GetAssetHoldersReply{}
+			err := s.GetAssetHolders(nil, &GetAssetHoldersArgs{
+				AssetID:  assetID,
+				Cursor:   cursor,
+				PageSize: 1,
+			}, reply)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(reply.Holders) != 1 {
+				t.Fatalf("expected 1 holder but got %d", len(reply.Holders))
+			}
+			if reply.Holders[0] != expectedHolder {
+				t.Fatalf("expected holder %d to be %+v but got %+v", i, expectedHolder, reply.Holders[0])
+			}
+			cursor = reply.Cursor
+		}
+		if cursor != "" {
+			t.Fatalf("expected no more holders but got cursor %q", cursor)
+		}
+	}
+
+	checkStats(assetID.String(), 3*startBalance, 0, 3, 0)
+	checkStats(varCapAssetID.String(), 0, 0, 0, 2)
+
+	vm.timer.Cancel()
+	sendReply := &api.JSONTxIDChangeAddr{}
+	err = s.Send(nil, &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs:  api.JSONFromAddrs{From: []string{addrStrs[0]}},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		},
+		SendOutput: SendOutput{
+			Amount:  1000,
+			AssetID: assetID.String(),
+			To:      addrStrs[1],
+		},
+	}, sendReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (&UniqueTx{vm: vm, txID: sendReply.TxID}).Accept(); err != nil {
+		t.Fatal(err)
+	}
+
+	mintReply := &api.JSONTxIDChangeAddr{}
+	err = s.Mint(nil, &MintArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs:  api.JSONFromAddrs{From: []string{addrStrs[1]}},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		},
+		Amount:  200,
+		AssetID: varCapAssetID.String(),
+		To:      addrStrs[2],
+	}, mintReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (&UniqueTx{vm: vm, txID: mintReply.TxID}).Accept(); err != nil {
+		t.Fatal(err)
+	}
+
+	checkAll := func() {
+		checkStats(assetID.String(), 3*startBalance, 2*vm.txFee, 3, 0)
+		checkHolders(assetID.String(), []Holder{
+			{Amount: json.Uint64(startBalance + 1000 - vm.txFee), Address: addrStrs[1]},
+			{Amount: json.Uint64(startBalance), Address: addrStrs[2]},
+			{Amount: json.Uint64(startBalance - 1000 - vm.txFee), Address: changeAddrStr},
+		})
+		checkStats(varCapAssetID.String(), 200, 0, 1, 2)
+		checkHolders(varCapAssetID.String(), []Holder{
+			{Amount: 200, Address: addrStrs[2]},
+		})
+	}
+	checkAll()
+
+	// Rebuilding the index results in the same stats
+	if err := vm.rebuildIndex(&vm.assetStats.acceptedTxIndex, vm.indexAssetStats); err != nil {
+		t.Fatal(err)
+	}
+	checkAll()
+
+	err = s.GetAssetHolders(nil, &GetAssetHoldersArgs{
+		AssetID: assetID.String(),
+		Cursor:  "00",
+	}, &GetAssetHoldersReply{})
+	if err == nil {
+		t.Fatal("expected an invalid cursor to be rejected")
+	}
+
+	// Disabling the index removes it
+	if err := vm.initAssetStatsIndex(false); err != nil {
+		t.Fatal(err)
+	}
+	if indexed, err := vm.assetStats.isIndexed(); err != nil {
+		t.Fatal(err)
+	} else if indexed {
+		t.Fatal("index should have been removed")
+	}
+	stats, err := vm.assetStats.stats(assetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *stats != (assetStats{}) {
+		t.Fatalf("expected the index to be empty but got %+v", stats)
+	}
+}
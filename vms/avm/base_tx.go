@@ -46,8 +46,14 @@ func (t *BaseTx) SyntacticVerify(
 	)
 }
 
+// memo returns the memo field of the transaction
+func (t *BaseTx) memo() []byte { return t.Memo }
+
 // SemanticVerify that this transaction is valid to be spent.
 func (t *BaseTx) SemanticVerify(vm *VM, tx UnsignedTx, creds []verify.Verifiable) error {
+	if err := vm.verifyFees(tx); err != nil {
+		return err
+	}
 	for i, in := range t.Ins {
 		cred := creds[i]
 		if err := vm.verifyTransfer(tx, in, cred); err != nil {
@@ -63,8 +69,14 @@ func (t *BaseTx) SemanticVerify(vm *VM, tx UnsignedTx, creds []verify.Verifiable
 			return errIncompatibleFx
 		}
 	}
-	return nil
+	if err := vm.verifyChainTimeOutputs(tx); err != nil {
+		return err
+	}
+	return vm.verifyVesting(t.Ins, t.Outs)
 }
 
 // ExecuteWithSideEffects writes the batch with any additional side effects
 func (t *BaseTx) ExecuteWithSideEffects(_ *VM, batch database.Batch) error { return batch.Write() }
+
+// inputs returns the inputs of this tx that consume UTXOs of this chain
+func (t *BaseTx) inputs() []*djtx.TransferableInput { return t.Ins }
@@ -0,0 +1,280 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/missing"
+)
+
+var (
+	errEmptyBlock        = errors.New("block contains no transactions")
+	errWrongHeight       = errors.New("block height isn't one more than its parent's")
+	errTimestampTooEarly = errors.New("block timestamp is before its parent's")
+	errTimestampTooLate  = errors.New("block timestamp is too far in the future")
+	errUnknownParent     = errors.New("parent block isn't the last accepted block or a verified block")
+	errTxAccepted        = errors.New("transaction is already accepted")
+	errDuplicateTx       = errors.New("transaction is already in the chain")
+	errConflictingTx     = errors.New("transaction consumes a UTXO that is already consumed in the chain")
+	errMissingDependency = errors.New("transaction depends on a transaction that isn't in the chain")
+
+	_ snowman.Block = &Block{}
+)
+
+// Block is a batch of transactions in a linearized chain
+type Block struct {
+	PrntID ids.ID `serialize:"true" json:"parentID"`
+	Hght   uint64 `serialize:"true" json:"height"`
+	// Unix time the transactions of the block are executed at
+	Tmstmp uint64   `serialize:"true" json:"timestamp"`
+	Txs    [][]byte `serialize:"true" json:"txs"`
+
+	chain  *ChainVM
+	id     ids.ID
+	bytes  []byte
+	txs    []*UniqueTx
+	status choices.Status
+}
+
+// initialize sets the bytes and ID of the block, and parses its transactions
+func (b *Block) initialize(chain *ChainVM, bytes []byte, status choices.Status) error {
+	b.chain = chain
+	b.id = hashing.ComputeHash256Array(bytes)
+	b.bytes = bytes
+	b.status = status
+	b.txs = make([]*UniqueTx, len(b.Txs))
+	for i, txBytes := range b.Txs {
+		tx, err := chain.vm.parseTx(txBytes)
+		if err != nil {
+			return fmt.Errorf("couldn't parse transaction %d of block %s: %w", i, b.id, err)
+		}
+		b.txs[i] = tx
+	}
+	return nil
+}
+
+// ID implements the snowman.Block interface
+func (b *Block) ID() ids.ID { return b.id }
+
+// Bytes implements the snowman.Block interface
+func (b *Block) Bytes() []byte { return b.bytes }
+
+// Height implements the snowman.Block interface
+func (b *Block) Height() uint64 { return b.Hght }
+
+// Status implements the snowman.Block interface
+func (b *Block) Status() choices.Status { return b.status }
+
+// Timestamp is the chain time of the block
+func (b *Block) Timestamp() time.Time { return time.Unix(int64(b.Tmstmp), 0) }
+
+// Parent implements the snowman.Block interface
+func (b *Block) Parent() snowman.Block {
+	parent, err := b.chain.getBlock(b.PrntID)
+	if err != nil {
+		return &missing.Block{BlkID: b.PrntID}
+	}
+	return parent
+}
+
+// Verify that the block extends either the last accepted block or a verified
+// block, and that its transactions are valid when executed in order after
+// those of its processing ancestors, at the time of the block
+func (b *Block) Verify() error {
+	if len(b.txs) == 0 {
+		return errEmptyBlock
+	}
+
+	parent, err := b.chain.getBlock(b.PrntID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errUnknownParent, b.PrntID)
+	}
+	if _, verified := b.chain.verifiedBlocks[b.PrntID]; !verified && b.PrntID != b.chain.lastAcceptedID {
+		return fmt.Errorf("%w: %s", errUnknownParent, b.PrntID)
+	}
+	if b.Hght != parent.Height()+1 {
+		return fmt.Errorf("%w: expected %d but got %d", errWrongHeight, parent.Height()+1, b.Hght)
+	}
+	timestamp := b.Timestamp()
+	if parentTimestamp := parent.Timestamp(); timestamp.Before(parentTimestamp) {
+		return fmt.Errorf("%w: %s is before %s", errTimestampTooEarly, timestamp, parentTimestamp)
+	}
+	if maxTimestamp := b.chain.vm.clock.Time().Add(syncBound); timestamp.After(maxTimestamp) {
+		return fmt.Errorf("%w: %s is after %s", errTimestampTooLate, timestamp, maxTimestamp)
+	}
+
+	processing, err := b.chain.processingTxs(b.PrntID)
+	if err != nil {
+		return err
+	}
+	b.chain.blockTime = &timestamp
+	defer func() { b.chain.blockTime = nil }()
+	for _, tx := range b.txs {
+		// If the node stopped while accepting this block, the transactions it
+		// had already accepted were valid
+		if b.id == b.chain.acceptingID && tx.Status() == choices.Accepted {
+			continue
+		}
+		if err := processing.add(tx); err != nil {
+			return fmt.Errorf("invalid transaction %s: %w", tx.ID(), err)
+		}
+	}
+
+	b.chain.verifiedBlocks[b.id] = b
+	return nil
+}
+
+// Accept the transactions of the block in order, and then the block. Each
+// transaction is committed on its own, so the block is recorded as being
+// accepted along with the first one. If the node stops before the block is
+// marked as accepted, the block is still valid when it's verified again.
+func (b *Block) Accept() error {
+	b.chain.vm.ctx.Log.Verbo("Accepting block %s at height %d", b.id, b.Hght)
+
+	if err := b.chain.db.Put(acceptingKey, b.id[:]); err != nil {
+		return fmt.Errorf("couldn't accept block %s: %w", b.id, err)
+	}
+	for _, tx := range b.txs {
+		// The transactions may have been accepted before the block was marked
+		// as accepted, if the node stopped in between
+		if tx.Status() == choices.Accepted {
+			continue
+		}
+		if err := tx.Accept(); err != nil {
+			return fmt.Errorf("couldn't accept transaction %s: %w", tx.ID(), err)
+		}
+	}
+
+	if err := b.chain.putLastAccepted(b); err != nil {
+		return fmt.Errorf("couldn't accept block %s: %w", b.id, err)
+	}
+	b.status = choices.Accepted
+	delete(b.chain.verifiedBlocks, b.id)
+	return nil
+}
+
+// Reject the block, and return its transactions that are still processing to
+// the mempool so they can be included in another block. Transactions that can
+// no longer be accepted are rejected instead.
+func (b *Block) Reject() error {
+	b.chain.vm.ctx.Log.Verbo("Rejecting block %s at height %d", b.id, b.Hght)
+
+	b.status = choices.Rejected
+	delete(b.chain.verifiedBlocks, b.id)
+	for _, tx := range b.txs {
+		if err := b.chain.returnTx(tx); err != nil {
+			return fmt.Errorf("couldn't return transaction %s to the mempool: %w", tx.ID(), err)
+		}
+	}
+	return nil
+}
+
+// acceptedConflict returns the ID of a UTXO consumed by [tx] that has already
+// been consumed by an accepted transaction, if there is one
+func acceptedConflict(tx *UniqueTx) (ids.ID, bool, error) {
+	for _, utxoID := range tx.InputUTXOs() {
+		if utxoID.Symbolic() {
+			continue
+		}
+		inputID := utxoID.InputID()
+		_, err := tx.vm.state.GetUTXO(inputID)
+		if err == nil {
+			continue
+		}
+		if err != database.ErrNotFound {
+			return ids.ID{}, false, err
+		}
+		// The UTXO was consumed if it was produced by an accepted transaction
+		// but isn't unspent anymore
+		parentID, _ := utxoID.InputSource()
+		status, err := tx.vm.state.GetStatus(parentID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return ids.ID{}, false, err
+		}
+		if status == choices.Accepted {
+			return inputID, true, nil
+		}
+	}
+	return ids.ID{}, false, nil
+}
+
+// isStale returns true if [tx] can never be accepted, because it conflicts
+// with an accepted transaction or depends on a rejected one
+func isStale(tx *UniqueTx) (bool, error) {
+	if _, conflicts, err := acceptedConflict(tx); err != nil || conflicts {
+		return conflicts, err
+	}
+	for _, dep := range tx.Dependencies() {
+		if dep.Status() == choices.Rejected {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// processingTxs are the transactions of a chain of processing blocks
+type processingTxs struct {
+	// IDs of the transactions
+	txIDs ids.Set
+	// IDs of the UTXOs the transactions consume
+	consumed ids.Set
+}
+
+// add [tx] after the processing transactions, if it's valid when executed
+// after them
+func (p *processingTxs) add(tx *UniqueTx) error {
+	switch tx.Status() {
+	case choices.Unknown:
+		return errUnknownTx
+	case choices.Accepted:
+		return errTxAccepted
+	case choices.Rejected:
+		return errRejectedTx
+	}
+
+	txID := tx.ID()
+	if p.txIDs.Contains(txID) {
+		return errDuplicateTx
+	}
+	inputIDs := tx.InputIDs()
+	for _, inputID := range inputIDs {
+		if p.consumed.Contains(inputID) {
+			return errConflictingTx
+		}
+	}
+	if inputID, conflicts, err := acceptedConflict(tx); err != nil {
+		return err
+	} else if conflicts {
+		return fmt.Errorf("%w: %s was consumed by an accepted transaction", errConflictingTx, inputID)
+	}
+	for _, dep := range tx.Dependencies() {
+		if dep.Status() != choices.Accepted && !p.txIDs.Contains(dep.ID()) {
+			return fmt.Errorf("%w: %s", errMissingDependency, dep.ID())
+		}
+	}
+
+	if err := tx.SyntacticVerify(); err != nil {
+		return err
+	}
+	// The cached result of semantic verification is skipped, as it doesn't
+	// account for the transactions in the chain
+	if err := tx.Tx.SemanticVerify(tx.vm, tx.UnsignedTx); err != nil {
+		return err
+	}
+
+	p.txIDs.Add(txID)
+	p.consumed.Add(inputIDs...)
+	return nil
+}
@@ -0,0 +1,156 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestBurnAndRenounceMinting(t *testing.T) {
+	_, vm, s, _, genesisTx := setupWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	minterAddrStr, err := vm.FormatLocalAddress(addrs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	spendHeader := api.JSONSpendHeader{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
+		JSONFromAddrs:  api.JSONFromAddrs{From: []string{minterAddrStr}},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: minterAddrStr},
+	}
+	accept := func(txID ids.ID) {
+		if err := (&UniqueTx{vm: vm, txID: txID}).Accept(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// balance returns the amount of [assetID] held by [addrs]
+	balance := func(assetID string) uint64 {
+		total := uint64(0)
+		for _, addr := range addrs {
+			addrStr, err := vm.FormatLocalAddress(addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reply := &GetBalanceReply{}
+			err = s.GetBalance(nil, &GetBalanceArgs{
+				Address: addrStr,
+				AssetID: assetID,
+			}, reply)
+			if err != nil {
+				t.Fatal(err)
+			}
+			total += uint64(reply.Balance)
+		}
+		return total
+	}
+
+	createReply := AssetIDChangeAddr{}
+	err = s.CreateVariableCapAsset(nil, &CreateAssetArgs{
+		JSONSpendHeader: spendHeader,
+		Name:            "burnable asset",
+		Symbol:          "BURN",
+		MinterSets: []Owners{{
+			Threshold: 1,
+			Minters:   []string{minterAddrStr},
+		}},
+	}, &createReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accept(createReply.AssetID)
+	assetID := createReply.AssetID.String()
+
+	mintArgs := &MintArgs{
+		JSONSpendHeader: spendHeader,
+		Amount:          1000,
+		AssetID:         assetID,
+		To:              minterAddrStr,
+	}
+	reply := &api.JSONTxIDChangeAddr{}
+	if err := s.Mint(nil, mintArgs, reply); err != nil {
+		t.Fatal(err)
+	}
+	accept(reply.TxID)
+
+	burnArgs := &BurnArgs{
+		JSONSpendHeader: spendHeader,
+		Amount:          300,
+		AssetID:         assetID,
+	}
+	renounceArgs := &RenounceMintingArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID,
+	}
+
+	// Funds can't be burned, and minting can't be renounced, on the DAG or
+	// before Apricot Phase 3
+	if err := s.Burn(nil, burnArgs, reply); err == nil || !strings.Contains(err.Error(), errNotApricotPhase3.Error()) {
+		t.Fatalf("expected %s but got %v", errNotApricotPhase3, err)
+	}
+	if err := s.RenounceMinting(nil, renounceArgs, reply); err == nil || !strings.Contains(err.Error(), errNotApricotPhase3.Error()) {
+		t.Fatalf("expected %s but got %v", errNotApricotPhase3, err)
+	}
+	linearize(t, vm)
+	vm.apricotPhase3Time = time.Now().Add(time.Hour)
+	if err := s.Burn(nil, burnArgs, reply); err == nil || !strings.Contains(err.Error(), errNotApricotPhase3.Error()) {
+		t.Fatalf("expected %s but got %v", errNotApricotPhase3, err)
+	}
+	vm.apricotPhase3Time = time.Time{}
+
+	if err := s.Burn(nil, burnArgs, reply); err != nil {
+		t.Fatal(err)
+	}
+	accept(reply.TxID)
+	if balance := balance(assetID); balance != 700 {
+		t.Fatalf("expected a balance of 700 after burning but got %d", balance)
+	}
+
+	burnArgs.Amount = 701
+	if err := s.Burn(nil, burnArgs, reply); err == nil {
+		t.Fatal("should have failed to burn more than the balance")
+	}
+	burnArgs.Amount = 0
+	if err := s.Burn(nil, burnArgs, reply); err != errInvalidBurnAmount {
+		t.Fatalf("expected %s but got %v", errInvalidBurnAmount, err)
+	}
+
+	// The burned funds of the fee asset don't pay the fee
+	djtxBalance := balance(genesisTx.ID().String())
+	burnArgs.From = nil
+	burnArgs.Amount = 500
+	burnArgs.AssetID = genesisTx.ID().String()
+	if err := s.Burn(nil, burnArgs, reply); err != nil {
+		t.Fatal(err)
+	}
+	accept(reply.TxID)
+	if balance := balance(burnArgs.AssetID); balance != djtxBalance-500-vm.txFee {
+		t.Fatalf("expected a balance of %d after burning but got %d", djtxBalance-500-vm.txFee, balance)
+	}
+
+	if err := s.RenounceMinting(nil, renounceArgs, reply); err != nil {
+		t.Fatal(err)
+	}
+	accept(reply.TxID)
+
+	if err := s.Mint(nil, mintArgs, reply); err != errAddressesCantMintAsset {
+		t.Fatalf("expected %s but got %v", errAddressesCantMintAsset, err)
+	}
+	if err := s.RenounceMinting(nil, renounceArgs, reply); err != errAddressesCantMintAsset {
+		t.Fatalf("expected %s but got %v", errAddressesCantMintAsset, err)
+	}
+}
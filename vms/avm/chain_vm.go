@@ -0,0 +1,402 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// targetBlockSize is the size of the transactions that are batched into a
+	// block, unless a single transaction is larger
+	targetBlockSize = 128 * units.KiB
+
+	// syncBound is how far ahead of the local time a block's timestamp may be
+	syncBound = 10 * time.Second
+)
+
+var (
+	errNoPendingTxs = errors.New("no pending transactions")
+
+	linearChainPrefix = []byte("linearChain")
+	blocksPrefix      = []byte("blocks")
+	lastAcceptedKey   = []byte("lastAccepted")
+	acceptingKey      = []byte("accepting")
+
+	_ block.ChainVM      = &ChainVM{}
+	_ block.Linearizable = &VM{}
+)
+
+// ChainVM runs the AVM on a linear chain. Transactions are issued and
+// verified as they are by the AVM, and batched into blocks.
+type ChainVM struct {
+	vm *VM
+
+	// lastAcceptedKey --> ID of the last accepted block
+	// acceptingKey --> ID of the block whose transactions are being accepted
+	db database.Database
+	// Block ID --> bytes of the accepted block
+	blocksDB database.Database
+
+	// ID of the last accepted block
+	lastAcceptedID ids.ID
+	// ID of the block whose transactions were being accepted when the node
+	// stopped, or ids.Empty. The transactions it has already accepted are
+	// valid when the block is verified again.
+	acceptingID ids.ID
+	// ID of the preferred block
+	preferredID ids.ID
+	// Blocks that have been verified but not yet decided
+	verifiedBlocks map[ids.ID]*Block
+	// Timestamp of the block whose transactions are being verified. Nil if
+	// transactions aren't being verified as part of a block.
+	blockTime *time.Time
+}
+
+// Linearize implements the block.Linearizable interface. The chain runs on the
+// linear Snowman engine, with transactions batched into blocks, once the
+// Apricot Phase 3 upgrade has activated. The upgrade time is the same on every
+// node of the network, and a node that was running the chain on the DAG
+// switches to the linear chain when it's restarted after the upgrade.
+func (vm *VM) Linearize() (block.ChainVM, bool, error) {
+	if vm.clock.Time().Before(vm.apricotPhase3Time) {
+		return nil, false, nil
+	}
+	return &ChainVM{vm: vm}, true, nil
+}
+
+// Initialize implements the common.VM interface
+func (cvm *ChainVM) Initialize(
+	ctx *snow.Context,
+	dbManager manager.Manager,
+	genesisBytes []byte,
+	upgradeBytes []byte,
+	configBytes []byte,
+	toEngine chan<- common.Message,
+	fxs []*common.Fx,
+) error {
+	if err := cvm.vm.Initialize(ctx, dbManager, genesisBytes, upgradeBytes, configBytes, toEngine, fxs); err != nil {
+		return err
+	}
+	return cvm.initChain()
+}
+
+// initChain loads the last accepted block, and accepts the genesis block if
+// the chain was just created. The genesis block contains no transactions, as
+// the genesis transactions are accepted when the AVM is initialized.
+func (cvm *ChainVM) initChain() error {
+	cvm.vm.chain = cvm
+	cvm.db = prefixdb.New(linearChainPrefix, cvm.vm.db)
+	cvm.blocksDB = prefixdb.NewNested(blocksPrefix, cvm.db)
+	cvm.verifiedBlocks = make(map[ids.ID]*Block)
+
+	acceptingBytes, err := cvm.db.Get(acceptingKey)
+	switch err {
+	case nil:
+		cvm.acceptingID, err = ids.ToID(acceptingBytes)
+		if err != nil {
+			return err
+		}
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+
+	lastAcceptedBytes, err := cvm.db.Get(lastAcceptedKey)
+	switch err {
+	case nil:
+		cvm.lastAcceptedID, err = ids.ToID(lastAcceptedBytes)
+		if err != nil {
+			return err
+		}
+		cvm.preferredID = cvm.lastAcceptedID
+		return nil
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+
+	genesis, err := cvm.newBlock(ids.Empty, 0, time.Unix(0, 0), nil)
+	if err != nil {
+		return err
+	}
+	genesis.status = choices.Accepted
+	cvm.preferredID = genesis.ID()
+	return cvm.putLastAccepted(genesis)
+}
+
+// HealthCheck implements the common.VM interface
+func (cvm *ChainVM) HealthCheck() (interface{}, error) { return cvm.vm.HealthCheck() }
+
+// Connected implements the common.VM interface
+func (cvm *ChainVM) Connected(id ids.ShortID) error { return cvm.vm.Connected(id) }
+
+// Disconnected implements the common.VM interface
+func (cvm *ChainVM) Disconnected(id ids.ShortID) error { return cvm.vm.Disconnected(id) }
+
+// Bootstrapping implements the common.VM interface
+func (cvm *ChainVM) Bootstrapping() error { return cvm.vm.Bootstrapping() }
+
+// Bootstrapped implements the common.VM interface
+func (cvm *ChainVM) Bootstrapped() error { return cvm.vm.Bootstrapped() }
+
+// Shutdown implements the common.VM interface
+func (cvm *ChainVM) Shutdown() error { return cvm.vm.Shutdown() }
+
+// Version implements the common.VM interface
+func (cvm *ChainVM) Version() (string, error) { return cvm.vm.Version() }
+
+// CreateStaticHandlers implements the common.VM interface
+func (cvm *ChainVM) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
+	return cvm.vm.CreateStaticHandlers()
+}
+
+// CreateHandlers implements the common.VM interface
+func (cvm *ChainVM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
+	return cvm.vm.CreateHandlers()
+}
+
+// BuildBlock implements the block.ChainVM interface. The pending transactions
+// that are valid on top of the preferred block are batched into a block. The
+// ones waiting for a dependency are returned to the mempool, and the other
+// invalid ones are rejected.
+func (cvm *ChainVM) BuildBlock() (snowman.Block, error) {
+	parent, err := cvm.getBlock(cvm.preferredID)
+	if err != nil {
+		return nil, err
+	}
+	processing, err := cvm.processingTxs(cvm.preferredID)
+	if err != nil {
+		return nil, err
+	}
+	timestamp := cvm.nextTimestamp(parent)
+	cvm.blockTime = &timestamp
+	defer func() { cvm.blockTime = nil }()
+
+	pendingTxs := cvm.vm.PendingTxs()
+	txs := [][]byte(nil)
+	size := 0
+	for i, pendingTx := range pendingTxs {
+		tx := pendingTx.(*UniqueTx)
+		txBytes := tx.Bytes()
+		if len(txs) > 0 && size+len(txBytes) > targetBlockSize {
+			// The remaining transactions will be included in a later block
+			for _, pendingTx := range pendingTxs[i:] {
+				cvm.vm.issueTx(pendingTx)
+			}
+			break
+		}
+		if err := processing.add(tx); err != nil {
+			if errors.Is(err, errMissingDependency) {
+				// The transaction may become valid once its dependencies are
+				// accepted
+				if err := cvm.returnTx(tx); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			cvm.vm.ctx.Log.Debug("Dropping transaction %s due to %s", tx.ID(), err)
+			if err := cvm.dropTx(tx); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		txs = append(txs, txBytes)
+		size += len(txBytes)
+	}
+	if len(txs) == 0 {
+		return nil, errNoPendingTxs
+	}
+	return cvm.newBlock(parent.ID(), parent.Height()+1, timestamp, txs)
+}
+
+// ParseBlock implements the block.ChainVM interface
+func (cvm *ChainVM) ParseBlock(bytes []byte) (snowman.Block, error) {
+	return cvm.parseBlock(bytes, choices.Processing)
+}
+
+// GetBlock implements the block.ChainVM interface
+func (cvm *ChainVM) GetBlock(blkID ids.ID) (snowman.Block, error) {
+	return cvm.getBlock(blkID)
+}
+
+// SetPreference implements the block.ChainVM interface
+func (cvm *ChainVM) SetPreference(blkID ids.ID) error {
+	cvm.preferredID = blkID
+	return nil
+}
+
+// LastAccepted implements the block.ChainVM interface
+func (cvm *ChainVM) LastAccepted() (ids.ID, error) {
+	return cvm.lastAcceptedID, nil
+}
+
+// newBlock returns the block at [height] on top of [parentID] that contains
+// [txs] executed at [timestamp]
+func (cvm *ChainVM) newBlock(parentID ids.ID, height uint64, timestamp time.Time, txs [][]byte) (*Block, error) {
+	bytes, err := cvm.vm.codec.Marshal(codecVersion, &Block{
+		PrntID: parentID,
+		Hght:   height,
+		Tmstmp: uint64(timestamp.Unix()),
+		Txs:    txs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cvm.parseBlock(bytes, choices.Processing)
+}
+
+// parseBlock returns the block serialized as [bytes]. Blocks that have been
+// verified are returned as they are, and other blocks are given [status]
+// unless they have been accepted.
+func (cvm *ChainVM) parseBlock(bytes []byte, status choices.Status) (*Block, error) {
+	if verified, ok := cvm.verifiedBlocks[hashing.ComputeHash256Array(bytes)]; ok {
+		return verified, nil
+	}
+
+	blk := &Block{}
+	if _, err := cvm.vm.codec.Unmarshal(bytes, blk); err != nil {
+		return nil, err
+	}
+	if err := blk.initialize(cvm, bytes, status); err != nil {
+		return nil, err
+	}
+	if blk.status != choices.Accepted {
+		accepted, err := cvm.blocksDB.Has(blk.id[:])
+		if err != nil {
+			return nil, err
+		}
+		if accepted {
+			blk.status = choices.Accepted
+		}
+	}
+	return blk, nil
+}
+
+// getBlock returns the block [blkID], which must have been verified or
+// accepted
+func (cvm *ChainVM) getBlock(blkID ids.ID) (*Block, error) {
+	if blk, ok := cvm.verifiedBlocks[blkID]; ok {
+		return blk, nil
+	}
+	bytes, err := cvm.blocksDB.Get(blkID[:])
+	if err != nil {
+		return nil, err
+	}
+	return cvm.parseBlock(bytes, choices.Accepted)
+}
+
+// putLastAccepted persists [blk] as the last accepted block
+func (cvm *ChainVM) putLastAccepted(blk *Block) error {
+	blkID := blk.ID()
+	if err := cvm.blocksDB.Put(blkID[:], blk.Bytes()); err != nil {
+		return err
+	}
+	if err := cvm.db.Put(lastAcceptedKey, blkID[:]); err != nil {
+		return err
+	}
+	if err := cvm.db.Delete(acceptingKey); err != nil {
+		return err
+	}
+	if err := cvm.vm.db.Commit(); err != nil {
+		return err
+	}
+	cvm.lastAcceptedID = blkID
+	cvm.acceptingID = ids.Empty
+	return nil
+}
+
+// nextTimestamp returns the timestamp of a block built on top of [parent] now
+func (cvm *ChainVM) nextTimestamp(parent *Block) time.Time {
+	now := time.Unix(int64(cvm.vm.clock.Unix()), 0)
+	if parentTimestamp := parent.Timestamp(); now.Before(parentTimestamp) {
+		return parentTimestamp
+	}
+	return now
+}
+
+// txTime returns the time transactions are verified at. That's the timestamp
+// of the block they are in, or the timestamp of the next block if they aren't
+// verified as part of a block.
+func (cvm *ChainVM) txTime() time.Time {
+	if cvm.blockTime != nil {
+		return *cvm.blockTime
+	}
+	preferred, err := cvm.getBlock(cvm.preferredID)
+	if err != nil {
+		return time.Unix(int64(cvm.vm.clock.Unix()), 0)
+	}
+	return cvm.nextTimestamp(preferred)
+}
+
+// processingTxs returns the transactions of the processing blocks from the
+// last accepted block to [blkID]
+func (cvm *ChainVM) processingTxs(blkID ids.ID) (*processingTxs, error) {
+	processing := &processingTxs{}
+	for blkID != cvm.lastAcceptedID {
+		blk, ok := cvm.verifiedBlocks[blkID]
+		if !ok {
+			return nil, errUnknownParent
+		}
+		for _, tx := range blk.txs {
+			processing.txIDs.Add(tx.ID())
+			processing.consumed.Add(tx.InputIDs()...)
+		}
+		blkID = blk.PrntID
+	}
+	return processing, nil
+}
+
+// inVerifiedBlock returns true if [txID] is in a block that has been verified
+// but not yet decided
+func (cvm *ChainVM) inVerifiedBlock(txID ids.ID) bool {
+	for _, blk := range cvm.verifiedBlocks {
+		for _, tx := range blk.txs {
+			if tx.ID() == txID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// returnTx returns [tx] to the mempool if it's processing and isn't in a
+// verified block, which would decide it. If the transaction can never be
+// accepted, it's rejected instead.
+func (cvm *ChainVM) returnTx(tx *UniqueTx) error {
+	if tx.Status() != choices.Processing || cvm.inVerifiedBlock(tx.ID()) {
+		return nil
+	}
+	stale, err := isStale(tx)
+	if err != nil {
+		return err
+	}
+	if stale {
+		return tx.Reject()
+	}
+	cvm.vm.issueTx(tx)
+	return nil
+}
+
+// dropTx rejects the invalid [tx] if it's processing and isn't in a verified
+// block, as it would otherwise never be decided
+func (cvm *ChainVM) dropTx(tx *UniqueTx) error {
+	if tx.Status() != choices.Processing || cvm.inVerifiedBlock(tx.ID()) {
+		return nil
+	}
+	return tx.Reject()
+}
@@ -0,0 +1,495 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestLinearize(t *testing.T) {
+	vm := &VM{apricotPhase3Time: time.Now().Add(time.Hour)}
+	if _, linearized, err := vm.Linearize(); err != nil {
+		t.Fatal(err)
+	} else if linearized {
+		t.Fatal("the VM shouldn't be linearized before Apricot Phase 3")
+	}
+	vm.apricotPhase3Time = time.Now()
+	if chainVM, linearized, err := vm.Linearize(); err != nil {
+		t.Fatal(err)
+	} else if !linearized || chainVM.(*ChainVM).vm != vm {
+		t.Fatal("the VM should have been linearized")
+	}
+}
+
+// linearize runs [vm] on a linear chain
+func linearize(t *testing.T, vm *VM) *ChainVM {
+	cvm := &ChainVM{vm: vm}
+	if err := cvm.initChain(); err != nil {
+		t.Fatal(err)
+	}
+	return cvm
+}
+
+func TestChainVM(t *testing.T) {
+	_, vm, s, _, genesisTx := setupWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+	vm.timer.Cancel()
+
+	cvm := &ChainVM{vm: vm}
+	if err := cvm.initChain(); err != nil {
+		t.Fatal(err)
+	}
+	genesisID, err := cvm.LastAccepted()
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesis, err := cvm.GetBlock(genesisID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if genesis.Height() != 0 || genesis.Status() != choices.Accepted {
+		t.Fatalf("expected an accepted genesis block at height 0 but got height %d and status %s", genesis.Height(), genesis.Status())
+	}
+
+	if _, err := cvm.BuildBlock(); err != errNoPendingTxs {
+		t.Fatalf("expected %s but got %v", errNoPendingTxs, err)
+	}
+
+	// send issues a transaction sending funds from [addr]
+	send := func(addr ids.ShortID) ids.ID {
+		addrStr, err := vm.FormatLocalAddress(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reply := &api.JSONTxIDChangeAddr{}
+		err = s.Send(nil, &SendArgs{
+			JSONSpendHeader: api.JSONSpendHeader{
+				UserPass: api.UserPass{
+					Username: username,
+					Password: password,
+				},
+				JSONFromAddrs: api.JSONFromAddrs{From: []string{addrStr}},
+			},
+			SendOutput: SendOutput{
+				Amount:  1000,
+				AssetID: genesisTx.ID().String(),
+				To:      addrStr,
+			},
+		}, reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reply.TxID
+	}
+
+	txID := send(addrs[0])
+	blk, err := cvm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blk.Height() != 1 || blk.Parent().ID() != genesisID {
+		t.Fatalf("expected the block to be built on the genesis block")
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if parsed, err := cvm.ParseBlock(blk.Bytes()); err != nil {
+		t.Fatal(err)
+	} else if parsed != blk {
+		t.Fatal("parsing a verified block should return it")
+	}
+	if err := cvm.SetPreference(blk.ID()); err != nil {
+		t.Fatal(err)
+	}
+
+	txBytes := blk.(*Block).Txs[0]
+	blkTime := blk.(*Block).Timestamp()
+	tests := []struct {
+		description string
+		parentID    ids.ID
+		height      uint64
+		timestamp   time.Time
+		txs         [][]byte
+		expectedErr error
+	}{
+		{
+			description: "no transactions",
+			parentID:    blk.ID(),
+			height:      2,
+			expectedErr: errEmptyBlock,
+		},
+		{
+			description: "wrong height",
+			parentID:    genesisID,
+			height:      2,
+			txs:         [][]byte{txBytes},
+			expectedErr: errWrongHeight,
+		},
+		{
+			description: "duplicate transaction",
+			parentID:    blk.ID(),
+			height:      2,
+			txs:         [][]byte{txBytes},
+			expectedErr: errDuplicateTx,
+		},
+		{
+			description: "unknown parent",
+			parentID:    ids.GenerateTestID(),
+			height:      1,
+			txs:         [][]byte{txBytes},
+			expectedErr: errUnknownParent,
+		},
+		{
+			description: "timestamp before parent",
+			parentID:    blk.ID(),
+			height:      2,
+			timestamp:   blkTime.Add(-time.Second),
+			txs:         [][]byte{txBytes},
+			expectedErr: errTimestampTooEarly,
+		},
+		{
+			description: "timestamp too far in the future",
+			parentID:    blk.ID(),
+			height:      2,
+			timestamp:   vm.clock.Time().Add(syncBound + time.Second),
+			txs:         [][]byte{txBytes},
+			expectedErr: errTimestampTooLate,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			timestamp := test.timestamp
+			if timestamp.IsZero() {
+				timestamp = blkTime
+			}
+			invalidBlk, err := cvm.newBlock(test.parentID, test.height, timestamp, test.txs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := invalidBlk.Verify(); !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected %s but got %v", test.expectedErr, err)
+			}
+		})
+	}
+
+	if err := blk.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if status := (&UniqueTx{vm: vm, txID: txID}).Status(); status != choices.Accepted {
+		t.Fatalf("expected the transaction to be accepted but it's %s", status)
+	}
+	if lastAccepted, err := cvm.LastAccepted(); err != nil {
+		t.Fatal(err)
+	} else if lastAccepted != blk.ID() {
+		t.Fatalf("expected the last accepted block to be %s but got %s", blk.ID(), lastAccepted)
+	}
+
+	// A rejected block returns its transactions to the mempool
+	send(addrs[1])
+	rejectedBlk, err := cvm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rejectedBlk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := rejectedBlk.Reject(); err != nil {
+		t.Fatal(err)
+	}
+	if len(vm.txs) != 1 {
+		t.Fatalf("expected the transaction to be returned to the mempool")
+	}
+
+	// The last accepted block is persisted
+	restarted := &ChainVM{vm: vm}
+	if err := restarted.initChain(); err != nil {
+		t.Fatal(err)
+	}
+	if lastAccepted, err := restarted.LastAccepted(); err != nil {
+		t.Fatal(err)
+	} else if lastAccepted != blk.ID() {
+		t.Fatalf("expected the last accepted block to be %s but got %s", blk.ID(), lastAccepted)
+	}
+	if accepted, err := restarted.GetBlock(blk.ID()); err != nil {
+		t.Fatal(err)
+	} else if accepted.Status() != choices.Accepted {
+		t.Fatalf("expected the block to be accepted but it's %s", accepted.Status())
+	}
+}
+
+func TestChainVMConflicts(t *testing.T) {
+	_, vm, ctx, txs := setupIssueTx(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+	vm.timer.Cancel()
+
+	// [firstTx] and [secondTx] consume the same UTXO, and [childTx] consumes
+	// the output of [firstTx]
+	firstTx, secondTx := txs[1], txs[2]
+	key := keys[0]
+	childTx := &Tx{UnsignedTx: &BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+		Ins: []*djtx.TransferableInput{{
+			UTXOID: djtx.UTXOID{
+				TxID:        firstTx.ID(),
+				OutputIndex: 0,
+			},
+			Asset: djtx.Asset{ID: txs[0].ID()},
+			In: &secp256k1fx.TransferInput{
+				Amt: startBalance - vm.txFee,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+		Outs: []*djtx.TransferableOutput{{
+			Asset: djtx.Asset{ID: txs[0].ID()},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: startBalance - 2*vm.txFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{key.PublicKey().Address()},
+				},
+			},
+		}},
+	}}}
+	if err := childTx.SignSECP256K1Fx(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{key}}); err != nil {
+		t.Fatal(err)
+	}
+
+	cvm := linearize(t, vm)
+	genesisID, err := cvm.LastAccepted()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := vm.IssueTx(firstTx.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	firstBlk, err := cvm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := firstBlk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	blkTime := firstBlk.(*Block).Timestamp()
+	secondBlk, err := cvm.newBlock(genesisID, 1, blkTime, [][]byte{secondTx.Bytes()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := secondBlk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// verify returns the error of verifying a block containing [tx] on top of
+	// [parentID]
+	verify := func(parentID ids.ID, height uint64, tx *Tx) error {
+		blk, err := cvm.newBlock(parentID, height, blkTime, [][]byte{tx.Bytes()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return blk.Verify()
+	}
+	if err := verify(firstBlk.ID(), 2, secondTx); !errors.Is(err, errConflictingTx) {
+		t.Fatalf("expected %s but got %v", errConflictingTx, err)
+	}
+	if err := verify(genesisID, 1, childTx); !errors.Is(err, errMissingDependency) {
+		t.Fatalf("expected %s but got %v", errMissingDependency, err)
+	}
+
+	// A pending transaction whose dependency isn't in the preferred chain is
+	// kept in the mempool
+	child, err := vm.parseTx(childTx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm.issueTx(child)
+	if err := cvm.SetPreference(secondBlk.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cvm.BuildBlock(); err != errNoPendingTxs {
+		t.Fatalf("expected %s but got %v", errNoPendingTxs, err)
+	}
+	if len(vm.txs) != 1 || child.Status() != choices.Processing {
+		t.Fatal("expected the transaction to be returned to the mempool")
+	}
+	vm.PendingTxs()
+
+	if err := firstBlk.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cvm.SetPreference(firstBlk.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if err := verify(firstBlk.ID(), 2, secondTx); !errors.Is(err, errConflictingTx) {
+		t.Fatalf("expected %s but got %v", errConflictingTx, err)
+	}
+
+	// A transaction that conflicts with an accepted transaction is rejected
+	// when its block is, rather than returned to the mempool
+	if err := secondBlk.Reject(); err != nil {
+		t.Fatal(err)
+	}
+	if len(vm.txs) != 0 {
+		t.Fatal("the conflicting transaction shouldn't be returned to the mempool")
+	}
+	if status := (&UniqueTx{vm: vm, txID: secondTx.ID()}).Status(); status != choices.Rejected {
+		t.Fatalf("expected the conflicting transaction to be rejected but it's %s", status)
+	}
+
+	// An invalid pending transaction is rejected when it's dropped
+	conflictingTx := &Tx{UnsignedTx: &BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+		Ins:          secondTx.UnsignedTx.(*BaseTx).Ins,
+		Outs:         firstTx.UnsignedTx.(*BaseTx).Outs,
+		Memo:         []byte{1},
+	}}}
+	if err := conflictingTx.SignSECP256K1Fx(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{key}}); err != nil {
+		t.Fatal(err)
+	}
+	conflicting, err := vm.parseTx(conflictingTx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm.issueTx(conflicting)
+	if _, err := cvm.BuildBlock(); err != errNoPendingTxs {
+		t.Fatalf("expected %s but got %v", errNoPendingTxs, err)
+	}
+	if status := conflicting.Status(); status != choices.Rejected {
+		t.Fatalf("expected the dropped transaction to be rejected but it's %s", status)
+	}
+
+	// The dependency of the pending transaction is now accepted
+	vm.issueTx(child)
+	if _, err := cvm.BuildBlock(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChainVMAcceptAfterRestart(t *testing.T) {
+	_, vm, ctx, txs := setupIssueTx(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+	vm.timer.Cancel()
+
+	// [childTx] consumes the output of [firstTx]
+	firstTx := txs[1]
+	key := keys[0]
+	childTx := &Tx{UnsignedTx: &BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+		Ins: []*djtx.TransferableInput{{
+			UTXOID: djtx.UTXOID{
+				TxID:        firstTx.ID(),
+				OutputIndex: 0,
+			},
+			Asset: djtx.Asset{ID: txs[0].ID()},
+			In: &secp256k1fx.TransferInput{
+				Amt: startBalance - vm.txFee,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+		Outs: []*djtx.TransferableOutput{{
+			Asset: djtx.Asset{ID: txs[0].ID()},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: startBalance - 2*vm.txFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{key.PublicKey().Address()},
+				},
+			},
+		}},
+	}}}
+	if err := childTx.SignSECP256K1Fx(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{key}}); err != nil {
+		t.Fatal(err)
+	}
+
+	cvm := linearize(t, vm)
+	genesisID, err := cvm.LastAccepted()
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesis, err := cvm.GetBlock(genesisID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blkTime := genesis.(*Block).Timestamp()
+	blk, err := cvm.newBlock(genesisID, 1, blkTime, [][]byte{firstTx.Bytes(), childTx.Bytes()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The node stops after the first transaction of the block was accepted
+	blkID := blk.ID()
+	if err := cvm.db.Put(acceptingKey, blkID[:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.txs[0].Accept(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A block can't include a transaction that was accepted by another block
+	restarted := linearize(t, vm)
+	otherBlk, err := restarted.newBlock(genesisID, 1, blkTime.Add(time.Second), [][]byte{firstTx.Bytes()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := otherBlk.Verify(); !errors.Is(err, errTxAccepted) {
+		t.Fatalf("expected %s but got %v", errTxAccepted, err)
+	}
+
+	// The block that was being accepted is still valid
+	parsedBlk, err := restarted.ParseBlock(blk.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := parsedBlk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := parsedBlk.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if status := (&UniqueTx{vm: vm, txID: childTx.ID()}).Status(); status != choices.Accepted {
+		t.Fatalf("expected the transaction to be accepted but it's %s", status)
+	}
+	if lastAccepted, err := restarted.LastAccepted(); err != nil {
+		t.Fatal(err)
+	} else if lastAccepted != blkID {
+		t.Fatalf("expected the last accepted block to be %s but got %s", blkID, lastAccepted)
+	}
+	if _, err := restarted.db.Get(acceptingKey); err != database.ErrNotFound {
+		t.Fatalf("expected the accepting block to be cleared but got %v", err)
+	}
+}
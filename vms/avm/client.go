@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
 
 	cjson "github.com/ava-labs/avalanchego/utils/json"
 )
@@ -42,6 +43,20 @@ func (c *Client) IssueTx(txBytes []byte) (ids.ID, error) {
 	return res.TxID, err
 }
 
+// SimulateTx verifies [txBytes] against the current state without issuing it
+func (c *Client) SimulateTx(txBytes []byte) (*api.SimulateTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &api.SimulateTxReply{}
+	err = c.requester.SendRequest("simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res)
+	return res, err
+}
+
 // GetTxStatus returns the status of [txID]
 func (c *Client) GetTxStatus(txID ids.ID) (choices.Status, error) {
 	res := &GetTxStatusReply{}
@@ -133,6 +148,14 @@ func (c *Client) GetAssetDescription(assetID string) (*GetAssetDescriptionReply,
 	return res, err
 }
 
+// GetFees returns the fees of the transactions, and the assets that can be
+// burned in place of the fee asset to pay them
+func (c *Client) GetFees() (*GetFeesReply, error) {
+	res := &GetFeesReply{}
+	err := c.requester.SendRequest("getFees", struct{}{}, res)
+	return res, err
+}
+
 // GetBalance returns the balance of [assetID] held by [addr].
 // If [includePartial], balance includes partial owned (i.e. in a multisig) funds.
 func (c *Client) GetBalance(addr string, assetID string, includePartial bool) (*GetBalanceReply, error) {
@@ -155,6 +178,42 @@ func (c *Client) GetAllBalances(addr string, includePartial bool) (*GetAllBalanc
 	return res, err
 }
 
+// GetAddressTxs returns at most [pageSize] of the transactions that moved funds
+// of [assetID] to or from [addr], starting at [cursor], and the cursor of the
+// next page
+func (c *Client) GetAddressTxs(addr string, assetID string, cursor uint64, pageSize uint64) ([]AddressTx, uint64, error) {
+	res := &GetAddressTxsReply{}
+	err := c.requester.SendRequest("getAddressTxs", &GetAddressTxsArgs{
+		Address:  addr,
+		AssetID:  assetID,
+		Cursor:   cjson.Uint64(cursor),
+		PageSize: cjson.Uint64(pageSize),
+	}, res)
+	return res.Txs, uint64(res.Cursor), err
+}
+
+// GetAssetStats returns the supply, number of holders and current minter sets
+// of [assetID]
+func (c *Client) GetAssetStats(assetID string) (*GetAssetStatsReply, error) {
+	res := &GetAssetStatsReply{}
+	err := c.requester.SendRequest("getAssetStats", &GetAssetStatsArgs{
+		AssetID: assetID,
+	}, res)
+	return res, err
+}
+
+// GetAssetHolders returns at most [pageSize] of the holders of [assetID], by
+// decreasing balance, starting at [cursor], and the cursor of the next page
+func (c *Client) GetAssetHolders(assetID string, cursor string, pageSize uint64) ([]Holder, string, error) {
+	res := &GetAssetHoldersReply{}
+	err := c.requester.SendRequest("getAssetHolders", &GetAssetHoldersArgs{
+		AssetID:  assetID,
+		Cursor:   cursor,
+		PageSize: cjson.Uint64(pageSize),
+	}, res)
+	return res.Holders, res.Cursor, err
+}
+
 // CreateAsset creates a new asset and returns its assetID
 func (c *Client) CreateAsset(
 	user api.UserPass,
@@ -311,7 +370,7 @@ func (c *Client) Send(
 			AssetID: assetID,
 			To:      to,
 		},
-		Memo: memo,
+		JSONMemo: api.JSONMemo{Memo: memo},
 	}, res)
 	return res.TxID, err
 }
@@ -331,12 +390,120 @@ func (c *Client) SendMultiple(
 			JSONFromAddrs:  api.JSONFromAddrs{From: from},
 			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
 		},
-		Outputs: outputs,
-		Memo:    memo,
+		Outputs:  outputs,
+		JSONMemo: api.JSONMemo{Memo: memo},
+	}, res)
+	return res.TxID, err
+}
+
+// SendMultipleEncrypted sends a transaction from [user] funding all [outputs]
+// with [memo] encrypted to the CB58 encoded public key [memoPublicKey] of a
+// recipient
+func (c *Client) SendMultipleEncrypted(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	outputs []SendOutput,
+	memo string,
+	memoPublicKey string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("sendMultiple", &SendMultipleArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		Outputs:  outputs,
+		JSONMemo: api.JSONMemo{Memo: memo, MemoPublicKey: memoPublicKey},
 	}, res)
 	return res.TxID, err
 }
 
+// DecryptMemo decrypts the encrypted memo of [txID] with a key of [user].
+// Returns the memo and the address whose key decrypted it.
+func (c *Client) DecryptMemo(user api.UserPass, txID ids.ID) (string, string, error) {
+	res := &DecryptMemoReply{}
+	err := c.requester.SendRequest("decryptMemo", &DecryptMemoArgs{
+		UserPass: user,
+		TxID:     txID,
+	}, res)
+	return res.Memo, res.Address, err
+}
+
+// CreateUnsignedTx creates a tx, funded by the UTXOs of [from], that sends
+// [outputs] without signing it. Returns the bytes of the tx and the addresses
+// that must sign each of its credentials.
+func (c *Client) CreateUnsignedTx(
+	from []string,
+	changeAddr string,
+	outputs []SendOutput,
+	memo string,
+) ([]byte, [][]string, error) {
+	res := &CreateUnsignedTxReply{}
+	err := c.requester.SendRequest("createUnsignedTx", &CreateUnsignedTxArgs{
+		JSONFromAddrs:  api.JSONFromAddrs{From: from},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		Outputs:        outputs,
+		Memo:           memo,
+		Encoding:       formatting.Hex,
+	}, res)
+	if err != nil {
+		return nil, nil, err
+	}
+	txBytes, err := formatting.Decode(res.Encoding, res.Tx)
+	return txBytes, res.Signers, err
+}
+
+// SignTx adds the signatures of [user] to the partially signed tx [txBytes].
+// Returns the bytes of the tx and the number of signatures that are still
+// missing.
+func (c *Client) SignTx(user api.UserPass, txBytes []byte) ([]byte, uint32, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := &SignTxReply{}
+	err = c.requester.SendRequest("signTx", &SignTxArgs{
+		UserPass: user,
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+	}, res)
+	if err != nil {
+		return nil, 0, err
+	}
+	txBytes, err = formatting.Decode(res.Encoding, res.Tx)
+	return txBytes, uint32(res.SignaturesMissing), err
+}
+
+// CombineSignatures merges the signatures of the partially signed versions of
+// the same tx in [txs]. Returns the bytes of the tx and the number of
+// signatures that are still missing.
+func (c *Client) CombineSignatures(txs [][]byte) ([]byte, uint32, error) {
+	txStrs := make([]string, len(txs))
+	for i, txBytes := range txs {
+		txStr, err := formatting.Encode(formatting.Hex, txBytes)
+		if err != nil {
+			return nil, 0, err
+		}
+		txStrs[i] = txStr
+	}
+
+	res := &CombineSignaturesReply{}
+	err := c.requester.SendRequest("combineSignatures", &CombineSignaturesArgs{
+		Txs:      txStrs,
+		Encoding: formatting.Hex,
+	}, res)
+	if err != nil {
+		return nil, 0, err
+	}
+	txBytes, err := formatting.Decode(res.Encoding, res.Tx)
+	return txBytes, uint32(res.SignaturesMissing), err
+}
+
 // Mint [amount] of [assetID] to be owned by [to]
 func (c *Client) Mint(
 	user api.UserPass,
@@ -360,6 +527,48 @@ func (c *Client) Mint(
 	return res.TxID, err
 }
 
+// Burn [amount] of [assetID] and returns the ID of the newly created
+// transaction
+func (c *Client) Burn(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	amount uint64,
+	assetID string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("burn", &BurnArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		Amount:  cjson.Uint64(amount),
+		AssetID: assetID,
+	}, res)
+	return res.TxID, err
+}
+
+// RenounceMinting gives up the user's authority to mint [assetID] and returns
+// the ID of the newly created transaction
+func (c *Client) RenounceMinting(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("renounceMinting", &RenounceMintingArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		AssetID: assetID,
+	}, res)
+	return res.TxID, err
+}
+
 // SendNFT sends an NFT and returns the ID of the newly created transaction
 func (c *Client) SendNFT(
 	user api.UserPass,
@@ -383,6 +592,61 @@ func (c *Client) SendNFT(
 	return res.TxID, err
 }
 
+// ClaimHTLC sends the funds locked in the HTLC of UTXO [outputIndex] of
+// [txID] to [to], by revealing [preimage]
+func (c *Client) ClaimHTLC(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	txID ids.ID,
+	outputIndex uint32,
+	preimage []byte,
+	to string,
+) (ids.ID, error) {
+	preimageStr, err := formatting.Encode(formatting.Hex, preimage)
+	if err != nil {
+		return ids.ID{}, err
+	}
+	res := &api.JSONTxID{}
+	err = c.requester.SendRequest("claimHTLC", &SpendHTLCArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		TxID:        txID,
+		OutputIndex: cjson.Uint32(outputIndex),
+		Preimage:    preimageStr,
+		Encoding:    formatting.Hex,
+		To:          to,
+	}, res)
+	return res.TxID, err
+}
+
+// RefundHTLC sends the funds locked in the HTLC of UTXO [outputIndex] of
+// [txID] back to [to] once its deadline has passed
+func (c *Client) RefundHTLC(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	txID ids.ID,
+	outputIndex uint32,
+	to string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("refundHTLC", &SpendHTLCArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		TxID:        txID,
+		OutputIndex: cjson.Uint32(outputIndex),
+		To:          to,
+	}, res)
+	return res.TxID, err
+}
+
 // MintNFT issues a MintNFT transaction and returns the ID of the newly created transaction
 func (c *Client) MintNFT(
 	user api.UserPass,
@@ -411,6 +675,55 @@ func (c *Client) MintNFT(
 	return res.TxID, err
 }
 
+// MintNFTMetadata issues a MintNFT transaction whose payload holds [metadata]
+// and returns the ID of the newly created transaction
+func (c *Client) MintNFTMetadata(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+	metadata *nftfx.Metadata,
+	to string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("mintNFT", &MintNFTArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		AssetID:  assetID,
+		To:       to,
+		Metadata: metadata,
+	}, res)
+	return res.TxID, err
+}
+
+// GetNFTs returns the unspent NFT outputs that [addr] owns
+func (c *Client) GetNFTs(addr string) ([]NFT, error) {
+	res := &GetNFTsReply{}
+	err := c.requester.SendRequest("getNFTs", &GetNFTsArgs{
+		Address:  addr,
+		Encoding: formatting.Hex,
+	}, res)
+	return res.NFTs, err
+}
+
+// GetNFTHistory returns at most [pageSize] of the outputs of the group
+// [groupID] of [assetID], starting at [cursor], and the cursor of the next
+// page
+func (c *Client) GetNFTHistory(assetID string, groupID uint32, cursor uint64, pageSize uint64) ([]NFTHistoryEntry, uint64, error) {
+	res := &GetNFTHistoryReply{}
+	err := c.requester.SendRequest("getNFTHistory", &GetNFTHistoryArgs{
+		AssetID:  assetID,
+		GroupID:  cjson.Uint32(groupID),
+		Cursor:   cjson.Uint64(cursor),
+		PageSize: cjson.Uint64(pageSize),
+		Encoding: formatting.Hex,
+	}, res)
+	return res.History, uint64(res.Cursor), err
+}
+
 // ImportDJTX sends an import transaction to import funds from [sourceChain] and
 // returns the ID of the newly created transaction
 // This is a deprecated name for Import
@@ -0,0 +1,103 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+)
+
+var (
+	errDuplicateFeeAsset = errors.New("duplicate fee asset")
+	errRedundantFeeAsset = errors.New("the fee asset can't be given a fee rate")
+)
+
+// Config contains all of the user-configurable parameters of the AVM
+type Config struct {
+	// IndexTransactions enables the index of the transactions that touched
+	// each address, which is served by avm.getAddressTxs.
+	IndexTransactions bool `json:"indexTransactions"`
+
+	// IndexAssets enables the index of the supply, holders and minters of
+	// each asset, which is served by avm.getAssetStats and
+	// avm.getAssetHolders.
+	IndexAssets bool `json:"indexAssets"`
+
+	// IndexNFTs enables the index of the ownership history of each NFT group,
+	// which is served by avm.getNFTHistory.
+	IndexNFTs bool `json:"indexNFTs"`
+
+	// CoinSelection is the strategy used to choose the UTXOs that fund the
+	// transactions built by the APIs. One of greedy, largestFirst,
+	// branchAndBound and minimizeChange. Defaults to greedy.
+	CoinSelection string `json:"coinSelection"`
+}
+
+// parseConfig parses the config bytes provided to the VM. An empty config
+// results in the default config.
+func parseConfig(configBytes []byte) (Config, error) {
+	config := Config{}
+	if len(configBytes) == 0 {
+		return config, nil
+	}
+	err := json.Unmarshal(configBytes, &config)
+	return config, err
+}
+
+// UpgradeConfig contains the parameters of the AVM that are enforced by
+// consensus, so every node validating the chain must use the same values.
+// It is read from the upgrade bytes of the chain.
+type UpgradeConfig struct {
+	// FeeAssets are the assets that can be burned in place of the fee asset
+	// to pay transaction fees. They are accepted once the chain time reaches
+	// the Apricot Phase 3 upgrade, which is the same on every node of the
+	// network.
+	FeeAssets []FeeAsset `json:"feeAssets"`
+}
+
+// FeeAsset is an asset that can pay transaction fees. Numerator units of the
+// asset pay for Denominator units of the fee asset.
+type FeeAsset struct {
+	AssetID     ids.ID `json:"assetID"`
+	Numerator   uint64 `json:"numerator"`
+	Denominator uint64 `json:"denominator"`
+}
+
+// parseUpgradeConfig parses the upgrade bytes provided to the VM. Empty
+// upgrade bytes result in the default upgrade config.
+func parseUpgradeConfig(upgradeBytes []byte) (UpgradeConfig, error) {
+	config := UpgradeConfig{}
+	if len(upgradeBytes) == 0 {
+		return config, nil
+	}
+	err := json.Unmarshal(upgradeBytes, &config)
+	return config, err
+}
+
+// feeRates returns the rates of the fee assets, which must not include
+// [feeAssetID].
+func (c *UpgradeConfig) feeRates(feeAssetID ids.ID) (djtx.FeeRates, error) {
+	rates := make(djtx.FeeRates, len(c.FeeAssets))
+	for _, feeAsset := range c.FeeAssets {
+		if feeAsset.AssetID == feeAssetID {
+			return nil, errRedundantFeeAsset
+		}
+		if _, ok := rates[feeAsset.AssetID]; ok {
+			return nil, fmt.Errorf("%w: %s", errDuplicateFeeAsset, feeAsset.AssetID)
+		}
+		rate := djtx.FeeRate{
+			Numerator:   feeAsset.Numerator,
+			Denominator: feeAsset.Denominator,
+		}
+		if err := rate.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid rate for fee asset %s: %w", feeAsset.AssetID, err)
+		}
+		rates[feeAsset.AssetID] = rate
+	}
+	return rates, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/vms/components/djtx"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 var errNoExportOutputs = errors.New("no export outputs")
@@ -74,6 +75,9 @@ func (t *ExportTx) SemanticVerify(vm *VM, tx UnsignedTx, creds []verify.Verifiab
 	}
 
 	for _, out := range t.ExportedOuts {
+		if _, ok := out.Out.(*secp256k1fx.VestingOutput); ok {
+			return errCantExportVesting
+		}
 		fxIndex, err := vm.getFx(out.Out)
 		if err != nil {
 			return err
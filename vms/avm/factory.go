@@ -4,6 +4,8 @@
 package avm
 
 import (
+	"time"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 )
@@ -16,11 +18,15 @@ var (
 type Factory struct {
 	CreationFee uint64
 	Fee         uint64
+
+	// Time of the Apricot Phase 3 upgrade, which is measured in chain time
+	ApricotPhase3Time time.Time
 }
 
 func (f *Factory) New(*snow.Context) (interface{}, error) {
 	return &VM{
-		creationTxFee: f.CreationFee,
-		txFee:         f.Fee,
+		creationTxFee:     f.CreationFee,
+		txFee:             f.Fee,
+		apricotPhase3Time: f.ApricotPhase3Time,
 	}, nil
 }
@@ -0,0 +1,160 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+)
+
+func TestUpgradeConfigFeeRates(t *testing.T) {
+	feeAssetID := ids.GenerateTestID()
+	otherAssetID := ids.GenerateTestID()
+
+	config, err := parseUpgradeConfig([]byte(`{"feeAssets":[{"assetID":"` + otherAssetID.String() + `","numerator":3,"denominator":2}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rates, err := config.feeRates(feeAssetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) != 1 || rates[otherAssetID] != (djtx.FeeRate{Numerator: 3, Denominator: 2}) {
+		t.Fatalf("wrong fee rates %v", rates)
+	}
+
+	config.FeeAssets = append(config.FeeAssets, config.FeeAssets[0])
+	if _, err := config.feeRates(feeAssetID); !errors.Is(err, errDuplicateFeeAsset) {
+		t.Fatalf("expected %s but got %v", errDuplicateFeeAsset, err)
+	}
+
+	config.FeeAssets = []FeeAsset{{AssetID: feeAssetID, Numerator: 1, Denominator: 1}}
+	if _, err := config.feeRates(feeAssetID); err != errRedundantFeeAsset {
+		t.Fatalf("expected %s but got %v", errRedundantFeeAsset, err)
+	}
+
+	config.FeeAssets = []FeeAsset{{AssetID: otherAssetID, Numerator: 1}}
+	if _, err := config.feeRates(feeAssetID); err == nil {
+		t.Fatal("should have failed because of the invalid rate")
+	}
+}
+
+func TestSendWithFeeAsset(t *testing.T) {
+	genesisBytes, vm, s, _, feeAssetTx := setupWithKeys(t, false)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+	vm.timer.Cancel()
+
+	feeAssetID := feeAssetTx.ID()
+	otherAssetID := GetCreateTxFromGenesisTest(t, genesisBytes, otherAssetName).ID()
+	fromAddrStr, err := vm.FormatLocalAddress(addrs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	toAddrStr, err := vm.FormatLocalAddress(addrs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Sending all of the fee asset held by the address leaves nothing to pay
+	// the fee with
+	args := &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs:  api.JSONFromAddrs{From: []string{fromAddrStr}},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: fromAddrStr},
+		},
+		SendOutput: SendOutput{
+			Amount:  json.Uint64(startBalance),
+			AssetID: feeAssetID.String(),
+			To:      toAddrStr,
+		},
+	}
+	reply := &api.JSONTxIDChangeAddr{}
+	if err := s.Send(nil, args, reply); err == nil {
+		t.Fatal("should have failed because the fee can't be paid")
+	}
+
+	// The other asset isn't accepted for fees on the DAG or before Apricot
+	// Phase 3
+	vm.feeRates = djtx.FeeRates{
+		otherAssetID: {Numerator: 2, Denominator: 1},
+	}
+	feesReply := &GetFeesReply{}
+	if err := s.GetFees(nil, nil, feesReply); err != nil {
+		t.Fatal(err)
+	}
+	if len(feesReply.FeeAssets) != 0 {
+		t.Fatalf("expected no fee assets but got %+v", feesReply.FeeAssets)
+	}
+	if err := s.Send(nil, args, reply); err == nil {
+		t.Fatal("should have failed because the fee can't be paid")
+	}
+	linearize(t, vm)
+	vm.apricotPhase3Time = time.Now().Add(time.Hour)
+	if err := s.Send(nil, args, reply); err == nil {
+		t.Fatal("should have failed because the fee can't be paid")
+	}
+	vm.apricotPhase3Time = time.Time{}
+
+	// Once the other asset is accepted for fees, it pays the fee
+	if err := s.GetFees(nil, nil, feesReply); err != nil {
+		t.Fatal(err)
+	}
+	expectedFeeAssets := []APIFeeAsset{{AssetID: otherAssetID, Numerator: 2, Denominator: 1}}
+	if feesReply.FeeAssetID != feeAssetID || !reflect.DeepEqual(feesReply.FeeAssets, expectedFeeAssets) {
+		t.Fatalf("wrong fees %+v", feesReply)
+	}
+	if err := s.Send(nil, args, reply); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := UniqueTx{vm: vm, txID: reply.TxID}
+	if status := tx.Status(); status != choices.Processing {
+		t.Fatalf("expected the tx to be processing but it's %s", status)
+	}
+	burned := map[ids.ID]uint64{}
+	for _, in := range tx.UnsignedTx.(*BaseTx).Ins {
+		burned[in.AssetID()] += in.Input().Amount()
+	}
+	for _, out := range tx.UnsignedTx.(*BaseTx).Outs {
+		burned[out.AssetID()] -= out.Output().Amount()
+	}
+	if burned[feeAssetID] != 0 {
+		t.Fatalf("expected no fee asset to be burned but %d was", burned[feeAssetID])
+	}
+	if burned[otherAssetID] != 2*vm.txFee {
+		t.Fatalf("expected %d of the other asset to be burned but %d was", 2*vm.txFee, burned[otherAssetID])
+	}
+
+	// The fee can't be paid in the other asset before Apricot Phase 3
+	vm.apricotPhase3Time = time.Now().Add(time.Hour)
+	if err := vm.verifyFees(tx.UnsignedTx); err == nil {
+		t.Fatal("should have failed because the fee isn't paid in the fee asset")
+	}
+	vm.apricotPhase3Time = time.Time{}
+
+	// The fee paid in the other asset is enforced at its rate
+	vm.feeRates = djtx.FeeRates{
+		otherAssetID: {Numerator: 3, Denominator: 1},
+	}
+	if err := vm.verifyFees(tx.UnsignedTx); err == nil {
+		t.Fatal("should have failed because the fee isn't fully paid")
+	}
+}
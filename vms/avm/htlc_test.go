@@ -0,0 +1,287 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// setupHTLC returns a VM that supports the htlcfx, and the ID of an asset held
+// by addrs[0] that can be locked in HTLCs
+func setupHTLC(t *testing.T) (*VM, *Service, ids.ID) {
+	genesisBytes, _, vm, _ := genesisVMWithFxs(t, nil, []*common.Fx{{
+		ID: htlcfx.ID,
+		Fx: &htlcfx.Fx{},
+	}})
+	s := &Service{vm: vm}
+	djtxTx := GetDJTXTxFromGenesisTest(genesisBytes, t)
+	vm.timer.Cancel()
+
+	user := userState{vm: vm}
+	db, err := vm.ctx.Keystore.GetDatabase(username, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range keys {
+		if err := user.SetKey(db, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := user.SetAddresses(db, addrs); err != nil {
+		t.Fatal(err)
+	}
+
+	createAssetTx := &Tx{UnsignedTx: &CreateAssetTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+			Ins: []*djtx.TransferableInput{{
+				UTXOID: djtx.UTXOID{
+					TxID:        djtxTx.ID(),
+					OutputIndex: 2,
+				},
+				Asset: djtx.Asset{ID: djtxTx.ID()},
+				In: &secp256k1fx.TransferInput{
+					Amt: startBalance,
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+				},
+			}},
+			Outs: []*djtx.TransferableOutput{{
+				Asset: djtx.Asset{ID: djtxTx.ID()},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: startBalance - vm.creationTxFee,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addrs[0]},
+					},
+				},
+			}},
+		}},
+		Name:   "swappable",
+		Symbol: "SWAP",
+		States: []*InitialState{
+			{
+				FxID: 0,
+				Outs: []verify.State{
+					&secp256k1fx.TransferOutput{
+						Amt: startBalance,
+						OutputOwners: secp256k1fx.OutputOwners{
+							Threshold: 1,
+							Addrs:     []ids.ShortID{addrs[0]},
+						},
+					},
+				},
+			},
+			{
+				FxID: 2,
+			},
+		},
+	}}
+	if err := createAssetTx.SignSECP256K1Fx(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}); err != nil {
+		t.Fatal(err)
+	}
+	assetID, err := vm.IssueTx(createAssetTx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (&UniqueTx{vm: vm, txID: assetID}).Accept(); err != nil {
+		t.Fatal(err)
+	}
+	return vm, s, assetID
+}
+
+func TestHTLCClaimAndRefund(t *testing.T) {
+	vm, s, assetID := setupHTLC(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	now := time.Unix(1_000_000, 0)
+	vm.clock.Set(now)
+
+	addrStrs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		addrStr, err := vm.FormatLocalAddress(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addrStrs[i] = addrStr
+	}
+
+	preimage := []byte("atomic swap secret")
+	hash := hashing.ComputeHash256(preimage)
+	hashStr, err := formatting.Encode(formatting.Hex, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	preimageStr, err := formatting.Encode(formatting.Hex, preimage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrongPreimageStr, err := formatting.Encode(formatting.Hex, []byte("wrong secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// lockArgs locks 1000 in an HTLC that addrs[1] can claim and that is
+	// refunded to addrs[0]
+	lockArgs := &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs:  api.JSONFromAddrs{From: []string{addrStrs[0]}},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStrs[0]},
+		},
+		SendOutput: SendOutput{
+			Amount:  1000,
+			AssetID: assetID.String(),
+			To:      addrStrs[1],
+			HTLC: &HTLCArgs{
+				Hash:       hashStr,
+				Encoding:   formatting.Hex,
+				Deadline:   json.Uint64(now.Unix() + 100),
+				RefundAddr: addrStrs[0],
+			},
+		},
+	}
+	// lockFunds issues [lockArgs] and returns the UTXO of the HTLC
+	lockFunds := func() *djtx.UTXOID {
+		reply := &api.JSONTxIDChangeAddr{}
+		if err := s.Send(nil, lockArgs, reply); err != nil {
+			t.Fatal(err)
+		}
+		lockTx := UniqueTx{vm: vm, txID: reply.TxID}
+		if err := lockTx.Accept(); err != nil {
+			t.Fatal(err)
+		}
+		for _, utxo := range lockTx.UTXOs() {
+			if _, ok := utxo.Out.(*htlcfx.TransferOutput); ok {
+				return &utxo.UTXOID
+			}
+		}
+		t.Fatal("HTLC output wasn't created")
+		return nil
+	}
+	spendArgs := func(utxoID *djtx.UTXOID, from, to int, preimage string) *SpendHTLCArgs {
+		return &SpendHTLCArgs{
+			JSONSpendHeader: api.JSONSpendHeader{
+				UserPass: api.UserPass{
+					Username: username,
+					Password: password,
+				},
+				JSONFromAddrs:  api.JSONFromAddrs{From: []string{addrStrs[from]}},
+				JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStrs[from]},
+			},
+			TxID:        utxoID.TxID,
+			OutputIndex: json.Uint32(utxoID.OutputIndex),
+			Preimage:    preimage,
+			Encoding:    formatting.Hex,
+			To:          addrStrs[to],
+		}
+	}
+	balance := func(addrStr string) uint64 {
+		reply := &GetBalanceReply{}
+		err := s.GetBalance(nil, &GetBalanceArgs{
+			Address: addrStr,
+			AssetID: assetID.String(),
+		}, reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return uint64(reply.Balance)
+	}
+
+	// The deadline of an HTLC is measured in chain time, so HTLCs can't be
+	// created on the DAG, or before Apricot Phase 3
+	reply := &api.JSONTxIDChangeAddr{}
+	if err := s.Send(nil, lockArgs, reply); err == nil || !strings.Contains(err.Error(), errNotApricotPhase3.Error()) {
+		t.Fatalf("expected %s but got %v", errNotApricotPhase3, err)
+	}
+	linearize(t, vm)
+	vm.apricotPhase3Time = now.Add(time.Second)
+	if err := s.Send(nil, lockArgs, reply); err == nil || !strings.Contains(err.Error(), errNotApricotPhase3.Error()) {
+		t.Fatalf("expected %s but got %v", errNotApricotPhase3, err)
+	}
+	vm.apricotPhase3Time = time.Time{}
+
+	// Claim the first HTLC
+	claimedUTXO := lockFunds()
+	if err := s.ClaimHTLC(nil, spendArgs(claimedUTXO, 1, 2, wrongPreimageStr), reply); err == nil {
+		t.Fatal("should have failed to claim with the wrong preimage")
+	}
+	if err := s.RefundHTLC(nil, spendArgs(claimedUTXO, 0, 0, ""), reply); err == nil {
+		t.Fatal("should have failed to refund before the deadline")
+	}
+	if err := s.ClaimHTLC(nil, spendArgs(claimedUTXO, 1, 2, preimageStr), reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := (&UniqueTx{vm: vm, txID: reply.TxID}).Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if balance := balance(addrStrs[2]); balance != 1000 {
+		t.Fatalf("expected the claimed funds to be sent but the balance is %d", balance)
+	}
+
+	// Refund the second HTLC
+	refundedUTXO := lockFunds()
+	vm.clock.Set(now.Add(100 * time.Second))
+	if err := s.ClaimHTLC(nil, spendArgs(refundedUTXO, 1, 2, preimageStr), reply); err == nil {
+		t.Fatal("should have failed to claim after the deadline")
+	}
+	if err := s.RefundHTLC(nil, spendArgs(refundedUTXO, 1, 1, ""), reply); err != errCantSpendHTLC {
+		t.Fatalf("expected %s but got %v", errCantSpendHTLC, err)
+	}
+	if err := s.RefundHTLC(nil, spendArgs(refundedUTXO, 0, 0, ""), reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := (&UniqueTx{vm: vm, txID: reply.TxID}).Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if balance := balance(addrStrs[0]); balance != startBalance-1000 {
+		t.Fatalf("expected %d after the refund but the balance is %d", startBalance-1000, balance)
+	}
+}
+
+// Test that checking whether an asset supports an fx doesn't cache that the
+// asset doesn't support its other fxs
+func TestVerifyFxUsageCachesEveryFx(t *testing.T) {
+	vm, _, assetID := setupHTLC(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	if !vm.verifyFxUsage(2, assetID) {
+		t.Fatal("the asset should support the htlcfx")
+	}
+	if !vm.verifyFxUsage(0, assetID) {
+		t.Fatal("the asset should support the secp256k1fx")
+	}
+	if vm.verifyFxUsage(1, assetID) {
+		t.Fatal("the asset shouldn't support the nftfx")
+	}
+}
@@ -0,0 +1,131 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+func TestEncryptedMemo(t *testing.T) {
+	_, vm, s, _, genesisTx := setupWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+	vm.timer.Cancel()
+
+	userPass := api.UserPass{
+		Username: username,
+		Password: password,
+	}
+	formatKey := func(key crypto.PublicKey) string {
+		keyStr, err := formatting.Encode(formatting.CB58, key.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return keyStr
+	}
+	// send issues a transaction that sends funds to [to] with [memo] encrypted
+	// to [memoPublicKey]
+	send := func(to ids.ShortID, memo string, memoPublicKey string) (ids.ID, error) {
+		toStr, err := vm.FormatLocalAddress(to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reply := &api.JSONTxIDChangeAddr{}
+		err = s.Send(nil, &SendArgs{
+			JSONSpendHeader: api.JSONSpendHeader{UserPass: userPass},
+			SendOutput: SendOutput{
+				Amount:  1000,
+				AssetID: genesisTx.ID().String(),
+				To:      toStr,
+			},
+			JSONMemo: api.JSONMemo{
+				Memo:          memo,
+				MemoPublicKey: memoPublicKey,
+			},
+		}, reply)
+		return reply.TxID, err
+	}
+
+	// The memo can only be encrypted to a recipient
+	if _, err := send(addrs[0], "invoice #1234", formatKey(keys[1].PublicKey())); err == nil {
+		t.Fatal("expected the memo to be rejected")
+	}
+	txID, err := send(addrs[1], "invoice #1234", formatKey(keys[1].PublicKey()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := UniqueTx{vm: vm, txID: txID}
+	if status := tx.Status(); !status.Fetched() {
+		t.Fatalf("expected the transaction to be fetched but it's %s", status)
+	}
+	memo := tx.UnsignedTx.memo()
+	if !crypto.IsEncryptedMemo(memo) {
+		t.Fatal("the memo should be encrypted")
+	}
+
+	reply := &DecryptMemoReply{}
+	if err := s.DecryptMemo(nil, &DecryptMemoArgs{UserPass: userPass, TxID: txID}, reply); err != nil {
+		t.Fatal(err)
+	}
+	addrStr, err := vm.FormatLocalAddress(addrs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Memo != "invoice #1234" || reply.Address != addrStr {
+		t.Fatalf("expected the memo to be decrypted by %s but got %+v", addrStr, reply)
+	}
+
+	// Memos can be decrypted without the transaction that holds them
+	memoStr, err := formatting.Encode(formatting.Hex, memo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply = &DecryptMemoReply{}
+	err = s.DecryptMemo(nil, &DecryptMemoArgs{
+		UserPass: userPass,
+		Memo:     memoStr,
+		Encoding: formatting.Hex,
+	}, reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Memo != "invoice #1234" {
+		t.Fatalf("expected the memo to be decrypted but got %q", reply.Memo)
+	}
+
+	// Plaintext memos can't be decrypted
+	txID, err = send(addrs[1], "invoice #1234", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.DecryptMemo(nil, &DecryptMemoArgs{UserPass: userPass, TxID: txID}, &DecryptMemoReply{})
+	if err != errMemoNotEncrypted {
+		t.Fatalf("expected %s but got %v", errMemoNotEncrypted, err)
+	}
+
+	// Memos encrypted to a key the user doesn't hold can't be decrypted
+	factory := crypto.FactorySECP256K1R{}
+	otherKey, err := factory.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	txID, err = send(otherKey.PublicKey().Address(), "invoice #1234", formatKey(otherKey.PublicKey()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.DecryptMemo(nil, &DecryptMemoArgs{UserPass: userPass, TxID: txID}, &DecryptMemoReply{})
+	if err != errCantDecryptMemo {
+		t.Fatalf("expected %s but got %v", errCantDecryptMemo, err)
+	}
+}
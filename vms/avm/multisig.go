@@ -0,0 +1,224 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// A partially signed tx is a tx whose credentials are complete, except that
+// some of their signatures are left empty. Each party that controls some of
+// the addresses that must sign the tx adds its signatures with signTx, on its
+// own node. The partially signed txs are then merged with combineTxs and the
+// result is issued once no signature is missing.
+
+var (
+	errNoTxs                = errors.New("no txs were provided")
+	errDifferentTxs         = errors.New("txs have different unsigned txs")
+	errMissingSignatures    = errors.New("tx is missing signatures")
+	errWrongNumberOfCreds   = errors.New("tx has the wrong number of credentials")
+	errUnknownCredential    = errors.New("unknown credential type")
+	errUnknownInputType     = errors.New("unknown input type")
+	errUnknownOutputType    = errors.New("unknown output type")
+	errUnsupportedOperation = errors.New("operation can't be partially signed")
+	errUnsupportedTx        = errors.New("tx doesn't spend UTXOs")
+)
+
+// spendingTx is a tx whose inputs consume UTXOs of this chain
+type spendingTx interface {
+	inputs() []*djtx.TransferableInput
+}
+
+// credentialSigners returns the addresses that must sign each credential of
+// [utx], in the order of the signatures of the credential
+func (vm *VM) credentialSigners(utx UnsignedTx) ([][]ids.ShortID, error) {
+	spender, ok := utx.(spendingTx)
+	if !ok {
+		return nil, errUnsupportedTx
+	}
+	ins := spender.inputs()
+
+	utxos := make([]*djtx.UTXO, len(ins))
+	for i, in := range ins {
+		utxo, err := vm.getUTXO(&in.UTXOID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get UTXO %s: %w", &in.UTXOID, err)
+		}
+		utxos[i] = utxo
+	}
+
+	var ops []*Operation
+	switch utx := utx.(type) {
+	case *ImportTx:
+		// Imported UTXOs are read from shared memory
+		utxoIDs := make([][]byte, len(utx.ImportedIns))
+		for i, in := range utx.ImportedIns {
+			inputID := in.InputID()
+			utxoIDs[i] = inputID[:]
+		}
+		allUTXOBytes, err := vm.ctx.SharedMemory.Get(utx.SourceChain, utxoIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get shared memory: %w", err)
+		}
+		for _, utxoBytes := range allUTXOBytes {
+			utxo := &djtx.UTXO{}
+			if _, err := vm.codec.Unmarshal(utxoBytes, utxo); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal UTXO: %w", err)
+			}
+			utxos = append(utxos, utxo)
+		}
+		ins = append(ins, utx.ImportedIns...)
+	case *OperationTx:
+		ops = utx.Ops
+	}
+
+	signers := make([][]ids.ShortID, 0, len(ins)+len(ops))
+	for i, in := range ins {
+		transferIn, ok := in.In.(*secp256k1fx.TransferInput)
+		if !ok {
+			return nil, errUnknownInputType
+		}
+		out, ok := utxos[i].Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			return nil, errUnknownOutputType
+		}
+		inSigners, err := transferIn.Signers(&out.OutputOwners)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, inSigners)
+	}
+
+	// Only secp256k1fx mint operations are supported, as they are the only
+	// operations whose authorization is described by a single owner
+	for _, op := range ops {
+		mintOp, ok := op.Op.(*secp256k1fx.MintOperation)
+		if !ok || len(op.UTXOIDs) != 1 {
+			return nil, errUnsupportedOperation
+		}
+		utxo, err := vm.getUTXO(op.UTXOIDs[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get UTXO %s: %w", op.UTXOIDs[0], err)
+		}
+		out, ok := utxo.Out.(*secp256k1fx.MintOutput)
+		if !ok {
+			return nil, errUnknownOutputType
+		}
+		opSigners, err := mintOp.MintInput.Signers(&out.OutputOwners)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, opSigners)
+	}
+	return signers, nil
+}
+
+// secp256k1fxCredential returns the signatures held by [credIntf]
+func secp256k1fxCredential(credIntf verify.Verifiable) (*secp256k1fx.Credential, error) {
+	switch cred := credIntf.(type) {
+	case *secp256k1fx.Credential:
+		return cred, nil
+	case *nftfx.Credential:
+		return &cred.Credential, nil
+	default:
+		return nil, errUnknownCredential
+	}
+}
+
+// signTx adds the signatures of [tx] that can be produced by the keys in [kc].
+// Returns the number of signatures that were added.
+func (vm *VM) signTx(tx *Tx, kc *secp256k1fx.Keychain) (int, error) {
+	signers, err := vm.credentialSigners(tx.UnsignedTx)
+	if err != nil {
+		return 0, err
+	}
+	if len(signers) != len(tx.Creds) {
+		return 0, errWrongNumberOfCreds
+	}
+
+	hash := hashing.ComputeHash256(tx.UnsignedBytes())
+	numSigned := 0
+	for i, credIntf := range tx.Creds {
+		cred, err := secp256k1fxCredential(credIntf)
+		if err != nil {
+			return 0, err
+		}
+		credSigned, err := cred.Sign(hash, signers[i], kc)
+		if err != nil {
+			return 0, err
+		}
+		numSigned += credSigned
+	}
+	// Re-initialize the tx with its new credentials
+	return numSigned, tx.SignSECP256K1Fx(vm.codec, nil)
+}
+
+// combineTxs returns the tx that has all the signatures added to any of the
+// partially signed [txs]. All of [txs] must have the same unsigned tx.
+func (vm *VM) combineTxs(txs []*Tx) (*Tx, error) {
+	if len(txs) == 0 {
+		return nil, errNoTxs
+	}
+
+	combinedTx := txs[0]
+	unsignedBytes := combinedTx.UnsignedBytes()
+	for _, tx := range txs[1:] {
+		if !bytes.Equal(tx.UnsignedBytes(), unsignedBytes) {
+			return nil, errDifferentTxs
+		}
+		if len(tx.Creds) != len(combinedTx.Creds) {
+			return nil, errWrongNumberOfCreds
+		}
+		for i, credIntf := range tx.Creds {
+			cred, err := secp256k1fxCredential(credIntf)
+			if err != nil {
+				return nil, err
+			}
+			combinedCred, err := secp256k1fxCredential(combinedTx.Creds[i])
+			if err != nil {
+				return nil, err
+			}
+			if err := combinedCred.Merge(cred); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// Re-initialize the tx with its new credentials
+	return combinedTx, combinedTx.SignSECP256K1Fx(vm.codec, nil)
+}
+
+// numMissingSigs returns the number of signatures that haven't been added to
+// [tx] yet
+func numMissingSigs(tx *Tx) int {
+	numMissing := 0
+	for _, credIntf := range tx.Creds {
+		if cred, err := secp256k1fxCredential(credIntf); err == nil {
+			numMissing += cred.NumMissingSigs()
+		}
+	}
+	return numMissing
+}
+
+// parseFormattedTx returns the tx encoded as [txStr] with [encoding]
+func (vm *VM) parseFormattedTx(encoding formatting.Encoding, txStr string) (*Tx, error) {
+	txBytes, err := formatting.Decode(encoding, txStr)
+	if err != nil {
+		return nil, fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := vm.parsePrivateTx(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse tx: %w", err)
+	}
+	return tx, nil
+}
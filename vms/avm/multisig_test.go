@@ -0,0 +1,144 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// signCopy returns a copy of the partially signed [tx] after it is signed
+// with [keys]
+func signCopy(t *testing.T, vm *VM, tx *Tx, keys ...*crypto.PrivateKeySECP256K1R) *Tx {
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	txCopy, err := vm.parseFormattedTx(formatting.Hex, txStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kc := secp256k1fx.NewKeychain()
+	for _, key := range keys {
+		kc.Add(key)
+	}
+	numSigned, err := vm.signTx(txCopy, kc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numSigned == 0 {
+		t.Fatal("expected signatures to be added")
+	}
+	return txCopy
+}
+
+func TestMultisigSend(t *testing.T) {
+	_, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	fromAddrsStr := make([]string, 2)
+	for i := range fromAddrsStr {
+		addrStr, err := vm.FormatLocalAddress(addrs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		fromAddrsStr[i] = addrStr
+	}
+	toAddrStr, err := vm.FormatLocalAddress(addrs[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Sending more than the balance of a single address requires both
+	// addresses to sign
+	reply := &CreateUnsignedTxReply{}
+	err = s.CreateUnsignedTx(nil, &CreateUnsignedTxArgs{
+		JSONFromAddrs:  api.JSONFromAddrs{From: fromAddrsStr},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: fromAddrsStr[0]},
+		Outputs: []SendOutput{{
+			Amount:  json.Uint64(startBalance + 1),
+			AssetID: genesisTx.ID().String(),
+			To:      toAddrStr,
+		}},
+		Encoding: formatting.Hex,
+	}, reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Signers) != 2 {
+		t.Fatalf("expected 2 credentials but got %d", len(reply.Signers))
+	}
+	for _, signers := range reply.Signers {
+		if len(signers) != 1 {
+			t.Fatalf("expected 1 signer but got %d", len(signers))
+		}
+	}
+
+	tx, err := vm.parseFormattedTx(reply.Encoding, reply.Tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numMissing := numMissingSigs(tx); numMissing != 2 {
+		t.Fatalf("expected 2 missing signatures but got %d", numMissing)
+	}
+
+	vm.timer.Cancel()
+	issueReply := &api.JSONTxID{}
+	err = s.IssueTx(nil, &api.FormattedTx{Tx: reply.Tx, Encoding: reply.Encoding}, issueReply)
+	if !errors.Is(err, errMissingSignatures) {
+		t.Fatalf("expected %s but got %v", errMissingSignatures, err)
+	}
+
+	// Each party signs its own copy of the tx
+	tx0 := signCopy(t, vm, tx, keys[0])
+	tx1 := signCopy(t, vm, tx, keys[1])
+	if numMissing := numMissingSigs(tx0); numMissing != 1 {
+		t.Fatalf("expected 1 missing signature but got %d", numMissing)
+	}
+
+	signedTx, err := vm.combineTxs([]*Tx{tx0, tx1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numMissing := numMissingSigs(signedTx); numMissing != 0 {
+		t.Fatalf("expected no missing signatures but got %d", numMissing)
+	}
+
+	signedTxStr, err := formatting.Encode(formatting.Hex, signedTx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.IssueTx(nil, &api.FormattedTx{Tx: signedTxStr, Encoding: formatting.Hex}, issueReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issueReply.TxID != signedTx.ID() {
+		t.Fatalf("expected tx %s to be issued but got %s", signedTx.ID(), issueReply.TxID)
+	}
+}
+
+func TestMultisigCombineDifferentTxs(t *testing.T) {
+	_, vm, ctx, txs := setupIssueTx(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	if _, err := vm.combineTxs(txs[1:]); !errors.Is(err, errDifferentTxs) {
+		t.Fatalf("expected %s but got %v", errDifferentTxs, err)
+	}
+}
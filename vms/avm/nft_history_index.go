@@ -0,0 +1,162 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+)
+
+const (
+	// Max number of entries returned by a single call to GetNFTHistory
+	maxNFTHistoryPageSize = 1024
+
+	nftHistoryEntrySize = 2*(hashing.HashLen+wrappers.IntLen) + wrappers.BoolLen
+)
+
+var (
+	nftHistoryPrefix   = []byte("nftHistory")
+	nftHistoryCountKey = []byte("count")
+)
+
+// nftHistoryEntry records that an NFT output was produced
+type nftHistoryEntry struct {
+	// The NFT output
+	UTXOID djtx.UTXOID
+	// True if the output was produced by a mint, rather than a transfer
+	Minted bool
+	// The output the NFT was transferred from. Empty if it was minted.
+	Spent djtx.UTXOID
+}
+
+// nftHistoryIndex maintains, for each NFT group of each asset, the list of the
+// outputs of the group. Outputs produced while the index is enabled are listed
+// in the order they were accepted. Outputs produced before the index was
+// (re)built are listed in an order that respects the dependencies of their
+// transactions, which may differ from the order they were accepted in.
+type nftHistoryIndex struct {
+	acceptedTxIndex
+}
+
+func newNFTHistoryIndex(db database.Database) *nftHistoryIndex {
+	return &nftHistoryIndex{
+		acceptedTxIndex: acceptedTxIndex{
+			db: prefixdb.New(nftHistoryPrefix, db),
+		},
+	}
+}
+
+func (i *nftHistoryIndex) listDB(assetID ids.ID, groupID uint32) database.Database {
+	p := wrappers.Packer{Bytes: make([]byte, hashing.HashLen+wrappers.IntLen)}
+	p.PackFixedBytes(assetID[:])
+	p.PackInt(groupID)
+	return prefixdb.NewNested(p.Bytes, i.db)
+}
+
+func (i *nftHistoryIndex) count(listDB database.Database) (uint64, error) {
+	count, err := database.GetUInt64(listDB, nftHistoryCountKey)
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return count, err
+}
+
+// add appends [entry] to the history of the group [groupID] of [assetID]
+func (i *nftHistoryIndex) add(assetID ids.ID, groupID uint32, entry *nftHistoryEntry) error {
+	listDB := i.listDB(assetID, groupID)
+	count, err := i.count(listDB)
+	if err != nil {
+		return err
+	}
+
+	p := wrappers.Packer{Bytes: make([]byte, nftHistoryEntrySize)}
+	p.PackFixedBytes(entry.UTXOID.TxID[:])
+	p.PackInt(entry.UTXOID.OutputIndex)
+	p.PackBool(entry.Minted)
+	p.PackFixedBytes(entry.Spent.TxID[:])
+	p.PackInt(entry.Spent.OutputIndex)
+	if err := listDB.Put(database.PackUInt64(count), p.Bytes); err != nil {
+		return err
+	}
+	return database.PutUInt64(listDB, nftHistoryCountKey, count+1)
+}
+
+// read returns at most [pageSize] entries of the history of the group
+// [groupID] of [assetID], starting at the [cursor]th entry. The returned
+// cursor is the index of the entry following the last one returned.
+func (i *nftHistoryIndex) read(assetID ids.ID, groupID uint32, cursor uint64, pageSize uint64) ([]nftHistoryEntry, uint64, error) {
+	listDB := i.listDB(assetID, groupID)
+	count, err := i.count(listDB)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := []nftHistoryEntry(nil)
+	for ; cursor < count && uint64(len(entries)) < pageSize; cursor++ {
+		entryBytes, err := listDB.Get(database.PackUInt64(cursor))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		p := wrappers.Packer{Bytes: entryBytes}
+		entry := nftHistoryEntry{}
+		copy(entry.UTXOID.TxID[:], p.UnpackFixedBytes(hashing.HashLen))
+		entry.UTXOID.OutputIndex = p.UnpackInt()
+		entry.Minted = p.UnpackBool()
+		copy(entry.Spent.TxID[:], p.UnpackFixedBytes(hashing.HashLen))
+		entry.Spent.OutputIndex = p.UnpackInt()
+		if p.Errored() {
+			return nil, 0, p.Err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, cursor, nil
+}
+
+// indexNFTs adds the NFT outputs produced by the accepted [tx] to the history
+// of their group
+func (vm *VM) indexNFTs(tx *Tx) error {
+	opTx, ok := tx.UnsignedTx.(*OperationTx)
+	if !ok {
+		return nil
+	}
+
+	txID := tx.ID()
+	// The outputs of the operations follow those of the base tx
+	outputIndex := uint32(len(opTx.BaseTx.UTXOs()))
+	for _, op := range opTx.Ops {
+		assetID := op.AssetID()
+		for _, out := range op.Op.Outs() {
+			entry := &nftHistoryEntry{
+				UTXOID: djtx.UTXOID{
+					TxID:        txID,
+					OutputIndex: outputIndex,
+				},
+			}
+			outputIndex++
+
+			nftOut, ok := out.(*nftfx.TransferOutput)
+			if !ok {
+				continue
+			}
+			switch op.Op.(type) {
+			case *nftfx.MintOperation:
+				entry.Minted = true
+			case *nftfx.TransferOperation:
+				entry.Spent = *op.UTXOIDs[0]
+			default:
+				continue
+			}
+			if err := vm.nftHistory.add(assetID, nftOut.GroupID, entry); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+)
+
+func TestNFTQueries(t *testing.T) {
+	_, vm, s, _, _ := setupWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+	vm.timer.Cancel()
+
+	addrStrs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		addrStr, err := vm.FormatLocalAddress(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addrStrs[i] = addrStr
+	}
+	spendHeader := api.JSONSpendHeader{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStrs[0]},
+	}
+	accept := func(txID ids.ID) {
+		if err := (&UniqueTx{vm: vm, txID: txID}).Accept(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := s.GetNFTHistory(nil, &GetNFTHistoryArgs{}, &GetNFTHistoryReply{})
+	if err != errNFTIndexingDisabled {
+		t.Fatalf("expected %s but got %v", errNFTIndexingDisabled, err)
+	}
+	if err := vm.initNFTHistoryIndex(true); err != nil {
+		t.Fatal(err)
+	}
+
+	createReply := &AssetIDChangeAddr{}
+	err = s.CreateNFTAsset(nil, &CreateNFTAssetArgs{
+		JSONSpendHeader: spendHeader,
+		Name:            "Paintings",
+		Symbol:          "ART",
+		MinterSets: []Owners{{
+			Threshold: 1,
+			Minters:   []string{addrStrs[0]},
+		}},
+	}, createReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accept(createReply.AssetID)
+	assetID := createReply.AssetID.String()
+
+	metadata := &nftfx.Metadata{
+		Name:        "Mona Lisa",
+		URI:         "https://example.com/mona-lisa.png",
+		ContentHash: make([]byte, 32),
+		Attributes:  []nftfx.Attribute{{Key: "artist", Value: "Leonardo"}},
+	}
+	err = s.MintNFT(nil, &MintNFTArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID,
+		Payload:         "0x01",
+		To:              addrStrs[0],
+		Metadata:        metadata,
+	}, &api.JSONTxIDChangeAddr{})
+	if err != errPayloadAndMetadata {
+		t.Fatalf("expected %s but got %v", errPayloadAndMetadata, err)
+	}
+	mintReply := &api.JSONTxIDChangeAddr{}
+	err = s.MintNFT(nil, &MintNFTArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID,
+		To:              addrStrs[0],
+		Metadata:        metadata,
+	}, mintReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accept(mintReply.TxID)
+
+	checkNFTs := func(addrStr string, numNFTs int) []NFT {
+		reply := &GetNFTsReply{}
+		err := s.GetNFTs(nil, &GetNFTsArgs{
+			Address:  addrStr,
+			Encoding: formatting.Hex,
+		}, reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reply.NFTs) != numNFTs {
+			t.Fatalf("expected %s to own %d NFTs but got %d", addrStr, numNFTs, len(reply.NFTs))
+		}
+		return reply.NFTs
+	}
+	nft := checkNFTs(addrStrs[0], 1)[0]
+	if nft.AssetID != createReply.AssetID || nft.GroupID != 0 {
+		t.Fatalf("unexpected NFT %+v", nft)
+	}
+	if !reflect.DeepEqual(nft.Metadata, metadata) {
+		t.Fatalf("expected metadata %+v but got %+v", metadata, nft.Metadata)
+	}
+
+	sendReply := &api.JSONTxIDChangeAddr{}
+	err = s.SendNFT(nil, &SendNFTArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID,
+		To:              addrStrs[1],
+	}, sendReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accept(sendReply.TxID)
+	checkNFTs(addrStrs[0], 0)
+	checkNFTs(addrStrs[1], 1)
+
+	checkHistory := func() {
+		history := []NFTHistoryEntry(nil)
+		for cursor := json.Uint64(0); ; {
+			reply := &GetNFTHistoryReply{}
+			err := s.GetNFTHistory(nil, &GetNFTHistoryArgs{
+				AssetID:  assetID,
+				Cursor:   cursor,
+				PageSize: 1,
+				Encoding: formatting.Hex,
+			}, reply)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(reply.History) == 0 {
+				break
+			}
+			history = append(history, reply.History...)
+			cursor = reply.Cursor
+		}
+
+		if len(history) != 2 {
+			t.Fatalf("expected 2 entries but got %d", len(history))
+		}
+		minted, transferred := history[0], history[1]
+		switch {
+		case !minted.Minted || minted.TxID != mintReply.TxID || minted.From != "":
+			t.Fatalf("expected the NFT to be minted by %s but got %+v", mintReply.TxID, minted)
+		case !reflect.DeepEqual(minted.Owners, []string{addrStrs[0]}):
+			t.Fatalf("expected the NFT to be minted to %s but got %v", addrStrs[0], minted.Owners)
+		case transferred.Minted || transferred.TxID != sendReply.TxID || transferred.From != minted.UTXOID:
+			t.Fatalf("expected the NFT to be transferred by %s but got %+v", sendReply.TxID, transferred)
+		case !reflect.DeepEqual(transferred.Owners, []string{addrStrs[1]}):
+			t.Fatalf("expected the NFT to be transferred to %s but got %v", addrStrs[1], transferred.Owners)
+		case !reflect.DeepEqual(transferred.Metadata, metadata):
+			t.Fatalf("expected metadata %+v but got %+v", metadata, transferred.Metadata)
+		}
+	}
+	checkHistory()
+
+	// Rebuilding the index results in the same history
+	if err := vm.rebuildIndex(&vm.nftHistory.acceptedTxIndex, vm.indexNFTs); err != nil {
+		t.Fatal(err)
+	}
+	checkHistory()
+
+	// Disabling the index removes it
+	if err := vm.initNFTHistoryIndex(false); err != nil {
+		t.Fatal(err)
+	}
+	if indexed, err := vm.nftHistory.isIndexed(); err != nil {
+		t.Fatal(err)
+	} else if indexed {
+		t.Fatal("index should have been removed")
+	}
+	entries, _, err := vm.nftHistory.read(createReply.AssetID, 0, 0, maxNFTHistoryPageSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected the index to be empty but got %d entries", len(entries))
+	}
+}
+
+func TestMintNFTInvalidMetadata(t *testing.T) {
+	_, vm, s, _, _ := setupWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+	vm.timer.Cancel()
+
+	addrStr, err := vm.FormatLocalAddress(addrs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	spendHeader := api.JSONSpendHeader{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStr},
+	}
+	createReply := &AssetIDChangeAddr{}
+	err = s.CreateNFTAsset(nil, &CreateNFTAssetArgs{
+		JSONSpendHeader: spendHeader,
+		Name:            "Paintings",
+		Symbol:          "ART",
+		// Each group can be minted once
+		MinterSets: []Owners{
+			{
+				Threshold: 1,
+				Minters:   []string{addrStr},
+			},
+			{
+				Threshold: 1,
+				Minters:   []string{addrStr},
+			},
+		},
+	}, createReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (&UniqueTx{vm: vm, txID: createReply.AssetID}).Accept(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The payload is flagged as holding metadata but doesn't
+	payload, err := formatting.Encode(formatting.Hex, append(nftfx.MetadataPrefix, 0xff))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mint := func() (ids.ID, error) {
+		reply := &api.JSONTxIDChangeAddr{}
+		err := s.MintNFT(nil, &MintNFTArgs{
+			JSONSpendHeader: spendHeader,
+			AssetID:         createReply.AssetID.String(),
+			Payload:         payload,
+			To:              addrStr,
+			Encoding:        formatting.Hex,
+		}, reply)
+		return reply.TxID, err
+	}
+
+	// The metadata isn't enforced before the Apricot Phase 3 upgrade
+	txID, err := mint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (&UniqueTx{vm: vm, txID: txID}).Accept(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The raw payload of an NFT with invalid metadata is still returned
+	reply := &GetNFTsReply{}
+	err = s.GetNFTs(nil, &GetNFTsArgs{
+		Address:  addrStr,
+		Encoding: formatting.Hex,
+	}, reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.NFTs) != 1 {
+		t.Fatalf("expected 1 NFT but got %d", len(reply.NFTs))
+	}
+	if nft := reply.NFTs[0]; nft.Payload != payload || nft.Metadata != nil {
+		t.Fatalf("expected the raw payload %s without metadata but got %+v", payload, nft)
+	}
+
+	linearize(t, vm)
+	if _, err := mint(); err == nil || !strings.Contains(err.Error(), "invalid metadata") {
+		t.Fatalf("expected invalid metadata to be rejected but got %v", err)
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/vms/components/djtx"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 var (
@@ -134,6 +136,23 @@ func (t *OperationTx) SemanticVerify(vm *VM, tx UnsignedTx, creds []verify.Verif
 
 	offset := t.BaseTx.NumCredentials()
 	for i, op := range t.Ops {
+		switch fxOp := op.Op.(type) {
+		case *secp256k1fx.BurnOperation, *secp256k1fx.RenounceMintOperation:
+			// Burning funds and renouncing minting are only enabled by the
+			// Apricot Phase 3 upgrade
+			if !vm.isApricotPhase3() {
+				return errNotApricotPhase3
+			}
+		case *nftfx.MintOperation:
+			// The metadata of NFTs is only enforced from the Apricot Phase 3
+			// upgrade, so that NFTs minted before it remain valid
+			if vm.isApricotPhase3() {
+				if err := nftfx.VerifyMetadata(fxOp.Payload); err != nil {
+					return err
+				}
+			}
+		}
+
 		cred := creds[offset+i]
 		if err := vm.verifyOperation(tx, op, cred); err != nil {
 			return err
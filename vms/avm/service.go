@@ -4,6 +4,7 @@
 package avm
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
@@ -16,9 +17,11 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/vms/components/djtx"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
@@ -43,10 +46,24 @@ var (
 	errSpendOverflow          = errors.New("spent amount overflows uint64")
 	errInvalidMintAmount      = errors.New("amount minted must be positive")
 	errAddressesCantMintAsset = errors.New("provided addresses don't have the authority to mint the provided asset")
+	errInvalidBurnAmount      = errors.New("amount burned must be positive")
 	errInvalidUTXO            = errors.New("invalid utxo")
 	errNilTxID                = errors.New("nil transaction ID")
 	errNoAddresses            = errors.New("no addresses provided")
 	errNoKeys                 = errors.New("from addresses have no keys or funds")
+	errNoChangeAddress        = errors.New("change address must be provided")
+	errIndexingDisabled       = errors.New("transaction indexing is disabled")
+	errAssetIndexingDisabled  = errors.New("asset indexing is disabled")
+	errNFTIndexingDisabled    = errors.New("NFT indexing is disabled")
+	errPayloadAndMetadata     = errors.New("only one of payload and metadata can be provided")
+	errInvalidCursor          = errors.New("invalid cursor")
+	errVestingHTLC            = errors.New("an output can't both vest and be locked in an HTLC")
+	errWrongHashLength        = errors.New("hash must be 32 bytes")
+	errNotHTLC                = errors.New("utxo isn't locked in an HTLC")
+	errCantSpendHTLC          = errors.New("provided keys can't spend the HTLC")
+	errNoPreimage             = errors.New("preimage must be provided to claim the HTLC")
+	errMemoNotEncrypted       = errors.New("memo isn't encrypted")
+	errCantDecryptMemo        = errors.New("the user's keys can't decrypt the memo")
 )
 
 // Service defines the base service for the asset vm
@@ -61,11 +78,14 @@ type FormattedAssetID struct {
 func (service *Service) IssueTx(r *http.Request, args *api.FormattedTx, reply *api.JSONTxID) error {
 	service.vm.ctx.Log.Debug("AVM: IssueTx called with %s", args.Tx)
 
-	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	tx, err := service.vm.parseFormattedTx(args.Encoding, args.Tx)
 	if err != nil {
-		return fmt.Errorf("problem decoding transaction: %w", err)
+		return err
+	}
+	if numMissing := numMissingSigs(tx); numMissing > 0 {
+		return fmt.Errorf("%w: %d signatures are missing", errMissingSignatures, numMissing)
 	}
-	txID, err := service.vm.IssueTx(txBytes)
+	txID, err := service.vm.IssueTx(tx.Bytes())
 	if err != nil {
 		return err
 	}
@@ -74,6 +94,18 @@ func (service *Service) IssueTx(r *http.Request, args *api.FormattedTx, reply *a
 	return nil
 }
 
+// SimulateTx verifies a transaction against the current state, as IssueTx
+// would, without issuing it
+func (service *Service) SimulateTx(r *http.Request, args *api.FormattedTx, reply *api.SimulateTxReply) error {
+	service.vm.ctx.Log.Debug("AVM: SimulateTx called with %s", args.Tx)
+
+	tx, err := service.vm.parseFormattedTx(args.Encoding, args.Tx)
+	if err != nil {
+		return err
+	}
+	return service.vm.simulateTx(tx, reply)
+}
+
 // GetTxStatusReply defines the GetTxStatus replies returned from the API
 type GetTxStatusReply struct {
 	Status choices.Status `json:"status"`
@@ -258,6 +290,49 @@ func (service *Service) GetAssetDescription(_ *http.Request, args *GetAssetDescr
 	return nil
 }
 
+// APIFeeAsset is an asset that can be burned in place of the fee asset to pay
+// fees. Numerator units of the asset pay for Denominator units of the fee.
+type APIFeeAsset struct {
+	AssetID     ids.ID      `json:"assetID"`
+	Numerator   json.Uint64 `json:"numerator"`
+	Denominator json.Uint64 `json:"denominator"`
+}
+
+// GetFeesReply defines the GetFees replies returned from the API
+type GetFeesReply struct {
+	FeeAssetID    ids.ID        `json:"feeAssetID"`
+	TxFee         json.Uint64   `json:"txFee"`
+	CreationTxFee json.Uint64   `json:"creationTxFee"`
+	FeeAssets     []APIFeeAsset `json:"feeAssets"`
+}
+
+// GetFees returns the fees of the transactions, in units of the fee asset, and
+// the other assets that can currently be burned to pay them, sorted by ID.
+func (service *Service) GetFees(_ *http.Request, _ *struct{}, reply *GetFeesReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetFees called")
+
+	reply.FeeAssetID = service.vm.feeAssetID
+	reply.TxFee = json.Uint64(service.vm.txFee)
+	reply.CreationTxFee = json.Uint64(service.vm.creationTxFee)
+
+	feeRates := service.vm.activeFeeRates()
+	assetIDs := make([]ids.ID, 0, len(feeRates))
+	for assetID := range feeRates {
+		assetIDs = append(assetIDs, assetID)
+	}
+	ids.SortIDs(assetIDs)
+	reply.FeeAssets = make([]APIFeeAsset, len(assetIDs))
+	for i, assetID := range assetIDs {
+		rate := feeRates[assetID]
+		reply.FeeAssets[i] = APIFeeAsset{
+			AssetID:     assetID,
+			Numerator:   json.Uint64(rate.Numerator),
+			Denominator: json.Uint64(rate.Denominator),
+		}
+	}
+	return nil
+}
+
 // GetBalanceArgs are arguments for passing into GetBalance requests
 type GetBalanceArgs struct {
 	Address        string `json:"address"`
@@ -267,7 +342,11 @@ type GetBalanceArgs struct {
 
 // GetBalanceReply defines the GetBalance replies returned from the API
 type GetBalanceReply struct {
-	Balance json.Uint64   `json:"balance"`
+	Balance json.Uint64 `json:"balance"`
+	// Part of [Balance] that can be spent now
+	Unlocked json.Uint64 `json:"unlocked"`
+	// Part of [Balance] that is locked by a locktime or a vesting schedule
+	Locked  json.Uint64   `json:"locked"`
 	UTXOIDs []djtx.UTXOID `json:"utxoIDs"`
 }
 
@@ -276,6 +355,7 @@ type GetBalanceReply struct {
 // (1 out of 1 multisig) by the address and with a locktime in the past.
 // Otherwise, returned balance includes assets held only partially by the
 // address, and includes balances with locktime in the future.
+// The funds of vesting outputs that are still locked are reported as locked.
 func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply *GetBalanceReply) error {
 	service.vm.ctx.Log.Debug("AVM: GetBalance called with address: %s assetID: %s", args.Address, args.AssetID)
 
@@ -303,20 +383,29 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 		if utxo.AssetID() != assetID {
 			continue
 		}
-		// TODO make this not specific to *secp256k1fx.TransferOutput
-		transferable, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		// TODO make this not specific to the secp256k1fx
+		owners, amount, locked, ok := lockedAmount(utxo.Out, now)
 		if !ok {
 			continue
 		}
-		owners := transferable.OutputOwners
 		if !args.IncludePartial && (len(owners.Addrs) != 1 || owners.Locktime > now) {
 			continue
 		}
-		amt, err := safemath.Add64(transferable.Amount(), uint64(reply.Balance))
+		amt, err := safemath.Add64(amount, uint64(reply.Balance))
 		if err != nil {
 			return err
 		}
 		reply.Balance = json.Uint64(amt)
+		lockedAmt, err := safemath.Add64(locked, uint64(reply.Locked))
+		if err != nil {
+			return err
+		}
+		reply.Locked = json.Uint64(lockedAmt)
+		unlockedAmt, err := safemath.Add64(amount-locked, uint64(reply.Unlocked))
+		if err != nil {
+			return err
+		}
+		reply.Unlocked = json.Uint64(unlockedAmt)
 		reply.UTXOIDs = append(reply.UTXOIDs, utxo.UTXOID)
 	}
 
@@ -326,6 +415,10 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 type Balance struct {
 	AssetID string      `json:"asset"`
 	Balance json.Uint64 `json:"balance"`
+	// Part of [Balance] that can be spent now
+	Unlocked json.Uint64 `json:"unlocked"`
+	// Part of [Balance] that is locked by a locktime or a vesting schedule
+	Locked json.Uint64 `json:"locked"`
 }
 
 type GetAllBalancesArgs struct {
@@ -362,40 +455,46 @@ func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs
 	now := service.vm.Clock().Unix()
 	assetIDs := ids.Set{}               // IDs of assets the address has a non-zero balance of
 	balances := make(map[ids.ID]uint64) // key: ID (as bytes). value: balance of that asset
+	lockedBalances := make(map[ids.ID]uint64)
 	for _, utxo := range utxos {
-		// TODO make this not specific to *secp256k1fx.TransferOutput
-		transferable, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		// TODO make this not specific to the secp256k1fx
+		owners, amount, locked, ok := lockedAmount(utxo.Out, now)
 		if !ok {
 			continue
 		}
-		owners := transferable.OutputOwners
 		if !args.IncludePartial && (len(owners.Addrs) != 1 || owners.Locktime > now) {
 			continue
 		}
 		assetID := utxo.AssetID()
 		assetIDs.Add(assetID)
 		balance := balances[assetID] // 0 if key doesn't exist
-		balance, err := safemath.Add64(transferable.Amount(), balance)
+		balance, err := safemath.Add64(amount, balance)
 		if err != nil {
 			balances[assetID] = math.MaxUint64
 		} else {
 			balances[assetID] = balance
 		}
+		lockedBalance, err := safemath.Add64(locked, lockedBalances[assetID])
+		if err != nil {
+			lockedBalances[assetID] = math.MaxUint64
+		} else {
+			lockedBalances[assetID] = lockedBalance
+		}
 	}
 
 	reply.Balances = make([]Balance, assetIDs.Len())
 	i := 0
 	for assetID := range assetIDs {
+		balance := balances[assetID]
+		locked := safemath.Min64(lockedBalances[assetID], balance)
+		reply.Balances[i] = Balance{
+			AssetID:  assetID.String(),
+			Balance:  json.Uint64(balance),
+			Unlocked: json.Uint64(balance - locked),
+			Locked:   json.Uint64(locked),
+		}
 		if alias, err := service.vm.PrimaryAlias(assetID); err == nil {
-			reply.Balances[i] = Balance{
-				AssetID: alias,
-				Balance: json.Uint64(balances[assetID]),
-			}
-		} else {
-			reply.Balances[i] = Balance{
-				AssetID: assetID.String(),
-				Balance: json.Uint64(balances[assetID]),
-			}
+			reply.Balances[i].AssetID = alias
 		}
 		i++
 	}
@@ -403,6 +502,220 @@ func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs
 	return nil
 }
 
+// GetAddressTxsArgs are arguments for passing into GetAddressTxs requests
+type GetAddressTxsArgs struct {
+	Address string `json:"address"`
+	AssetID string `json:"assetID"`
+	// Index of the first transaction to return
+	Cursor json.Uint64 `json:"cursor"`
+	// Max number of transactions to return. Defaults to, and is capped at,
+	// [maxAddressTxsPageSize].
+	PageSize json.Uint64 `json:"pageSize"`
+}
+
+// AddressTx is a transaction that moved funds of an asset to or from an
+// address
+type AddressTx struct {
+	TxID ids.ID `json:"txID"`
+	// Amount of the asset the address received in the transaction
+	In json.Uint64 `json:"in"`
+	// Amount of the asset the address spent in the transaction
+	Out json.Uint64 `json:"out"`
+}
+
+// GetAddressTxsReply defines the GetAddressTxs replies returned from the API
+type GetAddressTxsReply struct {
+	Txs []AddressTx `json:"txs"`
+	// Cursor to pass in to get the next page of transactions
+	Cursor json.Uint64 `json:"cursor"`
+}
+
+// GetAddressTxs returns the accepted transactions that moved funds of an asset
+// to or from an address. Requires the transactions to be indexed.
+//
+// The transactions accepted while the index is enabled are returned in the
+// order they were accepted. The transactions accepted before the index was
+// enabled are returned in an order that respects their dependencies, which
+// may differ from the order they were accepted in.
+func (service *Service) GetAddressTxs(r *http.Request, args *GetAddressTxsArgs, reply *GetAddressTxsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetAddressTxs called with address: %s assetID: %s cursor: %d pageSize: %d",
+		args.Address, args.AssetID, args.Cursor, args.PageSize)
+
+	if service.vm.addressTxs == nil {
+		return errIndexingDisabled
+	}
+
+	addr, err := service.vm.ParseLocalAddress(args.Address)
+	if err != nil {
+		return fmt.Errorf("problem parsing address '%s': %w", args.Address, err)
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	pageSize := uint64(args.PageSize)
+	if pageSize == 0 || pageSize > maxAddressTxsPageSize {
+		pageSize = maxAddressTxsPageSize
+	}
+
+	txs, cursor, err := service.vm.addressTxs.read(addr, assetID, uint64(args.Cursor), pageSize)
+	if err != nil {
+		return fmt.Errorf("problem reading the transactions of %s: %w", args.Address, err)
+	}
+
+	reply.Txs = make([]AddressTx, len(txs))
+	for i, tx := range txs {
+		reply.Txs[i] = AddressTx{
+			TxID: tx.TxID,
+			In:   json.Uint64(tx.In),
+			Out:  json.Uint64(tx.Out),
+		}
+	}
+	reply.Cursor = json.Uint64(cursor)
+	return nil
+}
+
+// GetAssetStatsArgs are arguments for passing into GetAssetStats
+type GetAssetStatsArgs struct {
+	AssetID string `json:"assetID"`
+}
+
+// GetAssetStatsReply defines the GetAssetStats replies returned from the API
+type GetAssetStatsReply struct {
+	// Amount of the asset that has been created
+	Minted json.Uint64 `json:"minted"`
+	// Amount of the asset that has been destroyed
+	Burned json.Uint64 `json:"burned"`
+	// Amount of the asset that exists. Funds exported to another chain still
+	// count as circulating, as they can be imported back.
+	Circulating json.Uint64 `json:"circulating"`
+	// Number of addresses that own some of the asset. A UTXO with several
+	// owners counts once for each of its owners.
+	Holders json.Uint64 `json:"holders"`
+	// The sets of addresses that can currently mint the asset
+	MinterSets []Owners `json:"minterSets"`
+}
+
+// GetAssetStats returns the supply, number of holders and current minter sets
+// of an asset. Requires the assets to be indexed.
+func (service *Service) GetAssetStats(r *http.Request, args *GetAssetStatsArgs, reply *GetAssetStatsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetAssetStats called with assetID: %s", args.AssetID)
+
+	if service.vm.assetStats == nil {
+		return errAssetIndexingDisabled
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	stats, err := service.vm.assetStats.stats(assetID)
+	if err != nil {
+		return fmt.Errorf("problem reading the stats of %s: %w", assetID, err)
+	}
+	utxoIDs, err := service.vm.assetStats.minters(assetID)
+	if err != nil {
+		return fmt.Errorf("problem reading the minters of %s: %w", assetID, err)
+	}
+
+	reply.Minted = json.Uint64(stats.Minted)
+	reply.Burned = json.Uint64(stats.Burned)
+	reply.Circulating = json.Uint64(stats.Minted - stats.Burned)
+	reply.Holders = json.Uint64(stats.Holders)
+	reply.MinterSets = make([]Owners, 0, len(utxoIDs))
+	for _, utxoID := range utxoIDs {
+		utxo, err := service.vm.state.GetUTXO(utxoID)
+		if err != nil {
+			return fmt.Errorf("problem reading mint output %s: %w", utxoID, err)
+		}
+		owners, ok := mintOwners(utxo.Out)
+		if !ok {
+			return errUnknownOutputType
+		}
+		minters := make([]string, len(owners.Addrs))
+		for i, addr := range owners.Addrs {
+			minters[i], err = service.vm.FormatLocalAddress(addr)
+			if err != nil {
+				return err
+			}
+		}
+		reply.MinterSets = append(reply.MinterSets, Owners{
+			Threshold: json.Uint32(owners.Threshold),
+			Minters:   minters,
+		})
+	}
+	return nil
+}
+
+// GetAssetHoldersArgs are arguments for passing into GetAssetHolders
+type GetAssetHoldersArgs struct {
+	AssetID string `json:"assetID"`
+	// Cursor returned by the previous call, or empty to start at the largest
+	// holder
+	Cursor string `json:"cursor"`
+	// Max number of holders to return. Defaults to, and is at most, 1024.
+	PageSize json.Uint64 `json:"pageSize"`
+}
+
+// GetAssetHoldersReply defines the GetAssetHolders replies returned from the
+// API
+type GetAssetHoldersReply struct {
+	Holders []Holder `json:"holders"`
+	// Cursor to pass in to get the next page of holders. Empty if there are
+	// no more holders.
+	Cursor string `json:"cursor"`
+}
+
+// GetAssetHolders returns the addresses that own an asset and their balances,
+// by decreasing balance. A UTXO with several owners counts towards the balance
+// of each of them, so the balances may add up to more than the circulating
+// supply. Requires the assets to be indexed.
+func (service *Service) GetAssetHolders(r *http.Request, args *GetAssetHoldersArgs, reply *GetAssetHoldersReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetAssetHolders called with assetID: %s cursor: %s pageSize: %d",
+		args.AssetID, args.Cursor, args.PageSize)
+
+	if service.vm.assetStats == nil {
+		return errAssetIndexingDisabled
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	start, err := hex.DecodeString(args.Cursor)
+	if err != nil || (len(start) != 0 && len(start) != assetHolderKeySize) {
+		return fmt.Errorf("%w: %q", errInvalidCursor, args.Cursor)
+	}
+
+	pageSize := int(args.PageSize)
+	if pageSize <= 0 || pageSize > maxAssetHoldersPageSize {
+		pageSize = maxAssetHoldersPageSize
+	}
+
+	addrs, balances, next, err := service.vm.assetStats.holders(assetID, start, pageSize)
+	if err != nil {
+		return fmt.Errorf("problem reading the holders of %s: %w", assetID, err)
+	}
+
+	reply.Holders = make([]Holder, len(addrs))
+	for i, addr := range addrs {
+		addrStr, err := service.vm.FormatLocalAddress(addr)
+		if err != nil {
+			return err
+		}
+		reply.Holders[i] = Holder{
+			Amount:  json.Uint64(balances[i]),
+			Address: addrStr,
+		}
+	}
+	reply.Cursor = hex.EncodeToString(next)
+	return nil
+}
+
 // Holder describes how much an address owns of an asset
 type Holder struct {
 	Amount  json.Uint64 `json:"amount"`
@@ -815,6 +1128,77 @@ func (service *Service) ExportKey(r *http.Request, args *ExportKeyArgs, reply *E
 	return db.Close()
 }
 
+// DecryptMemoArgs are arguments for DecryptMemo
+type DecryptMemoArgs struct {
+	api.UserPass
+	// ID of the transaction whose memo is decrypted
+	TxID ids.ID `json:"txID"`
+	// Memo to decrypt if no transaction ID is provided, which allows the memos
+	// of transactions on other chains to be decrypted
+	Memo     string              `json:"memo"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// DecryptMemoReply is the response from DecryptMemo
+type DecryptMemoReply struct {
+	// The decrypted memo
+	Memo string `json:"memo"`
+	// Address whose key decrypted the memo
+	Address string `json:"address"`
+}
+
+// DecryptMemo decrypts an encrypted memo with the key of one of the user's
+// addresses
+func (service *Service) DecryptMemo(r *http.Request, args *DecryptMemoArgs, reply *DecryptMemoReply) error {
+	service.vm.ctx.Log.Debug("AVM: DecryptMemo called for user %q", args.Username)
+
+	var memo []byte
+	if args.TxID != ids.Empty {
+		tx := UniqueTx{
+			vm:   service.vm,
+			txID: args.TxID,
+		}
+		if status := tx.Status(); !status.Fetched() {
+			return errUnknownTx
+		}
+		memo = tx.UnsignedTx.memo()
+	} else {
+		var err error
+		memo, err = formatting.Decode(args.Encoding, args.Memo)
+		if err != nil {
+			return fmt.Errorf("problem decoding memo: %w", err)
+		}
+	}
+	if !crypto.IsEncryptedMemo(memo) {
+		return errMemoNotEncrypted
+	}
+
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+	}
+	defer db.Close()
+
+	user := userState{vm: service.vm}
+	kc, err := user.Keychain(db, ids.ShortSet{})
+	if err != nil {
+		return err
+	}
+	for _, key := range kc.Keys {
+		plaintext, err := crypto.DecryptMemo(key, memo)
+		if err != nil {
+			continue
+		}
+		reply.Memo = string(plaintext)
+		reply.Address, err = service.vm.FormatLocalAddress(key.PublicKey().Address())
+		if err != nil {
+			return err
+		}
+		return db.Close()
+	}
+	return errCantDecryptMemo
+}
+
 // ImportKeyArgs are arguments for ImportKey
 type ImportKeyArgs struct {
 	api.UserPass
@@ -893,6 +1277,40 @@ type SendOutput struct {
 
 	// Address of the recipient
 	To string `json:"to"`
+
+	// If provided, the funds are unlocked by this schedule
+	Vesting *VestingArgs `json:"vesting,omitempty"`
+
+	// If provided, the funds are locked in a hash time-locked contract that
+	// [To] can claim
+	HTLC *HTLCArgs `json:"htlc,omitempty"`
+}
+
+// VestingArgs describes a schedule that unlocks the funds of an output
+// linearly over [NumPeriods] periods that start at [StartTime]. No funds are
+// unlocked before [StartTime] + [Cliff].
+type VestingArgs struct {
+	// Unix time that the first period starts at
+	StartTime json.Uint64 `json:"startTime"`
+	// Number of seconds after [StartTime] before any funds are unlocked
+	Cliff json.Uint64 `json:"cliff"`
+	// Number of seconds each period lasts
+	PeriodDuration json.Uint64 `json:"periodDuration"`
+	// Number of periods the funds are unlocked over
+	NumPeriods json.Uint32 `json:"numPeriods"`
+}
+
+// HTLCArgs describes a hash time-locked contract. The funds can be claimed by
+// revealing a preimage of [Hash] before [Deadline], and can be refunded to
+// [RefundAddr] from [Deadline] on.
+type HTLCArgs struct {
+	// SHA-256 hash of the preimage, encoded with [Encoding]
+	Hash     string              `json:"hash"`
+	Encoding formatting.Encoding `json:"encoding"`
+	// Unix time from which the funds can be refunded
+	Deadline json.Uint64 `json:"deadline"`
+	// Address the funds can be refunded to
+	RefundAddr string `json:"refundAddr"`
 }
 
 // SendArgs are arguments for passing into Send requests
@@ -903,8 +1321,7 @@ type SendArgs struct {
 	// The amount, assetID, and destination to send funds to
 	SendOutput
 
-	// Memo field
-	Memo string `json:"memo"`
+	api.JSONMemo
 }
 
 // SendMultipleArgs are arguments for passing into SendMultiple requests
@@ -915,8 +1332,7 @@ type SendMultipleArgs struct {
 	// The outputs of the transaction
 	Outputs []SendOutput `json:"outputs"`
 
-	// Memo field
-	Memo string `json:"memo"`
+	api.JSONMemo
 }
 
 // Send returns the ID of the newly created transaction
@@ -924,7 +1340,7 @@ func (service *Service) Send(r *http.Request, args *SendArgs, reply *api.JSONTxI
 	return service.SendMultiple(r, &SendMultipleArgs{
 		JSONSpendHeader: args.JSONSpendHeader,
 		Outputs:         []SendOutput{args.SendOutput},
-		Memo:            args.Memo,
+		JSONMemo:        args.JSONMemo,
 	}, reply)
 }
 
@@ -932,14 +1348,16 @@ func (service *Service) Send(r *http.Request, args *SendArgs, reply *api.JSONTxI
 func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, reply *api.JSONTxIDChangeAddr) error {
 	service.vm.ctx.Log.Debug("AVM: SendMultiple called with username: %s", args.Username)
 
-	// Validate the memo field
-	memoBytes := []byte(args.Memo)
-	if l := len(memoBytes); l > djtx.MaxMemoSize {
-		return fmt.Errorf("max memo length is %d but provided memo field is length %d", djtx.MaxMemoSize, l)
-	} else if len(args.Outputs) == 0 {
+	if len(args.Outputs) == 0 {
 		return errNoOutputs
 	}
 
+	// Validate the memo field
+	memoBytes, err := service.vm.parseMemo(args.Memo, args.MemoPublicKey, args.Outputs)
+	if err != nil {
+		return err
+	}
+
 	// Parse the from addresses
 	fromAddrs := ids.ShortSet{}
 	for _, addrStr := range args.From {
@@ -965,6 +1383,33 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		return err
 	}
 
+	tx, err := service.buildSendMultipleTx(utxos, kc, args.Outputs, memoBytes, changeAddr)
+	if err != nil {
+		return err
+	}
+
+	txID, err := service.vm.IssueTx(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
+// buildSendMultipleTx returns a tx that spends [utxos] with [kc] to send
+// [outputs]. If [kc] doesn't hold the key of an address that must sign, its
+// signature is left empty.
+func (service *Service) buildSendMultipleTx(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	outputs []SendOutput,
+	memo []byte,
+	changeAddr ids.ShortID,
+) (*Tx, error) {
+	var err error
+
 	// Calculate required input amounts and create the desired outputs
 	// String repr. of asset ID --> asset ID
 	assetIDs := make(map[string]ids.ID)
@@ -972,64 +1417,83 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 	amounts := make(map[ids.ID]uint64)
 	// Outputs of our tx
 	outs := []*djtx.TransferableOutput{}
-	for _, output := range args.Outputs {
+	for _, output := range outputs {
 		if output.Amount == 0 {
-			return errZeroAmount
+			return nil, errZeroAmount
 		}
 		assetID, ok := assetIDs[output.AssetID] // Asset ID of next output
 		if !ok {
 			assetID, err = service.vm.lookupAssetID(output.AssetID)
 			if err != nil {
-				return fmt.Errorf("couldn't find asset %s", output.AssetID)
+				return nil, fmt.Errorf("couldn't find asset %s", output.AssetID)
 			}
 			assetIDs[output.AssetID] = assetID
 		}
 		currentAmount := amounts[assetID]
 		newAmount, err := safemath.Add64(currentAmount, uint64(output.Amount))
 		if err != nil {
-			return fmt.Errorf("problem calculating required spend amount: %w", err)
+			return nil, fmt.Errorf("problem calculating required spend amount: %w", err)
 		}
 		amounts[assetID] = newAmount
 
 		// Parse the to address
 		to, err := service.vm.ParseLocalAddress(output.To)
 		if err != nil {
-			return fmt.Errorf("problem parsing to address %q: %w", output.To, err)
+			return nil, fmt.Errorf("problem parsing to address %q: %w", output.To, err)
 		}
 
 		// Create the Output
+		transferOut := secp256k1fx.TransferOutput{
+			Amt: uint64(output.Amount),
+			OutputOwners: secp256k1fx.OutputOwners{
+				Locktime:  0,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{to},
+			},
+		}
+		var out djtx.TransferableOut = &transferOut
+		switch {
+		case output.Vesting != nil && output.HTLC != nil:
+			return nil, errVestingHTLC
+		case output.HTLC != nil:
+			htlcOut, err := service.parseHTLCOutput(output.HTLC, uint64(output.Amount), to)
+			if err != nil {
+				return nil, err
+			}
+			out = htlcOut
+		case output.Vesting != nil:
+			vestingOut := &secp256k1fx.VestingOutput{
+				VestingSchedule: secp256k1fx.VestingSchedule{
+					StartTime:      uint64(output.Vesting.StartTime),
+					Cliff:          uint64(output.Vesting.Cliff),
+					PeriodDuration: uint64(output.Vesting.PeriodDuration),
+					NumPeriods:     uint32(output.Vesting.NumPeriods),
+					TotalAmount:    uint64(output.Amount),
+				},
+				TransferOutput: transferOut,
+			}
+			if err := vestingOut.Verify(); err != nil {
+				return nil, fmt.Errorf("invalid vesting schedule: %w", err)
+			}
+			out = vestingOut
+		}
 		outs = append(outs, &djtx.TransferableOutput{
 			Asset: djtx.Asset{ID: assetID},
-			Out: &secp256k1fx.TransferOutput{
-				Amt: uint64(output.Amount),
-				OutputOwners: secp256k1fx.OutputOwners{
-					Locktime:  0,
-					Threshold: 1,
-					Addrs:     []ids.ShortID{to},
-				},
-			},
+			Out:   out,
 		})
 	}
 
-	amountsWithFee := make(map[ids.ID]uint64, len(amounts)+1)
-	for assetID, amount := range amounts {
-		amountsWithFee[assetID] = amount
-	}
-
-	amountWithFee, err := safemath.Add64(amounts[service.vm.feeAssetID], service.vm.txFee)
-	if err != nil {
-		return fmt.Errorf("problem calculating required spend amount: %w", err)
-	}
-	amountsWithFee[service.vm.feeAssetID] = amountWithFee
-
-	amountsSpent, ins, keys, err := service.vm.Spend(
+	amountsWithFee, amountsSpent, ins, relockOuts, keys, err := service.vm.spendWithFee(
 		utxos,
 		kc,
-		amountsWithFee,
+		amounts,
+		service.vm.txFee,
+		true,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	outs = append(outs, relockOuts...)
 
 	// Add the required change outputs
 	for assetID, amountWithFee := range amountsWithFee {
@@ -1051,51 +1515,497 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 	}
 	djtx.SortTransferableOutputs(outs, service.vm.codec)
 
-	tx := Tx{UnsignedTx: &BaseTx{BaseTx: djtx.BaseTx{
+	tx := &Tx{UnsignedTx: &BaseTx{BaseTx: djtx.BaseTx{
 		NetworkID:    service.vm.ctx.NetworkID,
 		BlockchainID: service.vm.ctx.ChainID,
 		Outs:         outs,
 		Ins:          ins,
-		Memo:         memoBytes,
+		Memo:         memo,
 	}}}
-	if err := tx.SignSECP256K1Fx(service.vm.codec, keys); err != nil {
-		return err
+	return tx, tx.SignSECP256K1Fx(service.vm.codec, keys)
+}
+
+// parseHTLCOutput returns an output that locks [amount] in the hash time-locked
+// contract described by [args], that [to] can claim.
+func (service *Service) parseHTLCOutput(args *HTLCArgs, amount uint64, to ids.ShortID) (*htlcfx.TransferOutput, error) {
+	if _, err := service.vm.getFx(&htlcfx.TransferOutput{}); err != nil {
+		return nil, fmt.Errorf("htlcfx isn't supported by this chain: %w", err)
 	}
 
-	txID, err := service.vm.IssueTx(tx.Bytes())
+	hash, err := formatting.Decode(args.Encoding, args.Hash)
 	if err != nil {
-		return fmt.Errorf("problem issuing transaction: %w", err)
+		return nil, fmt.Errorf("problem decoding hash: %w", err)
 	}
-
-	reply.TxID = txID
-	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
-	return err
-}
-
-// MintArgs are arguments for passing into Mint requests
-type MintArgs struct {
-	api.JSONSpendHeader             // User, password, from addrs, change addr
-	Amount              json.Uint64 `json:"amount"`
-	AssetID             string      `json:"assetID"`
-	To                  string      `json:"to"`
-}
-
-// Mint issues a transaction that mints more of the asset
-func (service *Service) Mint(r *http.Request, args *MintArgs, reply *api.JSONTxIDChangeAddr) error {
-	service.vm.ctx.Log.Debug("AVM: Mint called with username: %s", args.Username)
-
-	if args.Amount == 0 {
-		return errInvalidMintAmount
+	if len(hash) != hashing.HashLen {
+		return nil, errWrongHashLength
 	}
 
-	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	refundAddr, err := service.vm.ParseLocalAddress(args.RefundAddr)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("problem parsing refund address %q: %w", args.RefundAddr, err)
 	}
 
-	to, err := service.vm.ParseLocalAddress(args.To)
-	if err != nil {
-		return fmt.Errorf("problem parsing to address %q: %w", args.To, err)
+	out := &htlcfx.TransferOutput{
+		Amt:      amount,
+		Deadline: uint64(args.Deadline),
+		Receivers: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{to},
+		},
+		Refund: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{refundAddr},
+		},
+	}
+	copy(out.Hash[:], hash)
+	if err := out.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid HTLC: %w", err)
+	}
+	return out, nil
+}
+
+// CreateUnsignedTxArgs are the arguments to CreateUnsignedTx
+type CreateUnsignedTxArgs struct {
+	// Addresses that fund the tx. Their keys don't need to be held by this
+	// node.
+	api.JSONFromAddrs
+	// Address to send change to
+	api.JSONChangeAddr
+
+	// The outputs of the transaction
+	Outputs []SendOutput `json:"outputs"`
+
+	// Memo field
+	Memo string `json:"memo"`
+
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// CreateUnsignedTxReply is the response from CreateUnsignedTx
+type CreateUnsignedTxReply struct {
+	// Tx with all of its signatures left empty
+	api.FormattedTx
+	// Addresses that must sign each credential of the tx
+	Signers [][]string `json:"signers"`
+}
+
+// CreateUnsignedTx creates a tx with multiple outputs that spends UTXOs of the
+// provided addresses without signing it. The returned tx is signed by each
+// party with SignTx and the results are combined with CombineSignatures.
+func (service *Service) CreateUnsignedTx(_ *http.Request, args *CreateUnsignedTxArgs, reply *CreateUnsignedTxReply) error {
+	service.vm.ctx.Log.Debug("AVM: CreateUnsignedTx called")
+
+	// Validate the memo field
+	memoBytes := []byte(args.Memo)
+	if l := len(memoBytes); l > djtx.MaxMemoSize {
+		return fmt.Errorf("max memo length is %d but provided memo field is length %d", djtx.MaxMemoSize, l)
+	} else if len(args.Outputs) == 0 {
+		return errNoOutputs
+	} else if len(args.From) == 0 {
+		return errNoAddresses
+	}
+
+	kc := secp256k1fx.NewKeychain()
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'From' address %s: %w", addrStr, err)
+		}
+		kc.AddAddress(addr)
+	}
+
+	if args.ChangeAddr == "" {
+		return errNoChangeAddress
+	}
+	changeAddr, err := service.vm.ParseLocalAddress(args.ChangeAddr)
+	if err != nil {
+		return fmt.Errorf("couldn't parse changeAddr: %w", err)
+	}
+
+	utxos, err := service.vm.getAllUTXOs(kc.Addresses())
+	if err != nil {
+		return fmt.Errorf("problem retrieving UTXOs: %w", err)
+	}
+
+	tx, err := service.buildSendMultipleTx(utxos, kc, args.Outputs, memoBytes, changeAddr)
+	if err != nil {
+		return err
+	}
+
+	signers, err := service.vm.credentialSigners(tx.UnsignedTx)
+	if err != nil {
+		return fmt.Errorf("couldn't get signers of tx: %w", err)
+	}
+	reply.Signers = make([][]string, len(signers))
+	for i, credSigners := range signers {
+		reply.Signers[i] = make([]string, len(credSigners))
+		for j, signer := range credSigners {
+			reply.Signers[i][j], err = service.vm.FormatLocalAddress(signer)
+			if err != nil {
+				return fmt.Errorf("couldn't format address %s: %w", signer, err)
+			}
+		}
+	}
+
+	reply.Tx, err = formatting.Encode(args.Encoding, tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode tx as string: %w", err)
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+// SignTxArgs are the arguments to SignTx
+type SignTxArgs struct {
+	api.UserPass
+	// Partially signed tx
+	api.FormattedTx
+}
+
+// SignTxReply is the response from SignTx
+type SignTxReply struct {
+	api.FormattedTx
+	// Number of signatures added by the user
+	SignaturesAdded json.Uint32 `json:"signaturesAdded"`
+	// Number of signatures that are still missing from the tx
+	SignaturesMissing json.Uint32 `json:"signaturesMissing"`
+}
+
+// SignTx adds the signatures of a partially signed tx that can be produced by
+// the keys of the user
+func (service *Service) SignTx(_ *http.Request, args *SignTxArgs, reply *SignTxReply) error {
+	service.vm.ctx.Log.Debug("AVM: SignTx called with username: %s", args.Username)
+
+	tx, err := service.vm.parseFormattedTx(args.Encoding, args.Tx)
+	if err != nil {
+		return err
+	}
+
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+	}
+	// Drop any potential error closing the database to report the original
+	// error
+	defer db.Close()
+
+	user := userState{vm: service.vm}
+	kc, err := user.Keychain(db, ids.ShortSet{})
+	if err != nil {
+		return err
+	}
+
+	numSigned, err := service.vm.signTx(tx, kc)
+	if err != nil {
+		return fmt.Errorf("couldn't sign tx: %w", err)
+	}
+
+	reply.Tx, err = formatting.Encode(args.Encoding, tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode tx as string: %w", err)
+	}
+	reply.Encoding = args.Encoding
+	reply.SignaturesAdded = json.Uint32(numSigned)
+	reply.SignaturesMissing = json.Uint32(numMissingSigs(tx))
+	return db.Close()
+}
+
+// CombineSignaturesArgs are the arguments to CombineSignatures
+type CombineSignaturesArgs struct {
+	// Partially signed versions of the same tx
+	Txs      []string            `json:"txs"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// CombineSignaturesReply is the response from CombineSignatures
+type CombineSignaturesReply struct {
+	api.FormattedTx
+	// Number of signatures that are still missing from the tx
+	SignaturesMissing json.Uint32 `json:"signaturesMissing"`
+}
+
+// CombineSignatures merges the signatures of partially signed versions of the
+// same tx
+func (service *Service) CombineSignatures(_ *http.Request, args *CombineSignaturesArgs, reply *CombineSignaturesReply) error {
+	service.vm.ctx.Log.Debug("AVM: CombineSignatures called")
+
+	txs := make([]*Tx, len(args.Txs))
+	for i, txStr := range args.Txs {
+		tx, err := service.vm.parseFormattedTx(args.Encoding, txStr)
+		if err != nil {
+			return err
+		}
+		txs[i] = tx
+	}
+
+	tx, err := service.vm.combineTxs(txs)
+	if err != nil {
+		return fmt.Errorf("couldn't combine signatures: %w", err)
+	}
+
+	reply.Tx, err = formatting.Encode(args.Encoding, tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode tx as string: %w", err)
+	}
+	reply.Encoding = args.Encoding
+	reply.SignaturesMissing = json.Uint32(numMissingSigs(tx))
+	return nil
+}
+
+// MintArgs are arguments for passing into Mint requests
+type MintArgs struct {
+	api.JSONSpendHeader             // User, password, from addrs, change addr
+	Amount              json.Uint64 `json:"amount"`
+	AssetID             string      `json:"assetID"`
+	To                  string      `json:"to"`
+}
+
+// Mint issues a transaction that mints more of the asset
+func (service *Service) Mint(r *http.Request, args *MintArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("AVM: Mint called with username: %s", args.Username)
+
+	if args.Amount == 0 {
+		return errInvalidMintAmount
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	to, err := service.vm.ParseLocalAddress(args.To)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address %q: %w", args.To, err)
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// Get the UTXOs/keys for the from addresses
+	feeUTXOs, feeKc, err := service.vm.LoadUser(args.Username, args.Password, fromAddrs)
+	if err != nil {
+		return err
+	}
+
+	// Parse the change address.
+	if len(feeKc.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr, err := service.vm.selectChangeAddr(feeKc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	amountsSpent, ins, keys, err := service.vm.Spend(
+		feeUTXOs,
+		feeKc,
+		map[ids.ID]uint64{
+			service.vm.feeAssetID: service.vm.txFee,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	outs := []*djtx.TransferableOutput{}
+	if amountSpent := amountsSpent[service.vm.feeAssetID]; amountSpent > service.vm.txFee {
+		outs = append(outs, &djtx.TransferableOutput{
+			Asset: djtx.Asset{ID: service.vm.feeAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amountSpent - service.vm.txFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  0,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{changeAddr},
+				},
+			},
+		})
+	}
+
+	// Get all UTXOs/keys for the user
+	utxos, kc, err := service.vm.LoadUser(args.Username, args.Password, nil)
+	if err != nil {
+		return err
+	}
+
+	ops, opKeys, err := service.vm.Mint(
+		utxos,
+		kc,
+		map[ids.ID]uint64{
+			assetID: uint64(args.Amount),
+		},
+		to,
+	)
+	if err != nil {
+		return err
+	}
+	keys = append(keys, opKeys...)
+
+	tx := Tx{UnsignedTx: &OperationTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    service.vm.ctx.NetworkID,
+			BlockchainID: service.vm.ctx.ChainID,
+			Outs:         outs,
+			Ins:          ins,
+		}},
+		Ops: ops,
+	}}
+	if err := tx.SignSECP256K1Fx(service.vm.codec, keys); err != nil {
+		return err
+	}
+
+	txID, err := service.vm.IssueTx(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
+// BurnArgs are arguments for passing into Burn requests
+type BurnArgs struct {
+	api.JSONSpendHeader             // User, password, from addrs, change addr
+	Amount              json.Uint64 `json:"amount"`
+	AssetID             string      `json:"assetID"`
+}
+
+// Burn issues a transaction that destroys some of the asset
+func (service *Service) Burn(r *http.Request, args *BurnArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("AVM: Burn called with username: %s", args.Username)
+
+	if args.Amount == 0 {
+		return errInvalidBurnAmount
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// Get the UTXOs/keys for the from addresses
+	utxos, kc, err := service.vm.LoadUser(args.Username, args.Password, fromAddrs)
+	if err != nil {
+		return err
+	}
+
+	// Parse the change address.
+	if len(kc.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr, err := service.vm.selectChangeAddr(kc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	ops, opKeys, err := service.vm.Burn(
+		utxos,
+		kc,
+		assetID,
+		uint64(args.Amount),
+		changeAddr,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The fee is paid with the UTXOs that aren't burned
+	burnedUTXOs := ids.Set{}
+	for _, op := range ops {
+		for _, utxoID := range op.UTXOIDs {
+			burnedUTXOs.Add(utxoID.InputID())
+		}
+	}
+	feeUTXOs := make([]*djtx.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		if !burnedUTXOs.Contains(utxo.InputID()) {
+			feeUTXOs = append(feeUTXOs, utxo)
+		}
+	}
+
+	amountsSpent, ins, keys, err := service.vm.Spend(
+		feeUTXOs,
+		kc,
+		map[ids.ID]uint64{
+			service.vm.feeAssetID: service.vm.txFee,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	keys = append(keys, opKeys...)
+
+	outs := []*djtx.TransferableOutput{}
+	if amountSpent := amountsSpent[service.vm.feeAssetID]; amountSpent > service.vm.txFee {
+		outs = append(outs, &djtx.TransferableOutput{
+			Asset: djtx.Asset{ID: service.vm.feeAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amountSpent - service.vm.txFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  0,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{changeAddr},
+				},
+			},
+		})
+	}
+
+	tx := Tx{UnsignedTx: &OperationTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    service.vm.ctx.NetworkID,
+			BlockchainID: service.vm.ctx.ChainID,
+			Outs:         outs,
+			Ins:          ins,
+		}},
+		Ops: ops,
+	}}
+	if err := tx.SignSECP256K1Fx(service.vm.codec, keys); err != nil {
+		return err
+	}
+
+	txID, err := service.vm.IssueTx(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
+// RenounceMintingArgs are arguments for passing into RenounceMinting requests
+type RenounceMintingArgs struct {
+	api.JSONSpendHeader        // User, password, from addrs, change addr
+	AssetID             string `json:"assetID"`
+}
+
+// RenounceMinting issues a transaction that consumes all of the user's mint
+// outputs of the asset. Once every mint output of the asset is consumed, the
+// supply of the asset can never grow.
+func (service *Service) RenounceMinting(r *http.Request, args *RenounceMintingArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("AVM: RenounceMinting called with username: %s", args.Username)
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
 	}
 
 	// Parse the from addresses
@@ -1155,14 +2065,7 @@ func (service *Service) Mint(r *http.Request, args *MintArgs, reply *api.JSONTxI
 		return err
 	}
 
-	ops, opKeys, err := service.vm.Mint(
-		utxos,
-		kc,
-		map[ids.ID]uint64{
-			assetID: uint64(args.Amount),
-		},
-		to,
-	)
+	ops, opKeys, err := service.vm.RenounceMinting(utxos, kc, assetID)
 	if err != nil {
 		return err
 	}
@@ -1303,6 +2206,382 @@ func (service *Service) SendNFT(r *http.Request, args *SendNFTArgs, reply *api.J
 	return err
 }
 
+// SpendHTLCArgs are arguments for passing into ClaimHTLC and RefundHTLC
+// requests
+type SpendHTLCArgs struct {
+	api.JSONSpendHeader // User, password, from addrs, change addr
+	// ID of the transaction that created the UTXO locked in the HTLC
+	TxID ids.ID `json:"txID"`
+	// Index of the UTXO locked in the HTLC in the outputs of [TxID]
+	OutputIndex json.Uint32 `json:"outputIndex"`
+	// Preimage of the hash of the HTLC, encoded with [Encoding]. Only used by
+	// ClaimHTLC.
+	Preimage string              `json:"preimage"`
+	Encoding formatting.Encoding `json:"encoding"`
+	// Address the funds are sent to
+	To string `json:"to"`
+}
+
+// ClaimHTLC claims the funds locked in an HTLC by revealing the preimage of its
+// hash
+func (service *Service) ClaimHTLC(_ *http.Request, args *SpendHTLCArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("AVM: ClaimHTLC called with username: %s", args.Username)
+
+	preimage, err := formatting.Decode(args.Encoding, args.Preimage)
+	if err != nil {
+		return fmt.Errorf("problem decoding preimage: %w", err)
+	}
+	if len(preimage) == 0 {
+		return errNoPreimage
+	}
+	return service.spendHTLC(args, preimage, reply)
+}
+
+// RefundHTLC refunds the funds locked in an HTLC whose deadline has passed
+func (service *Service) RefundHTLC(_ *http.Request, args *SpendHTLCArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("AVM: RefundHTLC called with username: %s", args.Username)
+
+	return service.spendHTLC(args, nil, reply)
+}
+
+func (service *Service) spendHTLC(args *SpendHTLCArgs, preimage []byte, reply *api.JSONTxIDChangeAddr) error {
+	// Parse the to address
+	to, err := service.vm.ParseLocalAddress(args.To)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address %q: %w", args.To, err)
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// Get the UTXOs/keys for the from addresses
+	utxos, kc, err := service.vm.LoadUser(args.Username, args.Password, fromAddrs)
+	if err != nil {
+		return err
+	}
+
+	// Parse the change address.
+	if len(kc.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr, err := service.vm.selectChangeAddr(kc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	utxoID := djtx.UTXOID{
+		TxID:        args.TxID,
+		OutputIndex: uint32(args.OutputIndex),
+	}
+	htlcUTXO, err := service.vm.state.GetUTXO(utxoID.InputID())
+	if err != nil {
+		return fmt.Errorf("problem retrieving the HTLC utxo: %w", err)
+	}
+
+	tx, err := service.buildSpendHTLCTx(utxos, kc, htlcUTXO, preimage, to, changeAddr)
+	if err != nil {
+		return err
+	}
+
+	txID, err := service.vm.IssueTx(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
+// buildSpendHTLCTx returns a transaction that sends the funds locked in
+// [htlcUTXO] to [to]. The funds are claimed if [preimage] is provided, and
+// refunded otherwise. The fee is paid out of the HTLC if it locks enough of the
+// fee asset, and out of [utxos] otherwise.
+func (service *Service) buildSpendHTLCTx(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	htlcUTXO *djtx.UTXO,
+	preimage []byte,
+	to ids.ShortID,
+	changeAddr ids.ShortID,
+) (*Tx, error) {
+	out, ok := htlcUTXO.Out.(*htlcfx.TransferOutput)
+	if !ok {
+		return nil, errNotHTLC
+	}
+	owners := &out.Receivers
+	if len(preimage) == 0 {
+		owners = &out.Refund
+	}
+	indices, htlcKeys, ok := kc.Match(owners, service.vm.clock.Unix())
+	if !ok {
+		return nil, errCantSpendHTLC
+	}
+
+	ins := []*djtx.TransferableInput{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	outs := []*djtx.TransferableOutput{}
+	amount := out.Amt
+	if htlcUTXO.AssetID() == service.vm.feeAssetID && amount > service.vm.txFee {
+		amount -= service.vm.txFee
+	} else {
+		amountsSpent, feeIns, feeKeys, err := service.vm.Spend(
+			utxos,
+			kc,
+			map[ids.ID]uint64{
+				service.vm.feeAssetID: service.vm.txFee,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		ins = feeIns
+		keys = feeKeys
+		if amountSpent := amountsSpent[service.vm.feeAssetID]; amountSpent > service.vm.txFee {
+			outs = append(outs, &djtx.TransferableOutput{
+				Asset: djtx.Asset{ID: service.vm.feeAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: amountSpent - service.vm.txFee,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Locktime:  0,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{changeAddr},
+					},
+				},
+			})
+		}
+	}
+
+	ins = append(ins, &djtx.TransferableInput{
+		UTXOID: htlcUTXO.UTXOID,
+		Asset:  htlcUTXO.Asset,
+		In: &htlcfx.TransferInput{
+			Amt:      out.Amt,
+			Preimage: preimage,
+			Input: secp256k1fx.Input{
+				SigIndices: indices,
+			},
+		},
+	})
+	keys = append(keys, htlcKeys)
+	outs = append(outs, &djtx.TransferableOutput{
+		Asset: htlcUTXO.Asset,
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Locktime:  0,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{to},
+			},
+		},
+	})
+	djtx.SortTransferableInputsWithSigners(ins, keys)
+	djtx.SortTransferableOutputs(outs, service.vm.codec)
+
+	tx := &Tx{UnsignedTx: &BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    service.vm.ctx.NetworkID,
+		BlockchainID: service.vm.ctx.ChainID,
+		Outs:         outs,
+		Ins:          ins,
+	}}}
+	// Each input is signed with a credential of the fx of the UTXO it spends
+	for i, in := range ins {
+		signers := [][]*crypto.PrivateKeySECP256K1R{keys[i]}
+		var err error
+		if _, ok := in.In.(*htlcfx.TransferInput); ok {
+			err = tx.SignHTLCFx(service.vm.codec, signers)
+		} else {
+			err = tx.SignSECP256K1Fx(service.vm.codec, signers)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tx, nil
+}
+
+// NFT describes an unspent NFT output
+type NFT struct {
+	UTXOID    string      `json:"utxoID"`
+	AssetID   ids.ID      `json:"assetID"`
+	GroupID   json.Uint32 `json:"groupID"`
+	Threshold json.Uint32 `json:"threshold"`
+	Owners    []string    `json:"owners"`
+	Payload   string      `json:"payload"`
+	// Metadata held by the payload, if it's flagged as holding valid metadata
+	Metadata *nftfx.Metadata `json:"metadata,omitempty"`
+}
+
+// formatNFT describes the NFT [out] of [assetID] that is in [utxoID]
+func (service *Service) formatNFT(utxoID *djtx.UTXOID, assetID ids.ID, out *nftfx.TransferOutput, encoding formatting.Encoding) (NFT, error) {
+	owners := make([]string, len(out.Addrs))
+	for i, addr := range out.Addrs {
+		addrStr, err := service.vm.FormatLocalAddress(addr)
+		if err != nil {
+			return NFT{}, err
+		}
+		owners[i] = addrStr
+	}
+	payload, err := formatting.Encode(encoding, out.Payload)
+	if err != nil {
+		return NFT{}, fmt.Errorf("couldn't encode payload: %w", err)
+	}
+	nft := NFT{
+		UTXOID:    utxoID.String(),
+		AssetID:   assetID,
+		GroupID:   json.Uint32(out.GroupID),
+		Threshold: json.Uint32(out.Threshold),
+		Owners:    owners,
+		Payload:   payload,
+	}
+	if nftfx.IsMetadata(out.Payload) {
+		// NFTs minted before metadata was enforced may hold invalid metadata,
+		// in which case only the raw payload is returned
+		if metadata, err := nftfx.ParseMetadata(out.Payload); err == nil {
+			nft.Metadata = metadata
+		}
+	}
+	return nft, nil
+}
+
+// GetNFTsArgs are arguments for passing into GetNFTs
+type GetNFTsArgs struct {
+	Address  string              `json:"address"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetNFTsReply defines the GetNFTs replies returned from the API
+type GetNFTsReply struct {
+	NFTs     []NFT               `json:"nfts"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetNFTs returns the unspent NFT outputs that an address owns
+func (service *Service) GetNFTs(r *http.Request, args *GetNFTsArgs, reply *GetNFTsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetNFTs called with address: %s", args.Address)
+
+	addr, err := service.vm.ParseLocalAddress(args.Address)
+	if err != nil {
+		return fmt.Errorf("problem parsing address '%s': %w", args.Address, err)
+	}
+	addrs := ids.ShortSet{}
+	addrs.Add(addr)
+	utxos, err := service.vm.getAllUTXOs(addrs)
+	if err != nil {
+		return fmt.Errorf("problem retrieving UTXOs: %w", err)
+	}
+
+	reply.NFTs = []NFT{}
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(*nftfx.TransferOutput)
+		if !ok {
+			continue
+		}
+		nft, err := service.formatNFT(&utxo.UTXOID, utxo.AssetID(), out, args.Encoding)
+		if err != nil {
+			return err
+		}
+		reply.NFTs = append(reply.NFTs, nft)
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+// GetNFTHistoryArgs are arguments for passing into GetNFTHistory
+type GetNFTHistoryArgs struct {
+	AssetID string      `json:"assetID"`
+	GroupID json.Uint32 `json:"groupID"`
+	// Index of the first entry to return
+	Cursor json.Uint64 `json:"cursor"`
+	// Max number of entries to return. Defaults to, and is at most, 1024.
+	PageSize json.Uint64         `json:"pageSize"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// NFTHistoryEntry describes an NFT output that was produced
+type NFTHistoryEntry struct {
+	TxID ids.ID `json:"txID"`
+	// True if the NFT was minted, rather than transferred
+	Minted bool `json:"minted"`
+	// The output the NFT was transferred from. Empty if the NFT was minted.
+	From string `json:"from"`
+	NFT
+}
+
+// GetNFTHistoryReply defines the GetNFTHistory replies returned from the API
+type GetNFTHistoryReply struct {
+	History []NFTHistoryEntry `json:"history"`
+	// Cursor to pass in to get the next page of entries
+	Cursor   json.Uint64         `json:"cursor"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetNFTHistory returns the outputs of an NFT group, which describes how the
+// ownership of the NFTs changed. Requires the NFTs to be indexed.
+//
+// The outputs produced while the index is enabled are returned in the order
+// they were accepted. The outputs produced before the index was enabled are
+// returned in an order that respects the dependencies of their transactions,
+// which may differ from the order they were accepted in.
+func (service *Service) GetNFTHistory(r *http.Request, args *GetNFTHistoryArgs, reply *GetNFTHistoryReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetNFTHistory called with assetID: %s groupID: %d cursor: %d pageSize: %d",
+		args.AssetID, args.GroupID, args.Cursor, args.PageSize)
+
+	if service.vm.nftHistory == nil {
+		return errNFTIndexingDisabled
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	pageSize := uint64(args.PageSize)
+	if pageSize == 0 || pageSize > maxNFTHistoryPageSize {
+		pageSize = maxNFTHistoryPageSize
+	}
+
+	entries, cursor, err := service.vm.nftHistory.read(assetID, uint32(args.GroupID), uint64(args.Cursor), pageSize)
+	if err != nil {
+		return fmt.Errorf("problem reading the history of group %d of %s: %w", args.GroupID, assetID, err)
+	}
+
+	reply.History = make([]NFTHistoryEntry, len(entries))
+	for i, entry := range entries {
+		entry := entry
+		utxo, err := service.vm.getProducedUTXO(&entry.UTXOID)
+		if err != nil {
+			return fmt.Errorf("problem reading NFT output %s: %w", &entry.UTXOID, err)
+		}
+		out, ok := utxo.Out.(*nftfx.TransferOutput)
+		if !ok {
+			return errUnknownOutputType
+		}
+		nft, err := service.formatNFT(&entry.UTXOID, assetID, out, args.Encoding)
+		if err != nil {
+			return err
+		}
+		reply.History[i] = NFTHistoryEntry{
+			TxID:   entry.UTXOID.TxID,
+			Minted: entry.Minted,
+			NFT:    nft,
+		}
+		if !entry.Minted {
+			reply.History[i].From = entry.Spent.String()
+		}
+	}
+	reply.Cursor = json.Uint64(cursor)
+	reply.Encoding = args.Encoding
+	return nil
+}
+
 // MintNFTArgs are arguments for passing into MintNFT requests
 type MintNFTArgs struct {
 	api.JSONSpendHeader                     // User, password, from addrs, change addr
@@ -1310,6 +2589,8 @@ type MintNFTArgs struct {
 	Payload             string              `json:"payload"`
 	To                  string              `json:"to"`
 	Encoding            formatting.Encoding `json:"encoding"`
+	// Metadata to use as the payload, in place of [Payload]
+	Metadata *nftfx.Metadata `json:"metadata"`
 }
 
 // MintNFT issues a MintNFT transaction and returns the ID of the newly created transaction
@@ -1326,9 +2607,20 @@ func (service *Service) MintNFT(r *http.Request, args *MintNFTArgs, reply *api.J
 		return fmt.Errorf("problem parsing to address %q: %w", args.To, err)
 	}
 
-	payloadBytes, err := formatting.Decode(args.Encoding, args.Payload)
-	if err != nil {
-		return fmt.Errorf("problem decoding payload bytes: %w", err)
+	var payloadBytes []byte
+	if args.Metadata != nil {
+		if args.Payload != "" {
+			return errPayloadAndMetadata
+		}
+		payloadBytes, err = args.Metadata.Payload()
+		if err != nil {
+			return fmt.Errorf("invalid metadata: %w", err)
+		}
+	} else {
+		payloadBytes, err = formatting.Decode(args.Encoding, args.Payload)
+		if err != nil {
+			return fmt.Errorf("problem decoding payload bytes: %w", err)
+		}
 	}
 
 	// Parse the from addresses
@@ -1554,6 +2846,8 @@ type ExportDJTXArgs struct {
 	// ID of the address that will receive the DJTX. This address includes the
 	// chainID, which is used to determine what the destination chain is.
 	To string `json:"to"`
+
+	api.JSONMemo
 }
 
 // ExportDJTX sends DJTX from this chain to the address specified by [to].
@@ -1594,6 +2888,14 @@ func (service *Service) Export(_ *http.Request, args *ExportArgs, reply *api.JSO
 		return errZeroAmount
 	}
 
+	// Validate the memo field
+	recipients := ids.NewShortSet(1)
+	recipients.Add(to)
+	memo, err := djtx.ParseMemo(args.Memo, args.MemoPublicKey, recipients)
+	if err != nil {
+		return err
+	}
+
 	// Parse the from addresses
 	fromAddrs := ids.ShortSet{}
 	for _, addrStr := range args.From {
@@ -1673,6 +2975,7 @@ func (service *Service) Export(_ *http.Request, args *ExportArgs, reply *api.JSO
 			BlockchainID: service.vm.ctx.ChainID,
 			Outs:         outs,
 			Ins:          ins,
+			Memo:         memo,
 		}},
 		DestinationChain: chainID,
 		ExportedOuts:     exportOuts,
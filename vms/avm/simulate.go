@@ -0,0 +1,135 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+)
+
+// simulateTx verifies [tx] as IssueTx would, but without issuing it or
+// writing it to the database, and describes the result in [reply].
+func (vm *VM) simulateTx(tx *Tx, reply *api.SimulateTxReply) error {
+	if !vm.bootstrapped {
+		return errBootstrapping
+	}
+
+	reply.TxID = tx.ID()
+	// The UTXOs of a malformed tx aren't reported
+	if err := vm.syntacticVerifyTx(tx); err != nil {
+		reply.FailedCheck = api.SyntacticCheck
+		reply.Error = err.Error()
+		return nil
+	}
+
+	ins, outs := transfers(tx.UnsignedTx)
+
+	reply.Consumed = make([]api.SimulatedUTXO, 0, len(ins))
+	for _, in := range ins {
+		reply.Consumed = append(reply.Consumed, api.SimulatedUTXO{
+			UTXOID:  in.UTXOID.String(),
+			AssetID: in.AssetID(),
+			Amount:  json.Uint64(in.Input().Amount()),
+		})
+	}
+	if opTx, ok := tx.UnsignedTx.(*OperationTx); ok {
+		for _, op := range opTx.Ops {
+			for _, utxoID := range op.UTXOIDs {
+				consumed := api.SimulatedUTXO{
+					UTXOID:  utxoID.String(),
+					AssetID: op.AssetID(),
+				}
+				// The amount is only reported if the UTXO is known
+				if utxo, err := vm.getUTXO(utxoID); err == nil {
+					if out, ok := utxo.Out.(djtx.Amounter); ok {
+						consumed.Amount = json.Uint64(out.Amount())
+					}
+				}
+				reply.Consumed = append(reply.Consumed, consumed)
+			}
+		}
+	}
+
+	utxos := tx.UTXOs()
+	if exportTx, ok := tx.UnsignedTx.(*ExportTx); ok {
+		for i, out := range exportTx.ExportedOuts {
+			utxos = append(utxos, &djtx.UTXO{
+				UTXOID: djtx.UTXOID{
+					TxID:        reply.TxID,
+					OutputIndex: uint32(len(exportTx.Outs) + i),
+				},
+				Asset: out.Asset,
+				Out:   out.Out,
+			})
+		}
+	}
+	reply.Produced = make([]api.SimulatedUTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		produced := api.SimulatedUTXO{
+			UTXOID:  utxo.UTXOID.String(),
+			AssetID: utxo.AssetID(),
+		}
+		if out, ok := utxo.Out.(djtx.Amounter); ok {
+			produced.Amount = json.Uint64(out.Amount())
+		}
+		reply.Produced = append(reply.Produced, produced)
+	}
+
+	burned, err := djtx.Burned(ins, outs)
+	if err != nil {
+		return fmt.Errorf("problem calculating the burned amounts: %w", err)
+	}
+	burnedAssetIDs := make([]ids.ID, 0, len(burned))
+	for assetID := range burned {
+		burnedAssetIDs = append(burnedAssetIDs, assetID)
+	}
+	ids.SortIDs(burnedAssetIDs)
+	reply.Burned = make([]api.BurnedAsset, len(burnedAssetIDs))
+	for i, assetID := range burnedAssetIDs {
+		reply.Burned[i] = api.BurnedAsset{
+			AssetID: assetID,
+			Amount:  json.Uint64(burned[assetID]),
+		}
+	}
+
+	if numMissing := numMissingSigs(tx); numMissing > 0 {
+		reply.FailedCheck = api.SignaturesCheck
+		reply.Error = fmt.Sprintf("%s: %d signatures are missing", errMissingSignatures, numMissing)
+		return nil
+	}
+	if err := tx.SemanticVerify(vm, tx.UnsignedTx); err != nil {
+		reply.FailedCheck = api.SemanticCheck
+		reply.Error = err.Error()
+		return nil
+	}
+	reply.Valid = true
+	return nil
+}
+
+// transfers returns the inputs that [utx] consumes and the outputs that it
+// produces, including those imported from and exported to other chains
+func transfers(utx UnsignedTx) ([]*djtx.TransferableInput, []*djtx.TransferableOutput) {
+	switch utx := utx.(type) {
+	case *BaseTx:
+		return utx.Ins, utx.Outs
+	case *CreateAssetTx:
+		return utx.Ins, utx.Outs
+	case *OperationTx:
+		return utx.Ins, utx.Outs
+	case *ImportTx:
+		ins := make([]*djtx.TransferableInput, 0, len(utx.Ins)+len(utx.ImportedIns))
+		ins = append(ins, utx.Ins...)
+		return append(ins, utx.ImportedIns...), utx.Outs
+	case *ExportTx:
+		outs := make([]*djtx.TransferableOutput, 0, len(utx.Outs)+len(utx.ExportedOuts))
+		outs = append(outs, utx.Outs...)
+		return utx.Ins, append(outs, utx.ExportedOuts...)
+	default:
+		return nil, nil
+	}
+}
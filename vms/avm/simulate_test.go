@@ -0,0 +1,90 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+func TestSimulateTx(t *testing.T) {
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+	s := &Service{vm: vm}
+	djtxTx := GetDJTXTxFromGenesisTest(genesisBytes, t)
+
+	simulate := func(tx *Tx) *api.SimulateTxReply {
+		txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		reply := &api.SimulateTxReply{}
+		if err := s.SimulateTx(nil, &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, reply); err != nil {
+			t.Fatal(err)
+		}
+		if reply.TxID != tx.ID() {
+			t.Fatalf("expected tx %s but got %s", tx.ID(), reply.TxID)
+		}
+		return reply
+	}
+
+	validTx := NewTx(t, genesisBytes, vm)
+	reply := simulate(validTx)
+	if !reply.Valid {
+		t.Fatalf("expected the tx to be valid but it failed the %s check: %s", reply.FailedCheck, reply.Error)
+	}
+	if len(reply.Consumed) != 1 {
+		t.Fatalf("expected 1 consumed UTXO but got %d", len(reply.Consumed))
+	}
+	if consumed := reply.Consumed[0]; consumed.AssetID != djtxTx.ID() || consumed.Amount != json.Uint64(startBalance) {
+		t.Fatalf("wrong consumed UTXO %+v", consumed)
+	}
+	if len(reply.Produced) != 0 {
+		t.Fatalf("expected no produced UTXOs but got %d", len(reply.Produced))
+	}
+	if len(reply.Burned) != 1 || reply.Burned[0].AssetID != djtxTx.ID() || reply.Burned[0].Amount != json.Uint64(startBalance) {
+		t.Fatalf("wrong burned amounts %+v", reply.Burned)
+	}
+	if status := (&UniqueTx{vm: vm, txID: validTx.ID()}).Status(); status != choices.Unknown {
+		t.Fatalf("simulated tx shouldn't be stored but its status is %s", status)
+	}
+
+	malformedTx := NewTx(t, genesisBytes, vm)
+	malformedTx.UnsignedTx.(*BaseTx).NetworkID++
+	if err := malformedTx.SignSECP256K1Fx(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}); err != nil {
+		t.Fatal(err)
+	}
+	if reply := simulate(malformedTx); reply.Valid || reply.FailedCheck != api.SyntacticCheck {
+		t.Fatalf("expected the %s check to fail but got %+v", api.SyntacticCheck, reply)
+	}
+
+	unsignedTx := &Tx{UnsignedTx: validTx.UnsignedTx}
+	if err := unsignedTx.SignSECP256K1Fx(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{nil}}); err != nil {
+		t.Fatal(err)
+	}
+	if reply := simulate(unsignedTx); reply.Valid || reply.FailedCheck != api.SignaturesCheck {
+		t.Fatalf("expected the %s check to fail but got %+v", api.SignaturesCheck, reply)
+	}
+
+	wronglySignedTx := &Tx{UnsignedTx: validTx.UnsignedTx}
+	if err := wronglySignedTx.SignSECP256K1Fx(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{keys[1]}}); err != nil {
+		t.Fatal(err)
+	}
+	if reply := simulate(wronglySignedTx); reply.Valid || reply.FailedCheck != api.SemanticCheck {
+		t.Fatalf("expected the %s check to fail but got %+v", api.SemanticCheck, reply)
+	}
+}
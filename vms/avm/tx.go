@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/components/djtx"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
@@ -30,6 +31,7 @@ type UnsignedTx interface {
 	NumCredentials() int
 	InputUTXOs() []*djtx.UTXOID
 	UTXOs() []*djtx.UTXO
+	memo() []byte
 
 	SyntacticVerify(
 		ctx *snow.Context,
@@ -111,6 +113,10 @@ func (t *Tx) SignSECP256K1Fx(c codec.Manager, signers [][]*crypto.PrivateKeySECP
 			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(keys)),
 		}
 		for i, key := range keys {
+			if key == nil {
+				// The signature is added later, by the holder of the key
+				continue
+			}
 			sig, err := key.SignHash(hash)
 			if err != nil {
 				return fmt.Errorf("problem creating transaction: %w", err)
@@ -140,6 +146,43 @@ func (t *Tx) SignNFTFx(c codec.Manager, signers [][]*crypto.PrivateKeySECP256K1R
 			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(keys)),
 		}}
 		for i, key := range keys {
+			if key == nil {
+				// The signature is added later, by the holder of the key
+				continue
+			}
+			sig, err := key.SignHash(hash)
+			if err != nil {
+				return fmt.Errorf("problem creating transaction: %w", err)
+			}
+			copy(cred.Sigs[i][:], sig)
+		}
+		t.Creds = append(t.Creds, cred)
+	}
+
+	signedBytes, err := c.Marshal(codecVersion, t)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+	t.Initialize(unsignedBytes, signedBytes)
+	return nil
+}
+
+func (t *Tx) SignHTLCFx(c codec.Manager, signers [][]*crypto.PrivateKeySECP256K1R) error {
+	unsignedBytes, err := c.Marshal(codecVersion, &t.UnsignedTx)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+
+	hash := hashing.ComputeHash256(unsignedBytes)
+	for _, keys := range signers {
+		cred := &htlcfx.Credential{Credential: secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(keys)),
+		}}
+		for i, key := range keys {
+			if key == nil {
+				// The signature is added later, by the holder of the key
+				continue
+			}
 			sig, err := key.SignHash(hash)
 			if err != nil {
 				return fmt.Errorf("problem creating transaction: %w", err)
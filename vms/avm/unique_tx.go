@@ -148,6 +148,25 @@ func (tx *UniqueTx) Accept() error {
 		return err
 	}
 
+	if tx.vm.addressTxs != nil {
+		if err := tx.vm.indexTx(tx.Tx); err != nil {
+			tx.vm.ctx.Log.Error("Failed to index tx %s due to %s", tx.txID, err)
+			return err
+		}
+	}
+	if tx.vm.assetStats != nil {
+		if err := tx.vm.indexAssetStats(tx.Tx); err != nil {
+			tx.vm.ctx.Log.Error("Failed to index the asset stats of tx %s due to %s", tx.txID, err)
+			return err
+		}
+	}
+	if tx.vm.nftHistory != nil {
+		if err := tx.vm.indexNFTs(tx.Tx); err != nil {
+			tx.vm.ctx.Log.Error("Failed to index the NFTs of tx %s due to %s", tx.txID, err)
+			return err
+		}
+	}
+
 	txID := tx.ID()
 
 	commitBatch, err := tx.vm.db.CommitBatch()
@@ -314,14 +333,7 @@ func (tx *UniqueTx) SyntacticVerify() error {
 	}
 
 	tx.verifiedTx = true
-	tx.validity = tx.Tx.SyntacticVerify(
-		tx.vm.ctx,
-		tx.vm.codec,
-		tx.vm.feeAssetID,
-		tx.vm.txFee,
-		tx.vm.creationTxFee,
-		len(tx.vm.fxs),
-	)
+	tx.validity = tx.vm.syntacticVerifyTx(tx.Tx)
 	return tx.validity
 }
 
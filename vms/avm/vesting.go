@@ -0,0 +1,131 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errCantExportVesting  = errors.New("vesting outputs can't be exported")
+	errVestingNotRelocked = errors.New("locked funds of a vesting output aren't sent to a vesting output with the same schedule and owners")
+)
+
+// relock is the amount of a vesting output that must be sent to new vesting
+// outputs with the same schedule and owners
+type relock struct {
+	assetID ids.ID
+	out     *secp256k1fx.VestingOutput
+	// Amount of the consumed vesting outputs that is still locked
+	locked uint64
+	// Amount sent to new vesting outputs with the same schedule and owners
+	relocked uint64
+}
+
+// verifyVesting ensures that the funds that are still locked in the vesting
+// outputs consumed by [ins] are sent to vesting outputs in [outs] with the
+// same schedule and owners. Vesting outputs can only be consumed after the
+// Apricot Phase 3 upgrade, as their funds unlock over chain time.
+func (vm *VM) verifyVesting(ins []*djtx.TransferableInput, outs []*djtx.TransferableOutput) error {
+	relocks := []*relock(nil)
+	for _, in := range ins {
+		utxo, err := vm.getUTXO(&in.UTXOID)
+		if err != nil {
+			return err
+		}
+		out, ok := utxo.Out.(*secp256k1fx.VestingOutput)
+		if !ok {
+			continue
+		}
+		if !vm.isApricotPhase3() {
+			return errNotApricotPhase3
+		}
+		now, _ := vm.ChainTime()
+		locked := out.LockedAmount(uint64(now.Unix()))
+		if locked == 0 {
+			continue
+		}
+		assetID := utxo.AssetID()
+		if r := findRelock(relocks, assetID, out); r != nil {
+			if r.locked, err = safemath.Add64(r.locked, locked); err != nil {
+				return err
+			}
+			continue
+		}
+		relocks = append(relocks, &relock{
+			assetID: assetID,
+			out:     out,
+			locked:  locked,
+		})
+	}
+	if len(relocks) == 0 {
+		return nil
+	}
+
+	for _, out := range outs {
+		vestingOut, ok := out.Out.(*secp256k1fx.VestingOutput)
+		if !ok {
+			continue
+		}
+		r := findRelock(relocks, out.AssetID(), vestingOut)
+		if r == nil {
+			continue
+		}
+		relocked, err := safemath.Add64(r.relocked, vestingOut.Amt)
+		if err != nil {
+			return err
+		}
+		r.relocked = relocked
+	}
+
+	for _, r := range relocks {
+		if r.relocked < r.locked {
+			return fmt.Errorf("%w: %d of asset %s are locked but %d are relocked",
+				errVestingNotRelocked,
+				r.locked,
+				r.assetID,
+				r.relocked,
+			)
+		}
+	}
+	return nil
+}
+
+// findRelock returns the relock in [relocks] of vesting outputs of [assetID]
+// with the same schedule and owners as [out], or nil if there isn't one
+func findRelock(relocks []*relock, assetID ids.ID, out *secp256k1fx.VestingOutput) *relock {
+	for _, r := range relocks {
+		if r.assetID == assetID && r.out.Equals(out) {
+			return r
+		}
+	}
+	return nil
+}
+
+// lockedAmount returns the owners and the amount of [out] and how much of the
+// amount is locked at [time]. Returns false if [out] isn't a transfer output.
+func lockedAmount(out verify.State, time uint64) (*secp256k1fx.OutputOwners, uint64, uint64, bool) {
+	switch out := out.(type) {
+	case *secp256k1fx.TransferOutput:
+		if out.Locktime > time {
+			return &out.OutputOwners, out.Amt, out.Amt, true
+		}
+		return &out.OutputOwners, out.Amt, 0, true
+	case *secp256k1fx.VestingOutput:
+		if out.Locktime > time {
+			return &out.OutputOwners, out.Amt, out.Amt, true
+		}
+		return &out.OutputOwners, out.Amt, out.LockedAmount(time), true
+	default:
+		return nil, 0, 0, false
+	}
+}
@@ -0,0 +1,214 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestSendVesting(t *testing.T) {
+	_, vm, s, _, genesisTx := setupWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	startTime := time.Unix(1_000_000, 0)
+	vm.clock.Set(startTime)
+
+	assetID := genesisTx.ID()
+	toAddrStr, err := vm.FormatLocalAddress(addrs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromAddrStr, err := vm.FormatLocalAddress(addrs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Send 1000 that unlock over 4 periods of 10 seconds
+	reply := &api.JSONTxIDChangeAddr{}
+	vm.timer.Cancel()
+	sendArgs := &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs:  api.JSONFromAddrs{From: []string{fromAddrStr}},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		},
+		SendOutput: SendOutput{
+			Amount:  1000,
+			AssetID: assetID.String(),
+			To:      toAddrStr,
+			Vesting: &VestingArgs{
+				StartTime:      json.Uint64(startTime.Unix()),
+				PeriodDuration: 10,
+				NumPeriods:     4,
+			},
+		},
+	}
+
+	// Vesting outputs can't be created on the DAG, or before Apricot Phase 3
+	if err := s.Send(nil, sendArgs, reply); err == nil || !strings.Contains(err.Error(), errNotApricotPhase3.Error()) {
+		t.Fatalf("expected %s but got %v", errNotApricotPhase3, err)
+	}
+	linearize(t, vm)
+	vm.apricotPhase3Time = startTime.Add(time.Second)
+	if err := s.Send(nil, sendArgs, reply); err == nil || !strings.Contains(err.Error(), errNotApricotPhase3.Error()) {
+		t.Fatalf("expected %s but got %v", errNotApricotPhase3, err)
+	}
+	vm.apricotPhase3Time = time.Time{}
+
+	if err := s.Send(nil, sendArgs, reply); err != nil {
+		t.Fatal(err)
+	}
+	sendTx := UniqueTx{
+		vm:   vm,
+		txID: reply.TxID,
+	}
+	if err := sendTx.Accept(); err != nil {
+		t.Fatal(err)
+	}
+
+	balanceReply := &GetBalanceReply{}
+	err = s.GetBalance(nil, &GetBalanceArgs{
+		Address: toAddrStr,
+		AssetID: assetID.String(),
+	}, balanceReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balanceReply.Balance != json.Uint64(startBalance+1000) {
+		t.Fatalf("expected balance %d but got %d", startBalance+1000, balanceReply.Balance)
+	}
+	if balanceReply.Locked != 1000 {
+		t.Fatalf("expected 1000 to be locked but got %d", balanceReply.Locked)
+	}
+	if balanceReply.Unlocked != json.Uint64(startBalance) {
+		t.Fatalf("expected %d to be unlocked but got %d", startBalance, balanceReply.Unlocked)
+	}
+
+	// Half of the funds unlock after 2 periods
+	vm.clock.Set(startTime.Add(20 * time.Second))
+
+	allBalancesReply := &GetAllBalancesReply{}
+	err = s.GetAllBalances(nil, &GetAllBalancesArgs{
+		JSONAddress: api.JSONAddress{Address: toAddrStr},
+	}, allBalancesReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allBalancesReply.Balances) != 1 {
+		t.Fatalf("expected 1 balance but got %d", len(allBalancesReply.Balances))
+	}
+	if balance := allBalancesReply.Balances[0]; balance.Locked != 500 || balance.Unlocked != json.Uint64(startBalance+500) {
+		t.Fatalf("expected 500 to be locked and %d to be unlocked but got %d and %d", startBalance+500, balance.Locked, balance.Unlocked)
+	}
+
+	addrSet := ids.ShortSet{}
+	addrSet.Add(addrs[1])
+	utxos, err := vm.getAllUTXOs(addrSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vestingUTXOs := []*djtx.UTXO(nil)
+	for _, utxo := range utxos {
+		if _, ok := utxo.Out.(*secp256k1fx.VestingOutput); ok {
+			vestingUTXOs = append(vestingUTXOs, utxo)
+		}
+	}
+	if len(vestingUTXOs) != 1 {
+		t.Fatalf("expected 1 vesting UTXO but got %d", len(vestingUTXOs))
+	}
+
+	// Only the unlocked funds can be spent
+	kc := secp256k1fx.NewKeychain()
+	kc.Add(keys[1])
+	if _, _, _, _, err := vm.SpendVested(vestingUTXOs, kc, map[ids.ID]uint64{assetID: 501}); err == nil {
+		t.Fatal("should have errored due to spending locked funds")
+	}
+	amountsSpent, ins, relockOuts, signers, err := vm.SpendVested(vestingUTXOs, kc, map[ids.ID]uint64{assetID: 500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amountsSpent[assetID] != 500 {
+		t.Fatalf("expected 500 to be spent but got %d", amountsSpent[assetID])
+	}
+	if len(relockOuts) != 1 || relockOuts[0].Out.(*secp256k1fx.VestingOutput).Amt != 500 {
+		t.Fatal("expected the 500 locked funds to be relocked")
+	}
+	if _, _, _, err := vm.Spend(vestingUTXOs, kc, map[ids.ID]uint64{assetID: 1}); err == nil {
+		t.Fatal("Spend shouldn't spend partially unlocked vesting outputs")
+	}
+
+	sendOut := &djtx.TransferableOutput{
+		Asset: djtx.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 500 - vm.txFee,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addrs[2]},
+			},
+		},
+	}
+	newTx := func(outs []*djtx.TransferableOutput, signers [][]*crypto.PrivateKeySECP256K1R) *Tx {
+		tx := &Tx{UnsignedTx: &BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+			Ins:          ins,
+			Outs:         outs,
+		}}}
+		if err := tx.SignSECP256K1Fx(vm.codec, signers); err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+
+	// The locked funds must be sent back to the vesting schedule
+	unlockedOut := &djtx.TransferableOutput{
+		Asset: djtx.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1000 - vm.txFee,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addrs[2]},
+			},
+		},
+	}
+	tx := newTx([]*djtx.TransferableOutput{unlockedOut}, signers)
+	if err := tx.UnsignedTx.SemanticVerify(vm, tx.UnsignedTx, tx.Creds); !errors.Is(err, errVestingNotRelocked) {
+		t.Fatalf("expected %s but got %v", errVestingNotRelocked, err)
+	}
+
+	outs := []*djtx.TransferableOutput{sendOut, relockOuts[0]}
+	djtx.SortTransferableOutputs(outs, vm.codec)
+	tx = newTx(outs, signers)
+	if err := tx.UnsignedTx.SemanticVerify(vm, tx.UnsignedTx, tx.Creds); err != nil {
+		t.Fatal(err)
+	}
+
+	// Vesting outputs can't be consumed before Apricot Phase 3
+	vm.apricotPhase3Time = vm.clock.Time().Add(time.Hour)
+	if err := tx.UnsignedTx.SemanticVerify(vm, tx.UnsignedTx, tx.Creds); err != errNotApricotPhase3 {
+		t.Fatalf("expected %s but got %v", errNotApricotPhase3, err)
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/components/djtx"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
@@ -58,6 +59,7 @@ var (
 	errWrongBlockchainID         = errors.New("wrong blockchain ID")
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
 	errInsufficientFunds         = errors.New("insufficient funds")
+	errNotApricotPhase3          = errors.New("transaction isn't valid before the Apricot Phase 3 upgrade")
 
 	_ vertex.DAGVM   = &VM{}
 	_ secp256k1fx.VM = &VM{}
@@ -90,11 +92,22 @@ type VM struct {
 
 	// asset id that will be used for fees
 	feeAssetID ids.ID
+	// assets that can be burned in place of the fee asset to pay fees
+	feeRates djtx.FeeRates
 	// fee that must be burned by every state creating transaction
 	creationTxFee uint64
 	// fee that must be burned by every non-state creating transaction
 	txFee uint64
 
+	// time of the Apricot Phase 3 upgrade
+	apricotPhase3Time time.Time
+
+	// Linear chain the VM runs on. Nil if the VM runs on the DAG.
+	chain *ChainVM
+
+	// chooses the UTXOs that fund the txs built by the APIs
+	coinSelector djtx.CoinSelector
+
 	// Asset ID --> Bit set with fx IDs the asset supports
 	assetToFxCache *cache.LRU
 
@@ -111,6 +124,18 @@ type VM struct {
 	fxs           []*parsedFx
 
 	walletService WalletService
+
+	// Index of the transactions of each address. Nil if transactions aren't
+	// being indexed.
+	addressTxs *addressTxIndex
+
+	// Index of the supply, holders and minters of each asset. Nil if assets
+	// aren't being indexed.
+	assetStats *assetStatsIndex
+
+	// Index of the ownership history of each NFT group. Nil if NFTs aren't
+	// being indexed.
+	nftHistory *nftHistoryIndex
 }
 
 func (vm *VM) Connected(id ids.ShortID) error {
@@ -137,6 +162,11 @@ func (vm *VM) Initialize(
 	toEngine chan<- common.Message,
 	fxs []*common.Fx,
 ) error {
+	config, err := parseConfig(configBytes)
+	if err != nil {
+		return err
+	}
+
 	if err := vm.metrics.Initialize(ctx.Namespace, ctx.Metrics); err != nil {
 		return err
 	}
@@ -203,6 +233,29 @@ func (vm *VM) Initialize(
 		}
 	}
 
+	// The types added to the secp256k1fx later are registered after the types
+	// of all the fxs, in the order they were added, so that the type IDs of
+	// the existing types are unchanged
+	for _, initialize := range []func(*secp256k1fx.Fx, codec.Registry) error{
+		(*secp256k1fx.Fx).InitializeVesting,
+		(*secp256k1fx.Fx).InitializeBurning,
+	} {
+		for i, parsedFx := range vm.fxs {
+			secpFx, ok := parsedFx.Fx.(*secp256k1fx.Fx)
+			if !ok {
+				continue
+			}
+			err := initialize(secpFx, &codecRegistry{
+				codecs:      []codec.Registry{genesisCodec, c},
+				index:       i,
+				typeToIndex: vm.typeToFxIndex,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	state, err := NewMeteredState(vm.db, vm.genesisCodec, vm.codec, ctx.Namespace, ctx.Metrics)
 	if err != nil {
 		return err
@@ -213,6 +266,30 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	upgradeConfig, err := parseUpgradeConfig(upgradeBytes)
+	if err != nil {
+		return err
+	}
+	vm.feeRates, err = upgradeConfig.feeRates(vm.feeAssetID)
+	if err != nil {
+		return err
+	}
+
+	vm.coinSelector, err = djtx.NewCoinSelector(config.CoinSelection)
+	if err != nil {
+		return err
+	}
+
+	if err := vm.initAddressTxIndex(config.IndexTransactions); err != nil {
+		return err
+	}
+	if err := vm.initAssetStatsIndex(config.IndexAssets); err != nil {
+		return err
+	}
+	if err := vm.initNFTHistoryIndex(config.IndexNFTs); err != nil {
+		return err
+	}
+
 	vm.timer = timer.NewTimer(func() {
 		ctx.Lock.Lock()
 		defer ctx.Lock.Unlock()
@@ -490,6 +567,39 @@ func (vm *VM) getAllUniqueAddressUTXOs(addr ids.ShortID, seen *ids.Set, utxos *[
 // Clock returns a reference to the internal clock of this VM
 func (vm *VM) Clock() *timer.Clock { return &vm.clock }
 
+// ChainTime returns the time that transactions are currently verified at.
+// Returns false if the VM runs on the DAG, which has no chain time.
+func (vm *VM) ChainTime() (time.Time, bool) {
+	if vm.chain == nil {
+		return time.Time{}, false
+	}
+	return vm.chain.txTime(), true
+}
+
+// isApricotPhase3 returns true if transactions are currently verified after
+// the Apricot Phase 3 upgrade. The upgrade is measured in chain time, so it
+// never activates on the DAG.
+func (vm *VM) isApricotPhase3() bool {
+	now, ok := vm.ChainTime()
+	return ok && !now.Before(vm.apricotPhase3Time)
+}
+
+// verifyChainTimeOutputs ensures that [tx] only produces outputs that are
+// spent depending on the chain time, such as vesting and HTLC outputs, after
+// the Apricot Phase 3 upgrade.
+func (vm *VM) verifyChainTimeOutputs(tx UnsignedTx) error {
+	if vm.isApricotPhase3() {
+		return nil
+	}
+	for _, utxo := range tx.UTXOs() {
+		switch utxo.Out.(type) {
+		case *secp256k1fx.VestingOutput, *htlcfx.TransferOutput:
+			return errNotApricotPhase3
+		}
+	}
+	return nil
+}
+
 // Codec returns a reference to the internal codec of this VM
 func (vm *VM) Codec() codec.Manager { return vm.codec }
 
@@ -574,6 +684,36 @@ func (vm *VM) initGenesis(genesisBytes []byte) error {
 	return nil
 }
 
+// initAddressTxIndex rebuilds the index of the transactions of each address if
+// it was just enabled, and removes it if it was just disabled.
+func (vm *VM) initAddressTxIndex(enabled bool) error {
+	addressTxs := newAddressTxIndex(vm.db)
+	if enabled {
+		vm.addressTxs = addressTxs
+	}
+	return vm.initIndex(&addressTxs.acceptedTxIndex, enabled, "the transactions of each address", vm.indexTx)
+}
+
+// initAssetStatsIndex rebuilds the index of the supply, holders and minters of
+// each asset if it was just enabled, and removes it if it was just disabled.
+func (vm *VM) initAssetStatsIndex(enabled bool) error {
+	assetStats := newAssetStatsIndex(vm.db)
+	if enabled {
+		vm.assetStats = assetStats
+	}
+	return vm.initIndex(&assetStats.acceptedTxIndex, enabled, "the stats of each asset", vm.indexAssetStats)
+}
+
+// initNFTHistoryIndex rebuilds the index of the ownership history of each NFT
+// group if it was just enabled, and removes it if it was just disabled.
+func (vm *VM) initNFTHistoryIndex(enabled bool) error {
+	nftHistory := newNFTHistoryIndex(vm.db)
+	if enabled {
+		vm.nftHistory = nftHistory
+	}
+	return vm.initIndex(&nftHistory.acceptedTxIndex, enabled, "the history of each NFT", vm.indexNFTs)
+}
+
 func (vm *VM) initState(tx Tx) error {
 	txID := tx.ID()
 	vm.ctx.Log.Info("initializing with AssetID %s", txID)
@@ -702,10 +842,8 @@ func (vm *VM) verifyFxUsage(fxID int, assetID ids.ID) bool {
 	}
 	fxIDs := ids.BitSet(0)
 	for _, state := range createAssetTx.States {
-		if state.FxID == uint32(fxID) {
-			// Cache that this asset supports this fx
-			fxIDs.Add(uint(fxID))
-		}
+		// Cache every fx this asset supports, not only [fxID]
+		fxIDs.Add(uint(state.FxID))
 	}
 	vm.assetToFxCache.Put(assetID, fxIDs)
 	return fxIDs.Contains(uint(fxID))
@@ -815,23 +953,178 @@ func (vm *VM) Spend(
 	[]*djtx.TransferableInput,
 	[][]*crypto.PrivateKeySECP256K1R,
 	error,
+) {
+	amountsSpent, ins, _, keys, err := vm.spend(utxos, kc, amounts, false)
+	return amountsSpent, ins, keys, err
+}
+
+// SpendVested is Spend, except that it also spends the unlocked funds of
+// vesting outputs whose funds are only partially unlocked. Returns the outputs
+// that the still locked funds of those vesting outputs must be sent to.
+func (vm *VM) SpendVested(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	amounts map[ids.ID]uint64,
+) (
+	map[ids.ID]uint64,
+	[]*djtx.TransferableInput,
+	[]*djtx.TransferableOutput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	return vm.spend(utxos, kc, amounts, true)
+}
+
+// syntacticVerifyTx verifies that [tx] is well-formed. When the chain accepts
+// other assets than the fee asset to pay fees, the fee is verified by
+// verifyFees instead.
+func (vm *VM) syntacticVerifyTx(tx *Tx) error {
+	txFee, creationTxFee := vm.txFee, vm.creationTxFee
+	if len(vm.feeRates) > 0 {
+		txFee, creationTxFee = 0, 0
+	}
+	return tx.SyntacticVerify(vm.ctx, vm.codec, vm.feeAssetID, txFee, creationTxFee, len(vm.fxs))
+}
+
+// activeFeeRates returns the rates of the other assets that can currently be
+// burned to pay fees. They are only accepted after the Apricot Phase 3
+// upgrade, so that every node starts accepting them at the same time.
+func (vm *VM) activeFeeRates() djtx.FeeRates {
+	if !vm.isApricotPhase3() {
+		return nil
+	}
+	return vm.feeRates
+}
+
+// verifyFees ensures that [tx] burns its fee in the fee asset, or in one of
+// the other assets the chain currently accepts to pay fees. If the chain only
+// accepts the fee asset, the fee was already verified by syntacticVerifyTx.
+func (vm *VM) verifyFees(tx UnsignedTx) error {
+	if len(vm.feeRates) == 0 {
+		return nil
+	}
+
+	fee := vm.txFee
+	if _, ok := tx.(*CreateAssetTx); ok {
+		fee = vm.creationTxFee
+	}
+	fees, err := vm.activeFeeRates().Fees(vm.feeAssetID, fee)
+	if err != nil {
+		return err
+	}
+
+	fc := djtx.NewFlowChecker()
+	fc.ProduceFee(fees)
+	ins, outs := transfers(tx)
+	for _, out := range outs {
+		fc.Produce(out.AssetID(), out.Output().Amount())
+	}
+	for _, in := range ins {
+		fc.Consume(in.AssetID(), in.Input().Amount())
+	}
+	return fc.Verify()
+}
+
+// spendWithFee is spend, except that it also spends a fee of [fee] units of
+// the fee asset. The fee is paid in the fee asset if the funds allow it, and
+// otherwise in the first of the other fee assets, by ID, that they allow.
+// Also returns the amounts that must be spent, including the fee.
+func (vm *VM) spendWithFee(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	amounts map[ids.ID]uint64,
+	fee uint64,
+	relock bool,
+) (
+	map[ids.ID]uint64,
+	map[ids.ID]uint64,
+	[]*djtx.TransferableInput,
+	[]*djtx.TransferableOutput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	feeRates := vm.activeFeeRates()
+	fees, err := feeRates.Fees(vm.feeAssetID, fee)
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+	feeAssetIDs := make([]ids.ID, 0, len(feeRates))
+	for assetID := range feeRates {
+		feeAssetIDs = append(feeAssetIDs, assetID)
+	}
+	ids.SortIDs(feeAssetIDs)
+	feeAssetIDs = append([]ids.ID{vm.feeAssetID}, feeAssetIDs...)
+
+	var firstErr error
+	for _, feeAssetID := range feeAssetIDs {
+		amountsWithFee := make(map[ids.ID]uint64, len(amounts)+1)
+		for assetID, amount := range amounts {
+			amountsWithFee[assetID] = amount
+		}
+		amountWithFee, err := safemath.Add64(amounts[feeAssetID], fees[feeAssetID])
+		if err != nil {
+			return nil, nil, nil, nil, nil, fmt.Errorf("problem calculating required spend amount: %w", err)
+		}
+		amountsWithFee[feeAssetID] = amountWithFee
+
+		amountsSpent, ins, relockOuts, keys, err := vm.spend(utxos, kc, amountsWithFee, relock)
+		if err == nil {
+			return amountsWithFee, amountsSpent, ins, relockOuts, keys, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, nil, nil, nil, nil, firstErr
+}
+
+func (vm *VM) spend(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	amounts map[ids.ID]uint64,
+	relock bool,
+) (
+	map[ids.ID]uint64,
+	[]*djtx.TransferableInput,
+	[]*djtx.TransferableOutput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
 ) {
 	amountsSpent := make(map[ids.ID]uint64, len(amounts))
 	time := vm.clock.Unix()
 
-	ins := []*djtx.TransferableInput{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	// spendable is a UTXO that can fund the spend of its asset
+	type spendable struct {
+		utxo       *djtx.UTXO
+		input      djtx.TransferableIn
+		signers    []*crypto.PrivateKeySECP256K1R
+		vestingOut *secp256k1fx.VestingOutput
+		locked     uint64
+	}
+	// Asset ID --> the UTXOs that can fund the spend of that asset, and the
+	// amounts they can spend
+	spendables := make(map[ids.ID][]spendable, len(amounts))
+	spendableAmounts := make(map[ids.ID][]uint64, len(amounts))
 	for _, utxo := range utxos {
 		assetID := utxo.AssetID()
-		amount := amounts[assetID]
-		amountSpent := amountsSpent[assetID]
-
-		if amountSpent >= amount {
-			// we already have enough inputs allocated to this asset
+		if amounts[assetID] == 0 {
+			// we don't need to spend this asset
 			continue
 		}
 
-		inputIntf, signers, err := kc.Spend(utxo.Out, time)
+		out := utxo.Out
+		vestingOut, isVesting := out.(*secp256k1fx.VestingOutput)
+		locked := uint64(0)
+		if isVesting {
+			locked = vestingOut.LockedAmount(time)
+			if locked == vestingOut.Amt || (locked > 0 && !relock) {
+				// none of the funds of this utxo can be spent right now
+				continue
+			}
+			out = &vestingOut.TransferOutput
+		}
+
+		inputIntf, signers, err := kc.Spend(out, time)
 		if err != nil {
 			// this utxo can't be spent with the current keys right now
 			continue
@@ -841,26 +1134,57 @@ func (vm *VM) Spend(
 			// this input doesn't have an amount, so I don't care about it here
 			continue
 		}
-		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
-		if err != nil {
-			// there was an error calculating the consumed amount, just error
-			return nil, nil, nil, errSpendOverflow
-		}
-		amountsSpent[assetID] = newAmountSpent
-
-		// add the new input to the array
-		ins = append(ins, &djtx.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  djtx.Asset{ID: assetID},
-			In:     input,
+		spendables[assetID] = append(spendables[assetID], spendable{
+			utxo:       utxo,
+			input:      input,
+			signers:    signers,
+			vestingOut: vestingOut,
+			locked:     locked,
 		})
-		// add the required keys to the array
-		keys = append(keys, signers)
+		spendableAmounts[assetID] = append(spendableAmounts[assetID], input.Amount()-locked)
+	}
+
+	ins := []*djtx.TransferableInput{}
+	relockOuts := []*djtx.TransferableOutput{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	for assetID, assetSpendables := range spendables {
+		for _, i := range vm.coinSelector.Select(spendableAmounts[assetID], amounts[assetID]) {
+			s := assetSpendables[i]
+			newAmountSpent, err := safemath.Add64(amountsSpent[assetID], spendableAmounts[assetID][i])
+			if err != nil {
+				// there was an error calculating the consumed amount, just error
+				return nil, nil, nil, nil, errSpendOverflow
+			}
+			amountsSpent[assetID] = newAmountSpent
+
+			if s.locked > 0 {
+				// the locked funds are sent back to the same schedule
+				relockOuts = append(relockOuts, &djtx.TransferableOutput{
+					Asset: djtx.Asset{ID: assetID},
+					Out: &secp256k1fx.VestingOutput{
+						VestingSchedule: s.vestingOut.VestingSchedule,
+						TransferOutput: secp256k1fx.TransferOutput{
+							Amt:          s.locked,
+							OutputOwners: s.vestingOut.OutputOwners,
+						},
+					},
+				})
+			}
+
+			// add the new input to the array
+			ins = append(ins, &djtx.TransferableInput{
+				UTXOID: s.utxo.UTXOID,
+				Asset:  djtx.Asset{ID: assetID},
+				In:     s.input,
+			})
+			// add the required keys to the array
+			keys = append(keys, s.signers)
+		}
 	}
 
 	for asset, amount := range amounts {
 		if amountsSpent[asset] < amount {
-			return nil, nil, nil, fmt.Errorf("want to spend %d of asset %s but only have %d",
+			return nil, nil, nil, nil, fmt.Errorf("want to spend %d of asset %s but only have %d",
 				amount,
 				asset,
 				amountsSpent[asset],
@@ -869,7 +1193,7 @@ func (vm *VM) Spend(
 	}
 
 	djtx.SortTransferableInputsWithSigners(ins, keys)
-	return amountsSpent, ins, keys, nil
+	return amountsSpent, ins, relockOuts, keys, nil
 }
 
 func (vm *VM) SpendNFT(
@@ -1068,6 +1392,156 @@ func (vm *VM) Mint(
 	return ops, keys, nil
 }
 
+// Burn returns the operations that burn [amount] of [assetID] held by [kc].
+// The funds of the burned UTXOs that aren't burned are sent to [changeAddr].
+func (vm *VM) Burn(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	amount uint64,
+	changeAddr ids.ShortID,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	time := vm.clock.Unix()
+
+	burnableUTXOs := []*djtx.UTXO{}
+	burnableIns := []*secp256k1fx.TransferInput{}
+	burnableSigners := [][]*crypto.PrivateKeySECP256K1R{}
+	burnableAmounts := []uint64{}
+	for _, utxo := range utxos {
+		if utxo.AssetID() != assetID {
+			continue
+		}
+
+		// Vesting outputs aren't burned, as their locks would be lost
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+
+		inIntf, signers, err := kc.Spend(out, time)
+		if err != nil {
+			continue
+		}
+
+		in, ok := inIntf.(*secp256k1fx.TransferInput)
+		if !ok {
+			continue
+		}
+
+		burnableUTXOs = append(burnableUTXOs, utxo)
+		burnableIns = append(burnableIns, in)
+		burnableSigners = append(burnableSigners, signers)
+		burnableAmounts = append(burnableAmounts, in.Amt)
+	}
+
+	ops := []*Operation{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	amountBurned := uint64(0)
+	for _, i := range vm.coinSelector.Select(burnableAmounts, amount) {
+		utxo := burnableUTXOs[i]
+		in := burnableIns[i]
+
+		op := &secp256k1fx.BurnOperation{
+			Input: in.Input,
+			Amt:   safemath.Min64(amount-amountBurned, in.Amt),
+		}
+		amountBurned += op.Amt
+		if change := in.Amt - op.Amt; change > 0 {
+			op.Change = []*secp256k1fx.TransferOutput{{
+				Amt: change,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{changeAddr},
+				},
+			}}
+		}
+
+		// add the operation to the array
+		ops = append(ops, &Operation{
+			Asset:   utxo.Asset,
+			UTXOIDs: []*djtx.UTXOID{&utxo.UTXOID},
+			Op:      op,
+		})
+		// add the required keys to the array
+		keys = append(keys, burnableSigners[i])
+	}
+
+	if amountBurned < amount {
+		return nil, nil, fmt.Errorf("want to burn %d of asset %s but only have %d",
+			amount,
+			assetID,
+			amountBurned,
+		)
+	}
+
+	sortOperationsWithSigners(ops, keys, vm.codec)
+	return ops, keys, nil
+}
+
+// RenounceMinting returns the operations that consume all of the mint outputs
+// of [assetID] held by [kc], which permanently gives up the authority to mint
+// the asset that they grant.
+func (vm *VM) RenounceMinting(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	time := vm.clock.Unix()
+
+	ops := []*Operation{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+
+	for _, utxo := range utxos {
+		// makes sure that the variable isn't overwritten with the next iteration
+		utxo := utxo
+
+		if utxo.AssetID() != assetID {
+			continue
+		}
+
+		out, ok := utxo.Out.(*secp256k1fx.MintOutput)
+		if !ok {
+			continue
+		}
+
+		inIntf, signers, err := kc.Spend(out, time)
+		if err != nil {
+			continue
+		}
+
+		in, ok := inIntf.(*secp256k1fx.Input)
+		if !ok {
+			continue
+		}
+
+		// add the operation to the array
+		ops = append(ops, &Operation{
+			Asset:   utxo.Asset,
+			UTXOIDs: []*djtx.UTXOID{&utxo.UTXOID},
+			Op: &secp256k1fx.RenounceMintOperation{
+				MintInput: *in,
+			},
+		})
+		// add the required keys to the array
+		keys = append(keys, signers)
+	}
+
+	if len(ops) == 0 {
+		return nil, nil, errAddressesCantMintAsset
+	}
+
+	sortOperationsWithSigners(ops, keys, vm.codec)
+	return ops, keys, nil
+}
+
 func (vm *VM) MintNFT(
 	utxos []*djtx.UTXO,
 	kc *secp256k1fx.Keychain,
@@ -1163,3 +1637,19 @@ func (vm *VM) lookupAssetID(asset string) (ids.ID, error) {
 	}
 	return ids.ID{}, fmt.Errorf("asset '%s' not found", asset)
 }
+
+// parseMemo returns the memo field of a transaction that sends [outputs]. If
+// [publicKey] isn't empty, [memo] is encrypted to it.
+func (vm *VM) parseMemo(memo string, publicKey string, outputs []SendOutput) ([]byte, error) {
+	recipients := ids.NewShortSet(len(outputs))
+	if publicKey != "" {
+		for _, output := range outputs {
+			to, err := vm.ParseLocalAddress(output.To)
+			if err != nil {
+				return nil, fmt.Errorf("problem parsing to address %q: %w", output.To, err)
+			}
+			recipients.Add(to)
+		}
+	}
+	return djtx.ParseMemo(memo, publicKey, recipients)
+}
@@ -239,6 +239,12 @@ func GenesisVM(tb testing.TB) ([]byte, chan common.Message, *VM, *atomic.Memory)
 }
 
 func GenesisVMWithArgs(tb testing.TB, args *BuildGenesisArgs) ([]byte, chan common.Message, *VM, *atomic.Memory) {
+	return genesisVMWithFxs(tb, args, nil)
+}
+
+// genesisVMWithFxs returns a VM that supports the secp256k1fx, the nftfx, and
+// [extraFxs], in that order
+func genesisVMWithFxs(tb testing.TB, args *BuildGenesisArgs, extraFxs []*common.Fx) ([]byte, chan common.Message, *VM, *atomic.Memory) {
 	var genesisBytes []byte
 
 	if args != nil {
@@ -283,7 +289,7 @@ func GenesisVMWithArgs(tb testing.TB, args *BuildGenesisArgs) ([]byte, chan comm
 		nil,
 		nil,
 		issuer,
-		[]*common.Fx{
+		append([]*common.Fx{
 			{
 				ID: ids.Empty,
 				Fx: &secp256k1fx.Fx{},
@@ -292,7 +298,7 @@ func GenesisVMWithArgs(tb testing.TB, args *BuildGenesisArgs) ([]byte, chan comm
 				ID: nftfx.ID,
 				Fx: &nftfx.Fx{},
 			},
-		},
+		}, extraFxs...),
 	)
 	if err != nil {
 		tb.Fatal(err)
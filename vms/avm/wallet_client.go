@@ -62,7 +62,7 @@ func (c *WalletClient) Send(
 			AssetID: assetID,
 			To:      to,
 		},
-		Memo: memo,
+		JSONMemo: api.JSONMemo{Memo: memo},
 	}, res)
 	return res.TxID, err
 }
@@ -82,8 +82,52 @@ func (c *WalletClient) SendMultiple(
 			JSONFromAddrs:  api.JSONFromAddrs{From: from},
 			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
 		},
-		Outputs: outputs,
-		Memo:    memo,
+		Outputs:  outputs,
+		JSONMemo: api.JSONMemo{Memo: memo},
 	}, res)
 	return res.TxID, err
 }
+
+// SendMultipleEncrypted sends a transaction from [user] funding all [outputs]
+// with [memo] encrypted to the CB58 encoded public key [memoPublicKey] of a
+// recipient
+func (c *WalletClient) SendMultipleEncrypted(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	outputs []SendOutput,
+	memo string,
+	memoPublicKey string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("sendMultiple", &SendMultipleArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		Outputs:  outputs,
+		JSONMemo: api.JSONMemo{Memo: memo, MemoPublicKey: memoPublicKey},
+	}, res)
+	return res.TxID, err
+}
+
+// Consolidate merges the UTXOs of [assetID] held by [from] into as few UTXOs
+// as possible, sent to [changeAddr]
+func (c *WalletClient) Consolidate(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+) ([]ids.ID, error) {
+	res := &ConsolidateReply{}
+	err := c.requester.SendRequest("consolidate", &ConsolidateArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		AssetID: assetID,
+	}, res)
+	return res.TxIDs, err
+}
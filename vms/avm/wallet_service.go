@@ -5,18 +5,28 @@ package avm
 
 import (
 	"container/list"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
+var (
+	errNothingToConsolidate  = errors.New("there are fewer than two UTXOs to consolidate")
+	errTooLargeToConsolidate = errors.New("not enough UTXOs fit in a transaction to consolidate them")
+)
+
 type WalletService struct {
 	vm *VM
 
@@ -103,7 +113,7 @@ func (w *WalletService) Send(r *http.Request, args *SendArgs, reply *api.JSONTxI
 	return w.SendMultiple(r, &SendMultipleArgs{
 		JSONSpendHeader: args.JSONSpendHeader,
 		Outputs:         []SendOutput{args.SendOutput},
-		Memo:            args.Memo,
+		JSONMemo:        args.JSONMemo,
 	}, reply)
 }
 
@@ -111,16 +121,16 @@ func (w *WalletService) Send(r *http.Request, args *SendArgs, reply *api.JSONTxI
 func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, reply *api.JSONTxIDChangeAddr) error {
 	w.vm.ctx.Log.Debug("AVM Wallet: SendMultiple called with username: %s", args.Username)
 
-	// Validate the memo field
-	memoBytes := []byte(args.Memo)
-	if l := len(memoBytes); l > djtx.MaxMemoSize {
-		return fmt.Errorf("max memo length is %d but provided memo field is length %d",
-			djtx.MaxMemoSize,
-			l)
-	} else if len(args.Outputs) == 0 {
+	if len(args.Outputs) == 0 {
 		return errNoOutputs
 	}
 
+	// Validate the memo field
+	memoBytes, err := w.vm.parseMemo(args.Memo, args.MemoPublicKey, args.Outputs)
+	if err != nil {
+		return err
+	}
+
 	// Parse the from addresses
 	fromAddrs := ids.NewShortSet(len(args.From))
 	for _, addrStr := range args.From {
@@ -197,21 +207,12 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		})
 	}
 
-	amountsWithFee := make(map[ids.ID]uint64, len(amounts)+1)
-	for assetKey, amount := range amounts {
-		amountsWithFee[assetKey] = amount
-	}
-
-	amountWithFee, err := safemath.Add64(amounts[w.vm.feeAssetID], w.vm.txFee)
-	if err != nil {
-		return fmt.Errorf("problem calculating required spend amount: %w", err)
-	}
-	amountsWithFee[w.vm.feeAssetID] = amountWithFee
-
-	amountsSpent, ins, keys, err := w.vm.Spend(
+	amountsWithFee, amountsSpent, ins, _, keys, err := w.vm.spendWithFee(
 		utxos,
 		kc,
-		amountsWithFee,
+		amounts,
+		w.vm.txFee,
+		false,
 	)
 	if err != nil {
 		return err
@@ -257,3 +258,277 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 	reply.ChangeAddr, err = w.vm.FormatLocalAddress(changeAddr)
 	return err
 }
+
+// ConsolidateArgs are the arguments for Consolidate
+type ConsolidateArgs struct {
+	api.JSONSpendHeader
+
+	// Asset whose UTXOs are merged. Defaults to the fee asset.
+	AssetID string `json:"assetID"`
+}
+
+// ConsolidateReply is the response from Consolidate
+type ConsolidateReply struct {
+	// IDs of the issued transactions, in the order they were issued
+	TxIDs []ids.ID `json:"txIDs"`
+	api.JSONChangeAddr
+}
+
+// mergeableUTXO is a UTXO that can be merged by Consolidate
+type mergeableUTXO struct {
+	in      *djtx.TransferableInput
+	signers []*crypto.PrivateKeySECP256K1R
+	// number of bytes that spending the UTXO adds to a tx
+	size int
+}
+
+// Consolidate merges the UTXOs of an asset into a single UTXO. The smallest
+// UTXOs are merged first. If the UTXOs don't fit in a single transaction,
+// they're merged by several transactions, each of which produces a UTXO.
+func (w *WalletService) Consolidate(r *http.Request, args *ConsolidateArgs, reply *ConsolidateReply) error {
+	w.vm.ctx.Log.Debug("AVM Wallet: Consolidate called with username: %s", args.Username)
+
+	// Parse the from addresses
+	fromAddrs := ids.NewShortSet(len(args.From))
+	for _, addrStr := range args.From {
+		addr, err := w.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'From' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// Load user's UTXOs/keys
+	allUTXOs, kc, err := w.vm.LoadUser(args.Username, args.Password, fromAddrs)
+	if err != nil {
+		return err
+	}
+
+	utxos, err := w.update(allUTXOs)
+	if err != nil {
+		return err
+	}
+
+	// Parse the change address.
+	if len(kc.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr, err := w.vm.selectChangeAddr(kc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	assetID := w.vm.feeAssetID
+	if args.AssetID != "" {
+		assetID, err = w.vm.lookupAssetID(args.AssetID)
+		if err != nil {
+			return fmt.Errorf("couldn't find asset %s", args.AssetID)
+		}
+	}
+
+	mergeables, err := w.mergeableUTXOs(utxos, kc, assetID)
+	if err != nil {
+		return err
+	}
+	if len(mergeables) < 2 {
+		return errNothingToConsolidate
+	}
+
+	for len(mergeables) >= 2 {
+		// The fee of each tx may be paid with the change of the previous one
+		utxos, err := w.update(allUTXOs)
+		if err != nil {
+			return err
+		}
+		tx, numMerged, err := w.consolidationTx(utxos, kc, assetID, mergeables, changeAddr)
+		if err != nil {
+			return err
+		}
+
+		txID, err := w.issue(tx.Bytes())
+		if err != nil {
+			return fmt.Errorf("problem issuing transaction: %w", err)
+		}
+		reply.TxIDs = append(reply.TxIDs, txID)
+		mergeables = mergeables[numMerged:]
+	}
+
+	reply.ChangeAddr, err = w.vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
+// mergeableUTXOs returns the UTXOs of [assetID] in [utxos] that [kc] can
+// spend now, smallest first. Locked UTXOs are skipped so that their locks
+// aren't lost.
+func (w *WalletService) mergeableUTXOs(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+) ([]mergeableUTXO, error) {
+	now := w.vm.clock.Unix()
+	mergeables := []mergeableUTXO{}
+	for _, utxo := range utxos {
+		if utxo.AssetID() != assetID {
+			continue
+		}
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+		inIntf, signers, err := kc.Spend(out, now)
+		if err != nil {
+			// this utxo can't be spent with the current keys right now
+			continue
+		}
+		in, ok := inIntf.(djtx.TransferableIn)
+		if !ok {
+			continue
+		}
+		transferableIn := &djtx.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  djtx.Asset{ID: assetID},
+			In:     in,
+		}
+
+		inBytes, err := w.vm.codec.Marshal(codecVersion, transferableIn)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't marshal input: %w", err)
+		}
+		var cred verify.Verifiable = &secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(signers)),
+		}
+		credBytes, err := w.vm.codec.Marshal(codecVersion, &cred)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't marshal credential: %w", err)
+		}
+
+		mergeables = append(mergeables, mergeableUTXO{
+			in:      transferableIn,
+			signers: signers,
+			// the codec version isn't repeated in the tx
+			size: len(inBytes) + len(credBytes) - 2*wrappers.ShortLen,
+		})
+	}
+	sort.SliceStable(mergeables, func(i, j int) bool {
+		return mergeables[i].in.Input().Amount() < mergeables[j].in.Input().Amount()
+	})
+	return mergeables, nil
+}
+
+// consolidationTx returns a tx that merges, in order, as many of the
+// [mergeables] as fit in a tx, and the number of them that it merges. If the
+// merged asset isn't the fee asset, the fee is paid with [utxos] of the other
+// assets.
+func (w *WalletService) consolidationTx(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	mergeables []mergeableUTXO,
+	changeAddr ids.ShortID,
+) (*Tx, int, error) {
+	ins := []*djtx.TransferableInput{}
+	outs := []*djtx.TransferableOutput{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	fee := uint64(0)
+	if assetID == w.vm.feeAssetID {
+		fee = w.vm.txFee
+	} else {
+		feeUTXOs := make([]*djtx.UTXO, 0, len(utxos))
+		for _, utxo := range utxos {
+			if utxo.AssetID() != assetID {
+				feeUTXOs = append(feeUTXOs, utxo)
+			}
+		}
+		amountsWithFee, amountsSpent, feeIns, _, feeKeys, err := w.vm.spendWithFee(
+			feeUTXOs,
+			kc,
+			nil,
+			w.vm.txFee,
+			false,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		ins = append(ins, feeIns...)
+		keys = append(keys, feeKeys...)
+
+		// Add the required change outputs
+		for feeAssetID, amountWithFee := range amountsWithFee {
+			amountSpent := amountsSpent[feeAssetID]
+
+			if amountSpent > amountWithFee {
+				outs = append(outs, &djtx.TransferableOutput{
+					Asset: djtx.Asset{ID: feeAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt: amountSpent - amountWithFee,
+						OutputOwners: secp256k1fx.OutputOwners{
+							Threshold: 1,
+							Addrs:     []ids.ShortID{changeAddr},
+						},
+					},
+				})
+			}
+		}
+	}
+	mergedOut := &secp256k1fx.TransferOutput{
+		OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{changeAddr},
+		},
+	}
+	outs = append(outs, &djtx.TransferableOutput{
+		Asset: djtx.Asset{ID: assetID},
+		Out:   mergedOut,
+	})
+
+	// Size of the tx before any UTXOs are merged
+	tx := Tx{UnsignedTx: &BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    w.vm.ctx.NetworkID,
+		BlockchainID: w.vm.ctx.ChainID,
+		Outs:         outs,
+		Ins:          ins,
+	}}}
+	if err := tx.SignSECP256K1Fx(w.vm.codec, keys); err != nil {
+		return nil, 0, err
+	}
+	size := len(tx.Bytes())
+
+	maxSize := w.vm.codec.MaxSize()
+	numMerged := 0
+	merged := uint64(0)
+	for _, mergeable := range mergeables {
+		if size+mergeable.size > maxSize {
+			break
+		}
+		newMerged, err := safemath.Add64(merged, mergeable.in.Input().Amount())
+		if err != nil {
+			// the rest is merged by another tx
+			break
+		}
+		size += mergeable.size
+		merged = newMerged
+		numMerged++
+		ins = append(ins, mergeable.in)
+		keys = append(keys, mergeable.signers)
+	}
+	if numMerged < 2 {
+		return nil, 0, errTooLargeToConsolidate
+	}
+	if merged <= fee {
+		return nil, 0, errInsufficientFunds
+	}
+	mergedOut.Amt = merged - fee
+
+	djtx.SortTransferableInputsWithSigners(ins, keys)
+	djtx.SortTransferableOutputs(outs, w.vm.codec)
+	tx = Tx{UnsignedTx: &BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    w.vm.ctx.NetworkID,
+		BlockchainID: w.vm.ctx.ChainID,
+		Outs:         outs,
+		Ins:          ins,
+	}}}
+	if err := tx.SignSECP256K1Fx(w.vm.codec, keys); err != nil {
+		return nil, 0, err
+	}
+	return &tx, numMerged, nil
+}
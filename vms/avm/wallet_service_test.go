@@ -10,6 +10,8 @@ import (
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 // Returns:
@@ -132,3 +134,143 @@ func TestWalletService_SendMultiple(t *testing.T) {
 		})
 	}
 }
+
+func TestWalletService_Consolidate(t *testing.T) {
+	_, vm, ws, _, genesisTx := setupWSWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+	vm.timer.Cancel()
+
+	assetID := genesisTx.ID()
+	addrStrs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		addrStr, err := vm.FormatLocalAddress(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addrStrs[i] = addrStr
+	}
+	accept := func(txID ids.ID) {
+		if err := (&UniqueTx{vm: vm, txID: txID}).Accept(); err != nil {
+			t.Fatal(err)
+		}
+		ws.decided(txID)
+	}
+	// balance returns the number of UTXOs of the asset that addrs[1] holds,
+	// and their total amount
+	balance := func() (int, uint64) {
+		utxos, err := vm.getAllUTXOs(ids.ShortSet{addrs[1]: struct{}{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		numUTXOs := 0
+		total := uint64(0)
+		for _, utxo := range utxos {
+			if utxo.AssetID() == assetID {
+				numUTXOs++
+				total += utxo.Out.(*secp256k1fx.TransferOutput).Amt
+			}
+		}
+		return numUTXOs, total
+	}
+	// sendDust sends [n] small UTXOs to addrs[1]
+	sendDust := func(n int) {
+		outputs := make([]SendOutput, n)
+		for i := range outputs {
+			outputs[i] = SendOutput{
+				Amount:  json.Uint64(1000 + i),
+				AssetID: assetID.String(),
+				To:      addrStrs[1],
+			}
+		}
+		reply := &api.JSONTxIDChangeAddr{}
+		err := ws.SendMultiple(nil, &SendMultipleArgs{
+			JSONSpendHeader: api.JSONSpendHeader{
+				UserPass: api.UserPass{
+					Username: username,
+					Password: password,
+				},
+				JSONFromAddrs:  api.JSONFromAddrs{From: []string{addrStrs[0]}},
+				JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStrs[0]},
+			},
+			Outputs: outputs,
+		}, reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+		accept(reply.TxID)
+	}
+	consolidate := func() []ids.ID {
+		reply := &ConsolidateReply{}
+		err := ws.Consolidate(nil, &ConsolidateArgs{
+			JSONSpendHeader: api.JSONSpendHeader{
+				UserPass: api.UserPass{
+					Username: username,
+					Password: password,
+				},
+				JSONFromAddrs:  api.JSONFromAddrs{From: []string{addrStrs[1]}},
+				JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStrs[1]},
+			},
+		}, reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, txID := range reply.TxIDs {
+			accept(txID)
+		}
+		return reply.TxIDs
+	}
+
+	// All of the UTXOs fit in a single tx
+	sendDust(10)
+	_, totalBefore := balance()
+	if txIDs := consolidate(); len(txIDs) != 1 {
+		t.Fatalf("expected 1 consolidation tx but got %d", len(txIDs))
+	}
+	numUTXOs, total := balance()
+	if numUTXOs != 1 {
+		t.Fatalf("expected the UTXOs to be merged into 1 but there are %d", numUTXOs)
+	}
+	if expected := totalBefore - vm.txFee; total != expected {
+		t.Fatalf("expected a balance of %d but got %d", expected, total)
+	}
+
+	// The UTXOs must be split between several txs
+	sendDust(10)
+	maxSize := vm.codec.MaxSize()
+	vm.codec.SetMaxSize(1000)
+	txIDs := consolidate()
+	vm.codec.SetMaxSize(maxSize)
+	if len(txIDs) < 2 {
+		t.Fatalf("expected several consolidation txs but got %d", len(txIDs))
+	}
+	for _, txID := range txIDs {
+		tx, err := vm.GetTx(txID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size := len(tx.Bytes()); size > 1000 {
+			t.Fatalf("consolidation tx is %d bytes", size)
+		}
+	}
+	// Each tx produces a UTXO, and a single UTXO may be left unmerged
+	if numUTXOs, _ := balance(); numUTXOs > len(txIDs)+1 {
+		t.Fatalf("expected at most %d UTXOs but there are %d", len(txIDs)+1, numUTXOs)
+	}
+
+	if err := ws.Consolidate(nil, &ConsolidateArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs: api.JSONFromAddrs{From: []string{addrStrs[2]}},
+		},
+	}, &ConsolidateReply{}); err != errNothingToConsolidate {
+		t.Fatalf("expected %s but got %v", errNothingToConsolidate, err)
+	}
+}
@@ -0,0 +1,188 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package djtx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// The coin selection strategies that can be configured
+const (
+	// GreedySelection spends UTXOs in the order they are given
+	GreedySelection = "greedy"
+	// LargestFirstSelection spends the largest UTXOs first, which minimizes
+	// the number of inputs
+	LargestFirstSelection = "largestFirst"
+	// BranchAndBoundSelection searches for UTXOs that exactly fund the spend,
+	// so that no change is produced, and otherwise minimizes the change
+	BranchAndBoundSelection = "branchAndBound"
+	// MinimizeChangeSelection spends the largest UTXOs until the remainder can
+	// be funded by a single UTXO, which is chosen as small as possible
+	MinimizeChangeSelection = "minimizeChange"
+
+	// maxBranchAndBoundTries is the number of branches that the branch and
+	// bound search explores before giving up
+	maxBranchAndBoundTries = 100000
+)
+
+var (
+	errUnknownCoinSelection = errors.New("unknown coin selection strategy")
+
+	_ CoinSelector = greedySelector{}
+	_ CoinSelector = largestFirstSelector{}
+	_ CoinSelector = branchAndBoundSelector{}
+	_ CoinSelector = minimizeChangeSelector{}
+)
+
+// CoinSelector chooses the UTXOs that fund a spend of a single asset
+type CoinSelector interface {
+	// Select returns, in increasing order, the indices of the [amounts] that
+	// should be spent to spend at least [target]. If the [amounts] can't fund
+	// [target], the indices of all of them are returned.
+	Select(amounts []uint64, target uint64) []int
+}
+
+// NewCoinSelector returns the coin selector that implements [strategy]. The
+// empty strategy is greedy selection.
+func NewCoinSelector(strategy string) (CoinSelector, error) {
+	switch strategy {
+	case "", GreedySelection:
+		return greedySelector{}, nil
+	case LargestFirstSelection:
+		return largestFirstSelector{}, nil
+	case BranchAndBoundSelection:
+		return branchAndBoundSelector{maxTries: maxBranchAndBoundTries}, nil
+	case MinimizeChangeSelection:
+		return minimizeChangeSelector{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownCoinSelection, strategy)
+	}
+}
+
+type greedySelector struct{}
+
+func (greedySelector) Select(amounts []uint64, target uint64) []int {
+	selected := []int(nil)
+	sum := uint64(0)
+	for i, amount := range amounts {
+		if sum >= target {
+			break
+		}
+		selected = append(selected, i)
+		sum = addSaturated(sum, amount)
+	}
+	return selected
+}
+
+type largestFirstSelector struct{}
+
+func (largestFirstSelector) Select(amounts []uint64, target uint64) []int {
+	selected := []int(nil)
+	sum := uint64(0)
+	for _, i := range descendingOrder(amounts) {
+		if sum >= target {
+			break
+		}
+		selected = append(selected, i)
+		sum = addSaturated(sum, amounts[i])
+	}
+	sort.Ints(selected)
+	return selected
+}
+
+type branchAndBoundSelector struct{ maxTries int }
+
+func (s branchAndBoundSelector) Select(amounts []uint64, target uint64) []int {
+	if target == 0 {
+		return nil
+	}
+
+	order := descendingOrder(amounts)
+	// remaining[i] is the sum of the amounts at order[i:]
+	remaining := make([]uint64, len(order)+1)
+	for i := len(order) - 1; i >= 0; i-- {
+		remaining[i] = addSaturated(remaining[i+1], amounts[order[i]])
+	}
+
+	selected := make([]int, 0, len(order))
+	tries := 0
+	// search returns true if the amounts at order[i:] can be added to [sum]
+	// to exactly reach [target]. If so, they are added to [selected].
+	var search func(i int, sum uint64) bool
+	search = func(i int, sum uint64) bool {
+		if sum == target {
+			return true
+		}
+		if i == len(order) || tries >= s.maxTries || addSaturated(sum, remaining[i]) < target {
+			return false
+		}
+		tries++
+
+		if amount := amounts[order[i]]; amount <= target-sum {
+			selected = append(selected, order[i])
+			if search(i+1, sum+amount) {
+				return true
+			}
+			selected = selected[:len(selected)-1]
+		}
+		return search(i+1, sum)
+	}
+	if !search(0, 0) {
+		return minimizeChangeSelector{}.Select(amounts, target)
+	}
+	sort.Ints(selected)
+	return selected
+}
+
+type minimizeChangeSelector struct{}
+
+func (minimizeChangeSelector) Select(amounts []uint64, target uint64) []int {
+	selected := []int(nil)
+	sum := uint64(0)
+	order := descendingOrder(amounts)
+	for sum < target && len(order) > 0 {
+		needed := target - sum
+		// As the amounts are in decreasing order, this is the index of the
+		// smallest amount that funds the remainder, if there is one
+		i := sort.Search(len(order), func(i int) bool { return amounts[order[i]] < needed }) - 1
+		if i >= 0 {
+			// Of equal amounts, the first one given is spent
+			for i > 0 && amounts[order[i-1]] == amounts[order[i]] {
+				i--
+			}
+			selected = append(selected, order[i])
+			break
+		}
+		selected = append(selected, order[0])
+		sum = addSaturated(sum, amounts[order[0]])
+		order = order[1:]
+	}
+	sort.Ints(selected)
+	return selected
+}
+
+// descendingOrder returns the indices of [amounts] sorted by decreasing
+// amount. Equal amounts keep their order.
+func descendingOrder(amounts []uint64) []int {
+	order := make([]int, len(amounts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return amounts[order[i]] > amounts[order[j]] })
+	return order
+}
+
+// addSaturated returns a+b, or the max uint64 if that overflows
+func addSaturated(a, b uint64) uint64 {
+	sum, err := safemath.Add64(a, b)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return sum
+}
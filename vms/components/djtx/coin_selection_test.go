@@ -0,0 +1,142 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package djtx
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestNewCoinSelector(t *testing.T) {
+	for _, strategy := range []string{
+		"",
+		GreedySelection,
+		LargestFirstSelection,
+		BranchAndBoundSelection,
+		MinimizeChangeSelection,
+	} {
+		if _, err := NewCoinSelector(strategy); err != nil {
+			t.Fatalf("strategy %q: %s", strategy, err)
+		}
+	}
+	if _, err := NewCoinSelector("smallestFirst"); !errors.Is(err, errUnknownCoinSelection) {
+		t.Fatalf("expected %s but got %v", errUnknownCoinSelection, err)
+	}
+}
+
+func TestCoinSelectors(t *testing.T) {
+	tests := []struct {
+		description string
+		amounts     []uint64
+		target      uint64
+		expected    map[string][]int
+	}{
+		{
+			description: "no target",
+			amounts:     []uint64{1, 2, 3},
+			target:      0,
+			expected: map[string][]int{
+				GreedySelection:         nil,
+				LargestFirstSelection:   nil,
+				BranchAndBoundSelection: nil,
+				MinimizeChangeSelection: nil,
+			},
+		},
+		{
+			description: "insufficient funds",
+			amounts:     []uint64{1, 2, 3},
+			target:      7,
+			expected: map[string][]int{
+				GreedySelection:         {0, 1, 2},
+				LargestFirstSelection:   {0, 1, 2},
+				BranchAndBoundSelection: {0, 1, 2},
+				MinimizeChangeSelection: {0, 1, 2},
+			},
+		},
+		{
+			description: "exact match",
+			amounts:     []uint64{1, 5, 9, 3, 4},
+			target:      8,
+			expected: map[string][]int{
+				GreedySelection:         {0, 1, 2},
+				LargestFirstSelection:   {2},
+				BranchAndBoundSelection: {1, 3},
+				MinimizeChangeSelection: {2},
+			},
+		},
+		{
+			description: "no exact match",
+			amounts:     []uint64{10, 6, 50, 7},
+			target:      14,
+			expected: map[string][]int{
+				GreedySelection:         {0, 1},
+				LargestFirstSelection:   {2},
+				BranchAndBoundSelection: {2},
+				MinimizeChangeSelection: {2},
+			},
+		},
+		{
+			description: "several inputs needed",
+			amounts:     []uint64{2, 10, 6, 4, 7},
+			target:      19,
+			expected: map[string][]int{
+				GreedySelection:         {0, 1, 2, 3},
+				LargestFirstSelection:   {1, 2, 4},
+				BranchAndBoundSelection: {0, 1, 4},
+				MinimizeChangeSelection: {0, 1, 4},
+			},
+		},
+		{
+			description: "minimal change",
+			amounts:     []uint64{2, 10, 6, 4, 9},
+			target:      14,
+			expected: map[string][]int{
+				GreedySelection:         {0, 1, 2},
+				LargestFirstSelection:   {1, 4},
+				BranchAndBoundSelection: {1, 3},
+				MinimizeChangeSelection: {1, 3},
+			},
+		},
+		{
+			description: "overflowing amounts",
+			amounts:     []uint64{math.MaxUint64, math.MaxUint64},
+			target:      math.MaxUint64,
+			expected: map[string][]int{
+				GreedySelection:         {0},
+				LargestFirstSelection:   {0},
+				BranchAndBoundSelection: {0},
+				MinimizeChangeSelection: {0},
+			},
+		},
+	}
+	for _, test := range tests {
+		for strategy, expected := range test.expected {
+			selector, err := NewCoinSelector(strategy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			selected := selector.Select(test.amounts, test.target)
+			if len(selected) != 0 || len(expected) != 0 {
+				if !reflect.DeepEqual(selected, expected) {
+					t.Fatalf("%s with %s selection: expected %v but got %v", test.description, strategy, expected, selected)
+				}
+			}
+		}
+	}
+}
+
+func TestBranchAndBoundSelectorGivesUp(t *testing.T) {
+	amounts := make([]uint64, 64)
+	for i := range amounts {
+		amounts[i] = 2
+	}
+	// An odd target can't be matched exactly, so the search would never
+	// end without a limit on the number of tries
+	selected := branchAndBoundSelector{maxTries: 1000}.Select(amounts, 101)
+	if len(selected) != 51 {
+		t.Fatalf("expected 51 inputs to be selected but got %d", len(selected))
+	}
+}
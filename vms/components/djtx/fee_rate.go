@@ -0,0 +1,72 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package djtx
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	errNoFeeRateNumerator   = errors.New("fee rate numerator must be positive")
+	errNoFeeRateDenominator = errors.New("fee rate denominator must be positive")
+	errFeeOverflow          = errors.New("fee overflows")
+)
+
+// FeeRate is the rate at which an asset can be burned in place of the fee
+// asset. Numerator units of the asset pay for Denominator units of the fee.
+type FeeRate struct {
+	Numerator   uint64
+	Denominator uint64
+}
+
+func (r FeeRate) Verify() error {
+	switch {
+	case r.Numerator == 0:
+		return errNoFeeRateNumerator
+	case r.Denominator == 0:
+		return errNoFeeRateDenominator
+	default:
+		return nil
+	}
+}
+
+// Fee returns the amount of the asset that pays for [fee] units of the fee
+// asset, rounded up.
+func (r FeeRate) Fee(fee uint64) (uint64, error) {
+	if err := r.Verify(); err != nil {
+		return 0, err
+	}
+	denominator := new(big.Int).SetUint64(r.Denominator)
+	amount := new(big.Int).SetUint64(fee)
+	amount.Mul(amount, new(big.Int).SetUint64(r.Numerator))
+	amount.Add(amount, denominator)
+	amount.Sub(amount, big.NewInt(1))
+	amount.Div(amount, denominator)
+	if !amount.IsUint64() {
+		return 0, errFeeOverflow
+	}
+	return amount.Uint64(), nil
+}
+
+// FeeRates maps the assets that can be burned in place of the fee asset to
+// their rate
+type FeeRates map[ids.ID]FeeRate
+
+// Fees returns the amount of each asset that pays for [fee] units of
+// [feeAssetID], including [feeAssetID] itself.
+func (r FeeRates) Fees(feeAssetID ids.ID, fee uint64) (map[ids.ID]uint64, error) {
+	fees := make(map[ids.ID]uint64, len(r)+1)
+	for assetID, rate := range r {
+		amount, err := rate.Fee(fee)
+		if err != nil {
+			return nil, err
+		}
+		fees[assetID] = amount
+	}
+	fees[feeAssetID] = fee
+	return fees, nil
+}
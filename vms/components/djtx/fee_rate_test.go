@@ -0,0 +1,65 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package djtx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestFeeRateVerify(t *testing.T) {
+	if err := (FeeRate{Denominator: 1}).Verify(); err != errNoFeeRateNumerator {
+		t.Fatalf("expected %s but got %v", errNoFeeRateNumerator, err)
+	}
+	if err := (FeeRate{Numerator: 1}).Verify(); err != errNoFeeRateDenominator {
+		t.Fatalf("expected %s but got %v", errNoFeeRateDenominator, err)
+	}
+	if err := (FeeRate{Numerator: 1, Denominator: 1}).Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFeeRateFee(t *testing.T) {
+	tests := []struct {
+		rate     FeeRate
+		fee      uint64
+		expected uint64
+	}{
+		{rate: FeeRate{Numerator: 1, Denominator: 1}, fee: 1000, expected: 1000},
+		{rate: FeeRate{Numerator: 3, Denominator: 1}, fee: 1000, expected: 3000},
+		{rate: FeeRate{Numerator: 1, Denominator: 3}, fee: 1000, expected: 334},
+		{rate: FeeRate{Numerator: 2, Denominator: 2}, fee: math.MaxUint64, expected: math.MaxUint64},
+		{rate: FeeRate{Numerator: 1, Denominator: 1}, fee: 0, expected: 0},
+	}
+	for _, test := range tests {
+		fee, err := test.rate.Fee(test.fee)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fee != test.expected {
+			t.Fatalf("expected a fee of %d with rate %+v but got %d", test.expected, test.rate, fee)
+		}
+	}
+
+	if _, err := (FeeRate{Numerator: 2, Denominator: 1}).Fee(math.MaxUint64); err != errFeeOverflow {
+		t.Fatalf("expected %s but got %v", errFeeOverflow, err)
+	}
+}
+
+func TestFeeRatesFees(t *testing.T) {
+	feeAssetID := ids.GenerateTestID()
+	otherAssetID := ids.GenerateTestID()
+	rates := FeeRates{
+		otherAssetID: {Numerator: 5, Denominator: 1},
+	}
+	fees, err := rates.Fees(feeAssetID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fees) != 2 || fees[feeAssetID] != 10 || fees[otherAssetID] != 50 {
+		t.Fatalf("wrong fees %v", fees)
+	}
+}
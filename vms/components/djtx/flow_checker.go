@@ -15,7 +15,10 @@ var errInsufficientFunds = errors.New("insufficient funds")
 
 type FlowChecker struct {
 	consumed, produced map[ids.ID]uint64
-	errs               wrappers.Errs
+	// fees maps the assets that can pay the fee to the amount of the asset
+	// that must be burned to pay it
+	fees map[ids.ID]uint64
+	errs wrappers.Errs
 }
 
 func NewFlowChecker() *FlowChecker {
@@ -29,6 +32,11 @@ func (fc *FlowChecker) Consume(assetID ids.ID, amount uint64) { fc.add(fc.consum
 
 func (fc *FlowChecker) Produce(assetID ids.ID, amount uint64) { fc.add(fc.produced, assetID, amount) }
 
+// ProduceFee requires that, on top of what is produced, the fee be burned in
+// any one of the assets of [fees], which maps each asset to the amount of it
+// that pays the fee.
+func (fc *FlowChecker) ProduceFee(fees map[ids.ID]uint64) { fc.fees = fees }
+
 func (fc *FlowChecker) add(value map[ids.ID]uint64, assetID ids.ID, amount uint64) {
 	var err error
 	value[assetID], err = math.Add64(value[assetID], amount)
@@ -45,5 +53,42 @@ func (fc *FlowChecker) Verify() error {
 			}
 		}
 	}
+	if !fc.errs.Errored() && len(fc.fees) > 0 && !fc.feePaid() {
+		fc.errs.Add(errInsufficientFunds)
+	}
 	return fc.errs.Err
 }
+
+// feePaid returns true if the amount burned of one of the fee assets pays the
+// fee. Assumes no more of any asset is produced than is consumed.
+func (fc *FlowChecker) feePaid() bool {
+	for assetID, fee := range fc.fees {
+		if fc.consumed[assetID]-fc.produced[assetID] >= fee {
+			return true
+		}
+	}
+	return false
+}
+
+// Burned returns the amount of each asset that [ins] consume but [outs] don't
+// produce
+func Burned(ins []*TransferableInput, outs []*TransferableOutput) (map[ids.ID]uint64, error) {
+	fc := NewFlowChecker()
+	for _, in := range ins {
+		fc.Consume(in.AssetID(), in.Input().Amount())
+	}
+	for _, out := range outs {
+		fc.Produce(out.AssetID(), out.Output().Amount())
+	}
+	if fc.errs.Errored() {
+		return nil, fc.errs.Err
+	}
+
+	burned := make(map[ids.ID]uint64, len(fc.consumed))
+	for assetID, consumed := range fc.consumed {
+		if produced := fc.produced[assetID]; consumed > produced {
+			burned[assetID] = consumed - produced
+		}
+	}
+	return burned, nil
+}
@@ -0,0 +1,87 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package djtx
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestFlowCheckerProduceFee(t *testing.T) {
+	feeAssetID := ids.GenerateTestID()
+	otherAssetID := ids.GenerateTestID()
+	fees := map[ids.ID]uint64{
+		feeAssetID:   10,
+		otherAssetID: 50,
+	}
+
+	tests := []struct {
+		name        string
+		consumed    map[ids.ID]uint64
+		produced    map[ids.ID]uint64
+		shouldError bool
+	}{
+		{
+			name:     "fee paid in the fee asset",
+			consumed: map[ids.ID]uint64{feeAssetID: 10, otherAssetID: 5},
+			produced: map[ids.ID]uint64{otherAssetID: 5},
+		},
+		{
+			name:     "fee paid in the other asset",
+			consumed: map[ids.ID]uint64{feeAssetID: 5, otherAssetID: 100},
+			produced: map[ids.ID]uint64{feeAssetID: 5, otherAssetID: 50},
+		},
+		{
+			name:        "fee split between assets",
+			consumed:    map[ids.ID]uint64{feeAssetID: 5, otherAssetID: 25},
+			shouldError: true,
+		},
+		{
+			name:        "fee paid but too much produced",
+			consumed:    map[ids.ID]uint64{feeAssetID: 10},
+			produced:    map[ids.ID]uint64{otherAssetID: 1},
+			shouldError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fc := NewFlowChecker()
+			fc.ProduceFee(fees)
+			for assetID, amount := range test.consumed {
+				fc.Consume(assetID, amount)
+			}
+			for assetID, amount := range test.produced {
+				fc.Produce(assetID, amount)
+			}
+			err := fc.Verify()
+			switch {
+			case test.shouldError && err == nil:
+				t.Fatal("should have failed verification")
+			case !test.shouldError && err != nil:
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestBurned(t *testing.T) {
+	assetID := ids.GenerateTestID()
+	otherAssetID := ids.GenerateTestID()
+	ins := []*TransferableInput{
+		{Asset: Asset{ID: assetID}, In: &TestTransferable{Val: 10}},
+		{Asset: Asset{ID: otherAssetID}, In: &TestTransferable{Val: 5}},
+	}
+	outs := []*TransferableOutput{
+		{Asset: Asset{ID: assetID}, Out: &TestTransferable{Val: 4}},
+		{Asset: Asset{ID: otherAssetID}, Out: &TestTransferable{Val: 5}},
+	}
+	burned, err := Burned(ins, outs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(burned) != 1 || burned[assetID] != 6 {
+		t.Fatalf("wrong burned amounts %v", burned)
+	}
+}
@@ -0,0 +1,52 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package djtx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+var (
+	errWrongMemoKeyLength  = fmt.Errorf("memo public key must be %d bytes", crypto.SECP256K1RPKLen)
+	errMemoKeyNotRecipient = errors.New("memo public key doesn't belong to a recipient")
+
+	memoKeyFactory = crypto.FactorySECP256K1R{}
+)
+
+// ParseMemo returns the memo field of a transaction that holds [memo]. If
+// [publicKey] isn't empty, [memo] is encrypted to that CB58 encoded secp256k1
+// public key, whose address must be one of [recipients].
+func ParseMemo(memo string, publicKey string, recipients ids.ShortSet) ([]byte, error) {
+	memoBytes := []byte(memo)
+	if publicKey == "" {
+		if l := len(memoBytes); l > MaxMemoSize {
+			return nil, fmt.Errorf("max memo length is %d but provided memo field is length %d", MaxMemoSize, l)
+		}
+		return memoBytes, nil
+	}
+
+	if l, maxLen := len(memoBytes), MaxMemoSize-crypto.EncryptedMemoOverhead; l > maxLen {
+		return nil, fmt.Errorf("max encrypted memo length is %d but provided memo field is length %d", maxLen, l)
+	}
+	pkBytes, err := formatting.Decode(formatting.CB58, publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("problem decoding memo public key: %w", err)
+	}
+	if len(pkBytes) != crypto.SECP256K1RPKLen {
+		return nil, errWrongMemoKeyLength
+	}
+	pk, err := memoKeyFactory.ToPublicKey(pkBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid memo public key: %w", err)
+	}
+	if !recipients.Contains(pk.Address()) {
+		return nil, errMemoKeyNotRecipient
+	}
+	return crypto.EncryptMemo(pk.(*crypto.PublicKeySECP256K1R), memoBytes)
+}
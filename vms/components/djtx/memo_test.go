@@ -0,0 +1,64 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package djtx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+func TestParseMemo(t *testing.T) {
+	f := crypto.FactorySECP256K1R{}
+	skIntf, err := f.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk := skIntf.(*crypto.PrivateKeySECP256K1R)
+	pk := sk.PublicKey()
+	pkStr, err := formatting.Encode(formatting.CB58, pk.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipients := ids.ShortSet{}
+	recipients.Add(pk.Address())
+
+	// Plaintext memos are returned as they are
+	if memo, err := ParseMemo("hello", "", nil); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(memo, []byte("hello")) {
+		t.Fatalf("expected the plaintext memo but got %q", memo)
+	}
+	if _, err := ParseMemo(strings.Repeat("a", MaxMemoSize+1), "", nil); err == nil {
+		t.Fatal("expected a memo that's too long to be rejected")
+	}
+
+	memo, err := ParseMemo("hello", pkStr, recipients)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plaintext, err := crypto.DecryptMemo(sk, memo); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(plaintext, []byte("hello")) {
+		t.Fatalf("expected %q but got %q", "hello", plaintext)
+	}
+
+	if _, err := ParseMemo(strings.Repeat("a", MaxMemoSize-crypto.EncryptedMemoOverhead+1), pkStr, recipients); err == nil {
+		t.Fatal("expected a memo that's too long to be encrypted to be rejected")
+	}
+	if _, err := ParseMemo("hello", pkStr, nil); err != errMemoKeyNotRecipient {
+		t.Fatalf("expected %s but got %v", errMemoKeyNotRecipient, err)
+	}
+	shortKey, err := formatting.Encode(formatting.CB58, pk.Bytes()[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseMemo("hello", shortKey, recipients); err != errWrongMemoKeyLength {
+		t.Fatalf("expected %s but got %v", errWrongMemoKeyLength, err)
+	}
+}
@@ -199,10 +199,21 @@ func VerifyTx(
 	allIns [][]*TransferableInput,
 	allOuts [][]*TransferableOutput,
 	c codec.Manager,
+) error {
+	return VerifyTxWithFees(map[ids.ID]uint64{feeAssetID: feeAmount}, allIns, allOuts, c)
+}
+
+// VerifyTxWithFees is VerifyTx where the fee can be burned in any one of the
+// assets of [fees], which maps each asset to the amount of it that pays the fee.
+func VerifyTxWithFees(
+	fees map[ids.ID]uint64,
+	allIns [][]*TransferableInput,
+	allOuts [][]*TransferableOutput,
+	c codec.Manager,
 ) error {
 	fc := NewFlowChecker()
 
-	fc.Produce(feeAssetID, feeAmount) // The txFee must be burned
+	fc.ProduceFee(fees) // The txFee must be burned
 
 	// Add all the outputs to the flow checker and make sure they are sorted
 	for _, outs := range allOuts {
@@ -0,0 +1,14 @@
+package htlcfx
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+func TestCredentialState(t *testing.T) {
+	intf := interface{}(&Credential{})
+	if _, ok := intf.(verify.State); ok {
+		t.Fatalf("shouldn't be marked as state")
+	}
+}
@@ -0,0 +1,15 @@
+package htlcfx
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+)
+
+// ID that this Fx uses when labeled
+var (
+	ID = ids.ID{'h', 't', 'l', 'c', 'f', 'x'}
+)
+
+type Factory struct{}
+
+func (f *Factory) New(*snow.Context) (interface{}, error) { return &Fx{}, nil }
@@ -0,0 +1,122 @@
+package htlcfx
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errWrongVMType         = errors.New("wrong vm type")
+	errNoChainTime         = errors.New("htlc outputs can't be spent without a chain time")
+	errWrongTxType         = errors.New("wrong tx type")
+	errWrongUTXOType       = errors.New("wrong utxo type")
+	errWrongInputType      = errors.New("wrong input type")
+	errWrongCredentialType = errors.New("wrong credential type")
+	errWrongAmount         = errors.New("input amount doesn't match the utxo amount")
+	errWrongPreimage       = errors.New("preimage doesn't match the hash")
+	errDeadlinePassed      = errors.New("deadline to claim the utxo has passed")
+	errDeadlineNotPassed   = errors.New("utxo can't be refunded before its deadline")
+	errCantOperate         = errors.New("cant operate with this fx")
+)
+
+// VM that this Fx must be run by
+type VM interface {
+	secp256k1fx.VM
+
+	// ChainTime returns the time of the chain that the transactions are
+	// currently verified at. Returns false if the chain has no time.
+	ChainTime() (time.Time, bool)
+}
+
+// Fx enables hash time-locked contracts, which allow atomic swaps with other
+// chains.
+type Fx struct {
+	secp256k1fx.Fx
+
+	chainVM VM
+}
+
+func (fx *Fx) Initialize(vmIntf interface{}) error {
+	chainVM, ok := vmIntf.(VM)
+	if !ok {
+		return errWrongVMType
+	}
+	if err := fx.InitializeVM(vmIntf); err != nil {
+		return err
+	}
+	fx.chainVM = chainVM
+
+	log := fx.VM.Logger()
+	log.Debug("initializing htlc fx")
+
+	c := fx.VM.CodecRegistry()
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&TransferInput{}),
+		c.RegisterType(&TransferOutput{}),
+		c.RegisterType(&Credential{}),
+	)
+	return errs.Err
+}
+
+func (fx *Fx) VerifyTransfer(txIntf, inIntf, credIntf, utxoIntf interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.Tx)
+	if !ok {
+		return errWrongTxType
+	}
+	in, ok := inIntf.(*TransferInput)
+	if !ok {
+		return errWrongInputType
+	}
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return errWrongCredentialType
+	}
+	out, ok := utxoIntf.(*TransferOutput)
+	if !ok {
+		return errWrongUTXOType
+	}
+	return fx.VerifySpend(tx, in, cred, out)
+}
+
+// VerifySpend ensures that [in] either claims [out] by revealing the preimage
+// of its hash before its deadline, or refunds it after its deadline.
+func (fx *Fx) VerifySpend(tx secp256k1fx.Tx, in *TransferInput, cred *Credential, out *TransferOutput) error {
+	if err := verify.All(in, cred, out); err != nil {
+		return err
+	}
+	if in.Amt != out.Amt {
+		return errWrongAmount
+	}
+
+	// The deadline is compared to the time of the chain, rather than to the
+	// local clock, so that every node verifies the transaction the same way,
+	// including when it is verified again while bootstrapping.
+	chainTime, ok := fx.chainVM.ChainTime()
+	if !ok {
+		return errNoChainTime
+	}
+	now := uint64(chainTime.Unix())
+	if in.IsRefund() {
+		if now < out.Deadline {
+			return errDeadlineNotPassed
+		}
+		return fx.VerifyCredentials(tx, &in.Input, &cred.Credential, &out.Refund)
+	}
+
+	if now >= out.Deadline {
+		return errDeadlinePassed
+	}
+	if ids.ID(hashing.ComputeHash256Array(in.Preimage)) != out.Hash {
+		return errWrongPreimage
+	}
+	return fx.VerifyCredentials(tx, &in.Input, &cred.Credential, &out.Receivers)
+}
+
+func (fx *Fx) VerifyOperation(_, _, _ interface{}, _ []interface{}) error { return errCantOperate }
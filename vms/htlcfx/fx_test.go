@@ -0,0 +1,236 @@
+package htlcfx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	txBytes  = []byte{0, 1, 2, 3, 4, 5}
+	sigBytes = [crypto.SECP256K1RSigLen]byte{
+		0x0e, 0x33, 0x4e, 0xbc, 0x67, 0xa7, 0x3f, 0xe8,
+		0x24, 0x33, 0xac, 0xa3, 0x47, 0x88, 0xa6, 0x3d,
+		0x58, 0xe5, 0x8e, 0xf0, 0x3a, 0xd5, 0x84, 0xf1,
+		0xbc, 0xa3, 0xb2, 0xd2, 0x5d, 0x51, 0xd6, 0x9b,
+		0x0f, 0x28, 0x5d, 0xcd, 0x3f, 0x71, 0x17, 0x0a,
+		0xf9, 0xbf, 0x2d, 0xb1, 0x10, 0x26, 0x5c, 0xe9,
+		0xdc, 0xc3, 0x9d, 0x7a, 0x01, 0x50, 0x9d, 0xe8,
+		0x35, 0xbd, 0xcb, 0x29, 0x3a, 0xd1, 0x49, 0x32,
+		0x00,
+	}
+	addr = [hashing.AddrLen]byte{
+		0x01, 0x5c, 0xce, 0x6c, 0x55, 0xd6, 0xb5, 0x09,
+		0x84, 0x5c, 0x8c, 0x4e, 0x30, 0xbe, 0xd9, 0x8d,
+		0x39, 0x1a, 0xe7, 0xf0,
+	}
+)
+
+var (
+	preimage = []byte("secret")
+	deadline = time.Date(2019, time.January, 19, 16, 25, 17, 3, time.UTC)
+)
+
+// testVM is a VM whose chain has the time [chainTime], if [hasChainTime]
+type testVM struct {
+	secp256k1fx.TestVM
+
+	chainTime    time.Time
+	hasChainTime bool
+}
+
+func (vm *testVM) ChainTime() (time.Time, bool) { return vm.chainTime, vm.hasChainTime }
+
+func setupFx(t *testing.T, now time.Time, hasChainTime bool) *Fx {
+	vm := testVM{
+		TestVM: secp256k1fx.TestVM{
+			Codec: linearcodec.NewDefault(),
+			Log:   logging.NoLog{},
+		},
+		chainTime:    now,
+		hasChainTime: hasChainTime,
+	}
+	fx := &Fx{}
+	if err := fx.Initialize(&vm); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.Bootstrapping(); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+	return fx
+}
+
+func testOutput() *TransferOutput {
+	return &TransferOutput{
+		Amt:      1,
+		Hash:     hashing.ComputeHash256Array(preimage),
+		Deadline: uint64(deadline.Unix()),
+		Receivers: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		},
+		Refund: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		},
+	}
+}
+
+func testInput(preimage []byte) *TransferInput {
+	return &TransferInput{
+		Amt:      1,
+		Preimage: preimage,
+		Input: secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+	}
+}
+
+func testCredential() *Credential {
+	return &Credential{Credential: secp256k1fx.Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{
+			sigBytes,
+		},
+	}}
+}
+
+func TestFxInitialize(t *testing.T) {
+	vm := testVM{TestVM: secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}}
+	fx := Fx{}
+	err := fx.Initialize(&vm)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFxInitializeInvalid(t *testing.T) {
+	fx := Fx{}
+	err := fx.Initialize(nil)
+	if err == nil {
+		t.Fatalf("Should have returned an error")
+	}
+}
+
+func TestFxInitializeNoChainTime(t *testing.T) {
+	vm := secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := Fx{}
+	if err := fx.Initialize(&vm); err != errWrongVMType {
+		t.Fatalf("expected %s but got %v", errWrongVMType, err)
+	}
+}
+
+func TestFxVerifyTransferClaim(t *testing.T) {
+	fx := setupFx(t, deadline.Add(-time.Second), true)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	if err := fx.VerifyTransfer(tx, testInput(preimage), testCredential(), testOutput()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFxVerifyTransferClaimWrongPreimage(t *testing.T) {
+	fx := setupFx(t, deadline.Add(-time.Second), true)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	err := fx.VerifyTransfer(tx, testInput([]byte("wrong")), testCredential(), testOutput())
+	if err != errWrongPreimage {
+		t.Fatalf("expected %s but got %v", errWrongPreimage, err)
+	}
+}
+
+func TestFxVerifyTransferClaimAfterDeadline(t *testing.T) {
+	fx := setupFx(t, deadline, true)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	err := fx.VerifyTransfer(tx, testInput(preimage), testCredential(), testOutput())
+	if err != errDeadlinePassed {
+		t.Fatalf("expected %s but got %v", errDeadlinePassed, err)
+	}
+}
+
+func TestFxVerifyTransferNoChainTime(t *testing.T) {
+	fx := setupFx(t, deadline.Add(-time.Second), false)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	err := fx.VerifyTransfer(tx, testInput(preimage), testCredential(), testOutput())
+	if err != errNoChainTime {
+		t.Fatalf("expected %s but got %v", errNoChainTime, err)
+	}
+	err = fx.VerifyTransfer(tx, testInput(nil), testCredential(), testOutput())
+	if err != errNoChainTime {
+		t.Fatalf("expected %s but got %v", errNoChainTime, err)
+	}
+}
+
+func TestFxVerifyTransferRefund(t *testing.T) {
+	fx := setupFx(t, deadline, true)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	if err := fx.VerifyTransfer(tx, testInput(nil), testCredential(), testOutput()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFxVerifyTransferRefundBeforeDeadline(t *testing.T) {
+	fx := setupFx(t, deadline.Add(-time.Second), true)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	err := fx.VerifyTransfer(tx, testInput(nil), testCredential(), testOutput())
+	if err != errDeadlineNotPassed {
+		t.Fatalf("expected %s but got %v", errDeadlineNotPassed, err)
+	}
+}
+
+func TestFxVerifyTransferWrongRefundOwner(t *testing.T) {
+	fx := setupFx(t, deadline, true)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	out := testOutput()
+	out.Refund.Addrs = []ids.ShortID{ids.GenerateTestShortID()}
+	if err := fx.VerifyTransfer(tx, testInput(nil), testCredential(), out); err == nil {
+		t.Fatalf("should have failed to refund with the wrong signer")
+	}
+}
+
+func TestFxVerifyTransferWrongAmount(t *testing.T) {
+	fx := setupFx(t, deadline.Add(-time.Second), true)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	in := testInput(preimage)
+	in.Amt = 2
+	err := fx.VerifyTransfer(tx, in, testCredential(), testOutput())
+	if err != errWrongAmount {
+		t.Fatalf("expected %s but got %v", errWrongAmount, err)
+	}
+}
+
+func TestFxVerifyTransferWrongTypes(t *testing.T) {
+	fx := setupFx(t, deadline.Add(-time.Second), true)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	if err := fx.VerifyTransfer(nil, testInput(preimage), testCredential(), testOutput()); err != errWrongTxType {
+		t.Fatalf("expected %s but got %v", errWrongTxType, err)
+	}
+	if err := fx.VerifyTransfer(tx, nil, testCredential(), testOutput()); err != errWrongInputType {
+		t.Fatalf("expected %s but got %v", errWrongInputType, err)
+	}
+	if err := fx.VerifyTransfer(tx, testInput(preimage), nil, testOutput()); err != errWrongCredentialType {
+		t.Fatalf("expected %s but got %v", errWrongCredentialType, err)
+	}
+	if err := fx.VerifyTransfer(tx, testInput(preimage), testCredential(), nil); err != errWrongUTXOType {
+		t.Fatalf("expected %s but got %v", errWrongUTXOType, err)
+	}
+}
+
+func TestFxVerifyOperation(t *testing.T) {
+	fx := setupFx(t, deadline, true)
+	if err := fx.VerifyOperation(nil, nil, nil, nil); err != errCantOperate {
+		t.Fatalf("expected %s but got %v", errCantOperate, err)
+	}
+}
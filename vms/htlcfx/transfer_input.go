@@ -0,0 +1,47 @@
+package htlcfx
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const (
+	// MaxPreimageSize is the maximum size of a preimage that can be revealed
+	MaxPreimageSize = 256
+)
+
+var (
+	errNilTransferInput = errors.New("nil transfer input")
+	errNoValueInput     = errors.New("input has no value")
+	errPreimageTooLarge = errors.New("preimage too large")
+)
+
+// TransferInput spends a TransferOutput. The funds are claimed by the receivers
+// if a preimage is revealed, and refunded to the refund owners otherwise.
+type TransferInput struct {
+	Amt uint64 `serialize:"true" json:"amount"`
+	// Preimage of the hash of the output being claimed. Empty when the output
+	// is being refunded.
+	Preimage          []byte `serialize:"true" json:"preimage"`
+	secp256k1fx.Input `serialize:"true"`
+}
+
+// Amount returns the quantity of the asset this input produces
+func (in *TransferInput) Amount() uint64 { return in.Amt }
+
+// IsRefund returns true if this input refunds the output it spends
+func (in *TransferInput) IsRefund() bool { return len(in.Preimage) == 0 }
+
+func (in *TransferInput) Verify() error {
+	switch {
+	case in == nil:
+		return errNilTransferInput
+	case in.Amt == 0:
+		return errNoValueInput
+	case len(in.Preimage) > MaxPreimageSize:
+		return errPreimageTooLarge
+	default:
+		return in.Input.Verify()
+	}
+}
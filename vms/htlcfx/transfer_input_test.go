@@ -0,0 +1,57 @@
+package htlcfx
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestTransferInputVerifyNil(t *testing.T) {
+	in := (*TransferInput)(nil)
+	if err := in.Verify(); err != errNilTransferInput {
+		t.Fatalf("expected %s but got %v", errNilTransferInput, err)
+	}
+}
+
+func TestTransferInputVerifyNoValue(t *testing.T) {
+	in := TransferInput{}
+	if err := in.Verify(); err != errNoValueInput {
+		t.Fatalf("expected %s but got %v", errNoValueInput, err)
+	}
+}
+
+func TestTransferInputVerifyLargePreimage(t *testing.T) {
+	in := TransferInput{
+		Amt:      1,
+		Preimage: make([]byte, MaxPreimageSize+1),
+	}
+	if err := in.Verify(); err != errPreimageTooLarge {
+		t.Fatalf("expected %s but got %v", errPreimageTooLarge, err)
+	}
+}
+
+func TestTransferInputVerifyUnsortedSigIndices(t *testing.T) {
+	in := TransferInput{
+		Amt: 1,
+		Input: secp256k1fx.Input{
+			SigIndices: []uint32{1, 0},
+		},
+	}
+	if err := in.Verify(); err == nil {
+		t.Fatalf("TransferInput.Verify should have errored on unsorted signature indices")
+	}
+}
+
+func TestTransferInputIsRefund(t *testing.T) {
+	in := TransferInput{Amt: 1}
+	if !in.IsRefund() {
+		t.Fatalf("input without a preimage should be a refund")
+	}
+	in.Preimage = []byte{0}
+	if in.IsRefund() {
+		t.Fatalf("input with a preimage shouldn't be a refund")
+	}
+	if err := in.Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,52 @@
+package htlcfx
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errNilTransferOutput              = errors.New("nil transfer output")
+	errNoValueOutput                  = errors.New("output has no value")
+	errNoDeadline                     = errors.New("output has no deadline")
+	_                    verify.State = &TransferOutput{}
+)
+
+// TransferOutput locks funds in a hash time-locked contract. Before the
+// deadline, the funds can be spent by the receivers by revealing a preimage of
+// the hash. From the deadline on, the funds can be spent by the refund owners.
+type TransferOutput struct {
+	Amt uint64 `serialize:"true" json:"amount"`
+	// SHA-256 hash of the preimage that must be revealed to claim the funds
+	Hash ids.ID `serialize:"true" json:"hash"`
+	// Unix time from which the funds can no longer be claimed and can be
+	// refunded
+	Deadline  uint64                   `serialize:"true" json:"deadline"`
+	Receivers secp256k1fx.OutputOwners `serialize:"true" json:"receivers"`
+	Refund    secp256k1fx.OutputOwners `serialize:"true" json:"refund"`
+}
+
+// Amount returns the quantity of the asset this output consumes
+func (out *TransferOutput) Amount() uint64 { return out.Amt }
+
+// Addresses returns the addresses of both the receivers and the refund owners
+func (out *TransferOutput) Addresses() [][]byte {
+	return append(out.Receivers.Addresses(), out.Refund.Addresses()...)
+}
+
+func (out *TransferOutput) Verify() error {
+	switch {
+	case out == nil:
+		return errNilTransferOutput
+	case out.Amt == 0:
+		return errNoValueOutput
+	case out.Deadline == 0:
+		return errNoDeadline
+	}
+	return verify.All(&out.Receivers, &out.Refund)
+}
+
+func (out *TransferOutput) VerifyState() error { return out.Verify() }
@@ -0,0 +1,80 @@
+package htlcfx
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestTransferOutputVerifyNil(t *testing.T) {
+	out := (*TransferOutput)(nil)
+	if err := out.Verify(); err != errNilTransferOutput {
+		t.Fatalf("expected %s but got %v", errNilTransferOutput, err)
+	}
+}
+
+func TestTransferOutputVerifyNoValue(t *testing.T) {
+	out := TransferOutput{Deadline: 1}
+	if err := out.Verify(); err != errNoValueOutput {
+		t.Fatalf("expected %s but got %v", errNoValueOutput, err)
+	}
+}
+
+func TestTransferOutputVerifyNoDeadline(t *testing.T) {
+	out := TransferOutput{Amt: 1}
+	if err := out.Verify(); err != errNoDeadline {
+		t.Fatalf("expected %s but got %v", errNoDeadline, err)
+	}
+}
+
+func TestTransferOutputVerifyInvalidOwners(t *testing.T) {
+	out := TransferOutput{
+		Amt:      1,
+		Deadline: 1,
+		Refund: secp256k1fx.OutputOwners{
+			Addrs: []ids.ShortID{
+				ids.ShortEmpty,
+				ids.ShortEmpty,
+			},
+		},
+	}
+	if err := out.Verify(); err == nil {
+		t.Fatalf("TransferOutput.Verify should have errored on invalid refund owners")
+	}
+}
+
+func TestTransferOutputAddresses(t *testing.T) {
+	receiver := ids.ShortID{1}
+	refund := ids.ShortID{2}
+	out := TransferOutput{
+		Amt:      1,
+		Deadline: 1,
+		Receivers: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{receiver},
+		},
+		Refund: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{refund},
+		},
+	}
+	if err := out.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	addrs := out.Addresses()
+	if len(addrs) != 2 {
+		t.Fatalf("expected 2 addresses but got %d", len(addrs))
+	}
+	if string(addrs[0]) != string(receiver[:]) || string(addrs[1]) != string(refund[:]) {
+		t.Fatalf("wrong addresses returned")
+	}
+}
+
+func TestTransferOutputState(t *testing.T) {
+	intf := interface{}(&TransferOutput{})
+	if _, ok := intf.(verify.State); !ok {
+		t.Fatalf("should be marked as state")
+	}
+}
@@ -15,7 +15,10 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
 
-var _ block.ChainVM = &blockVM{}
+var (
+	_ block.ChainVM = &blockVM{}
+	_ common.AppVM  = &blockVM{}
+)
 
 func NewBlockVM(vm block.ChainVM) block.ChainVM {
 	return &blockVM{
@@ -84,3 +87,17 @@ func (vm *blockVM) LastAccepted() (ids.ID, error) {
 	vm.blockMetrics.lastAccepted.Observe(float64(end.Sub(start)))
 	return lastAcceptedID, err
 }
+
+func (vm *blockVM) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	appVM, ok := vm.ChainVM.(common.AppHandler)
+	if !ok {
+		return nil
+	}
+	return appVM.AppGossip(nodeID, msg)
+}
+
+func (vm *blockVM) SetAppSender(appSender common.AppSender) {
+	if appVM, ok := vm.ChainVM.(common.AppVM); ok {
+		appVM.SetAppSender(appSender)
+	}
+}
@@ -15,7 +15,10 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
 
-var _ vertex.DAGVM = &vertexVM{}
+var (
+	_ vertex.DAGVM = &vertexVM{}
+	_ common.AppVM = &vertexVM{}
+)
 
 func NewVertexVM(vm vertex.DAGVM) vertex.DAGVM {
 	return &vertexVM{
@@ -68,3 +71,17 @@ func (vm *vertexVM) GetTx(txID ids.ID) (snowstorm.Tx, error) {
 	vm.vertexMetrics.get.Observe(float64(end.Sub(start)))
 	return tx, err
 }
+
+func (vm *vertexVM) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	appVM, ok := vm.DAGVM.(common.AppHandler)
+	if !ok {
+		return nil
+	}
+	return appVM.AppGossip(nodeID, msg)
+}
+
+func (vm *vertexVM) SetAppSender(appSender common.AppSender) {
+	if appVM, ok := vm.DAGVM.(common.AppVM); ok {
+		appVM.SetAppSender(appSender)
+	}
+}
@@ -0,0 +1,143 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nftfx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	// MetadataPrefix flags a payload that holds Metadata. Payloads that start
+	// with it should hold valid metadata, see VerifyMetadata.
+	MetadataPrefix = []byte{0x00, 'n', 'f', 't', 'm', 'e', 't', 'a'}
+
+	errNoMetadata           = errors.New("payload doesn't hold metadata")
+	errInvalidMetadata      = errors.New("invalid metadata")
+	errNoName               = errors.New("metadata must have a name")
+	errInvalidURI           = errors.New("metadata URI must be absolute")
+	errWrongContentHashSize = errors.New("content hash must be 32 bytes")
+	errNoAttributeKey       = errors.New("attribute keys must not be empty")
+	errDuplicateAttribute   = errors.New("duplicate attribute key")
+)
+
+// Metadata is the structured content of an NFT payload
+type Metadata struct {
+	// Name of the NFT. Must not be empty.
+	Name string `json:"name"`
+	// URI of the content of the NFT. Optional, but must be absolute if set.
+	URI string `json:"uri"`
+	// Hash of the content of the NFT. Optional, but must be 32 bytes if set.
+	ContentHash []byte `json:"contentHash"`
+	// Attributes of the NFT, with distinct non-empty keys
+	Attributes []Attribute `json:"attributes"`
+}
+
+// Attribute is a key-value pair that describes an NFT
+type Attribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Verify that the metadata is well formed
+func (m *Metadata) Verify() error {
+	switch {
+	case m == nil:
+		return errInvalidMetadata
+	case m.Name == "":
+		return errNoName
+	case len(m.ContentHash) != 0 && len(m.ContentHash) != hashing.HashLen:
+		return errWrongContentHashSize
+	}
+	if m.URI != "" {
+		uri, err := url.Parse(m.URI)
+		if err != nil || !uri.IsAbs() {
+			return errInvalidURI
+		}
+	}
+	keys := make(map[string]struct{}, len(m.Attributes))
+	for _, attribute := range m.Attributes {
+		if attribute.Key == "" {
+			return errNoAttributeKey
+		}
+		if _, ok := keys[attribute.Key]; ok {
+			return fmt.Errorf("%w: %q", errDuplicateAttribute, attribute.Key)
+		}
+		keys[attribute.Key] = struct{}{}
+	}
+	return nil
+}
+
+// Payload returns the payload that holds the metadata
+func (m *Metadata) Payload() ([]byte, error) {
+	if err := m.Verify(); err != nil {
+		return nil, err
+	}
+
+	p := wrappers.Packer{MaxSize: MaxPayloadSize, Bytes: make([]byte, 0, MaxPayloadSize)}
+	p.PackFixedBytes(MetadataPrefix)
+	p.PackStr(m.Name)
+	p.PackStr(m.URI)
+	p.PackBytes(m.ContentHash)
+	p.PackShort(uint16(len(m.Attributes)))
+	for _, attribute := range m.Attributes {
+		p.PackStr(attribute.Key)
+		p.PackStr(attribute.Value)
+	}
+	if p.Errored() {
+		return nil, errPayloadTooLarge
+	}
+	return p.Bytes, nil
+}
+
+// IsMetadata returns true if [payload] is flagged as holding metadata
+func IsMetadata(payload []byte) bool {
+	return bytes.HasPrefix(payload, MetadataPrefix)
+}
+
+// ParseMetadata returns the metadata held by [payload]
+func ParseMetadata(payload []byte) (*Metadata, error) {
+	if !IsMetadata(payload) {
+		return nil, errNoMetadata
+	}
+
+	p := wrappers.Packer{Bytes: payload, Offset: len(MetadataPrefix)}
+	m := &Metadata{
+		Name:        p.UnpackStr(),
+		URI:         p.UnpackStr(),
+		ContentHash: p.UnpackBytes(),
+	}
+	numAttributes := p.UnpackShort()
+	for i := uint16(0); i < numAttributes && !p.Errored(); i++ {
+		m.Attributes = append(m.Attributes, Attribute{
+			Key:   p.UnpackStr(),
+			Value: p.UnpackStr(),
+		})
+	}
+	switch {
+	case p.Errored():
+		return nil, fmt.Errorf("%w: %s", errInvalidMetadata, p.Err)
+	case p.Offset != len(payload):
+		return nil, fmt.Errorf("%w: %d trailing bytes", errInvalidMetadata, len(payload)-p.Offset)
+	}
+	return m, m.Verify()
+}
+
+// VerifyMetadata returns an error if [payload] is flagged as holding metadata
+// but doesn't hold valid metadata. Payloads that aren't flagged are valid.
+// This isn't verified by the outputs and operations of this fx, so that NFTs
+// minted before metadata was introduced remain valid. The VM decides which NFTs
+// it's enforced on.
+func VerifyMetadata(payload []byte) error {
+	if !IsMetadata(payload) {
+		return nil
+	}
+	_, err := ParseMetadata(payload)
+	return err
+}
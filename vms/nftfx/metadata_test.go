@@ -0,0 +1,124 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nftfx
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestMetadataPayload(t *testing.T) {
+	metadata := &Metadata{
+		Name:        "Mona Lisa",
+		URI:         "ipfs://QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o",
+		ContentHash: make([]byte, 32),
+		Attributes: []Attribute{
+			{Key: "artist", Value: "Leonardo"},
+			{Key: "year", Value: "1503"},
+		},
+	}
+	payload, err := metadata.Payload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsMetadata(payload) {
+		t.Fatal("payload should be flagged as holding metadata")
+	}
+	parsed, err := ParseMetadata(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, metadata) {
+		t.Fatalf("expected %+v but got %+v", metadata, parsed)
+	}
+
+	if err := VerifyMetadata(payload); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyMetadata(append(payload, 0)); !errors.Is(err, errInvalidMetadata) {
+		t.Fatalf("expected %s but got %v", errInvalidMetadata, err)
+	}
+	if err := VerifyMetadata(payload[:len(payload)-1]); !errors.Is(err, errInvalidMetadata) {
+		t.Fatalf("expected %s but got %v", errInvalidMetadata, err)
+	}
+
+	// Outputs with invalid metadata remain valid, so that NFTs minted before
+	// metadata was introduced can still be spent
+	out := TransferOutput{
+		Payload: append(payload, 0),
+		OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		},
+	}
+	if err := out.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Payloads that aren't flagged aren't parsed
+	notMetadata := []byte("not metadata")
+	if err := VerifyMetadata(notMetadata); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseMetadata(notMetadata); err != errNoMetadata {
+		t.Fatalf("expected %s but got %v", errNoMetadata, err)
+	}
+}
+
+func TestMetadataVerify(t *testing.T) {
+	tests := []struct {
+		description string
+		metadata    *Metadata
+		expectedErr error
+	}{
+		{
+			description: "nil",
+			expectedErr: errInvalidMetadata,
+		},
+		{
+			description: "no name",
+			metadata:    &Metadata{},
+			expectedErr: errNoName,
+		},
+		{
+			description: "relative URI",
+			metadata:    &Metadata{Name: "nft", URI: "images/nft.png"},
+			expectedErr: errInvalidURI,
+		},
+		{
+			description: "short content hash",
+			metadata:    &Metadata{Name: "nft", ContentHash: []byte{1}},
+			expectedErr: errWrongContentHashSize,
+		},
+		{
+			description: "empty attribute key",
+			metadata:    &Metadata{Name: "nft", Attributes: []Attribute{{Value: "value"}}},
+			expectedErr: errNoAttributeKey,
+		},
+		{
+			description: "duplicate attribute key",
+			metadata: &Metadata{Name: "nft", Attributes: []Attribute{
+				{Key: "color", Value: "red"},
+				{Key: "color", Value: "blue"},
+			}},
+			expectedErr: errDuplicateAttribute,
+		},
+		{
+			description: "too large",
+			metadata:    &Metadata{Name: "nft", Attributes: []Attribute{{Key: "text", Value: string(make([]byte, MaxPayloadSize))}}},
+			expectedErr: errPayloadTooLarge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			if _, err := test.metadata.Payload(); !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected %s but got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
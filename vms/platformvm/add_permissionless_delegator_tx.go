@@ -0,0 +1,330 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	_ UnsignedProposalTx = &UnsignedAddPermissionlessDelegatorTx{}
+	_ TimedTx            = &UnsignedAddPermissionlessDelegatorTx{}
+)
+
+// UnsignedAddPermissionlessDelegatorTx is an unsigned
+// addPermissionlessDelegatorTx. It delegates the staking asset of a
+// transformed subnet to a permissionless validator of that subnet.
+type UnsignedAddPermissionlessDelegatorTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// Describes the delegatee
+	Validator SubnetValidator `serialize:"true" json:"validator"`
+	// Where to send staked tokens when done validating
+	Stake []*djtx.TransferableOutput `serialize:"true" json:"stake"`
+	// Where to send staking rewards when done validating
+	RewardsOwner verify.Verifiable `serialize:"true" json:"rewardsOwner"`
+}
+
+// StartTime of this delegator
+func (tx *UnsignedAddPermissionlessDelegatorTx) StartTime() time.Time {
+	return tx.Validator.StartTime()
+}
+
+// EndTime of this delegator
+func (tx *UnsignedAddPermissionlessDelegatorTx) EndTime() time.Time {
+	return tx.Validator.EndTime()
+}
+
+// Weight of this delegator
+func (tx *UnsignedAddPermissionlessDelegatorTx) Weight() uint64 {
+	return tx.Validator.Weight()
+}
+
+// Verify return nil iff [tx] is valid
+func (tx *UnsignedAddPermissionlessDelegatorTx) Verify(
+	ctx *snow.Context,
+	c codec.Manager,
+) error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case tx.syntacticallyVerified: // already passed syntactic verification
+		return nil
+	}
+
+	if err := tx.BaseTx.Verify(ctx, c); err != nil {
+		return err
+	}
+	if err := verify.All(&tx.Validator, tx.RewardsOwner); err != nil {
+		return fmt.Errorf("failed to verify validator or rewards owner: %w", err)
+	}
+
+	totalStakeWeight := uint64(0)
+	for _, out := range tx.Stake {
+		if err := out.Verify(); err != nil {
+			return fmt.Errorf("output verification failed: %w", err)
+		}
+		newWeight, err := safemath.Add64(totalStakeWeight, out.Output().Amount())
+		if err != nil {
+			return err
+		}
+		totalStakeWeight = newWeight
+	}
+
+	switch {
+	case !djtx.IsSortedTransferableOutputs(tx.Stake, c):
+		return errOutputsNotSorted
+	case totalStakeWeight != tx.Validator.Wght:
+		return fmt.Errorf("delegator weight %d is not equal to total stake weight %d", tx.Validator.Wght, totalStakeWeight)
+	}
+
+	// cache that this is valid
+	tx.syntacticallyVerified = true
+	return nil
+}
+
+// SemanticVerify this transaction is valid.
+func (tx *UnsignedAddPermissionlessDelegatorTx) SemanticVerify(
+	vm *VM,
+	parentState MutableState,
+	stx *Tx,
+) (
+	VersionedState,
+	VersionedState,
+	func() error,
+	func() error,
+	TxError,
+) {
+	// Verify the tx is well-formed
+	if err := tx.Verify(vm.ctx, vm.codec); err != nil {
+		return nil, nil, nil, nil, permError{err}
+	}
+	if !vm.isApricotPhase3(parentState.GetTimestamp()) {
+		return nil, nil, nil, nil, tempError{errNotApricotPhase3}
+	}
+
+	outs := make([]*djtx.TransferableOutput, len(tx.Outs)+len(tx.Stake))
+	copy(outs, tx.Outs)
+	copy(outs[len(tx.Outs):], tx.Stake)
+
+	currentStakers := parentState.CurrentStakerChainState()
+	pendingStakers := parentState.PendingStakerChainState()
+
+	if vm.bootstrapped {
+		transformSubnetTx, txErr := getSubnetTransformation(parentState, tx.Validator.Subnet)
+		if txErr != nil {
+			return nil, nil, nil, nil, txErr
+		}
+
+		duration := tx.Validator.Duration()
+		switch {
+		case tx.Validator.Wght < transformSubnetTx.MinDelegatorStake:
+			return nil, nil, nil, nil, permError{errWeightTooSmall}
+		case duration < transformSubnetTx.MinStakeDurationTime():
+			return nil, nil, nil, nil, permError{errStakeTooShort}
+		case duration > transformSubnetTx.MaxStakeDurationTime():
+			return nil, nil, nil, nil, permError{errStakeTooLong}
+		}
+
+		for _, out := range tx.Stake {
+			if assetID := out.AssetID(); assetID != transformSubnetTx.AssetID {
+				return nil, nil, nil, nil, permError{
+					fmt.Errorf(
+						"stake must be in %s but is in %s",
+						transformSubnetTx.AssetID,
+						assetID,
+					),
+				}
+			}
+		}
+
+		currentTimestamp := parentState.GetTimestamp()
+		// Ensure the proposed delegator starts after the current timestamp
+		if delegatorStartTime := tx.StartTime(); !currentTimestamp.Before(delegatorStartTime) {
+			return nil, nil, nil, nil, permError{
+				fmt.Errorf(
+					"chain timestamp (%s) not before delegator's start time (%s)",
+					currentTimestamp,
+					delegatorStartTime,
+				),
+			}
+		} else if delegatorStartTime.After(currentTimestamp.Add(maxFutureStartTime)) {
+			return nil, nil, nil, nil, permError{
+				fmt.Errorf(
+					"delegator start time (%s) more than two weeks after current chain timestamp (%s)",
+					delegatorStartTime,
+					currentTimestamp,
+				),
+			}
+		}
+
+		currentValidator, err := currentStakers.GetValidator(tx.Validator.NodeID)
+		if err != nil && err != database.ErrNotFound {
+			return nil, nil, nil, nil, tempError{
+				fmt.Errorf(
+					"failed to find whether %s is a validator: %w",
+					tx.Validator.NodeID.PrefixedString(constants.NodeIDPrefix),
+					err,
+				),
+			}
+		}
+
+		pendingValidator := pendingStakers.GetValidator(tx.Validator.NodeID)
+
+		// The delegations that may overlap with this delegator
+		delegators := pendingValidator.PermissionlessDelegators()
+
+		var (
+			vdrTx  *UnsignedAddPermissionlessValidatorTx
+			exists bool
+		)
+		if err == nil {
+			vdrTx, exists = currentValidator.PermissionlessValidators()[tx.Validator.Subnet]
+			delegators = append(
+				delegators[:len(delegators):len(delegators)],
+				currentValidator.PermissionlessDelegators()...,
+			)
+		}
+		if !exists {
+			// This delegator may be delegating to a node that hasn't started
+			// validating the subnet yet.
+			vdrTx, exists = pendingValidator.PermissionlessValidators()[tx.Validator.Subnet]
+		}
+		if !exists {
+			return nil, nil, nil, nil, permError{errDelegatorSubset}
+		}
+
+		// Ensure that the period this delegator delegates is a subset of the
+		// time the validator validates.
+		if !tx.Validator.BoundedBy(vdrTx.StartTime(), vdrTx.EndTime()) {
+			return nil, nil, nil, nil, permError{errDelegatorSubset}
+		}
+
+		// Ensure that the validator can't become over delegated. Every
+		// delegation that overlaps with this delegator is assumed to be active
+		// at the same time.
+		totalWeight, err := safemath.Add64(vdrTx.Weight(), tx.Validator.Wght)
+		if err != nil {
+			return nil, nil, nil, nil, permError{err}
+		}
+		for _, delegator := range delegators {
+			if delegator.Validator.Subnet != tx.Validator.Subnet ||
+				delegator.StartTime().After(tx.EndTime()) ||
+				delegator.EndTime().Before(tx.StartTime()) {
+				continue
+			}
+			totalWeight, err = safemath.Add64(totalWeight, delegator.Validator.Wght)
+			if err != nil {
+				return nil, nil, nil, nil, permError{err}
+			}
+		}
+		if totalWeight > transformSubnetTx.MaxValidatorStake {
+			return nil, nil, nil, nil, permError{errOverDelegated}
+		}
+
+		// Verify the flowcheck
+		burned := map[ids.ID]uint64{vm.ctx.DJTXAssetID: vm.AddStakerTxFee}
+		if err := vm.semanticVerifySpendAssets(parentState, tx, tx.Ins, outs, stx.Creds, burned); err != nil {
+			switch err.(type) {
+			case permError:
+				return nil, nil, nil, nil, permError{
+					fmt.Errorf("failed semanticVerifySpend: %w", err),
+				}
+			default:
+				return nil, nil, nil, nil, tempError{
+					fmt.Errorf("failed semanticVerifySpend: %w", err),
+				}
+			}
+		}
+	}
+
+	// Set up the state if this tx is committed
+	newlyPendingStakers := pendingStakers.AddStaker(stx)
+	onCommitState := newVersionedState(parentState, currentStakers, newlyPendingStakers)
+
+	// Consume the UTXOS
+	consumeInputs(onCommitState, tx.Ins)
+	// Produce the UTXOS
+	txID := tx.ID()
+	produceAssetOutputs(onCommitState, txID, tx.Outs)
+
+	// Set up the state if this tx is aborted
+	onAbortState := newVersionedState(parentState, currentStakers, pendingStakers)
+	// Consume the UTXOS
+	consumeInputs(onAbortState, tx.Ins)
+	// Produce the UTXOS
+	produceAssetOutputs(onAbortState, txID, outs)
+
+	return onCommitState, onAbortState, nil, nil, nil
+}
+
+// InitiallyPrefersCommit returns true if the proposed delegators start time is
+// after the current wall clock time,
+func (tx *UnsignedAddPermissionlessDelegatorTx) InitiallyPrefersCommit(vm *VM) bool {
+	return tx.StartTime().After(vm.clock.Time())
+}
+
+// Creates a new transaction
+func (vm *VM) newAddPermissionlessDelegatorTx(
+	stakeAmt, // Amount the delegator stakes
+	startTime, // Unix time they start delegating
+	endTime uint64, // Unix time they stop delegating
+	nodeID ids.ShortID, // ID of the node we are delegating to
+	subnetID ids.ID, // ID of the transformed subnet the node validates
+	rewardAddress ids.ShortID, // Address to send reward to, if applicable
+	keys []*crypto.PrivateKeySECP256K1R, // Keys providing the staked tokens
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	transformSubnetTx, txErr := getSubnetTransformation(vm.internalState, subnetID)
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	ins, unlockedOuts, lockedOuts, signers, err := vm.stakeAsset(keys, transformSubnetTx.AssetID, stakeAmt, vm.AddStakerTxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+	// Create the tx
+	utx := &UnsignedAddPermissionlessDelegatorTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    vm.ctx.NetworkID,
+			BlockchainID: vm.ctx.ChainID,
+			Ins:          ins,
+			Outs:         unlockedOuts,
+		}},
+		Validator: SubnetValidator{
+			Validator: Validator{
+				NodeID: nodeID,
+				Start:  startTime,
+				End:    endTime,
+				Wght:   stakeAmt,
+			},
+			Subnet: subnetID,
+		},
+		Stake: lockedOuts,
+		RewardsOwner: &secp256k1fx.OutputOwners{
+			Locktime:  0,
+			Threshold: 1,
+			Addrs:     []ids.ShortID{rewardAddress},
+		},
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(vm.codec, signers); err != nil {
+		return nil, err
+	}
+	return tx, utx.Verify(vm.ctx, vm.codec)
+}
@@ -0,0 +1,348 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	_ UnsignedProposalTx = &UnsignedAddPermissionlessValidatorTx{}
+	_ TimedTx            = &UnsignedAddPermissionlessValidatorTx{}
+)
+
+// UnsignedAddPermissionlessValidatorTx is an unsigned
+// addPermissionlessValidatorTx. It adds a validator to a transformed subnet
+// that stakes the subnet's staking asset.
+type UnsignedAddPermissionlessValidatorTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// Describes the validator
+	Validator SubnetValidator `serialize:"true" json:"validator"`
+	// Where to send staked tokens when done validating
+	Stake []*djtx.TransferableOutput `serialize:"true" json:"stake"`
+	// Where to send staking rewards when done validating
+	RewardsOwner verify.Verifiable `serialize:"true" json:"rewardsOwner"`
+	// Fee this validator charges delegators as a percentage, times 10,000
+	// For example, if this validator has Shares=300,000 then they take 30% of rewards from delegators
+	Shares uint32 `serialize:"true" json:"shares"`
+}
+
+// StartTime of this validator
+func (tx *UnsignedAddPermissionlessValidatorTx) StartTime() time.Time {
+	return tx.Validator.StartTime()
+}
+
+// EndTime of this validator
+func (tx *UnsignedAddPermissionlessValidatorTx) EndTime() time.Time {
+	return tx.Validator.EndTime()
+}
+
+// Weight of this validator
+func (tx *UnsignedAddPermissionlessValidatorTx) Weight() uint64 {
+	return tx.Validator.Weight()
+}
+
+// Verify return nil iff [tx] is valid
+func (tx *UnsignedAddPermissionlessValidatorTx) Verify(
+	ctx *snow.Context,
+	c codec.Manager,
+) error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case tx.syntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Shares > PercentDenominator: // Ensure delegators shares are in the allowed amount
+		return errTooManyShares
+	}
+
+	if err := tx.BaseTx.Verify(ctx, c); err != nil {
+		return fmt.Errorf("failed to verify BaseTx: %w", err)
+	}
+	if err := verify.All(&tx.Validator, tx.RewardsOwner); err != nil {
+		return fmt.Errorf("failed to verify validator or rewards owner: %w", err)
+	}
+
+	totalStakeWeight := uint64(0)
+	for _, out := range tx.Stake {
+		if err := out.Verify(); err != nil {
+			return fmt.Errorf("failed to verify output: %w", err)
+		}
+		newWeight, err := safemath.Add64(totalStakeWeight, out.Output().Amount())
+		if err != nil {
+			return err
+		}
+		totalStakeWeight = newWeight
+	}
+
+	switch {
+	case !djtx.IsSortedTransferableOutputs(tx.Stake, c):
+		return errOutputsNotSorted
+	case totalStakeWeight != tx.Validator.Wght:
+		return fmt.Errorf("validator weight %d is not equal to total stake weight %d", tx.Validator.Wght, totalStakeWeight)
+	}
+
+	// cache that this is valid
+	tx.syntacticallyVerified = true
+	return nil
+}
+
+// SemanticVerify this transaction is valid.
+func (tx *UnsignedAddPermissionlessValidatorTx) SemanticVerify(
+	vm *VM,
+	parentState MutableState,
+	stx *Tx,
+) (
+	VersionedState,
+	VersionedState,
+	func() error,
+	func() error,
+	TxError,
+) {
+	// Verify the tx is well-formed
+	if err := tx.Verify(vm.ctx, vm.codec); err != nil {
+		return nil, nil, nil, nil, permError{err}
+	}
+	if !vm.isApricotPhase3(parentState.GetTimestamp()) {
+		return nil, nil, nil, nil, tempError{errNotApricotPhase3}
+	}
+
+	currentStakers := parentState.CurrentStakerChainState()
+	pendingStakers := parentState.PendingStakerChainState()
+
+	outs := make([]*djtx.TransferableOutput, len(tx.Outs)+len(tx.Stake))
+	copy(outs, tx.Outs)
+	copy(outs[len(tx.Outs):], tx.Stake)
+
+	if vm.bootstrapped {
+		transformSubnetTx, txErr := getSubnetTransformation(parentState, tx.Validator.Subnet)
+		if txErr != nil {
+			return nil, nil, nil, nil, txErr
+		}
+
+		duration := tx.Validator.Duration()
+		switch {
+		case tx.Validator.Wght < transformSubnetTx.MinValidatorStake:
+			return nil, nil, nil, nil, permError{errWeightTooSmall}
+		case tx.Validator.Wght > transformSubnetTx.MaxValidatorStake:
+			return nil, nil, nil, nil, permError{errWeightTooLarge}
+		case tx.Shares < transformSubnetTx.MinDelegationFee:
+			return nil, nil, nil, nil, permError{errInsufficientDelegationFee}
+		case duration < transformSubnetTx.MinStakeDurationTime():
+			return nil, nil, nil, nil, permError{errStakeTooShort}
+		case duration > transformSubnetTx.MaxStakeDurationTime():
+			return nil, nil, nil, nil, permError{errStakeTooLong}
+		}
+
+		for _, out := range tx.Stake {
+			if assetID := out.AssetID(); assetID != transformSubnetTx.AssetID {
+				return nil, nil, nil, nil, permError{
+					fmt.Errorf(
+						"stake must be in %s but is in %s",
+						transformSubnetTx.AssetID,
+						assetID,
+					),
+				}
+			}
+		}
+
+		currentTimestamp := parentState.GetTimestamp()
+		// Ensure the proposed validator starts after the current time
+		if startTime := tx.StartTime(); !currentTimestamp.Before(startTime) {
+			return nil, nil, nil, nil, permError{
+				fmt.Errorf(
+					"validator's start time (%s) at or before current timestamp (%s)",
+					startTime,
+					currentTimestamp,
+				),
+			}
+		} else if startTime.After(currentTimestamp.Add(maxFutureStartTime)) {
+			return nil, nil, nil, nil, permError{
+				fmt.Errorf(
+					"validator start time (%s) more than two weeks after current chain timestamp (%s)",
+					startTime,
+					currentTimestamp,
+				),
+			}
+		}
+
+		currentValidator, err := currentStakers.GetValidator(tx.Validator.NodeID)
+		if err != nil && err != database.ErrNotFound {
+			return nil, nil, nil, nil, tempError{
+				fmt.Errorf(
+					"failed to find whether %s is a validator: %w",
+					tx.Validator.NodeID.PrefixedString(constants.NodeIDPrefix),
+					err,
+				),
+			}
+		}
+
+		var vdrTx *UnsignedAddValidatorTx
+		if err == nil {
+			// This validator is attempting to validate with a currently
+			// validing node.
+			vdrTx = currentValidator.AddValidatorTx()
+
+			// Ensure that this transaction isn't a duplicate add validator tx.
+			if isSubnetValidator(currentValidator, tx.Validator.Subnet) {
+				return nil, nil, nil, nil, permError{
+					fmt.Errorf(
+						"already validating subnet %s",
+						tx.Validator.Subnet,
+					),
+				}
+			}
+		} else {
+			// This validator is attempting to validate with a node that hasn't
+			// started validating yet.
+			vdrTx, err = pendingStakers.GetValidatorTx(tx.Validator.NodeID)
+			if err != nil {
+				if err == database.ErrNotFound {
+					return nil, nil, nil, nil, permError{errDSValidatorSubset}
+				}
+				return nil, nil, nil, nil, tempError{
+					fmt.Errorf(
+						"failed to find whether %s is a validator: %w",
+						tx.Validator.NodeID.PrefixedString(constants.NodeIDPrefix),
+						err,
+					),
+				}
+			}
+		}
+
+		// Ensure that the period this validator validates the specified subnet
+		// is a subset of the time they validate the primary network.
+		if !tx.Validator.BoundedBy(vdrTx.StartTime(), vdrTx.EndTime()) {
+			return nil, nil, nil, nil, permError{errDSValidatorSubset}
+		}
+
+		// Ensure that this transaction isn't a duplicate add validator tx.
+		pendingValidator := pendingStakers.GetValidator(tx.Validator.NodeID)
+		if isSubnetValidator(pendingValidator, tx.Validator.Subnet) {
+			return nil, nil, nil, nil, permError{
+				fmt.Errorf(
+					"already validating subnet %s",
+					tx.Validator.Subnet,
+				),
+			}
+		}
+
+		// Verify the flowcheck
+		burned := map[ids.ID]uint64{vm.ctx.DJTXAssetID: vm.AddStakerTxFee}
+		if err := vm.semanticVerifySpendAssets(parentState, tx, tx.Ins, outs, stx.Creds, burned); err != nil {
+			switch err.(type) {
+			case permError:
+				return nil, nil, nil, nil, permError{
+					fmt.Errorf("failed semanticVerifySpend: %w", err),
+				}
+			default:
+				return nil, nil, nil, nil, tempError{
+					fmt.Errorf("failed semanticVerifySpend: %w", err),
+				}
+			}
+		}
+	}
+
+	// Set up the state if this tx is committed
+	newlyPendingStakers := pendingStakers.AddStaker(stx)
+	onCommitState := newVersionedState(parentState, currentStakers, newlyPendingStakers)
+
+	// Consume the UTXOS
+	consumeInputs(onCommitState, tx.Ins)
+	// Produce the UTXOS
+	txID := tx.ID()
+	produceAssetOutputs(onCommitState, txID, tx.Outs)
+
+	// Set up the state if this tx is aborted
+	onAbortState := newVersionedState(parentState, currentStakers, pendingStakers)
+	// Consume the UTXOS
+	consumeInputs(onAbortState, tx.Ins)
+	// Produce the UTXOS
+	produceAssetOutputs(onAbortState, txID, outs)
+
+	return onCommitState, onAbortState, nil, nil, nil
+}
+
+// InitiallyPrefersCommit returns true if the proposed validators start time is
+// after the current wall clock time,
+func (tx *UnsignedAddPermissionlessValidatorTx) InitiallyPrefersCommit(vm *VM) bool {
+	return tx.StartTime().After(vm.clock.Time())
+}
+
+// isSubnetValidator returns true if [vdr] is either a permissioned or a
+// permissionless validator of [subnetID].
+func isSubnetValidator(vdr validator, subnetID ids.ID) bool {
+	if _, validates := vdr.SubnetValidators()[subnetID]; validates {
+		return true
+	}
+	_, validates := vdr.PermissionlessValidators()[subnetID]
+	return validates
+}
+
+// Creates a new transaction
+func (vm *VM) newAddPermissionlessValidatorTx(
+	stakeAmt, // Amount the validator stakes
+	startTime, // Unix time they start validating
+	endTime uint64, // Unix time they stop validating
+	nodeID ids.ShortID, // ID of the node validating
+	subnetID ids.ID, // ID of the transformed subnet the validator will validate
+	rewardAddress ids.ShortID, // Address to send reward to, if applicable
+	shares uint32, // 10,000 times percentage of reward taken from delegators
+	keys []*crypto.PrivateKeySECP256K1R, // Keys providing the staked tokens
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	transformSubnetTx, txErr := getSubnetTransformation(vm.internalState, subnetID)
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	ins, unlockedOuts, lockedOuts, signers, err := vm.stakeAsset(keys, transformSubnetTx.AssetID, stakeAmt, vm.AddStakerTxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+	// Create the tx
+	utx := &UnsignedAddPermissionlessValidatorTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    vm.ctx.NetworkID,
+			BlockchainID: vm.ctx.ChainID,
+			Ins:          ins,
+			Outs:         unlockedOuts,
+		}},
+		Validator: SubnetValidator{
+			Validator: Validator{
+				NodeID: nodeID,
+				Start:  startTime,
+				End:    endTime,
+				Wght:   stakeAmt,
+			},
+			Subnet: subnetID,
+		},
+		Stake: lockedOuts,
+		RewardsOwner: &secp256k1fx.OutputOwners{
+			Locktime:  0,
+			Threshold: 1,
+			Addrs:     []ids.ShortID{rewardAddress},
+		},
+		Shares: shares,
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(vm.codec, signers); err != nil {
+		return nil, err
+	}
+	return tx, utx.Verify(vm.ctx, vm.codec)
+}
@@ -0,0 +1,330 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+)
+
+func TestAddPermissionlessValidatorTxSemanticVerify(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	startTime := defaultValidateStartTime.Add(syncBound).Add(1 * time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	nodeID := keys[0].PublicKey().Address()
+	stakerKeys := []*crypto.PrivateKeySECP256K1R{keys[0]}
+
+	// Case: Subnet hasn't been transformed
+	if _, err := vm.newAddPermissionlessValidatorTx(
+		defaultWeight,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		testSubnet1.ID(),
+		nodeID,
+		PercentDenominator,
+		stakerKeys,
+		ids.ShortEmpty, // change addr
+	); err == nil {
+		t.Fatal("should have failed because the subnet isn't permissionless")
+	}
+
+	assetID := ids.GenerateTestID()
+	addTestAssetUTXO(t, vm, assetID, testSubnetMaximumSupply, keys[0])
+	transformTx, err := newTestTransformSubnetTx(vm, assetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs := newVersionedState(
+		vm.internalState,
+		vm.internalState.CurrentStakerChainState(),
+		vm.internalState.PendingStakerChainState(),
+	)
+	if _, err := transformTx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(vm, vs, transformTx); err != nil {
+		t.Fatal(err)
+	}
+	vs.AddTx(transformTx, Committed)
+	vs.Apply(vm.internalState)
+	if err := vm.internalState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		description string
+		weight      uint64
+		startTime   time.Time
+		endTime     time.Time
+		nodeID      ids.ShortID
+		shares      uint32
+		shouldErr   bool
+	}{
+		{
+			description: "weight too small",
+			weight:      defaultWeight - 1,
+			startTime:   startTime,
+			endTime:     endTime,
+			nodeID:      nodeID,
+			shares:      PercentDenominator,
+			shouldErr:   true,
+		},
+		{
+			description: "weight too large",
+			weight:      10*defaultWeight + 1,
+			startTime:   startTime,
+			endTime:     endTime,
+			nodeID:      nodeID,
+			shares:      PercentDenominator,
+			shouldErr:   true,
+		},
+		{
+			description: "delegation fee too small",
+			weight:      defaultWeight,
+			startTime:   startTime,
+			endTime:     endTime,
+			nodeID:      nodeID,
+			shares:      20000 - 1,
+			shouldErr:   true,
+		},
+		{
+			description: "stake too short",
+			weight:      defaultWeight,
+			startTime:   startTime,
+			endTime:     startTime.Add(defaultMinStakingDuration).Add(-time.Second),
+			nodeID:      nodeID,
+			shares:      PercentDenominator,
+			shouldErr:   true,
+		},
+		{
+			description: "not validating the primary network",
+			weight:      defaultWeight,
+			startTime:   startTime,
+			endTime:     endTime,
+			nodeID:      ids.GenerateTestShortID(),
+			shares:      PercentDenominator,
+			shouldErr:   true,
+		},
+		{
+			description: "validating longer than the primary network",
+			weight:      defaultWeight,
+			startTime:   defaultValidateEndTime.Add(-defaultMinStakingDuration),
+			endTime:     defaultValidateEndTime.Add(time.Second),
+			nodeID:      nodeID,
+			shares:      PercentDenominator,
+			shouldErr:   true,
+		},
+		{
+			description: "valid",
+			weight:      defaultWeight,
+			startTime:   startTime,
+			endTime:     endTime,
+			nodeID:      nodeID,
+			shares:      PercentDenominator,
+			shouldErr:   false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tx, err := vm.newAddPermissionlessValidatorTx(
+				test.weight,
+				uint64(test.startTime.Unix()),
+				uint64(test.endTime.Unix()),
+				test.nodeID,
+				testSubnet1.ID(),
+				nodeID,
+				test.shares,
+				stakerKeys,
+				nodeID, // change addr
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _, _, _, err = tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.internalState, tx)
+			if err != nil && !test.shouldErr {
+				t.Fatal(err)
+			}
+			if err == nil && test.shouldErr {
+				t.Fatal("expected error but got none")
+			}
+		})
+	}
+}
+
+// Stake on a transformed subnet and receive rewards in its staking asset
+func TestPermissionlessStakingRewards(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := ids.GenerateTestID()
+	addTestAssetUTXO(t, vm, assetID, testSubnetMaximumSupply, keys[0])
+
+	transformTx, err := newTestTransformSubnetTx(vm, assetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.mempool.IssueTx(transformTx); err != nil {
+		t.Fatal(err)
+	}
+	blk, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Accept(); err != nil {
+		t.Fatal(err)
+	}
+
+	vdrStartTime := defaultValidateStartTime.Add(syncBound).Add(1 * time.Second)
+	vdrEndTime := vdrStartTime.Add(2 * defaultMinStakingDuration)
+	delStartTime := vdrStartTime.Add(1 * time.Second)
+	delEndTime := delStartTime.Add(defaultMinStakingDuration)
+	nodeID := keys[0].PublicKey().Address()
+	rewardAddr := keys[4].PublicKey().Address()
+
+	vdrTx, err := vm.newAddPermissionlessValidatorTx(
+		defaultWeight,
+		uint64(vdrStartTime.Unix()),
+		uint64(vdrEndTime.Unix()),
+		nodeID,
+		testSubnet1.ID(),
+		rewardAddr,
+		PercentDenominator/2,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		nodeID, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.mempool.IssueTx(vdrTx); err != nil {
+		t.Fatal(err)
+	}
+	acceptProposalBlock(t, vm)
+
+	// Case: Delegating more than the validator can accept
+	overDelegatedTx, err := vm.newAddPermissionlessDelegatorTx(
+		10*defaultWeight,
+		uint64(delStartTime.Unix()),
+		uint64(delEndTime.Unix()),
+		nodeID,
+		testSubnet1.ID(),
+		rewardAddr,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		nodeID, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, _, err := overDelegatedTx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.internalState, overDelegatedTx); err == nil {
+		t.Fatal("should have failed because the validator would be over delegated")
+	}
+
+	delTx, err := vm.newAddPermissionlessDelegatorTx(
+		defaultWeight,
+		uint64(delStartTime.Unix()),
+		uint64(delEndTime.Unix()),
+		nodeID,
+		testSubnet1.ID(),
+		rewardAddr,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		nodeID, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.mempool.IssueTx(delTx); err != nil {
+		t.Fatal(err)
+	}
+	acceptProposalBlock(t, vm)
+
+	// Move the validator and then the delegator into the current staker set
+	for _, timestamp := range []time.Time{vdrStartTime, delStartTime} {
+		vm.clock.Set(timestamp)
+		acceptProposalBlock(t, vm)
+	}
+
+	vdrs, err := vm.internalState.CurrentStakerChainState().ValidatorSet(testSubnet1.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weight, ok := vdrs.GetWeight(nodeID); !ok {
+		t.Fatal("should be validating the subnet")
+	} else if weight != 2*defaultWeight {
+		t.Fatalf("expected weight %d but got %d", 2*defaultWeight, weight)
+	}
+
+	// The potential rewards of the current stakers were minted
+	if supply, err := vm.internalState.GetSubnetCurrentSupply(testSubnet1.ID()); err != nil {
+		t.Fatal(err)
+	} else if supply <= testSubnetInitialSupply {
+		t.Fatalf("expected current supply to be above %d but got %d", testSubnetInitialSupply, supply)
+	}
+
+	// Remove the delegator and then the validator
+	for _, staker := range []struct {
+		tx      *Tx
+		endTime time.Time
+	}{
+		{tx: delTx, endTime: delEndTime},
+		{tx: vdrTx, endTime: vdrEndTime},
+	} {
+		supply, err := vm.internalState.GetSubnetCurrentSupply(testSubnet1.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vm.clock.Set(staker.endTime)
+		acceptProposalBlock(t, vm) // advance time
+		acceptProposalBlock(t, vm) // reward the staker
+
+		rewardUTXOs, err := vm.internalState.GetRewardUTXOs(staker.tx.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rewardUTXOs) == 0 {
+			t.Fatal("expected the staker to be rewarded")
+		}
+		for _, utxo := range rewardUTXOs {
+			if utxo.AssetID() != assetID {
+				t.Fatalf("expected reward in %s but got %s", assetID, utxo.AssetID())
+			}
+		}
+
+		newSupply, err := vm.internalState.GetSubnetCurrentSupply(testSubnet1.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if newSupply != supply {
+			t.Fatalf("expected current supply %d but got %d", supply, newSupply)
+		}
+		if newSupply > testSubnetMaximumSupply {
+			t.Fatalf("current supply %d exceeds the maximum supply", newSupply)
+		}
+	}
+
+	vdrs, err = vm.internalState.CurrentStakerChainState().ValidatorSet(testSubnet1.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vdrs.Contains(nodeID) {
+		t.Fatal("should have stopped validating the subnet")
+	}
+}
@@ -0,0 +1,309 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errNoRenewals = errors.New("a renewable validator must be renewed at least once")
+
+	_ UnsignedProposalTx = &UnsignedAddRenewableValidatorTx{}
+	_ TimedTx            = &UnsignedAddRenewableValidatorTx{}
+)
+
+// UnsignedAddRenewableValidatorTx is an unsigned addRenewableValidatorTx. It
+// adds a primary network validator whose staking period is renewed [Renewals]
+// times. Each renewal starts when the previous staking period ends and lasts
+// as long as the first staking period.
+//
+// When a staking period ends, the validator is renewed only if it is rewarded.
+// Its stake, including any stakeable locks, is moved into the next staking
+// period instead of being returned. If [RestakeRewards] is true, the reward is
+// added to the stake of the next staking period as long as the validator
+// doesn't exceed the maximum validator stake. Otherwise, the reward is paid
+// out as usual. The stake is returned when the last staking period ends or
+// when the validator isn't rewarded.
+type UnsignedAddRenewableValidatorTx struct {
+	// Describes the validator during its first staking period
+	UnsignedAddValidatorTx `serialize:"true"`
+	// Number of times the staking period is renewed
+	Renewals uint32 `serialize:"true" json:"renewals"`
+	// True if the rewards are added to the stake when the validator is renewed
+	RestakeRewards bool `serialize:"true" json:"restakeRewards"`
+}
+
+// Verify return nil iff [tx] is valid
+func (tx *UnsignedAddRenewableValidatorTx) Verify(
+	ctx *snow.Context,
+	c codec.Manager,
+	minStake uint64,
+	maxStake uint64,
+	minStakeDuration time.Duration,
+	maxStakeDuration time.Duration,
+	minDelegationFee uint32,
+) error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case tx.Renewals == 0:
+		return errNoRenewals
+	}
+	return tx.UnsignedAddValidatorTx.Verify(
+		ctx,
+		c,
+		minStake,
+		maxStake,
+		minStakeDuration,
+		maxStakeDuration,
+		minDelegationFee,
+	)
+}
+
+// SemanticVerify this transaction is valid.
+func (tx *UnsignedAddRenewableValidatorTx) SemanticVerify(
+	vm *VM,
+	parentState MutableState,
+	stx *Tx,
+) (
+	VersionedState,
+	VersionedState,
+	func() error,
+	func() error,
+	TxError,
+) {
+	// Verify the tx is well-formed
+	if err := tx.Verify(
+		vm.ctx,
+		vm.codec,
+		vm.MinValidatorStake,
+		vm.MaxValidatorStake,
+		vm.MinStakeDuration,
+		vm.MaxStakeDuration,
+		vm.MinDelegationFee,
+	); err != nil {
+		return nil, nil, nil, nil, permError{err}
+	}
+	if !vm.isApricotPhase3(parentState.GetTimestamp()) {
+		return nil, nil, nil, nil, tempError{errNotApricotPhase3}
+	}
+
+	stakerTx, err := tx.stakerTx(vm.codec)
+	if err != nil {
+		return nil, nil, nil, nil, permError{err}
+	}
+
+	// The inputs are authorized by the signatures over this tx, rather than
+	// over the staker tx.
+	onCommitState, onAbortState, _, _, txErr := tx.UnsignedAddValidatorTx.semanticVerify(vm, parentState, stx.Creds, stakerTx)
+	if txErr != nil {
+		return nil, nil, nil, nil, txErr
+	}
+
+	onCommitState.AddTx(stakerTx, Committed)
+	onCommitState.SetValidatorRenewal(stakerTx.ID(), stx)
+	return onCommitState, onAbortState, nil, nil, nil
+}
+
+// stakerTx returns the tx that adds this validator to the staker set for its
+// first staking period
+func (tx *UnsignedAddRenewableValidatorTx) stakerTx(c codec.Manager) (*Tx, error) {
+	utx := tx.UnsignedAddValidatorTx
+	stakerTx := &Tx{UnsignedTx: &utx}
+	return stakerTx, stakerTx.Sign(c, nil)
+}
+
+// renewsAfter returns true iff the staking period of this validator that ends
+// at [endTime], in Unix time, is followed by another staking period
+func (tx *UnsignedAddRenewableValidatorTx) renewsAfter(endTime uint64) bool {
+	duration := tx.Validator.End - tx.Validator.Start
+	numPeriods := (endTime - tx.Validator.Start) / duration
+	return numPeriods <= uint64(tx.Renewals)
+}
+
+// validatorRenewal is the next staking period of a renewable validator
+type validatorRenewal struct {
+	// AddValidatorTx that adds the validator for its next staking period
+	stakerTx        *Tx
+	potentialReward uint64
+	// current supply after the potential reward of the next staking period is
+	// minted
+	currentSupply uint64
+	// true if the reward of the previous staking period was added to the
+	// stake of the next staking period
+	restakedReward bool
+	// tx that registered the renewable validator
+	registrationTx *Tx
+}
+
+// renewValidator returns the next staking period of the primary network
+// validator added by [stakerTx] if it is rewarded [stakerReward]. Returns nil
+// if the validator isn't renewed. Validators aren't renewed before the Apricot
+// Phase 3 upgrade.
+func (vm *VM) renewValidator(parentState MutableState, stakerTx *Tx, stakerReward uint64) (*validatorRenewal, TxError) {
+	uStakerTx, ok := stakerTx.UnsignedTx.(*UnsignedAddValidatorTx)
+	if !ok || !vm.isApricotPhase3(parentState.GetTimestamp()) {
+		return nil, nil
+	}
+
+	stakerID := stakerTx.ID()
+	registrationTx, err := parentState.GetValidatorRenewal(stakerID)
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, tempError{
+			fmt.Errorf("failed to get the renewal of %s: %w", stakerID, err),
+		}
+	}
+	registration, ok := registrationTx.UnsignedTx.(*UnsignedAddRenewableValidatorTx)
+	if !ok {
+		return nil, permError{errWrongTxType}
+	}
+	if !registration.renewsAfter(uStakerTx.Validator.End) {
+		return nil, nil
+	}
+
+	stake := uStakerTx.Stake
+	weight := uStakerTx.Validator.Wght
+	restakedReward := false
+	if registration.RestakeRewards && stakerReward > 0 {
+		newWeight, err := safemath.Add64(weight, stakerReward)
+		if err == nil && newWeight <= vm.MaxValidatorStake {
+			outIntf, err := vm.fx.CreateOutput(stakerReward, uStakerTx.RewardsOwner)
+			if err != nil {
+				return nil, permError{
+					fmt.Errorf("failed to create output: %w", err),
+				}
+			}
+			out, ok := outIntf.(djtx.TransferableOut)
+			if !ok {
+				return nil, permError{errInvalidState}
+			}
+
+			stake = make([]*djtx.TransferableOutput, len(uStakerTx.Stake)+1)
+			copy(stake, uStakerTx.Stake)
+			stake[len(uStakerTx.Stake)] = &djtx.TransferableOutput{
+				Asset: djtx.Asset{ID: vm.ctx.DJTXAssetID},
+				Out:   out,
+			}
+			djtx.SortTransferableOutputs(stake, Codec)
+			weight = newWeight
+			restakedReward = true
+		}
+	}
+
+	duration := uStakerTx.Validator.End - uStakerTx.Validator.Start
+	renewedTx := &Tx{UnsignedTx: &UnsignedAddValidatorTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    vm.ctx.NetworkID,
+			BlockchainID: vm.ctx.ChainID,
+			// Links the next staking period to the one it renews
+			Memo: stakerID[:],
+		}},
+		Validator: Validator{
+			NodeID: uStakerTx.Validator.NodeID,
+			Start:  uStakerTx.Validator.End,
+			End:    uStakerTx.Validator.End + duration,
+			Wght:   weight,
+		},
+		Stake:        stake,
+		RewardsOwner: uStakerTx.RewardsOwner,
+		Shares:       uStakerTx.Shares,
+	}}
+	if err := renewedTx.Sign(vm.codec, nil); err != nil {
+		return nil, permError{err}
+	}
+
+	currentSupply := parentState.GetCurrentSupply()
+	potentialReward := reward(
+		time.Duration(duration)*time.Second,
+		weight,
+		currentSupply,
+		vm.StakeMintingPeriod,
+	)
+	currentSupply, err = safemath.Add64(currentSupply, potentialReward)
+	if err != nil {
+		return nil, permError{err}
+	}
+
+	return &validatorRenewal{
+		stakerTx:        renewedTx,
+		potentialReward: potentialReward,
+		currentSupply:   currentSupply,
+		restakedReward:  restakedReward,
+		registrationTx:  registrationTx,
+	}, nil
+}
+
+// newAddRenewableValidatorTx returns a new AddRenewableValidatorTx
+func (vm *VM) newAddRenewableValidatorTx(
+	stakeAmt, // Amount the validator stakes
+	startTime, // Unix time they start validating
+	endTime uint64, // Unix time their first staking period ends
+	nodeID ids.ShortID, // ID of the node that validates
+	rewardAddress ids.ShortID, // Address to send reward to, if applicable
+	shares uint32, // 10,000 times percentage of reward taken from delegators
+	renewals uint32, // Number of times the staking period is renewed
+	restakeRewards bool, // True if the rewards are added to the stake on renewal
+	keys []*crypto.PrivateKeySECP256K1R, // Keys providing the staked tokens
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, unlockedOuts, lockedOuts, signers, err := vm.stake(keys, stakeAmt, vm.AddStakerTxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+	// Create the tx
+	utx := &UnsignedAddRenewableValidatorTx{
+		UnsignedAddValidatorTx: UnsignedAddValidatorTx{
+			BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+				NetworkID:    vm.ctx.NetworkID,
+				BlockchainID: vm.ctx.ChainID,
+				Ins:          ins,
+				Outs:         unlockedOuts,
+			}},
+			Validator: Validator{
+				NodeID: nodeID,
+				Start:  startTime,
+				End:    endTime,
+				Wght:   stakeAmt,
+			},
+			Stake: lockedOuts,
+			RewardsOwner: &secp256k1fx.OutputOwners{
+				Locktime:  0,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{rewardAddress},
+			},
+			Shares: shares,
+		},
+		Renewals:       renewals,
+		RestakeRewards: restakeRewards,
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(vm.codec, signers); err != nil {
+		return nil, err
+	}
+	return tx, utx.Verify(
+		vm.ctx,
+		vm.codec,
+		vm.MinValidatorStake,
+		vm.MaxValidatorStake,
+		vm.MinStakeDuration,
+		vm.MaxStakeDuration,
+		vm.MinDelegationFee,
+	)
+}
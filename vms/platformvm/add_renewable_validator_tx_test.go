@@ -0,0 +1,217 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+)
+
+func TestAddRenewableValidatorTxSyntacticVerify(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	startTime := defaultValidateStartTime.Add(syncBound).Add(time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+
+	// Case: tx is nil
+	var unsignedTx *UnsignedAddRenewableValidatorTx
+	if err := unsignedTx.Verify(
+		vm.ctx,
+		vm.codec,
+		vm.MinValidatorStake,
+		vm.MaxValidatorStake,
+		vm.MinStakeDuration,
+		vm.MaxStakeDuration,
+		vm.MinDelegationFee,
+	); err != errNilTx {
+		t.Fatalf("expected %s but got %v", errNilTx, err)
+	}
+
+	// Case: Never renewed
+	if _, err := vm.newAddRenewableValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		ids.GenerateTestShortID(),
+		keys[0].PublicKey().Address(),
+		PercentDenominator,
+		0,
+		false,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	); err != errNoRenewals {
+		t.Fatalf("expected %s but got %v", errNoRenewals, err)
+	}
+
+	// Case: Stake too short
+	if _, err := vm.newAddRenewableValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Add(-time.Second).Unix()),
+		ids.GenerateTestShortID(),
+		keys[0].PublicKey().Address(),
+		PercentDenominator,
+		1,
+		false,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	); err != errStakeTooShort {
+		t.Fatalf("expected %s but got %v", errStakeTooShort, err)
+	}
+}
+
+// Renew a validator once, restaking its reward, and then return its stake
+func TestRenewableValidatorRestakesRewards(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	startTime := defaultValidateStartTime.Add(syncBound).Add(time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	renewedEndTime := endTime.Add(defaultMinStakingDuration)
+	nodeID := ids.GenerateTestShortID()
+
+	tx, err := vm.newAddRenewableValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		keys[1].PublicKey().Address(),
+		PercentDenominator,
+		1,
+		true,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stakerTx, err := tx.UnsignedTx.(*UnsignedAddRenewableValidatorTx).stakerTx(vm.codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stakerID := stakerTx.ID()
+
+	// Case: Before Apricot Phase 3
+	vm.ApricotPhase3Time = vm.internalState.GetTimestamp().Add(time.Second)
+	if _, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.internalState, tx); err == nil {
+		t.Fatal("should have failed because Apricot Phase 3 isn't active")
+	} else if !err.Temporary() {
+		t.Fatal("should have failed with a temporary error")
+	}
+	vm.ApricotPhase3Time = time.Time{}
+
+	if err := vm.mempool.IssueTx(tx); err != nil {
+		t.Fatal(err)
+	}
+	acceptProposalBlock(t, vm)
+
+	if _, err := vm.internalState.PendingStakerChainState().GetValidatorTx(nodeID); err != nil {
+		t.Fatalf("should be a pending validator: %s", err)
+	}
+	if _, status, err := vm.internalState.GetTx(stakerID); err != nil {
+		t.Fatal(err)
+	} else if status != Committed {
+		t.Fatalf("expected staker tx to be %s but is %s", Committed, status)
+	}
+
+	// Start the first staking period
+	vm.clock.Set(startTime)
+	acceptProposalBlock(t, vm)
+
+	vdr, err := vm.internalState.CurrentStakerChainState().GetValidator(nodeID)
+	if err != nil {
+		t.Fatalf("should be a current validator: %s", err)
+	}
+	potentialReward := vdr.PotentialReward()
+	if potentialReward == 0 {
+		t.Fatal("expected a non-zero potential reward")
+	}
+
+	// Validators aren't renewed before Apricot Phase 3
+	vm.ApricotPhase3Time = vm.internalState.GetTimestamp().Add(time.Second)
+	if renewal, err := vm.renewValidator(vm.internalState, stakerTx, potentialReward); err != nil {
+		t.Fatal(err)
+	} else if renewal != nil {
+		t.Fatal("shouldn't have been renewed before Apricot Phase 3")
+	}
+	vm.ApricotPhase3Time = time.Time{}
+
+	// End the first staking period
+	vm.clock.Set(endTime)
+	acceptProposalBlock(t, vm) // advance time
+	acceptProposalBlock(t, vm) // reward the validator
+
+	vdr, err = vm.internalState.CurrentStakerChainState().GetValidator(nodeID)
+	if err != nil {
+		t.Fatalf("should have been renewed: %s", err)
+	}
+	renewedTx := vdr.AddValidatorTx()
+	switch {
+	case !renewedTx.StartTime().Equal(endTime):
+		t.Fatalf("expected renewal to start at %s but starts at %s", endTime, renewedTx.StartTime())
+	case !renewedTx.EndTime().Equal(renewedEndTime):
+		t.Fatalf("expected renewal to end at %s but ends at %s", renewedEndTime, renewedTx.EndTime())
+	case renewedTx.Weight() != vm.MinValidatorStake+potentialReward:
+		t.Fatalf("expected weight %d but got %d", vm.MinValidatorStake+potentialReward, renewedTx.Weight())
+	}
+
+	// The stake was moved into the renewal rather than being refunded
+	uStakerTx := stakerTx.UnsignedTx.(*UnsignedAddValidatorTx)
+	stakeUTXOID := djtx.UTXOID{
+		TxID:        stakerID,
+		OutputIndex: uint32(len(uStakerTx.Outs)),
+	}
+	if _, err := vm.internalState.GetUTXO(stakeUTXOID.InputID()); err != database.ErrNotFound {
+		t.Fatalf("expected %s but got %v", database.ErrNotFound, err)
+	}
+	if rewardUTXOs, err := vm.internalState.GetRewardUTXOs(stakerID); err != nil {
+		t.Fatal(err)
+	} else if len(rewardUTXOs) != 0 {
+		t.Fatal("the restaked reward shouldn't have been paid out")
+	}
+
+	// End the last staking period
+	vm.clock.Set(renewedEndTime)
+	acceptProposalBlock(t, vm) // advance time
+	acceptProposalBlock(t, vm) // reward the validator
+
+	if _, err := vm.internalState.CurrentStakerChainState().GetValidator(nodeID); err != database.ErrNotFound {
+		t.Fatalf("expected %s but got %v", database.ErrNotFound, err)
+	}
+
+	// The stake, including the restaked reward, was refunded
+	renewedID := renewedTx.ID()
+	for i := range renewedTx.Stake {
+		utxoID := djtx.UTXOID{
+			TxID:        renewedID,
+			OutputIndex: uint32(len(renewedTx.Outs) + i),
+		}
+		if _, err := vm.internalState.GetUTXO(utxoID.InputID()); err != nil {
+			t.Fatalf("stake output %d wasn't refunded: %s", i, err)
+		}
+	}
+	if rewardUTXOs, err := vm.internalState.GetRewardUTXOs(renewedID); err != nil {
+		t.Fatal(err)
+	} else if len(rewardUTXOs) == 0 {
+		t.Fatal("expected the last staking period to be rewarded")
+	}
+}
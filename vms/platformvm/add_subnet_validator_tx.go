@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/vms/components/djtx"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 var (
@@ -219,8 +220,7 @@ func (tx *UnsignedAddSubnetValidatorTx) SemanticVerify(
 			}
 		}
 
-		subnet, ok := subnetIntf.UnsignedTx.(*UnsignedCreateSubnetTx)
-		if !ok {
+		if _, ok := subnetIntf.UnsignedTx.(*UnsignedCreateSubnetTx); !ok {
 			return nil, nil, nil, nil, permError{
 				fmt.Errorf(
 					"%s is not a subnet",
@@ -229,7 +229,32 @@ func (tx *UnsignedAddSubnetValidatorTx) SemanticVerify(
 			}
 		}
 
-		if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnet.Owner); err != nil {
+		// Transformed subnets are only validated by permissionless validators
+		switch _, err := parentState.GetSubnetTransformation(tx.Validator.Subnet); err {
+		case nil:
+			return nil, nil, nil, nil, permError{
+				fmt.Errorf(
+					"%s has been transformed",
+					tx.Validator.Subnet,
+				),
+			}
+		case database.ErrNotFound:
+		default:
+			return nil, nil, nil, nil, tempError{err}
+		}
+
+		subnetOwner, err := parentState.GetSubnetOwner(tx.Validator.Subnet)
+		if err != nil {
+			return nil, nil, nil, nil, tempError{
+				fmt.Errorf(
+					"couldn't find owner of subnet %s: %w",
+					tx.Validator.Subnet,
+					err,
+				),
+			}
+		}
+
+		if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
 			return nil, nil, nil, nil, permError{err}
 		}
 
@@ -275,12 +300,36 @@ func (vm *VM) newAddSubnetValidatorTx(
 	keys []*crypto.PrivateKeySECP256K1R, // Keys to use for adding the validator
 	changeAddr ids.ShortID, // Address to send change to, if there is any
 ) (*Tx, error) {
-	ins, outs, _, signers, err := vm.stake(keys, 0, vm.TxFee, changeAddr)
+	return vm.newAddSubnetValidatorTxWithKeychain(
+		weight,
+		startTime,
+		endTime,
+		nodeID,
+		subnetID,
+		newKeychain(keys),
+		changeAddr,
+	)
+}
+
+// newAddSubnetValidatorTxWithKeychain is the same as newAddSubnetValidatorTx,
+// except that the fee is paid, and the subnet is authorized, by the addresses
+// in [kc]. The signatures of the addresses whose keys aren't in [kc] are left
+// empty.
+func (vm *VM) newAddSubnetValidatorTxWithKeychain(
+	weight, // Sampling weight of the new validator
+	startTime, // Unix time they start delegating
+	endTime uint64, // Unix time they top delegating
+	nodeID ids.ShortID, // ID of the node validating
+	subnetID ids.ID, // ID of the subnet the validator will validate
+	kc *secp256k1fx.Keychain, // Addresses to use for adding the validator
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, outs, _, signers, err := vm.stakeAssetWithKeychain(kc, vm.ctx.DJTXAssetID, 0, vm.TxFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
-	subnetAuth, subnetSigners, err := vm.authorize(vm.internalState, subnetID, keys)
+	subnetAuth, subnetSigners, err := vm.authorizeWithKeychain(vm.internalState, subnetID, kc)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
 	}
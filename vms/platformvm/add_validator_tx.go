@@ -139,6 +139,24 @@ func (tx *UnsignedAddValidatorTx) SemanticVerify(
 	func() error,
 	func() error,
 	TxError,
+) {
+	return tx.semanticVerify(vm, parentState, stx.Creds, stx)
+}
+
+// semanticVerify verifies that adding the validator described by [tx] is a
+// valid state transition. The inputs of [tx] must be authorized by [creds]. If
+// the tx is committed, [stakerTx] is added to the pending stakers.
+func (tx *UnsignedAddValidatorTx) semanticVerify(
+	vm *VM,
+	parentState MutableState,
+	creds []verify.Verifiable,
+	stakerTx *Tx,
+) (
+	VersionedState,
+	VersionedState,
+	func() error,
+	func() error,
+	TxError,
 ) {
 	// Verify the tx is well-formed
 	if err := tx.Verify(
@@ -222,7 +240,7 @@ func (tx *UnsignedAddValidatorTx) SemanticVerify(
 		}
 
 		// Verify the flowcheck
-		if err := vm.semanticVerifySpend(parentState, tx, tx.Ins, outs, stx.Creds, vm.AddStakerTxFee, vm.ctx.DJTXAssetID); err != nil {
+		if err := vm.semanticVerifySpend(parentState, tx, tx.Ins, outs, creds, vm.AddStakerTxFee, vm.ctx.DJTXAssetID); err != nil {
 			switch err.(type) {
 			case permError:
 				return nil, nil, nil, nil, permError{
@@ -237,7 +255,7 @@ func (tx *UnsignedAddValidatorTx) SemanticVerify(
 	}
 
 	// Set up the state if this tx is committed
-	newlyPendingStakers := pendingStakers.AddStaker(stx)
+	newlyPendingStakers := pendingStakers.AddStaker(stakerTx)
 	onCommitState := newVersionedState(parentState, currentStakers, newlyPendingStakers)
 
 	// Consume the UTXOS
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/components/djtx"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
@@ -91,6 +92,8 @@ func (tx *UnsignedAdvanceTimeTx) SemanticVerify(
 	}
 
 	currentSupply := parentState.GetCurrentSupply()
+	// Supplies of the transformed subnets that have stakers being added
+	subnetSupplies := make(map[ids.ID]uint64)
 
 	pendingStakers := parentState.PendingStakerChainState()
 	toAddValidatorsWithRewardToCurrent := []*validatorReward(nil)
@@ -146,6 +149,36 @@ pendingStakerLoop:
 				potentialReward: r,
 			})
 			numToRemoveFromPending++
+		case *UnsignedAddPermissionlessValidatorTx:
+			if staker.StartTime().After(timestamp) {
+				break pendingStakerLoop
+			}
+
+			r, txErr := permissionlessReward(parentState, subnetSupplies, &staker.Validator)
+			if txErr != nil {
+				return nil, nil, nil, nil, txErr
+			}
+
+			toAddValidatorsWithRewardToCurrent = append(toAddValidatorsWithRewardToCurrent, &validatorReward{
+				addStakerTx:     tx,
+				potentialReward: r,
+			})
+			numToRemoveFromPending++
+		case *UnsignedAddPermissionlessDelegatorTx:
+			if staker.StartTime().After(timestamp) {
+				break pendingStakerLoop
+			}
+
+			r, txErr := permissionlessReward(parentState, subnetSupplies, &staker.Validator)
+			if txErr != nil {
+				return nil, nil, nil, nil, txErr
+			}
+
+			toAddDelegatorsWithRewardToCurrent = append(toAddDelegatorsWithRewardToCurrent, &validatorReward{
+				addStakerTx:     tx,
+				potentialReward: r,
+			})
+			numToRemoveFromPending++
 		case *UnsignedAddSubnetValidatorTx:
 			if staker.StartTime().After(timestamp) {
 				break pendingStakerLoop
@@ -179,8 +212,9 @@ currentStakerLoop:
 			}
 
 			numToRemoveFromCurrent++
-		case *UnsignedAddValidatorTx, *UnsignedAddDelegatorTx:
-			// We shouldn't be removing any primary network validators here
+		case *UnsignedAddValidatorTx, *UnsignedAddDelegatorTx,
+			*UnsignedAddPermissionlessValidatorTx, *UnsignedAddPermissionlessDelegatorTx:
+			// We shouldn't be removing any stakers that are rewarded here
 			break currentStakerLoop
 		default:
 			return nil, nil, nil, nil, permError{errWrongTxType}
@@ -199,6 +233,9 @@ currentStakerLoop:
 	onCommitState := newVersionedState(parentState, newlyCurrentStakers, newlyPendingStakers)
 	onCommitState.SetTimestamp(timestamp)
 	onCommitState.SetCurrentSupply(currentSupply)
+	for subnetID, supply := range subnetSupplies {
+		onCommitState.SetSubnetCurrentSupply(subnetID, supply)
+	}
 
 	// State doesn't change if this proposal is aborted
 	onAbortState := newVersionedState(parentState, currentStakers, pendingStakers)
@@ -228,3 +265,44 @@ func (vm *VM) newAdvanceTimeTx(timestamp time.Time) (*Tx, error) {
 	}}
 	return tx, tx.Sign(vm.codec, nil)
 }
+
+// permissionlessReward returns the potential reward of [validator], a staker of
+// a transformed subnet, and adds it to the subnet's supply in
+// [subnetSupplies]. If the subnet isn't in [subnetSupplies] yet, its supply is
+// read from [parentState].
+func permissionlessReward(
+	parentState MutableState,
+	subnetSupplies map[ids.ID]uint64,
+	validator *SubnetValidator,
+) (uint64, TxError) {
+	transformSubnetTx, txErr := getSubnetTransformation(parentState, validator.Subnet)
+	if txErr != nil {
+		return 0, txErr
+	}
+
+	supply, ok := subnetSupplies[validator.Subnet]
+	if !ok {
+		var err error
+		supply, err = parentState.GetSubnetCurrentSupply(validator.Subnet)
+		if err != nil {
+			return 0, tempError{err}
+		}
+	}
+
+	r := rewardWithConfig(
+		validator.Duration(),
+		validator.Wght,
+		supply,
+		transformSubnetTx.MaxStakeDurationTime(),
+		transformSubnetTx.MinConsumptionRate,
+		transformSubnetTx.MaxConsumptionRate,
+		transformSubnetTx.MaximumSupply,
+	)
+	// Never promise more rewards than were burned when the subnet was
+	// transformed
+	if remaining := transformSubnetTx.MaximumSupply - supply; r > remaining {
+		r = remaining
+	}
+	subnetSupplies[validator.Subnet] = supply + r
+	return r, nil
+}
@@ -118,6 +118,7 @@ func (ab *AtomicBlock) Verify() error {
 	onAccept.AddTx(&ab.Tx, Committed)
 
 	ab.onAcceptState = onAccept
+	ab.vm.mempool.RemoveTx(ab.Tx.ID())
 	ab.vm.currentBlocks[blkID] = ab
 	parentIntf.addChild(ab)
 	return nil
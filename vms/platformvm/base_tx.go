@@ -47,3 +47,10 @@ func (tx *BaseTx) Verify(ctx *snow.Context, c codec.Manager) error {
 		return nil
 	}
 }
+
+// inputs returns the inputs of this tx that consume UTXOs of this chain
+func (tx *BaseTx) inputs() []*djtx.TransferableInput { return tx.Ins }
+
+// outputs returns the outputs of this tx that produce UTXOs of this chain when
+// the tx is accepted
+func (tx *BaseTx) outputs() []*djtx.TransferableOutput { return tx.Outs }
@@ -26,9 +26,10 @@ var (
 
 type currentStakerChainState interface {
 	// The NextStaker value returns the next staker that is going to be removed
-	// using a RewardValidatorTx. Therefore, only AddValidatorTxs and
-	// AddDelegatorTxs will be returned. AddSubnetValidatorTxs are removed using
-	// AdvanceTimestampTxs.
+	// using a RewardValidatorTx. Therefore, only AddValidatorTxs,
+	// AddDelegatorTxs, AddPermissionlessValidatorTxs, and
+	// AddPermissionlessDelegatorTxs will be returned. AddSubnetValidatorTxs are
+	// removed using AdvanceTimestampTxs.
 	GetNextStaker() (addStakerTx *Tx, potentialReward uint64, err error)
 	GetStaker(txID ids.ID) (tx *Tx, potentialReward uint64, err error)
 	GetValidator(nodeID ids.ShortID) (currentValidator, error)
@@ -40,6 +41,13 @@ type currentStakerChainState interface {
 		numTxsToRemove int,
 	) (currentStakerChainState, error)
 	DeleteNextStaker() (currentStakerChainState, error)
+	// RenewNextStaker replaces the next staker, which must be a primary
+	// network validator, with [renewedTx], which adds the same validator for
+	// its next staking period.
+	RenewNextStaker(renewedTx *Tx, potentialReward uint64) (currentStakerChainState, error)
+	// DeleteSubnetValidator removes the subnet validator added by [txID]
+	// before its end time.
+	DeleteSubnetValidator(txID ids.ID) (currentStakerChainState, error)
 
 	// Stakers returns the current stakers on the network sorted in order of the
 	// order of their future removal from the validator set.
@@ -144,6 +152,19 @@ func (cs *currentStakerChainStateImpl) UpdateStakers(
 					potentialReward: vdr.potentialReward,
 				}
 				newCS.validatorsByTxID[vdr.addStakerTx.ID()] = vdr
+			case *UnsignedAddPermissionlessValidatorTx:
+				oldVdr, exists := newCS.validatorsByNodeID[tx.Validator.NodeID]
+				if !exists {
+					return nil, errDSValidatorSubset
+				}
+				newVdr := *oldVdr
+				newVdr.permissionlessValidators = make(map[ids.ID]*UnsignedAddPermissionlessValidatorTx, len(oldVdr.permissionlessValidators)+1)
+				for subnetID, addTx := range oldVdr.permissionlessValidators {
+					newVdr.permissionlessValidators[subnetID] = addTx
+				}
+				newVdr.permissionlessValidators[tx.Validator.Subnet] = tx
+				newCS.validatorsByNodeID[tx.Validator.NodeID] = &newVdr
+				newCS.validatorsByTxID[vdr.addStakerTx.ID()] = vdr
 			default:
 				return nil, errWrongTxType
 			}
@@ -161,6 +182,17 @@ func (cs *currentStakerChainStateImpl) UpdateStakers(
 				newVdr.delegatorWeight += tx.Validator.Wght
 				newCS.validatorsByNodeID[tx.Validator.NodeID] = &newVdr
 				newCS.validatorsByTxID[vdr.addStakerTx.ID()] = vdr
+			case *UnsignedAddPermissionlessDelegatorTx:
+				oldVdr, exists := newCS.validatorsByNodeID[tx.Validator.NodeID]
+				if !exists {
+					return nil, errDelegatorSubset
+				}
+				newVdr := *oldVdr
+				newVdr.permissionlessDelegators = make([]*UnsignedAddPermissionlessDelegatorTx, len(oldVdr.permissionlessDelegators)+1)
+				copy(newVdr.permissionlessDelegators, oldVdr.permissionlessDelegators)
+				newVdr.permissionlessDelegators[len(oldVdr.permissionlessDelegators)] = tx
+				newCS.validatorsByNodeID[tx.Validator.NodeID] = &newVdr
+				newCS.validatorsByTxID[vdr.addStakerTx.ID()] = vdr
 			default:
 				return nil, errWrongTxType
 			}
@@ -241,15 +273,35 @@ func (cs *currentStakerChainStateImpl) DeleteNextStaker() (currentStakerChainSta
 			if nodeID != tx.Validator.NodeID {
 				newCS.validatorsByNodeID[nodeID] = vdr
 			} else {
-				newCS.validatorsByNodeID[nodeID] = &currentValidatorImpl{
-					validatorImpl: validatorImpl{
-						delegators: vdr.delegators[1:], // sorted in order of removal
-						subnets:    vdr.subnets,
-					},
-					addValidatorTx:  vdr.addValidatorTx,
-					delegatorWeight: vdr.delegatorWeight - tx.Validator.Wght,
-					potentialReward: vdr.potentialReward,
+				newVdr := *vdr
+				newVdr.delegators = vdr.delegators[1:] // sorted in order of removal
+				newVdr.delegatorWeight = vdr.delegatorWeight - tx.Validator.Wght
+				newCS.validatorsByNodeID[nodeID] = &newVdr
+			}
+		}
+	case *UnsignedAddPermissionlessValidatorTx:
+		for nodeID, vdr := range cs.validatorsByNodeID {
+			if nodeID != tx.Validator.NodeID {
+				newCS.validatorsByNodeID[nodeID] = vdr
+			} else {
+				newVdr := *vdr
+				newVdr.permissionlessValidators = make(map[ids.ID]*UnsignedAddPermissionlessValidatorTx, len(vdr.permissionlessValidators)-1)
+				for subnetID, addTx := range vdr.permissionlessValidators {
+					if subnetID != tx.Validator.Subnet {
+						newVdr.permissionlessValidators[subnetID] = addTx
+					}
 				}
+				newCS.validatorsByNodeID[nodeID] = &newVdr
+			}
+		}
+	case *UnsignedAddPermissionlessDelegatorTx:
+		for nodeID, vdr := range cs.validatorsByNodeID {
+			if nodeID != tx.Validator.NodeID {
+				newCS.validatorsByNodeID[nodeID] = vdr
+			} else {
+				newVdr := *vdr
+				newVdr.permissionlessDelegators = withoutPermissionlessDelegator(vdr.permissionlessDelegators, removedTxID)
+				newCS.validatorsByNodeID[nodeID] = &newVdr
 			}
 		}
 	default:
@@ -266,6 +318,87 @@ func (cs *currentStakerChainStateImpl) DeleteNextStaker() (currentStakerChainSta
 	return newCS, nil
 }
 
+func (cs *currentStakerChainStateImpl) RenewNextStaker(renewedTx *Tx, potentialReward uint64) (currentStakerChainState, error) {
+	removedTx, _, err := cs.GetNextStaker()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := removedTx.UnsignedTx.(*UnsignedAddValidatorTx); !ok {
+		return nil, errWrongTxType
+	}
+
+	deletedCS, err := cs.DeleteNextStaker()
+	if err != nil {
+		return nil, err
+	}
+	renewedCSIntf, err := deletedCS.UpdateStakers(
+		[]*validatorReward{{
+			addStakerTx:     renewedTx,
+			potentialReward: potentialReward,
+		}},
+		nil,
+		nil,
+		0,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// [deletedCS] is never applied, so the removal of the next staker must be
+	// applied along with the renewal.
+	renewedCS := renewedCSIntf.(*currentStakerChainStateImpl)
+	renewedCS.deletedStakers = []*Tx{removedTx}
+	return renewedCS, nil
+}
+
+func (cs *currentStakerChainStateImpl) DeleteSubnetValidator(txID ids.ID) (currentStakerChainState, error) {
+	staker, exists := cs.validatorsByTxID[txID]
+	if !exists {
+		return nil, database.ErrNotFound
+	}
+	tx, ok := staker.addStakerTx.UnsignedTx.(*UnsignedAddSubnetValidatorTx)
+	if !ok {
+		return nil, errWrongTxType
+	}
+
+	newCS := &currentStakerChainStateImpl{
+		validatorsByNodeID: make(map[ids.ShortID]*currentValidatorImpl, len(cs.validatorsByNodeID)),
+		validatorsByTxID:   make(map[ids.ID]*validatorReward, len(cs.validatorsByTxID)-1),
+		validators:         make([]*Tx, 0, len(cs.validators)-1),
+
+		deletedStakers: []*Tx{staker.addStakerTx},
+	}
+
+	// Removing an element keeps the stakers sorted in order of removal
+	for _, vdr := range cs.validators {
+		if vdr.ID() != txID {
+			newCS.validators = append(newCS.validators, vdr)
+		}
+	}
+
+	for nodeID, vdr := range cs.validatorsByNodeID {
+		newCS.validatorsByNodeID[nodeID] = vdr
+	}
+	oldVdr := cs.validatorsByNodeID[tx.Validator.NodeID]
+	newVdr := *oldVdr
+	newVdr.subnets = make(map[ids.ID]*UnsignedAddSubnetValidatorTx, len(oldVdr.subnets)-1)
+	for subnetID, addTx := range oldVdr.subnets {
+		if subnetID != tx.Validator.Subnet {
+			newVdr.subnets[subnetID] = addTx
+		}
+	}
+	newCS.validatorsByNodeID[tx.Validator.NodeID] = &newVdr
+
+	for stakerTxID, vdr := range cs.validatorsByTxID {
+		if stakerTxID != txID {
+			newCS.validatorsByTxID[stakerTxID] = vdr
+		}
+	}
+
+	newCS.setNextStaker()
+	return newCS, nil
+}
+
 func (cs *currentStakerChainStateImpl) Stakers() []*Tx {
 	return cs.validators
 }
@@ -313,11 +446,28 @@ func (cs *currentStakerChainStateImpl) subnetValidatorSet(subnetID ids.ID) (vali
 	vdrs := validators.NewSet()
 
 	for nodeID, vdr := range cs.validatorsByNodeID {
-		subnetVDR, exists := vdr.subnets[subnetID]
+		if subnetVDR, exists := vdr.subnets[subnetID]; exists {
+			if err := vdrs.AddWeight(nodeID, subnetVDR.Validator.Wght); err != nil {
+				return nil, err
+			}
+		}
+
+		permissionlessVDR, exists := vdr.permissionlessValidators[subnetID]
 		if !exists {
 			continue
 		}
-		if err := vdrs.AddWeight(nodeID, subnetVDR.Validator.Wght); err != nil {
+		vdrWeight := permissionlessVDR.Validator.Wght
+		for _, delegator := range vdr.permissionlessDelegators {
+			if delegator.Validator.Subnet != subnetID {
+				continue
+			}
+			newWeight, err := safemath.Add64(vdrWeight, delegator.Validator.Wght)
+			if err != nil {
+				return nil, err
+			}
+			vdrWeight = newWeight
+		}
+		if err := vdrs.AddWeight(nodeID, vdrWeight); err != nil {
 			return nil, err
 		}
 	}
@@ -338,7 +488,8 @@ func (cs *currentStakerChainStateImpl) GetStaker(txID ids.ID) (tx *Tx, reward ui
 func (cs *currentStakerChainStateImpl) setNextStaker() {
 	for _, tx := range cs.validators {
 		switch tx.UnsignedTx.(type) {
-		case *UnsignedAddValidatorTx, *UnsignedAddDelegatorTx:
+		case *UnsignedAddValidatorTx, *UnsignedAddDelegatorTx,
+			*UnsignedAddPermissionlessValidatorTx, *UnsignedAddPermissionlessDelegatorTx:
 			cs.nextStaker = cs.validatorsByTxID[tx.ID()]
 			return
 		}
@@ -362,6 +513,12 @@ func (s innerSortValidatorsByRemoval) Less(i, j int) bool {
 	case *UnsignedAddDelegatorTx:
 		iEndTime = tx.EndTime()
 		iPriority = mediumPriority
+	case *UnsignedAddPermissionlessValidatorTx:
+		iEndTime = tx.EndTime()
+		iPriority = mediumPriority
+	case *UnsignedAddPermissionlessDelegatorTx:
+		iEndTime = tx.EndTime()
+		iPriority = highPriority
 	case *UnsignedAddSubnetValidatorTx:
 		iEndTime = tx.EndTime()
 		iPriority = topPriority
@@ -380,6 +537,12 @@ func (s innerSortValidatorsByRemoval) Less(i, j int) bool {
 	case *UnsignedAddDelegatorTx:
 		jEndTime = tx.EndTime()
 		jPriority = mediumPriority
+	case *UnsignedAddPermissionlessValidatorTx:
+		jEndTime = tx.EndTime()
+		jPriority = mediumPriority
+	case *UnsignedAddPermissionlessDelegatorTx:
+		jEndTime = tx.EndTime()
+		jPriority = highPriority
 	case *UnsignedAddSubnetValidatorTx:
 		jEndTime = tx.EndTime()
 		jPriority = topPriority
@@ -395,8 +558,10 @@ func (s innerSortValidatorsByRemoval) Less(i, j int) bool {
 	}
 
 	// If the end times are the same, then we sort by the tx type. First we
-	// remove UnsignedAddSubnetValidatorTxs, then UnsignedAddDelegatorTx, then
-	// UnsignedAddValidatorTx.
+	// remove UnsignedAddSubnetValidatorTxs, then
+	// UnsignedAddPermissionlessDelegatorTxs, then UnsignedAddDelegatorTxs and
+	// UnsignedAddPermissionlessValidatorTxs, then UnsignedAddValidatorTxs. This
+	// ensures that stakers are removed before the validator they depend on.
 	if iPriority > jPriority {
 		return true
 	}
@@ -21,25 +21,33 @@ import (
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/uptime"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 var (
-	validatorsPrefix      = []byte("validators")
-	currentPrefix         = []byte("current")
-	pendingPrefix         = []byte("pending")
-	validatorPrefix       = []byte("validator")
-	delegatorPrefix       = []byte("delegator")
-	subnetValidatorPrefix = []byte("subnetValidator")
-	blockPrefix           = []byte("block")
-	txPrefix              = []byte("tx")
-	rewardUTXOsPrefix     = []byte("rewardUTXOs")
-	utxoPrefix            = []byte("utxo")
-	subnetPrefix          = []byte("subnet")
-	chainPrefix           = []byte("chain")
-	singletonPrefix       = []byte("singleton")
+	validatorsPrefix              = []byte("validators")
+	currentPrefix                 = []byte("current")
+	pendingPrefix                 = []byte("pending")
+	validatorPrefix               = []byte("validator")
+	delegatorPrefix               = []byte("delegator")
+	subnetValidatorPrefix         = []byte("subnetValidator")
+	permissionlessValidatorPrefix = []byte("permissionlessValidator")
+	permissionlessDelegatorPrefix = []byte("permissionlessDelegator")
+	blockPrefix                   = []byte("block")
+	txPrefix                      = []byte("tx")
+	rewardUTXOsPrefix             = []byte("rewardUTXOs")
+	rewardHistoryPrefix           = []byte("rewardHistory")
+	utxoPrefix                    = []byte("utxo")
+	subnetPrefix                  = []byte("subnet")
+	subnetOwnerPrefix             = []byte("subnetOwner")
+	transformedSubnetPrefix       = []byte("transformedSubnet")
+	subnetSupplyPrefix            = []byte("subnetSupply")
+	validatorRenewalPrefix        = []byte("validatorRenewal")
+	chainPrefix                   = []byte("chain")
+	singletonPrefix               = []byte("singleton")
 
 	timestampKey     = []byte("timestamp")
 	currentSupplyKey = []byte("current supply")
@@ -47,6 +55,8 @@ var (
 	initializedKey   = []byte("initialized")
 	migratedKey      = []byte("migrated")
 
+	rewardHistoryIndexedKey = []byte("reward history indexed")
+
 	errWrongNetworkID = errors.New("tx has wrong network ID")
 
 	_ InternalState = &internalStateImpl{}
@@ -58,13 +68,18 @@ const (
 	// be added/removed.
 	lowPriority byte = iota
 	mediumPriority
+	highPriority
 	topPriority
 
-	blockCacheSize       = 2048
-	txCacheSize          = 2048
-	rewardUTXOsCacheSize = 2048
-	chainCacheSize       = 2048
-	chainDBCacheSize     = 2048
+	blockCacheSize             = 2048
+	txCacheSize                = 2048
+	rewardUTXOsCacheSize       = 2048
+	chainCacheSize             = 2048
+	chainDBCacheSize           = 2048
+	subnetOwnerCacheSize       = 2048
+	transformedSubnetCacheSize = 2048
+	subnetSupplyCacheSize      = 2048
+	validatorRenewalCacheSize  = 2048
 )
 
 type InternalState interface {
@@ -88,6 +103,10 @@ type InternalState interface {
 
 	UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, error)
 
+	// GetRewardHistory returns the stakers whose rewards were, or would have
+	// been, paid to [addr]
+	GetRewardHistory(addr ids.ShortID) ([]rewardRecord, error)
+
 	Abort()
 	Commit() error
 	CommitBatch() (database.Batch, error)
@@ -107,9 +126,15 @@ type InternalState interface {
  * | | |-. delegator
  * | | | '-. list
  * | | |   '-- txID -> potential reward
- * | | '-. subnetValidator
+ * | | |-. subnetValidator
+ * | | | '-. list
+ * | | |   '-- txID -> nil
+ * | | |-. permissionlessValidator
+ * | | | '-. list
+ * | | |   '-- txID -> potential reward
+ * | | '-. permissionlessDelegator
  * | |   '-. list
- * | |     '-- txID -> nil
+ * | |     '-- txID -> potential reward
  * | '-. pending
  * |   |-. validator
  * |   | '-. list
@@ -117,7 +142,13 @@ type InternalState interface {
  * |   |-. delegator
  * |   | '-. list
  * |   |   '-- txID -> nil
- * |   '-. subnetValidator
+ * |   |-. subnetValidator
+ * |   | '-. list
+ * |   |   '-- txID -> nil
+ * |   |-. permissionlessValidator
+ * |   | '-. list
+ * |   |   '-- txID -> nil
+ * |   '-. permissionlessDelegator
  * |     '-. list
  * |       '-- txID -> nil
  * |-. blocks
@@ -128,11 +159,22 @@ type InternalState interface {
  * | '-. txID
  * |   '-. list
  * |     '-- utxoID -> utxo bytes
+ * |- rewardHistory
+ * | '-. address
+ * |   '-- stakerTxID -> rewardValidatorTxID
  * |- utxos
  * | '-- utxoDB
  * |-. subnets
  * | '-. list
  * |   '-- txID -> nil
+ * |-. subnetOwners
+ * | '-- subnetID -> owner bytes
+ * |-. transformedSubnets
+ * | '-- subnetID -> transformSubnetTxID
+ * |-. subnetSupplies
+ * | '-- subnetID -> current supply
+ * |-. validatorRenewals
+ * | '-- stakerTxID -> addRenewableValidatorTxID
  * |-. chains
  * | '-. subnetID
  * |   '-. list
@@ -159,21 +201,29 @@ type internalStateImpl struct {
 	uptimes               map[ids.ShortID]*currentValidatorState // nodeID -> uptimes
 	updatedUptimes        map[ids.ShortID]struct{}               // nodeID -> nil
 
-	validatorsDB                 database.Database
-	currentValidatorsDB          database.Database
-	currentValidatorBaseDB       database.Database
-	currentValidatorList         linkeddb.LinkedDB
-	currentDelegatorBaseDB       database.Database
-	currentDelegatorList         linkeddb.LinkedDB
-	currentSubnetValidatorBaseDB database.Database
-	currentSubnetValidatorList   linkeddb.LinkedDB
-	pendingValidatorsDB          database.Database
-	pendingValidatorBaseDB       database.Database
-	pendingValidatorList         linkeddb.LinkedDB
-	pendingDelegatorBaseDB       database.Database
-	pendingDelegatorList         linkeddb.LinkedDB
-	pendingSubnetValidatorBaseDB database.Database
-	pendingSubnetValidatorList   linkeddb.LinkedDB
+	validatorsDB                         database.Database
+	currentValidatorsDB                  database.Database
+	currentValidatorBaseDB               database.Database
+	currentValidatorList                 linkeddb.LinkedDB
+	currentDelegatorBaseDB               database.Database
+	currentDelegatorList                 linkeddb.LinkedDB
+	currentSubnetValidatorBaseDB         database.Database
+	currentSubnetValidatorList           linkeddb.LinkedDB
+	currentPermissionlessValidatorBaseDB database.Database
+	currentPermissionlessValidatorList   linkeddb.LinkedDB
+	currentPermissionlessDelegatorBaseDB database.Database
+	currentPermissionlessDelegatorList   linkeddb.LinkedDB
+	pendingValidatorsDB                  database.Database
+	pendingValidatorBaseDB               database.Database
+	pendingValidatorList                 linkeddb.LinkedDB
+	pendingDelegatorBaseDB               database.Database
+	pendingDelegatorList                 linkeddb.LinkedDB
+	pendingSubnetValidatorBaseDB         database.Database
+	pendingSubnetValidatorList           linkeddb.LinkedDB
+	pendingPermissionlessValidatorBaseDB database.Database
+	pendingPermissionlessValidatorList   linkeddb.LinkedDB
+	pendingPermissionlessDelegatorBaseDB database.Database
+	pendingPermissionlessDelegatorList   linkeddb.LinkedDB
 
 	addedBlocks map[ids.ID]Block // map of blockID -> Block
 	blockCache  cache.Cacher     // cache of blockID -> Block, if the entry is nil, it is not in the database
@@ -187,6 +237,8 @@ type internalStateImpl struct {
 	rewardUTXOsCache cache.Cacher            // cache of txID -> []*UTXO
 	rewardUTXODB     database.Database
 
+	rewardHistoryDB database.Database
+
 	modifiedUTXOs map[ids.ID]*djtx.UTXO // map of modified UTXOID -> *UTXO if the UTXO is nil, it has been removed
 	utxoDB        database.Database
 	utxoState     djtx.UTXOState
@@ -196,6 +248,22 @@ type internalStateImpl struct {
 	subnetBaseDB  database.Database
 	subnetDB      linkeddb.LinkedDB
 
+	modifiedSubnetOwners map[ids.ID]verify.Verifiable // map of subnetID -> owner
+	subnetOwnerCache     cache.Cacher                 // cache of subnetID -> owner
+	subnetOwnerDB        database.Database
+
+	transformedSubnets     map[ids.ID]*Tx // map of subnetID -> transformSubnetTx
+	transformedSubnetCache cache.Cacher   // cache of subnetID -> transformSubnetTx, if the entry is nil, it is not in the database
+	transformedSubnetDB    database.Database
+
+	modifiedSubnetSupplies map[ids.ID]uint64 // map of subnetID -> current supply
+	subnetSupplyCache      cache.Cacher      // cache of subnetID -> current supply
+	subnetSupplyDB         database.Database
+
+	validatorRenewals     map[ids.ID]*Tx // map of stakerTxID -> addRenewableValidatorTx
+	validatorRenewalCache cache.Cacher   // cache of stakerTxID -> addRenewableValidatorTx, if the entry is nil, it is not in the database
+	validatorRenewalDB    database.Database
+
 	addedChains  map[ids.ID][]*Tx // maps subnetID -> the newly added chains to the subnet
 	chainCache   cache.Cacher     // cache of subnetID -> the chains after all local modifications []*Tx
 	chainDBCache cache.Cacher     // cache of subnetID -> linkedDB
@@ -226,11 +294,15 @@ func newInternalStateDatabases(vm *VM, db database.Database) *internalStateImpl
 	currentValidatorBaseDB := prefixdb.New(validatorPrefix, currentValidatorsDB)
 	currentDelegatorBaseDB := prefixdb.New(delegatorPrefix, currentValidatorsDB)
 	currentSubnetValidatorBaseDB := prefixdb.New(subnetValidatorPrefix, currentValidatorsDB)
+	currentPermissionlessValidatorBaseDB := prefixdb.New(permissionlessValidatorPrefix, currentValidatorsDB)
+	currentPermissionlessDelegatorBaseDB := prefixdb.New(permissionlessDelegatorPrefix, currentValidatorsDB)
 
 	pendingValidatorsDB := prefixdb.New(pendingPrefix, validatorsDB)
 	pendingValidatorBaseDB := prefixdb.New(validatorPrefix, pendingValidatorsDB)
 	pendingDelegatorBaseDB := prefixdb.New(delegatorPrefix, pendingValidatorsDB)
 	pendingSubnetValidatorBaseDB := prefixdb.New(subnetValidatorPrefix, pendingValidatorsDB)
+	pendingPermissionlessValidatorBaseDB := prefixdb.New(permissionlessValidatorPrefix, pendingValidatorsDB)
+	pendingPermissionlessDelegatorBaseDB := prefixdb.New(permissionlessDelegatorPrefix, pendingValidatorsDB)
 
 	rewardUTXODB := prefixdb.New(rewardUTXOsPrefix, baseDB)
 	utxoDB := prefixdb.New(utxoPrefix, baseDB)
@@ -243,21 +315,29 @@ func newInternalStateDatabases(vm *VM, db database.Database) *internalStateImpl
 		uptimes:        make(map[ids.ShortID]*currentValidatorState),
 		updatedUptimes: make(map[ids.ShortID]struct{}),
 
-		validatorsDB:                 validatorsDB,
-		currentValidatorsDB:          currentValidatorsDB,
-		currentValidatorBaseDB:       currentValidatorBaseDB,
-		currentValidatorList:         linkeddb.NewDefault(currentValidatorBaseDB),
-		currentDelegatorBaseDB:       currentDelegatorBaseDB,
-		currentDelegatorList:         linkeddb.NewDefault(currentDelegatorBaseDB),
-		currentSubnetValidatorBaseDB: currentSubnetValidatorBaseDB,
-		currentSubnetValidatorList:   linkeddb.NewDefault(currentSubnetValidatorBaseDB),
-		pendingValidatorsDB:          pendingValidatorsDB,
-		pendingValidatorBaseDB:       pendingValidatorBaseDB,
-		pendingValidatorList:         linkeddb.NewDefault(pendingValidatorBaseDB),
-		pendingDelegatorBaseDB:       pendingDelegatorBaseDB,
-		pendingDelegatorList:         linkeddb.NewDefault(pendingDelegatorBaseDB),
-		pendingSubnetValidatorBaseDB: pendingSubnetValidatorBaseDB,
-		pendingSubnetValidatorList:   linkeddb.NewDefault(pendingSubnetValidatorBaseDB),
+		validatorsDB:                         validatorsDB,
+		currentValidatorsDB:                  currentValidatorsDB,
+		currentValidatorBaseDB:               currentValidatorBaseDB,
+		currentValidatorList:                 linkeddb.NewDefault(currentValidatorBaseDB),
+		currentDelegatorBaseDB:               currentDelegatorBaseDB,
+		currentDelegatorList:                 linkeddb.NewDefault(currentDelegatorBaseDB),
+		currentSubnetValidatorBaseDB:         currentSubnetValidatorBaseDB,
+		currentSubnetValidatorList:           linkeddb.NewDefault(currentSubnetValidatorBaseDB),
+		currentPermissionlessValidatorBaseDB: currentPermissionlessValidatorBaseDB,
+		currentPermissionlessValidatorList:   linkeddb.NewDefault(currentPermissionlessValidatorBaseDB),
+		currentPermissionlessDelegatorBaseDB: currentPermissionlessDelegatorBaseDB,
+		currentPermissionlessDelegatorList:   linkeddb.NewDefault(currentPermissionlessDelegatorBaseDB),
+		pendingValidatorsDB:                  pendingValidatorsDB,
+		pendingValidatorBaseDB:               pendingValidatorBaseDB,
+		pendingValidatorList:                 linkeddb.NewDefault(pendingValidatorBaseDB),
+		pendingDelegatorBaseDB:               pendingDelegatorBaseDB,
+		pendingDelegatorList:                 linkeddb.NewDefault(pendingDelegatorBaseDB),
+		pendingSubnetValidatorBaseDB:         pendingSubnetValidatorBaseDB,
+		pendingSubnetValidatorList:           linkeddb.NewDefault(pendingSubnetValidatorBaseDB),
+		pendingPermissionlessValidatorBaseDB: pendingPermissionlessValidatorBaseDB,
+		pendingPermissionlessValidatorList:   linkeddb.NewDefault(pendingPermissionlessValidatorBaseDB),
+		pendingPermissionlessDelegatorBaseDB: pendingPermissionlessDelegatorBaseDB,
+		pendingPermissionlessDelegatorList:   linkeddb.NewDefault(pendingPermissionlessDelegatorBaseDB),
 
 		addedBlocks: make(map[ids.ID]Block),
 		blockDB:     prefixdb.New(blockPrefix, baseDB),
@@ -268,12 +348,26 @@ func newInternalStateDatabases(vm *VM, db database.Database) *internalStateImpl
 		addedRewardUTXOs: make(map[ids.ID][]*djtx.UTXO),
 		rewardUTXODB:     rewardUTXODB,
 
+		rewardHistoryDB: prefixdb.New(rewardHistoryPrefix, baseDB),
+
 		modifiedUTXOs: make(map[ids.ID]*djtx.UTXO),
 		utxoDB:        utxoDB,
 
 		subnetBaseDB: subnetBaseDB,
 		subnetDB:     linkeddb.NewDefault(subnetBaseDB),
 
+		modifiedSubnetOwners: make(map[ids.ID]verify.Verifiable),
+		subnetOwnerDB:        prefixdb.New(subnetOwnerPrefix, baseDB),
+
+		transformedSubnets:  make(map[ids.ID]*Tx),
+		transformedSubnetDB: prefixdb.New(transformedSubnetPrefix, baseDB),
+
+		modifiedSubnetSupplies: make(map[ids.ID]uint64),
+		subnetSupplyDB:         prefixdb.New(subnetSupplyPrefix, baseDB),
+
+		validatorRenewals:  make(map[ids.ID]*Tx),
+		validatorRenewalDB: prefixdb.New(validatorRenewalPrefix, baseDB),
+
 		addedChains: make(map[ids.ID][]*Tx),
 		chainDB:     prefixdb.New(chainPrefix, baseDB),
 
@@ -288,6 +382,10 @@ func (st *internalStateImpl) initCaches() {
 	st.utxoState = djtx.NewUTXOState(st.utxoDB, GenesisCodec)
 	st.chainCache = &cache.LRU{Size: chainCacheSize}
 	st.chainDBCache = &cache.LRU{Size: chainDBCacheSize}
+	st.subnetOwnerCache = &cache.LRU{Size: subnetOwnerCacheSize}
+	st.transformedSubnetCache = &cache.LRU{Size: transformedSubnetCacheSize}
+	st.subnetSupplyCache = &cache.LRU{Size: subnetSupplyCacheSize}
+	st.validatorRenewalCache = &cache.LRU{Size: validatorRenewalCacheSize}
 }
 
 func (st *internalStateImpl) initMeteredCaches(namespace string, metrics prometheus.Registerer) error {
@@ -337,12 +435,52 @@ func (st *internalStateImpl) initMeteredCaches(namespace string, metrics prometh
 		metrics,
 		&cache.LRU{Size: chainDBCacheSize},
 	)
+	if err != nil {
+		return err
+	}
+
+	subnetOwnerCache, err := metercacher.New(
+		fmt.Sprintf("%s_subnet_owner_cache", namespace),
+		metrics,
+		&cache.LRU{Size: subnetOwnerCacheSize},
+	)
+	if err != nil {
+		return err
+	}
+
+	transformedSubnetCache, err := metercacher.New(
+		fmt.Sprintf("%s_transformed_subnet_cache", namespace),
+		metrics,
+		&cache.LRU{Size: transformedSubnetCacheSize},
+	)
+	if err != nil {
+		return err
+	}
+
+	subnetSupplyCache, err := metercacher.New(
+		fmt.Sprintf("%s_subnet_supply_cache", namespace),
+		metrics,
+		&cache.LRU{Size: subnetSupplyCacheSize},
+	)
+	if err != nil {
+		return err
+	}
+
+	validatorRenewalCache, err := metercacher.New(
+		fmt.Sprintf("%s_validator_renewal_cache", namespace),
+		metrics,
+		&cache.LRU{Size: validatorRenewalCacheSize},
+	)
 	st.blockCache = blockCache
 	st.txCache = txCache
 	st.rewardUTXOsCache = rewardUTXOsCache
 	st.utxoState = utxoState
 	st.chainCache = chainCache
 	st.chainDBCache = chainDBCache
+	st.subnetOwnerCache = subnetOwnerCache
+	st.transformedSubnetCache = transformedSubnetCache
+	st.subnetSupplyCache = subnetSupplyCache
+	st.validatorRenewalCache = validatorRenewalCache
 	return err
 }
 
@@ -372,6 +510,13 @@ func (st *internalStateImpl) sync(genesis []byte) error {
 			err,
 		)
 	}
+
+	if err := st.indexRewardHistory(); err != nil {
+		return fmt.Errorf(
+			"failed to index the reward history: %w",
+			err,
+		)
+	}
 	return nil
 }
 
@@ -451,6 +596,130 @@ func (st *internalStateImpl) AddSubnet(createSubnetTx *Tx) {
 	}
 }
 
+func (st *internalStateImpl) GetSubnetOwner(subnetID ids.ID) (verify.Verifiable, error) {
+	if owner, exists := st.modifiedSubnetOwners[subnetID]; exists {
+		return owner, nil
+	}
+	if ownerIntf, cached := st.subnetOwnerCache.Get(subnetID); cached {
+		return ownerIntf.(verify.Verifiable), nil
+	}
+
+	ownerBytes, err := st.subnetOwnerDB.Get(subnetID[:])
+	if err == nil {
+		var owner verify.Verifiable
+		if _, err := GenesisCodec.Unmarshal(ownerBytes, &owner); err != nil {
+			return nil, err
+		}
+		st.subnetOwnerCache.Put(subnetID, owner)
+		return owner, nil
+	}
+	if err != database.ErrNotFound {
+		return nil, err
+	}
+
+	// The ownership of this subnet was never transferred, so the owner is the
+	// one specified when the subnet was created.
+	subnetTx, _, err := st.GetTx(subnetID)
+	if err != nil {
+		return nil, err
+	}
+	subnet, ok := subnetTx.UnsignedTx.(*UnsignedCreateSubnetTx)
+	if !ok {
+		return nil, errWrongTxType
+	}
+	st.subnetOwnerCache.Put(subnetID, subnet.Owner)
+	return subnet.Owner, nil
+}
+
+func (st *internalStateImpl) SetSubnetOwner(subnetID ids.ID, owner verify.Verifiable) {
+	st.modifiedSubnetOwners[subnetID] = owner
+}
+
+func (st *internalStateImpl) GetSubnetTransformation(subnetID ids.ID) (*Tx, error) {
+	if tx, exists := st.transformedSubnets[subnetID]; exists {
+		return tx, nil
+	}
+	if txIntf, cached := st.transformedSubnetCache.Get(subnetID); cached {
+		if txIntf == nil {
+			return nil, database.ErrNotFound
+		}
+		return txIntf.(*Tx), nil
+	}
+
+	txID, err := database.GetID(st.transformedSubnetDB, subnetID[:])
+	if err == database.ErrNotFound {
+		st.transformedSubnetCache.Put(subnetID, nil)
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tx, _, err := st.GetTx(txID)
+	if err != nil {
+		return nil, err
+	}
+	st.transformedSubnetCache.Put(subnetID, tx)
+	return tx, nil
+}
+
+func (st *internalStateImpl) AddSubnetTransformation(transformSubnetTxIntf *Tx) {
+	transformSubnetTx := transformSubnetTxIntf.UnsignedTx.(*UnsignedTransformSubnetTx)
+	st.transformedSubnets[transformSubnetTx.Subnet] = transformSubnetTxIntf
+}
+
+func (st *internalStateImpl) GetSubnetCurrentSupply(subnetID ids.ID) (uint64, error) {
+	if currentSupply, exists := st.modifiedSubnetSupplies[subnetID]; exists {
+		return currentSupply, nil
+	}
+	if currentSupplyIntf, cached := st.subnetSupplyCache.Get(subnetID); cached {
+		return currentSupplyIntf.(uint64), nil
+	}
+
+	currentSupply, err := database.GetUInt64(st.subnetSupplyDB, subnetID[:])
+	if err != nil {
+		return 0, err
+	}
+	st.subnetSupplyCache.Put(subnetID, currentSupply)
+	return currentSupply, nil
+}
+
+func (st *internalStateImpl) SetSubnetCurrentSupply(subnetID ids.ID, currentSupply uint64) {
+	st.modifiedSubnetSupplies[subnetID] = currentSupply
+}
+
+func (st *internalStateImpl) GetValidatorRenewal(stakerTxID ids.ID) (*Tx, error) {
+	if tx, exists := st.validatorRenewals[stakerTxID]; exists {
+		return tx, nil
+	}
+	if txIntf, cached := st.validatorRenewalCache.Get(stakerTxID); cached {
+		if txIntf == nil {
+			return nil, database.ErrNotFound
+		}
+		return txIntf.(*Tx), nil
+	}
+
+	txID, err := database.GetID(st.validatorRenewalDB, stakerTxID[:])
+	if err == database.ErrNotFound {
+		st.validatorRenewalCache.Put(stakerTxID, nil)
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tx, _, err := st.GetTx(txID)
+	if err != nil {
+		return nil, err
+	}
+	st.validatorRenewalCache.Put(stakerTxID, tx)
+	return tx, nil
+}
+
+func (st *internalStateImpl) SetValidatorRenewal(stakerTxID ids.ID, addRenewableValidatorTx *Tx) {
+	st.validatorRenewals[stakerTxID] = addRenewableValidatorTx
+}
+
 func (st *internalStateImpl) GetChains(subnetID ids.ID) ([]*Tx, error) {
 	if chainsIntf, cached := st.chainCache.Get(subnetID); cached {
 		return chainsIntf.([]*Tx), nil
@@ -644,6 +913,29 @@ func (st *internalStateImpl) UTXOIDs(addr []byte, start ids.ID, limit int) ([]id
 	return st.utxoState.UTXOIDs(addr, start, limit)
 }
 
+func (st *internalStateImpl) GetRewardHistory(addr ids.ShortID) ([]rewardRecord, error) {
+	addrDB := prefixdb.New(addr[:], st.rewardHistoryDB)
+	it := addrDB.NewIterator()
+	defer it.Release()
+
+	records := []rewardRecord(nil)
+	for it.Next() {
+		stakerTxID, err := ids.ToID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		rewardTxID, err := ids.ToID(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rewardRecord{
+			StakerTxID: stakerTxID,
+			RewardTxID: rewardTxID,
+		})
+	}
+	return records, it.Error()
+}
+
 func (st *internalStateImpl) CurrentStakerChainState() currentStakerChainState {
 	return st.currentStakerChainState
 }
@@ -724,6 +1016,11 @@ func (st *internalStateImpl) CommitBatch() (database.Batch, error) {
 	if err := st.writeBlocks(); err != nil {
 		return nil, err
 	}
+	// The reward history must be written before the txs and reward UTXOs
+	// that it's derived from are flushed
+	if err := st.writeRewardHistory(); err != nil {
+		return nil, err
+	}
 	if err := st.writeTXs(); err != nil {
 		return nil, err
 	}
@@ -736,6 +1033,18 @@ func (st *internalStateImpl) CommitBatch() (database.Batch, error) {
 	if err := st.writeSubnets(); err != nil {
 		return nil, err
 	}
+	if err := st.writeSubnetOwners(); err != nil {
+		return nil, err
+	}
+	if err := st.writeTransformedSubnets(); err != nil {
+		return nil, err
+	}
+	if err := st.writeSubnetSupplies(); err != nil {
+		return nil, err
+	}
+	if err := st.writeValidatorRenewals(); err != nil {
+		return nil, err
+	}
 	if err := st.writeChains(); err != nil {
 		return nil, err
 	}
@@ -748,10 +1057,14 @@ func (st *internalStateImpl) CommitBatch() (database.Batch, error) {
 func (st *internalStateImpl) Close() error {
 	errs := wrappers.Errs{}
 	errs.Add(
+		st.pendingPermissionlessDelegatorBaseDB.Close(),
+		st.pendingPermissionlessValidatorBaseDB.Close(),
 		st.pendingSubnetValidatorBaseDB.Close(),
 		st.pendingDelegatorBaseDB.Close(),
 		st.pendingValidatorBaseDB.Close(),
 		st.pendingValidatorsDB.Close(),
+		st.currentPermissionlessDelegatorBaseDB.Close(),
+		st.currentPermissionlessValidatorBaseDB.Close(),
 		st.currentSubnetValidatorBaseDB.Close(),
 		st.currentDelegatorBaseDB.Close(),
 		st.currentValidatorBaseDB.Close(),
@@ -760,8 +1073,13 @@ func (st *internalStateImpl) Close() error {
 		st.blockDB.Close(),
 		st.txDB.Close(),
 		st.rewardUTXODB.Close(),
+		st.rewardHistoryDB.Close(),
 		st.utxoDB.Close(),
 		st.subnetBaseDB.Close(),
+		st.subnetOwnerDB.Close(),
+		st.transformedSubnetDB.Close(),
+		st.subnetSupplyDB.Close(),
+		st.validatorRenewalDB.Close(),
 		st.chainDB.Close(),
 		st.singletonDB.Close(),
 		st.baseDB.Close(),
@@ -779,6 +1097,34 @@ type currentValidatorState struct {
 }
 
 func (st *internalStateImpl) writeCurrentStakers() error {
+	// Stakers are deleted before stakers are added so that a validator that
+	// was renewed tracks the uptime of its new staking period.
+	for _, tx := range st.deletedCurrentStakers {
+		var db database.KeyValueWriter
+		switch tx := tx.UnsignedTx.(type) {
+		case *UnsignedAddValidatorTx:
+			db = st.currentValidatorList
+			delete(st.uptimes, tx.Validator.NodeID)
+			delete(st.updatedUptimes, tx.Validator.NodeID)
+		case *UnsignedAddDelegatorTx:
+			db = st.currentDelegatorList
+		case *UnsignedAddSubnetValidatorTx:
+			db = st.currentSubnetValidatorList
+		case *UnsignedAddPermissionlessValidatorTx:
+			db = st.currentPermissionlessValidatorList
+		case *UnsignedAddPermissionlessDelegatorTx:
+			db = st.currentPermissionlessDelegatorList
+		default:
+			return errWrongTxType
+		}
+
+		txID := tx.ID()
+		if err := db.Delete(txID[:]); err != nil {
+			return err
+		}
+	}
+	st.deletedCurrentStakers = nil
+
 	for _, currentStaker := range st.addedCurrentStakers {
 		txID := currentStaker.addStakerTx.ID()
 		potentialReward := currentStaker.potentialReward
@@ -812,33 +1158,19 @@ func (st *internalStateImpl) writeCurrentStakers() error {
 			if err := st.currentSubnetValidatorList.Put(txID[:], nil); err != nil {
 				return err
 			}
+		case *UnsignedAddPermissionlessValidatorTx:
+			if err := database.PutUInt64(st.currentPermissionlessValidatorList, txID[:], potentialReward); err != nil {
+				return err
+			}
+		case *UnsignedAddPermissionlessDelegatorTx:
+			if err := database.PutUInt64(st.currentPermissionlessDelegatorList, txID[:], potentialReward); err != nil {
+				return err
+			}
 		default:
 			return errWrongTxType
 		}
 	}
 	st.addedCurrentStakers = nil
-
-	for _, tx := range st.deletedCurrentStakers {
-		var db database.KeyValueWriter
-		switch tx := tx.UnsignedTx.(type) {
-		case *UnsignedAddValidatorTx:
-			db = st.currentValidatorList
-			delete(st.uptimes, tx.Validator.NodeID)
-			delete(st.updatedUptimes, tx.Validator.NodeID)
-		case *UnsignedAddDelegatorTx:
-			db = st.currentDelegatorList
-		case *UnsignedAddSubnetValidatorTx:
-			db = st.currentSubnetValidatorList
-		default:
-			return errWrongTxType
-		}
-
-		txID := tx.ID()
-		if err := db.Delete(txID[:]); err != nil {
-			return err
-		}
-	}
-	st.deletedCurrentStakers = nil
 	return nil
 }
 
@@ -852,6 +1184,10 @@ func (st *internalStateImpl) writePendingStakers() error {
 			db = st.pendingDelegatorList
 		case *UnsignedAddSubnetValidatorTx:
 			db = st.pendingSubnetValidatorList
+		case *UnsignedAddPermissionlessValidatorTx:
+			db = st.pendingPermissionlessValidatorList
+		case *UnsignedAddPermissionlessDelegatorTx:
+			db = st.pendingPermissionlessDelegatorList
 		default:
 			return errWrongTxType
 		}
@@ -872,6 +1208,10 @@ func (st *internalStateImpl) writePendingStakers() error {
 			db = st.pendingDelegatorList
 		case *UnsignedAddSubnetValidatorTx:
 			db = st.pendingSubnetValidatorList
+		case *UnsignedAddPermissionlessValidatorTx:
+			db = st.pendingPermissionlessValidatorList
+		case *UnsignedAddPermissionlessDelegatorTx:
+			db = st.pendingPermissionlessDelegatorList
 		default:
 			return errWrongTxType
 		}
@@ -948,6 +1288,113 @@ func (st *internalStateImpl) writeTXs() error {
 	return nil
 }
 
+// writeRewardHistory indexes each added RewardValidatorTx by the addresses that
+// own the staker's rewards, and by the addresses that were paid a reward.
+func (st *internalStateImpl) writeRewardHistory() error {
+	for rewardTxID, txStatus := range st.addedTxs {
+		utx, ok := txStatus.tx.UnsignedTx.(*UnsignedRewardValidatorTx)
+		if !ok {
+			continue
+		}
+		if err := st.putRewardHistory(rewardTxID, utx, st.addedRewardUTXOs[utx.TxID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexRewardHistory adds every RewardValidatorTx that was accepted before the
+// reward history index existed to the index. This only happens once.
+func (st *internalStateImpl) indexRewardHistory() error {
+	indexed, err := st.singletonDB.Has(rewardHistoryIndexedKey)
+	if err != nil || indexed {
+		return err
+	}
+
+	st.vm.ctx.Log.Info("indexing the reward history of previously accepted transactions")
+	txIt := st.txDB.NewIterator()
+	defer txIt.Release()
+
+	numIndexed := 0
+	for txIt.Next() {
+		stx := stateTx{}
+		if _, err := GenesisCodec.Unmarshal(txIt.Value(), &stx); err != nil {
+			return err
+		}
+		tx := Tx{}
+		if _, err := GenesisCodec.Unmarshal(stx.Tx, &tx); err != nil {
+			return err
+		}
+		utx, ok := tx.UnsignedTx.(*UnsignedRewardValidatorTx)
+		if !ok {
+			continue
+		}
+		rewardTxID, err := ids.ToID(txIt.Key())
+		if err != nil {
+			return err
+		}
+		rewardUTXOs, err := st.GetRewardUTXOs(utx.TxID)
+		if err != nil {
+			return fmt.Errorf("failed to get reward UTXOs of staker tx %s: %w", utx.TxID, err)
+		}
+		if err := st.putRewardHistory(rewardTxID, utx, rewardUTXOs); err != nil {
+			return err
+		}
+		numIndexed++
+	}
+	if err := txIt.Error(); err != nil {
+		return err
+	}
+
+	if err := st.singletonDB.Put(rewardHistoryIndexedKey, nil); err != nil {
+		return err
+	}
+	if err := st.Commit(); err != nil {
+		return err
+	}
+	st.vm.ctx.Log.Info("finished indexing the reward history of %d reward transactions", numIndexed)
+	return nil
+}
+
+// putRewardHistory records [rewardTxID] under each address that owns the
+// rewards of the staker tx rewarded by [utx] or that was paid [rewardUTXOs].
+func (st *internalStateImpl) putRewardHistory(rewardTxID ids.ID, utx *UnsignedRewardValidatorTx, rewardUTXOs []*djtx.UTXO) error {
+	stakerTx, _, err := st.GetTx(utx.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to get staker tx %s: %w", utx.TxID, err)
+	}
+
+	addrs := ids.ShortSet{}
+	if owner, ok := stakerRewardsOwner(stakerTx).(djtx.Addressable); ok {
+		for _, addr := range owner.Addresses() {
+			addrID, err := ids.ToShortID(addr)
+			if err != nil {
+				return err
+			}
+			addrs.Add(addrID)
+		}
+	}
+	for _, utxo := range rewardUTXOs {
+		if out, ok := utxo.Out.(djtx.Addressable); ok {
+			for _, addr := range out.Addresses() {
+				addrID, err := ids.ToShortID(addr)
+				if err != nil {
+					return err
+				}
+				addrs.Add(addrID)
+			}
+		}
+	}
+
+	for _, addr := range addrs.List() {
+		addrDB := prefixdb.New(addr[:], st.rewardHistoryDB)
+		if err := addrDB.Put(utx.TxID[:], rewardTxID[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (st *internalStateImpl) writeRewardUTXOs() error {
 	for txID, utxos := range st.addedRewardUTXOs {
 		delete(st.addedRewardUTXOs, txID)
@@ -1000,6 +1447,62 @@ func (st *internalStateImpl) writeSubnets() error {
 	return nil
 }
 
+func (st *internalStateImpl) writeSubnetOwners() error {
+	for subnetID, owner := range st.modifiedSubnetOwners {
+		subnetID := subnetID
+		owner := owner
+
+		ownerBytes, err := GenesisCodec.Marshal(codecVersion, &owner)
+		if err != nil {
+			return err
+		}
+
+		delete(st.modifiedSubnetOwners, subnetID)
+		st.subnetOwnerCache.Put(subnetID, owner)
+		if err := st.subnetOwnerDB.Put(subnetID[:], ownerBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (st *internalStateImpl) writeTransformedSubnets() error {
+	for subnetID, tx := range st.transformedSubnets {
+		txID := tx.ID()
+
+		delete(st.transformedSubnets, subnetID)
+		st.transformedSubnetCache.Put(subnetID, tx)
+		if err := database.PutID(st.transformedSubnetDB, subnetID[:], txID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (st *internalStateImpl) writeSubnetSupplies() error {
+	for subnetID, currentSupply := range st.modifiedSubnetSupplies {
+		delete(st.modifiedSubnetSupplies, subnetID)
+		st.subnetSupplyCache.Put(subnetID, currentSupply)
+		if err := database.PutUInt64(st.subnetSupplyDB, subnetID[:], currentSupply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (st *internalStateImpl) writeValidatorRenewals() error {
+	for stakerTxID, tx := range st.validatorRenewals {
+		txID := tx.ID()
+
+		delete(st.validatorRenewals, stakerTxID)
+		st.validatorRenewalCache.Put(stakerTxID, tx)
+		if err := database.PutID(st.validatorRenewalDB, stakerTxID[:], txID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (st *internalStateImpl) writeChains() error {
 	for subnetID, chains := range st.addedChains {
 		for _, chain := range chains {
@@ -1111,7 +1614,8 @@ func (st *internalStateImpl) loadCurrentValidators() error {
 		cs.validators = append(cs.validators, tx)
 		cs.validatorsByNodeID[addValidatorTx.Validator.NodeID] = &currentValidatorImpl{
 			validatorImpl: validatorImpl{
-				subnets: make(map[ids.ID]*UnsignedAddSubnetValidatorTx),
+				subnets:                  make(map[ids.ID]*UnsignedAddSubnetValidatorTx),
+				permissionlessValidators: make(map[ids.ID]*UnsignedAddPermissionlessValidatorTx),
 			},
 			addValidatorTx:  addValidatorTx,
 			potentialReward: uptime.PotentialReward,
@@ -1200,6 +1704,84 @@ func (st *internalStateImpl) loadCurrentValidators() error {
 		return err
 	}
 
+	permissionlessValidatorIt := st.currentPermissionlessValidatorList.NewIterator()
+	defer permissionlessValidatorIt.Release()
+	for permissionlessValidatorIt.Next() {
+		txIDBytes := permissionlessValidatorIt.Key()
+		txID, err := ids.ToID(txIDBytes)
+		if err != nil {
+			return err
+		}
+		tx, _, err := st.GetTx(txID)
+		if err != nil {
+			return err
+		}
+
+		potentialRewardBytes := permissionlessValidatorIt.Value()
+		potentialReward, err := database.ParseUInt64(potentialRewardBytes)
+		if err != nil {
+			return err
+		}
+
+		addPermissionlessValidatorTx, ok := tx.UnsignedTx.(*UnsignedAddPermissionlessValidatorTx)
+		if !ok {
+			return errWrongTxType
+		}
+
+		cs.validators = append(cs.validators, tx)
+		vdr, exists := cs.validatorsByNodeID[addPermissionlessValidatorTx.Validator.NodeID]
+		if !exists {
+			return errDSValidatorSubset
+		}
+		vdr.permissionlessValidators[addPermissionlessValidatorTx.Validator.Subnet] = addPermissionlessValidatorTx
+		cs.validatorsByTxID[txID] = &validatorReward{
+			addStakerTx:     tx,
+			potentialReward: potentialReward,
+		}
+	}
+	if err := permissionlessValidatorIt.Error(); err != nil {
+		return err
+	}
+
+	permissionlessDelegatorIt := st.currentPermissionlessDelegatorList.NewIterator()
+	defer permissionlessDelegatorIt.Release()
+	for permissionlessDelegatorIt.Next() {
+		txIDBytes := permissionlessDelegatorIt.Key()
+		txID, err := ids.ToID(txIDBytes)
+		if err != nil {
+			return err
+		}
+		tx, _, err := st.GetTx(txID)
+		if err != nil {
+			return err
+		}
+
+		potentialRewardBytes := permissionlessDelegatorIt.Value()
+		potentialReward, err := database.ParseUInt64(potentialRewardBytes)
+		if err != nil {
+			return err
+		}
+
+		addPermissionlessDelegatorTx, ok := tx.UnsignedTx.(*UnsignedAddPermissionlessDelegatorTx)
+		if !ok {
+			return errWrongTxType
+		}
+
+		cs.validators = append(cs.validators, tx)
+		vdr, exists := cs.validatorsByNodeID[addPermissionlessDelegatorTx.Validator.NodeID]
+		if !exists {
+			return errDelegatorSubset
+		}
+		vdr.permissionlessDelegators = append(vdr.permissionlessDelegators, addPermissionlessDelegatorTx)
+		cs.validatorsByTxID[txID] = &validatorReward{
+			addStakerTx:     tx,
+			potentialReward: potentialReward,
+		}
+	}
+	if err := permissionlessDelegatorIt.Error(); err != nil {
+		return err
+	}
+
 	for _, vdr := range cs.validatorsByNodeID {
 		sortDelegatorsByRemoval(vdr.delegators)
 	}
@@ -1306,6 +1888,69 @@ func (st *internalStateImpl) loadPendingValidators() error {
 		return err
 	}
 
+	permissionlessValidatorIt := st.pendingPermissionlessValidatorList.NewIterator()
+	defer permissionlessValidatorIt.Release()
+	for permissionlessValidatorIt.Next() {
+		txIDBytes := permissionlessValidatorIt.Key()
+		txID, err := ids.ToID(txIDBytes)
+		if err != nil {
+			return err
+		}
+		tx, _, err := st.GetTx(txID)
+		if err != nil {
+			return err
+		}
+
+		addPermissionlessValidatorTx, ok := tx.UnsignedTx.(*UnsignedAddPermissionlessValidatorTx)
+		if !ok {
+			return errWrongTxType
+		}
+
+		ps.validators = append(ps.validators, tx)
+		vdr, exists := ps.validatorExtrasByNodeID[addPermissionlessValidatorTx.Validator.NodeID]
+		if !exists {
+			vdr = &validatorImpl{}
+			ps.validatorExtrasByNodeID[addPermissionlessValidatorTx.Validator.NodeID] = vdr
+		}
+		if vdr.permissionlessValidators == nil {
+			vdr.permissionlessValidators = make(map[ids.ID]*UnsignedAddPermissionlessValidatorTx)
+		}
+		vdr.permissionlessValidators[addPermissionlessValidatorTx.Validator.Subnet] = addPermissionlessValidatorTx
+	}
+	if err := permissionlessValidatorIt.Error(); err != nil {
+		return err
+	}
+
+	permissionlessDelegatorIt := st.pendingPermissionlessDelegatorList.NewIterator()
+	defer permissionlessDelegatorIt.Release()
+	for permissionlessDelegatorIt.Next() {
+		txIDBytes := permissionlessDelegatorIt.Key()
+		txID, err := ids.ToID(txIDBytes)
+		if err != nil {
+			return err
+		}
+		tx, _, err := st.GetTx(txID)
+		if err != nil {
+			return err
+		}
+
+		addPermissionlessDelegatorTx, ok := tx.UnsignedTx.(*UnsignedAddPermissionlessDelegatorTx)
+		if !ok {
+			return errWrongTxType
+		}
+
+		ps.validators = append(ps.validators, tx)
+		vdr, exists := ps.validatorExtrasByNodeID[addPermissionlessDelegatorTx.Validator.NodeID]
+		if !exists {
+			vdr = &validatorImpl{}
+			ps.validatorExtrasByNodeID[addPermissionlessDelegatorTx.Validator.NodeID] = vdr
+		}
+		vdr.permissionlessDelegators = append(vdr.permissionlessDelegators, addPermissionlessDelegatorTx)
+	}
+	if err := permissionlessDelegatorIt.Error(); err != nil {
+		return err
+	}
+
 	for _, vdr := range ps.validatorExtrasByNodeID {
 		sortDelegatorsByAddition(vdr.delegators)
 	}
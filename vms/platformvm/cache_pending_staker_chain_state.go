@@ -23,6 +23,9 @@ type pendingStakerChainState interface {
 
 	AddStaker(addStakerTx *Tx) pendingStakerChainState
 	DeleteStakers(numToRemove int) pendingStakerChainState
+	// DeleteSubnetValidator removes the subnet validator added by [txID]
+	// before its start time.
+	DeleteSubnetValidator(txID ids.ID) (pendingStakerChainState, error)
 
 	// Stakers returns the list of pending validators in order of their removal
 	// from the pending staker set
@@ -83,56 +86,40 @@ func (ps *pendingStakerChainStateImpl) AddStaker(addStakerTx *Tx) pendingStakerC
 	case *UnsignedAddDelegatorTx:
 		newPS.validatorsByNodeID = ps.validatorsByNodeID
 
-		newPS.validatorExtrasByNodeID = make(map[ids.ShortID]*validatorImpl, len(ps.validatorExtrasByNodeID)+1)
-		for nodeID, vdr := range ps.validatorExtrasByNodeID {
-			if nodeID != tx.Validator.NodeID {
-				newPS.validatorExtrasByNodeID[nodeID] = vdr
-			}
-		}
-		if vdr, exists := ps.validatorExtrasByNodeID[tx.Validator.NodeID]; exists {
-			newDelegators := make([]*UnsignedAddDelegatorTx, len(vdr.delegators)+1)
-			copy(newDelegators, vdr.delegators)
-			newDelegators[len(vdr.delegators)] = tx
-			sortDelegatorsByAddition(newDelegators)
-
-			newPS.validatorExtrasByNodeID[tx.Validator.NodeID] = &validatorImpl{
-				delegators: newDelegators,
-				subnets:    vdr.subnets,
-			}
-		} else {
-			newPS.validatorExtrasByNodeID[tx.Validator.NodeID] = &validatorImpl{
-				delegators: []*UnsignedAddDelegatorTx{
-					tx,
-				},
-			}
-		}
+		newVdr := ps.copyValidatorExtras(newPS, tx.Validator.NodeID)
+		newDelegators := make([]*UnsignedAddDelegatorTx, len(newVdr.delegators)+1)
+		copy(newDelegators, newVdr.delegators)
+		newDelegators[len(newVdr.delegators)] = tx
+		sortDelegatorsByAddition(newDelegators)
+		newVdr.delegators = newDelegators
 	case *UnsignedAddSubnetValidatorTx:
 		newPS.validatorsByNodeID = ps.validatorsByNodeID
 
-		newPS.validatorExtrasByNodeID = make(map[ids.ShortID]*validatorImpl, len(ps.validatorExtrasByNodeID)+1)
-		for nodeID, vdr := range ps.validatorExtrasByNodeID {
-			if nodeID != tx.Validator.NodeID {
-				newPS.validatorExtrasByNodeID[nodeID] = vdr
-			}
+		newVdr := ps.copyValidatorExtras(newPS, tx.Validator.NodeID)
+		newSubnets := make(map[ids.ID]*UnsignedAddSubnetValidatorTx, len(newVdr.subnets)+1)
+		for subnet, subnetTx := range newVdr.subnets {
+			newSubnets[subnet] = subnetTx
 		}
-		if vdr, exists := ps.validatorExtrasByNodeID[tx.Validator.NodeID]; exists {
-			newSubnets := make(map[ids.ID]*UnsignedAddSubnetValidatorTx, len(vdr.subnets)+1)
-			for subnet, subnetTx := range vdr.subnets {
-				newSubnets[subnet] = subnetTx
-			}
-			newSubnets[tx.Validator.Subnet] = tx
+		newSubnets[tx.Validator.Subnet] = tx
+		newVdr.subnets = newSubnets
+	case *UnsignedAddPermissionlessValidatorTx:
+		newPS.validatorsByNodeID = ps.validatorsByNodeID
 
-			newPS.validatorExtrasByNodeID[tx.Validator.NodeID] = &validatorImpl{
-				delegators: vdr.delegators,
-				subnets:    newSubnets,
-			}
-		} else {
-			newPS.validatorExtrasByNodeID[tx.Validator.NodeID] = &validatorImpl{
-				subnets: map[ids.ID]*UnsignedAddSubnetValidatorTx{
-					tx.Validator.Subnet: tx,
-				},
-			}
+		newVdr := ps.copyValidatorExtras(newPS, tx.Validator.NodeID)
+		newValidators := make(map[ids.ID]*UnsignedAddPermissionlessValidatorTx, len(newVdr.permissionlessValidators)+1)
+		for subnet, subnetTx := range newVdr.permissionlessValidators {
+			newValidators[subnet] = subnetTx
 		}
+		newValidators[tx.Validator.Subnet] = tx
+		newVdr.permissionlessValidators = newValidators
+	case *UnsignedAddPermissionlessDelegatorTx:
+		newPS.validatorsByNodeID = ps.validatorsByNodeID
+
+		newVdr := ps.copyValidatorExtras(newPS, tx.Validator.NodeID)
+		newDelegators := make([]*UnsignedAddPermissionlessDelegatorTx, len(newVdr.permissionlessDelegators)+1)
+		copy(newDelegators, newVdr.permissionlessDelegators)
+		newDelegators[len(newVdr.permissionlessDelegators)] = tx
+		newVdr.permissionlessDelegators = newDelegators
 	default:
 		panic(fmt.Errorf("expected staker tx type but got %T", addStakerTx.UnsignedTx))
 	}
@@ -140,6 +127,23 @@ func (ps *pendingStakerChainStateImpl) AddStaker(addStakerTx *Tx) pendingStakerC
 	return newPS
 }
 
+// copyValidatorExtras sets the validator extras of [newPS] to be a copy of
+// the validator extras of [ps]. The extras of [nodeID] are replaced by a
+// shallow copy that is returned so that the caller can modify it.
+func (ps *pendingStakerChainStateImpl) copyValidatorExtras(newPS *pendingStakerChainStateImpl, nodeID ids.ShortID) *validatorImpl {
+	newPS.validatorExtrasByNodeID = make(map[ids.ShortID]*validatorImpl, len(ps.validatorExtrasByNodeID)+1)
+	for vdrID, vdr := range ps.validatorExtrasByNodeID {
+		newPS.validatorExtrasByNodeID[vdrID] = vdr
+	}
+
+	newVdr := &validatorImpl{}
+	if vdr, exists := ps.validatorExtrasByNodeID[nodeID]; exists {
+		*newVdr = *vdr
+	}
+	newPS.validatorExtrasByNodeID[nodeID] = newVdr
+	return newVdr
+}
+
 func (ps *pendingStakerChainStateImpl) DeleteStakers(numToRemove int) pendingStakerChainState {
 	newPS := &pendingStakerChainStateImpl{
 		validatorsByNodeID:      make(map[ids.ShortID]*UnsignedAddValidatorTx, len(ps.validatorsByNodeID)),
@@ -157,43 +161,91 @@ func (ps *pendingStakerChainStateImpl) DeleteStakers(numToRemove int) pendingSta
 	}
 
 	for _, removedTx := range ps.validators[:numToRemove] {
+		var nodeID ids.ShortID
 		switch tx := removedTx.UnsignedTx.(type) {
 		case *UnsignedAddValidatorTx:
 			delete(newPS.validatorsByNodeID, tx.Validator.NodeID)
+			continue
 		case *UnsignedAddDelegatorTx:
-			vdr := newPS.validatorExtrasByNodeID[tx.Validator.NodeID]
-			if len(vdr.delegators) == 1 && len(vdr.subnets) == 0 {
-				delete(newPS.validatorExtrasByNodeID, tx.Validator.NodeID)
-				break
-			}
-			newPS.validatorExtrasByNodeID[tx.Validator.NodeID] = &validatorImpl{
-				delegators: vdr.delegators[1:], // sorted in order of removal
-				subnets:    vdr.subnets,
-			}
+			nodeID = tx.Validator.NodeID
+			newVdr := *newPS.validatorExtrasByNodeID[nodeID]
+			newVdr.delegators = newVdr.delegators[1:] // sorted in order of removal
+			newPS.validatorExtrasByNodeID[nodeID] = &newVdr
 		case *UnsignedAddSubnetValidatorTx:
-			vdr := newPS.validatorExtrasByNodeID[tx.Validator.NodeID]
-			if len(vdr.delegators) == 0 && len(vdr.subnets) == 1 {
-				delete(newPS.validatorExtrasByNodeID, tx.Validator.NodeID)
-				break
-			}
-			newSubnets := make(map[ids.ID]*UnsignedAddSubnetValidatorTx, len(vdr.subnets)-1)
-			for subnetID, subnetTx := range vdr.subnets {
+			nodeID = tx.Validator.NodeID
+			newVdr := *newPS.validatorExtrasByNodeID[nodeID]
+			newVdr.subnets = make(map[ids.ID]*UnsignedAddSubnetValidatorTx, len(newVdr.subnets)-1)
+			for subnetID, subnetTx := range newPS.validatorExtrasByNodeID[nodeID].subnets {
 				if subnetID != tx.Validator.Subnet {
-					newSubnets[subnetID] = subnetTx
+					newVdr.subnets[subnetID] = subnetTx
 				}
 			}
-			newPS.validatorExtrasByNodeID[tx.Validator.NodeID] = &validatorImpl{
-				delegators: vdr.delegators,
-				subnets:    newSubnets,
+			newPS.validatorExtrasByNodeID[nodeID] = &newVdr
+		case *UnsignedAddPermissionlessValidatorTx:
+			nodeID = tx.Validator.NodeID
+			newVdr := *newPS.validatorExtrasByNodeID[nodeID]
+			newVdr.permissionlessValidators = make(map[ids.ID]*UnsignedAddPermissionlessValidatorTx, len(newVdr.permissionlessValidators)-1)
+			for subnetID, subnetTx := range newPS.validatorExtrasByNodeID[nodeID].permissionlessValidators {
+				if subnetID != tx.Validator.Subnet {
+					newVdr.permissionlessValidators[subnetID] = subnetTx
+				}
 			}
+			newPS.validatorExtrasByNodeID[nodeID] = &newVdr
+		case *UnsignedAddPermissionlessDelegatorTx:
+			nodeID = tx.Validator.NodeID
+			newVdr := *newPS.validatorExtrasByNodeID[nodeID]
+			newVdr.permissionlessDelegators = withoutPermissionlessDelegator(newVdr.permissionlessDelegators, removedTx.ID())
+			newPS.validatorExtrasByNodeID[nodeID] = &newVdr
 		default:
 			panic(fmt.Errorf("expected staker tx type but got %T", removedTx.UnsignedTx))
 		}
+
+		if newPS.validatorExtrasByNodeID[nodeID].isEmpty() {
+			delete(newPS.validatorExtrasByNodeID, nodeID)
+		}
 	}
 
 	return newPS
 }
 
+func (ps *pendingStakerChainStateImpl) DeleteSubnetValidator(txID ids.ID) (pendingStakerChainState, error) {
+	var removedTx *Tx
+	newValidators := make([]*Tx, 0, len(ps.validators))
+	for _, vdr := range ps.validators {
+		if vdr.ID() == txID {
+			removedTx = vdr
+			continue
+		}
+		newValidators = append(newValidators, vdr)
+	}
+	if removedTx == nil {
+		return nil, database.ErrNotFound
+	}
+	tx, ok := removedTx.UnsignedTx.(*UnsignedAddSubnetValidatorTx)
+	if !ok {
+		return nil, errWrongTxType
+	}
+
+	newPS := &pendingStakerChainStateImpl{
+		validatorsByNodeID: ps.validatorsByNodeID,
+		validators:         newValidators,
+
+		deletedStakers: []*Tx{removedTx},
+	}
+
+	newVdr := ps.copyValidatorExtras(newPS, tx.Validator.NodeID)
+	newVdr.subnets = make(map[ids.ID]*UnsignedAddSubnetValidatorTx, len(newVdr.subnets)-1)
+	for subnetID, subnetTx := range ps.validatorExtrasByNodeID[tx.Validator.NodeID].subnets {
+		if subnetID != tx.Validator.Subnet {
+			newVdr.subnets[subnetID] = subnetTx
+		}
+	}
+	if newVdr.isEmpty() {
+		delete(newPS.validatorExtrasByNodeID, tx.Validator.NodeID)
+	}
+	return newPS, nil
+}
+
 func (ps *pendingStakerChainStateImpl) Stakers() []*Tx {
 	return ps.validators
 }
@@ -232,6 +284,12 @@ func (s innerSortValidatorsByAddition) Less(i, j int) bool {
 	case *UnsignedAddSubnetValidatorTx:
 		iStartTime = tx.StartTime()
 		iPriority = lowPriority
+	case *UnsignedAddPermissionlessValidatorTx:
+		iStartTime = tx.StartTime()
+		iPriority = lowPriority
+	case *UnsignedAddPermissionlessDelegatorTx:
+		iStartTime = tx.StartTime()
+		iPriority = lowPriority
 	default:
 		panic(fmt.Errorf("expected staker tx type but got %T", iDel.UnsignedTx))
 	}
@@ -250,6 +308,12 @@ func (s innerSortValidatorsByAddition) Less(i, j int) bool {
 	case *UnsignedAddSubnetValidatorTx:
 		jStartTime = tx.StartTime()
 		jPriority = lowPriority
+	case *UnsignedAddPermissionlessValidatorTx:
+		jStartTime = tx.StartTime()
+		jPriority = lowPriority
+	case *UnsignedAddPermissionlessDelegatorTx:
+		jStartTime = tx.StartTime()
+		jPriority = lowPriority
 	default:
 		panic(fmt.Errorf("expected staker tx type but got %T", jDel.UnsignedTx))
 	}
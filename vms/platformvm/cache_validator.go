@@ -12,6 +12,8 @@ var _ validator = &validatorImpl{}
 type validator interface {
 	Delegators() []*UnsignedAddDelegatorTx
 	SubnetValidators() map[ids.ID]*UnsignedAddSubnetValidatorTx
+	PermissionlessValidators() map[ids.ID]*UnsignedAddPermissionlessValidatorTx
+	PermissionlessDelegators() []*UnsignedAddPermissionlessDelegatorTx
 }
 
 type validatorImpl struct {
@@ -19,6 +21,11 @@ type validatorImpl struct {
 	delegators []*UnsignedAddDelegatorTx
 	// maps subnetID to tx
 	subnets map[ids.ID]*UnsignedAddSubnetValidatorTx
+	// maps the ID of a transformed subnet to tx
+	permissionlessValidators map[ids.ID]*UnsignedAddPermissionlessValidatorTx
+	// delegations to this node on any transformed subnet, in no particular
+	// order.
+	permissionlessDelegators []*UnsignedAddPermissionlessDelegatorTx
 }
 
 func (v *validatorImpl) Delegators() []*UnsignedAddDelegatorTx {
@@ -28,3 +35,34 @@ func (v *validatorImpl) Delegators() []*UnsignedAddDelegatorTx {
 func (v *validatorImpl) SubnetValidators() map[ids.ID]*UnsignedAddSubnetValidatorTx {
 	return v.subnets
 }
+
+func (v *validatorImpl) PermissionlessValidators() map[ids.ID]*UnsignedAddPermissionlessValidatorTx {
+	return v.permissionlessValidators
+}
+
+func (v *validatorImpl) PermissionlessDelegators() []*UnsignedAddPermissionlessDelegatorTx {
+	return v.permissionlessDelegators
+}
+
+// isEmpty returns true if this validator doesn't have any stakers
+func (v *validatorImpl) isEmpty() bool {
+	return len(v.delegators) == 0 &&
+		len(v.subnets) == 0 &&
+		len(v.permissionlessValidators) == 0 &&
+		len(v.permissionlessDelegators) == 0
+}
+
+// withoutPermissionlessDelegator returns a copy of [delegators] without the
+// delegator with [txID].
+func withoutPermissionlessDelegator(
+	delegators []*UnsignedAddPermissionlessDelegatorTx,
+	txID ids.ID,
+) []*UnsignedAddPermissionlessDelegatorTx {
+	newDelegators := make([]*UnsignedAddPermissionlessDelegatorTx, 0, len(delegators))
+	for _, delegator := range delegators {
+		if delegator.ID() != txID {
+			newDelegators = append(newDelegators, delegator)
+		}
+	}
+	return newDelegators
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 )
 
 var _ VersionedState = &versionedStateImpl{}
@@ -46,6 +47,29 @@ type MutableState interface {
 	GetSubnets() ([]*Tx, error)
 	AddSubnet(createSubnetTx *Tx)
 
+	// GetSubnetOwner returns the current owner of [subnetID]. This is the
+	// owner specified in the subnet's creation unless the ownership has since
+	// been transferred.
+	GetSubnetOwner(subnetID ids.ID) (verify.Verifiable, error)
+	SetSubnetOwner(subnetID ids.ID, owner verify.Verifiable)
+
+	// GetSubnetTransformation returns the tx that transformed [subnetID] into
+	// a subnet with its own staking asset. Returns database.ErrNotFound if the
+	// subnet was never transformed.
+	GetSubnetTransformation(subnetID ids.ID) (*Tx, error)
+	AddSubnetTransformation(transformSubnetTx *Tx)
+
+	// GetSubnetCurrentSupply returns the current supply of the staking asset
+	// of the transformed [subnetID].
+	GetSubnetCurrentSupply(subnetID ids.ID) (uint64, error)
+	SetSubnetCurrentSupply(subnetID ids.ID, currentSupply uint64)
+
+	// GetValidatorRenewal returns the tx that registered the renewable primary
+	// network validator added by [stakerTxID]. Returns database.ErrNotFound if
+	// the validator was registered without renewals.
+	GetValidatorRenewal(stakerTxID ids.ID) (*Tx, error)
+	SetValidatorRenewal(stakerTxID ids.ID, addRenewableValidatorTx *Tx)
+
 	GetChains(subnetID ids.ID) ([]*Tx, error)
 	AddChain(createChainTx *Tx)
 
@@ -76,6 +100,18 @@ type versionedStateImpl struct {
 	addedSubnets  []*Tx
 	cachedSubnets []*Tx
 
+	// map of subnetID -> owner
+	modifiedSubnetOwners map[ids.ID]verify.Verifiable
+
+	// map of subnetID -> transformSubnetTx
+	transformedSubnets map[ids.ID]*Tx
+
+	// map of subnetID -> current supply of the subnet's staking asset
+	modifiedSubnetSupplies map[ids.ID]uint64
+
+	// map of stakerTxID -> addRenewableValidatorTx
+	validatorRenewals map[ids.ID]*Tx
+
 	addedChains  map[ids.ID][]*Tx
 	cachedChains map[ids.ID][]*Tx
 
@@ -156,6 +192,75 @@ func (vs *versionedStateImpl) AddSubnet(createSubnetTx *Tx) {
 	}
 }
 
+func (vs *versionedStateImpl) GetSubnetOwner(subnetID ids.ID) (verify.Verifiable, error) {
+	if owner, modified := vs.modifiedSubnetOwners[subnetID]; modified {
+		return owner, nil
+	}
+	return vs.parentState.GetSubnetOwner(subnetID)
+}
+
+func (vs *versionedStateImpl) SetSubnetOwner(subnetID ids.ID, owner verify.Verifiable) {
+	if vs.modifiedSubnetOwners == nil {
+		vs.modifiedSubnetOwners = map[ids.ID]verify.Verifiable{
+			subnetID: owner,
+		}
+	} else {
+		vs.modifiedSubnetOwners[subnetID] = owner
+	}
+}
+
+func (vs *versionedStateImpl) GetSubnetTransformation(subnetID ids.ID) (*Tx, error) {
+	if tx, exists := vs.transformedSubnets[subnetID]; exists {
+		return tx, nil
+	}
+	return vs.parentState.GetSubnetTransformation(subnetID)
+}
+
+func (vs *versionedStateImpl) AddSubnetTransformation(transformSubnetTxIntf *Tx) {
+	transformSubnetTx := transformSubnetTxIntf.UnsignedTx.(*UnsignedTransformSubnetTx)
+	if vs.transformedSubnets == nil {
+		vs.transformedSubnets = map[ids.ID]*Tx{
+			transformSubnetTx.Subnet: transformSubnetTxIntf,
+		}
+	} else {
+		vs.transformedSubnets[transformSubnetTx.Subnet] = transformSubnetTxIntf
+	}
+}
+
+func (vs *versionedStateImpl) GetSubnetCurrentSupply(subnetID ids.ID) (uint64, error) {
+	if currentSupply, modified := vs.modifiedSubnetSupplies[subnetID]; modified {
+		return currentSupply, nil
+	}
+	return vs.parentState.GetSubnetCurrentSupply(subnetID)
+}
+
+func (vs *versionedStateImpl) SetSubnetCurrentSupply(subnetID ids.ID, currentSupply uint64) {
+	if vs.modifiedSubnetSupplies == nil {
+		vs.modifiedSubnetSupplies = map[ids.ID]uint64{
+			subnetID: currentSupply,
+		}
+	} else {
+		vs.modifiedSubnetSupplies[subnetID] = currentSupply
+	}
+}
+
+func (vs *versionedStateImpl) GetValidatorRenewal(stakerTxID ids.ID) (*Tx, error) {
+	if tx, exists := vs.validatorRenewals[stakerTxID]; exists {
+		return tx, nil
+	}
+	return vs.parentState.GetValidatorRenewal(stakerTxID)
+}
+
+func (vs *versionedStateImpl) SetValidatorRenewal(stakerTxID ids.ID, addRenewableValidatorTx *Tx) {
+	if vs.validatorRenewals == nil {
+		vs.validatorRenewals = map[ids.ID]*Tx{
+			stakerTxID: addRenewableValidatorTx,
+		}
+	} else {
+		vs.validatorRenewals[stakerTxID] = addRenewableValidatorTx
+	}
+}
+
 func (vs *versionedStateImpl) GetChains(subnetID ids.ID) ([]*Tx, error) {
 	if len(vs.addedChains) == 0 {
 		// No chains have been added
@@ -304,6 +409,18 @@ func (vs *versionedStateImpl) Apply(is InternalState) {
 	for _, subnet := range vs.addedSubnets {
 		is.AddSubnet(subnet)
 	}
+	for subnetID, owner := range vs.modifiedSubnetOwners {
+		is.SetSubnetOwner(subnetID, owner)
+	}
+	for _, tx := range vs.transformedSubnets {
+		is.AddSubnetTransformation(tx)
+	}
+	for subnetID, currentSupply := range vs.modifiedSubnetSupplies {
+		is.SetSubnetCurrentSupply(subnetID, currentSupply)
+	}
+	for stakerTxID, tx := range vs.validatorRenewals {
+		is.SetValidatorRenewal(stakerTxID, tx)
+	}
 	for _, chains := range vs.addedChains {
 		for _, chain := range chains {
 			is.AddChain(chain)
@@ -192,6 +192,43 @@ func (c *Client) AddValidator(
 	return res.TxID, err
 }
 
+// AddRenewableValidator issues a transaction to add a validator to the primary
+// network whose staking period is renewed [renewals] times and returns the txID
+func (c *Client) AddRenewableValidator(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	rewardAddress,
+	nodeID string,
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	delegationFeeRate float32,
+	renewals uint32,
+	restakeRewards bool,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	jsonStakeAmount := cjson.Uint64(stakeAmount)
+	err := c.requester.SendRequest("addValidator", &AddValidatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		APIStaker: APIStaker{
+			NodeID:      nodeID,
+			StakeAmount: &jsonStakeAmount,
+			StartTime:   cjson.Uint64(startTime),
+			EndTime:     cjson.Uint64(endTime),
+		},
+		RewardAddress:     rewardAddress,
+		DelegationFeeRate: cjson.Float32(delegationFeeRate),
+		Renewals:          cjson.Uint32(renewals),
+		RestakeRewards:    restakeRewards,
+	}, res)
+	return res.TxID, err
+}
+
 // AddDelegator issues a transaction to add a delegator to the primary network and returns the txID
 func (c *Client) AddDelegator(
 	user api.UserPass,
@@ -274,6 +311,162 @@ func (c *Client) CreateSubnet(
 	return res.TxID, err
 }
 
+// RemoveSubnetValidator issues a transaction to remove validator [nodeID] from subnet with ID [subnetID] and returns the txID
+func (c *Client) RemoveSubnetValidator(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	subnetID,
+	nodeID string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("removeSubnetValidator", &RemoveSubnetValidatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		NodeID:   nodeID,
+		SubnetID: subnetID,
+	}, res)
+	return res.TxID, err
+}
+
+// TransferSubnetOwnership issues a transaction to make [controlKeys] the owners of subnet with ID [subnetID] and returns the txID
+func (c *Client) TransferSubnetOwnership(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	subnetID string,
+	controlKeys []string,
+	threshold uint32,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("transferSubnetOwnership", &TransferSubnetOwnershipArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		SubnetID: subnetID,
+		APISubnet: APISubnet{
+			ControlKeys: controlKeys,
+			Threshold:   cjson.Uint32(threshold),
+		},
+	}, res)
+	return res.TxID, err
+}
+
+// TransformSubnet issues a transaction to make subnet with ID [subnetID]
+// validated by stakers of [assetID] and returns the txID
+func (c *Client) TransformSubnet(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	subnetID,
+	assetID string,
+	initialSupply,
+	maximumSupply,
+	minConsumptionRate,
+	maxConsumptionRate,
+	minValidatorStake,
+	maxValidatorStake uint64,
+	minStakeDuration,
+	maxStakeDuration uint32,
+	minDelegationFeeRate float32,
+	minDelegatorStake uint64,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("transformSubnet", &TransformSubnetArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		SubnetID:             subnetID,
+		AssetID:              assetID,
+		InitialSupply:        cjson.Uint64(initialSupply),
+		MaximumSupply:        cjson.Uint64(maximumSupply),
+		MinConsumptionRate:   cjson.Uint64(minConsumptionRate),
+		MaxConsumptionRate:   cjson.Uint64(maxConsumptionRate),
+		MinValidatorStake:    cjson.Uint64(minValidatorStake),
+		MaxValidatorStake:    cjson.Uint64(maxValidatorStake),
+		MinStakeDuration:     cjson.Uint32(minStakeDuration),
+		MaxStakeDuration:     cjson.Uint32(maxStakeDuration),
+		MinDelegationFeeRate: cjson.Float32(minDelegationFeeRate),
+		MinDelegatorStake:    cjson.Uint64(minDelegatorStake),
+	}, res)
+	return res.TxID, err
+}
+
+// AddPermissionlessValidator issues a transaction to add a validator to the
+// transformed subnet with ID [subnetID] and returns the txID
+func (c *Client) AddPermissionlessValidator(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	rewardAddress,
+	nodeID,
+	subnetID string,
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	delegationFeeRate float32,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	jsonStakeAmount := cjson.Uint64(stakeAmount)
+	err := c.requester.SendRequest("addPermissionlessValidator", &AddPermissionlessValidatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		APIStaker: APIStaker{
+			NodeID:      nodeID,
+			StakeAmount: &jsonStakeAmount,
+			StartTime:   cjson.Uint64(startTime),
+			EndTime:     cjson.Uint64(endTime),
+		},
+		SubnetID:          subnetID,
+		RewardAddress:     rewardAddress,
+		DelegationFeeRate: cjson.Float32(delegationFeeRate),
+	}, res)
+	return res.TxID, err
+}
+
+// AddPermissionlessDelegator issues a transaction to add a delegator to the
+// transformed subnet with ID [subnetID] and returns the txID
+func (c *Client) AddPermissionlessDelegator(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	rewardAddress,
+	nodeID,
+	subnetID string,
+	stakeAmount,
+	startTime,
+	endTime uint64,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	jsonStakeAmount := cjson.Uint64(stakeAmount)
+	err := c.requester.SendRequest("addPermissionlessDelegator", &AddPermissionlessDelegatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		APIStaker: APIStaker{
+			NodeID:      nodeID,
+			StakeAmount: &jsonStakeAmount,
+			StartTime:   cjson.Uint64(startTime),
+			EndTime:     cjson.Uint64(endTime),
+		},
+		SubnetID:      subnetID,
+		RewardAddress: rewardAddress,
+	}, res)
+	return res.TxID, err
+}
+
 // ExportDJTX issues an ExportDJTX transaction and returns the txID
 func (c *Client) ExportDJTX(
 	user api.UserPass,
@@ -295,6 +488,32 @@ func (c *Client) ExportDJTX(
 	return res.TxID, err
 }
 
+// ExportDJTXEncrypted issues an ExportDJTX transaction whose [memo] is
+// encrypted to the CB58 encoded public key [memoPublicKey] of the recipient
+// and returns the txID
+func (c *Client) ExportDJTXEncrypted(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	to string,
+	amount uint64,
+	memo string,
+	memoPublicKey string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("exportDJTX", &ExportDJTXArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		To:       to,
+		Amount:   cjson.Uint64(amount),
+		JSONMemo: api.JSONMemo{Memo: memo, MemoPublicKey: memoPublicKey},
+	}, res)
+	return res.TxID, err
+}
+
 // ImportDJTX issues an ImportDJTX transaction and returns the txID
 func (c *Client) ImportDJTX(
 	user api.UserPass,
@@ -398,6 +617,84 @@ func (c *Client) IssueTx(txBytes []byte) (ids.ID, error) {
 	return res.TxID, err
 }
 
+// SimulateTx verifies [txBytes] against the state the preferred block would
+// produce, without issuing it
+func (c *Client) SimulateTx(txBytes []byte) (*api.SimulateTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &api.SimulateTxReply{}
+	err = c.requester.SendRequest("simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res)
+	return res, err
+}
+
+// CreateUnsignedTx creates the tx described by [args] without signing it.
+// Returns the bytes of the tx and the addresses that must sign each of its
+// credentials.
+func (c *Client) CreateUnsignedTx(args *CreateUnsignedTxArgs) ([]byte, [][]string, error) {
+	args.Encoding = formatting.Hex
+	res := &CreateUnsignedTxReply{}
+	if err := c.requester.SendRequest("createUnsignedTx", args, res); err != nil {
+		return nil, nil, err
+	}
+	txBytes, err := formatting.Decode(res.Encoding, res.Tx)
+	return txBytes, res.Signers, err
+}
+
+// SignTx adds the signatures of [user] to the partially signed tx [txBytes].
+// Returns the bytes of the tx and the number of signatures that are still
+// missing.
+func (c *Client) SignTx(user api.UserPass, txBytes []byte) ([]byte, uint32, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := &SignTxReply{}
+	err = c.requester.SendRequest("signTx", &SignTxArgs{
+		UserPass: user,
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+	}, res)
+	if err != nil {
+		return nil, 0, err
+	}
+	txBytes, err = formatting.Decode(res.Encoding, res.Tx)
+	return txBytes, uint32(res.SignaturesMissing), err
+}
+
+// CombineSignatures merges the signatures of the partially signed versions of
+// the same tx in [txs]. Returns the bytes of the tx and the number of
+// signatures that are still missing.
+func (c *Client) CombineSignatures(txs [][]byte) ([]byte, uint32, error) {
+	txStrs := make([]string, len(txs))
+	for i, txBytes := range txs {
+		txStr, err := formatting.Encode(formatting.Hex, txBytes)
+		if err != nil {
+			return nil, 0, err
+		}
+		txStrs[i] = txStr
+	}
+
+	res := &CombineSignaturesReply{}
+	err := c.requester.SendRequest("combineSignatures", &CombineSignaturesArgs{
+		Txs:      txStrs,
+		Encoding: formatting.Hex,
+	}, res)
+	if err != nil {
+		return nil, 0, err
+	}
+	txBytes, err := formatting.Decode(res.Encoding, res.Tx)
+	return txBytes, uint32(res.SignaturesMissing), err
+}
+
 // GetTx returns the byte representation of the transaction corresponding to [txID]
 func (c *Client) GetTx(txID ids.ID) ([]byte, error) {
 	res := &api.FormattedTx{}
@@ -411,6 +708,25 @@ func (c *Client) GetTx(txID ids.ID) ([]byte, error) {
 	return formatting.Decode(res.Encoding, res.Tx)
 }
 
+// GetMempool returns the txs that are waiting to be put into a block
+func (c *Client) GetMempool() ([][]byte, error) {
+	res := &GetMempoolReply{}
+	err := c.requester.SendRequest("getMempool", &GetMempoolArgs{
+		Encoding: formatting.Hex,
+	}, res)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([][]byte, len(res.Txs))
+	for i, txStr := range res.Txs {
+		txs[i], err = formatting.Decode(res.Encoding, txStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return txs, nil
+}
+
 // GetTxStatus returns the status of the transaction corresponding to [txID]
 func (c *Client) GetTxStatus(txID ids.ID, includeReason bool) (*GetTxStatusResponse, error) {
 	res := new(GetTxStatusResponse)
@@ -476,3 +792,38 @@ func (c *Client) GetRewardUTXOs(args *api.GetTxArgs) ([][]byte, error) {
 	}
 	return utxos, err
 }
+
+// EstimateReward returns the reward that staking [stakeAmount] on the primary
+// network for [duration] would earn. If [nodeID] is non-empty, the estimate is
+// for delegating to that validator and [delegationFee] is ignored.
+func (c *Client) EstimateReward(stakeAmount uint64, duration time.Duration, delegationFee float32, nodeID string) (*EstimateRewardReply, error) {
+	res := &EstimateRewardReply{}
+	err := c.requester.SendRequest("estimateReward", &EstimateRewardArgs{
+		StakeAmount:   cjson.Uint64(stakeAmount),
+		Duration:      cjson.Uint64(duration / time.Second),
+		DelegationFee: cjson.Float32(delegationFee),
+		NodeID:        nodeID,
+	}, res)
+	return res, err
+}
+
+// GetRewardHistory returns the outcomes of the staking periods whose rewards
+// were, or would have been, paid to [address]
+func (c *Client) GetRewardHistory(address string) ([]RewardHistoryEntry, error) {
+	res := &GetRewardHistoryReply{}
+	err := c.requester.SendRequest("getRewardHistory", &api.JSONAddress{
+		Address: address,
+	}, res)
+	return res.Rewards, err
+}
+
+// GetObservedUptimes returns the uptimes this node has observed for the
+// current validators in [nodeIDs] that can be rewarded, or for all of them if
+// [nodeIDs] is empty
+func (c *Client) GetObservedUptimes(nodeIDs []string) ([]APIObservedUptime, error) {
+	res := &GetObservedUptimesReply{}
+	err := c.requester.SendRequest("getObservedUptimes", &GetObservedUptimesArgs{
+		NodeIDs: nodeIDs,
+	}, res)
+	return res.Uptimes, err
+}
@@ -64,6 +64,15 @@ func init() {
 
 			c.RegisterType(&StakeableLockIn{}),
 			c.RegisterType(&StakeableLockOut{}),
+
+			c.RegisterType(&UnsignedRemoveSubnetValidatorTx{}),
+			c.RegisterType(&UnsignedTransferSubnetOwnershipTx{}),
+
+			c.RegisterType(&UnsignedTransformSubnetTx{}),
+			c.RegisterType(&UnsignedAddPermissionlessValidatorTx{}),
+			c.RegisterType(&UnsignedAddPermissionlessDelegatorTx{}),
+
+			c.RegisterType(&UnsignedAddRenewableValidatorTx{}),
 		)
 	}
 	errs.Add(
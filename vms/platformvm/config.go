@@ -0,0 +1,28 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"encoding/json"
+)
+
+// Config contains all of the user-configurable parameters of the platform
+// chain
+type Config struct {
+	// CoinSelection is the strategy used to choose the UTXOs that fund the
+	// transactions built by the APIs. One of greedy, largestFirst,
+	// branchAndBound and minimizeChange. Defaults to greedy.
+	CoinSelection string `json:"coinSelection"`
+}
+
+// ParseConfig parses the config bytes provided to the VM. An empty config
+// results in the default config.
+func ParseConfig(configBytes []byte) (Config, error) {
+	config := Config{}
+	if len(configBytes) == 0 {
+		return config, nil
+	}
+	err := json.Unmarshal(configBytes, &config)
+	return config, err
+}
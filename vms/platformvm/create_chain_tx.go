@@ -130,15 +130,19 @@ func (tx *UnsignedCreateChainTx) SemanticVerify(
 		return nil, tempError{err}
 	}
 
-	subnet, ok := subnetIntf.UnsignedTx.(*UnsignedCreateSubnetTx)
-	if !ok {
+	if _, ok := subnetIntf.UnsignedTx.(*UnsignedCreateSubnetTx); !ok {
 		return nil, permError{
 			fmt.Errorf("%s isn't a subnet", tx.SubnetID),
 		}
 	}
 
+	subnetOwner, err := vs.GetSubnetOwner(tx.SubnetID)
+	if err != nil {
+		return nil, tempError{err}
+	}
+
 	// Verify that this chain is authorized by the subnet
-	if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnet.Owner); err != nil {
+	if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
 		return nil, permError{err}
 	}
 
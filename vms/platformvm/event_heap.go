@@ -48,8 +48,8 @@ func (h *EventHeap) Less(i, j int) bool {
 	case iTime.Unix() < jTime.Unix():
 		return true
 	case iTime == jTime:
-		_, iOk := iTx.(*UnsignedAddValidatorTx)
-		_, jOk := jTx.(*UnsignedAddValidatorTx)
+		iOk := addsPrimaryValidator(iTx)
+		jOk := addsPrimaryValidator(jTx)
 
 		if iOk != jOk {
 			return iOk == h.SortByStartTime
@@ -93,3 +93,24 @@ func (h *EventHeap) Pop() interface{} {
 func (h *EventHeap) Bytes() ([]byte, error) {
 	return Codec.Marshal(codecVersion, h)
 }
+
+// RemoveTx removes the tx with ID [txID] from the heap. Returns nil if the tx
+// isn't in the heap.
+func (h *EventHeap) RemoveTx(txID ids.ID) *Tx {
+	for i, tx := range h.Txs {
+		if tx.ID() == txID {
+			return heap.Remove(h, i).(*Tx)
+		}
+	}
+	return nil
+}
+
+// addsPrimaryValidator returns true iff [tx] adds a primary network validator
+func addsPrimaryValidator(tx TimedTx) bool {
+	switch tx.(type) {
+	case *UnsignedAddValidatorTx, *UnsignedAddRenewableValidatorTx:
+		return true
+	default:
+		return false
+	}
+}
@@ -95,8 +95,17 @@ func (tx *UnsignedExportTx) SemanticVerify(
 	copy(outs, tx.Outs)
 	copy(outs[len(tx.Outs):], tx.ExportedOutputs)
 
-	// Verify the flowcheck
-	if err := vm.semanticVerifySpend(parentState, tx, tx.Ins, outs, stx.Creds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
+	// Verify the flowcheck. After Apricot Phase 3, assets other than DJTX
+	// may be exported, but the fee is always paid in DJTX.
+	multiAsset := vm.isApricotPhase3(parentState.GetTimestamp())
+	var err TxError
+	if multiAsset {
+		burned := map[ids.ID]uint64{vm.ctx.DJTXAssetID: vm.TxFee}
+		err = vm.semanticVerifySpendAssets(parentState, tx, tx.Ins, outs, stx.Creds, burned)
+	} else {
+		err = vm.semanticVerifySpend(parentState, tx, tx.Ins, outs, stx.Creds, vm.TxFee, vm.ctx.DJTXAssetID)
+	}
+	if err != nil {
 		switch err.(type) {
 		case permError:
 			return nil, permError{
@@ -119,7 +128,11 @@ func (tx *UnsignedExportTx) SemanticVerify(
 	consumeInputs(newState, tx.Ins)
 	// Produce the UTXOS
 	txID := tx.ID()
-	produceOutputs(newState, txID, vm.ctx.DJTXAssetID, tx.Outs)
+	if multiAsset {
+		produceAssetOutputs(newState, txID, tx.Outs)
+	} else {
+		produceOutputs(newState, txID, vm.ctx.DJTXAssetID, tx.Outs)
+	}
 	return newState, nil
 }
 
@@ -164,6 +177,20 @@ func (vm *VM) newExportTx(
 	to ids.ShortID, // Address of chain recipient
 	keys []*crypto.PrivateKeySECP256K1R, // Pay the fee and provide the tokens
 	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	return vm.newExportTxWithKeychain(amount, chainID, to, newKeychain(keys), changeAddr, nil)
+}
+
+// newExportTxWithKeychain is the same as newExportTx, except that the tokens
+// are provided by the addresses in [kc] and the tx holds [memo]. The
+// signatures of the addresses whose keys aren't in [kc] are left empty.
+func (vm *VM) newExportTxWithKeychain(
+	amount uint64, // Amount of tokens to export
+	chainID ids.ID, // Chain to send the UTXOs to
+	to ids.ShortID, // Address of chain recipient
+	kc *secp256k1fx.Keychain, // Pay the fee and provide the tokens
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+	memo []byte, // Memo field of the tx
 ) (*Tx, error) {
 	if vm.ctx.XChainID != chainID {
 		return nil, errWrongChainID
@@ -173,7 +200,7 @@ func (vm *VM) newExportTx(
 	if err != nil {
 		return nil, errOverflowExport
 	}
-	ins, outs, _, signers, err := vm.stake(keys, 0, toBurn, changeAddr)
+	ins, outs, _, signers, err := vm.stakeAssetWithKeychain(kc, vm.ctx.DJTXAssetID, 0, toBurn, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -185,6 +212,7 @@ func (vm *VM) newExportTx(
 			BlockchainID: vm.ctx.ChainID,
 			Ins:          ins,
 			Outs:         outs, // Non-exported outputs
+			Memo:         memo,
 		}},
 		DestinationChain: chainID,
 		ExportedOutputs: []*djtx.TransferableOutput{{ // Exported to X-Chain
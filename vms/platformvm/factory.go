@@ -63,6 +63,10 @@ type Factory struct {
 
 	// Consumption period for the minting function
 	StakeMintingPeriod time.Duration
+
+	// Time of the Apricot Phase 3 upgrade. Transactions introduced by the
+	// upgrade are only valid in blocks whose timestamp is at or after it.
+	ApricotPhase3Time time.Time
 }
 
 // New returns a new instance of the Platform Chain
@@ -0,0 +1,104 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+// SetAppSender sets the sender that is used to gossip mempool txs to our peers
+func (vm *VM) SetAppSender(appSender common.AppSender) { vm.appSender = appSender }
+
+// AppGossip is called when a peer gossips a tx to us. If the tx is new and
+// valid, it's added to the mempool and gossiped to our peers. Invalid txs are
+// dropped.
+func (vm *VM) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	if !vm.bootstrapped {
+		vm.ctx.Log.Verbo("dropping gossiped tx from %s because the chain isn't bootstrapped", nodeID)
+		return nil
+	}
+
+	tx := &Tx{}
+	if _, err := vm.codec.Unmarshal(msg, tx); err != nil {
+		vm.ctx.Log.Debug("dropping unparsable tx gossiped by %s: %s", nodeID, err)
+		return nil
+	}
+	if err := tx.Sign(vm.codec, nil); err != nil {
+		vm.ctx.Log.Debug("dropping uninitializable tx gossiped by %s: %s", nodeID, err)
+		return nil
+	}
+
+	txID := tx.ID()
+	if vm.mempool.Has(txID) {
+		return nil
+	}
+	if _, dropped := vm.droppedTxCache.Get(txID); dropped {
+		return nil
+	}
+	if _, _, err := vm.internalState.GetTx(txID); err == nil {
+		// The tx has already been accepted
+		return nil
+	}
+
+	if err := vm.verifyUnissuedTx(tx); err != nil {
+		vm.ctx.Log.Debug("dropping invalid tx %s gossiped by %s: %s", txID, nodeID, err)
+		return nil
+	}
+	if err := vm.mempool.IssueTx(tx); err != nil {
+		vm.ctx.Log.Debug("dropping tx %s gossiped by %s: %s", txID, nodeID, err)
+	}
+	return nil
+}
+
+// gossipTx sends [tx] to a sample of our peers
+func (vm *VM) gossipTx(tx *Tx) {
+	if vm.appSender == nil || !vm.bootstrapped {
+		return
+	}
+	if err := vm.appSender.SendAppGossip(tx.Bytes()); err != nil {
+		vm.ctx.Log.Debug("failed to gossip tx %s: %s", tx.ID(), err)
+	}
+}
+
+// verifyUnissuedTx returns nil iff [tx] would be valid if it were put into a
+// block built on top of the preferred block
+func (vm *VM) verifyUnissuedTx(tx *Tx) error {
+	preferred, err := vm.Preferred()
+	if err != nil {
+		return fmt.Errorf("couldn't get preferred block: %w", err)
+	}
+	preferredDecision, ok := preferred.(decision)
+	if !ok {
+		// The preferred block should always be a decision block
+		return errInvalidBlockType
+	}
+	preferredState := preferredDecision.onAccept()
+
+	switch utx := tx.UnsignedTx.(type) {
+	case UnsignedProposalTx:
+		_, _, _, _, err := utx.SemanticVerify(vm, preferredState, tx)
+		if err != nil {
+			return err
+		}
+	case UnsignedDecisionTx:
+		vs := newVersionedState(
+			preferredState,
+			preferredState.CurrentStakerChainState(),
+			preferredState.PendingStakerChainState(),
+		)
+		if _, err := utx.SemanticVerify(vm, vs, tx); err != nil {
+			return err
+		}
+	case UnsignedAtomicTx:
+		if _, err := utx.SemanticVerify(vm, preferredState, tx); err != nil {
+			return err
+		}
+	default:
+		return errUnknownTxType
+	}
+	return nil
+}
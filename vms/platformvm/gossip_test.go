@@ -0,0 +1,135 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+func TestGossipIssuedTx(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	var gossiped [][]byte
+	sender := &common.SenderTest{T: t}
+	sender.SendAppGossipF = func(msg []byte) error {
+		gossiped = append(gossiped, msg)
+		return nil
+	}
+	vm.SetAppSender(sender)
+
+	tx, _ := newTestCreateSubnetTxs(t, vm)
+	if err := vm.mempool.IssueTx(tx); err != nil {
+		t.Fatal(err)
+	}
+	if len(gossiped) != 1 {
+		t.Fatalf("expected the tx to be gossiped once but was gossiped %d times", len(gossiped))
+	}
+
+	// Issuing the tx again shouldn't gossip it again
+	if err := vm.mempool.IssueTx(tx); err != nil {
+		t.Fatal(err)
+	}
+	if len(gossiped) != 1 {
+		t.Fatalf("expected the tx to be gossiped once but was gossiped %d times", len(gossiped))
+	}
+
+	parsedTx := &Tx{}
+	if _, err := vm.codec.Unmarshal(gossiped[0], parsedTx); err != nil {
+		t.Fatal(err)
+	}
+	if err := parsedTx.Sign(vm.codec, nil); err != nil {
+		t.Fatal(err)
+	}
+	if parsedTx.ID() != tx.ID() {
+		t.Fatalf("expected %s to be gossiped but got %s", tx.ID(), parsedTx.ID())
+	}
+}
+
+func TestAppGossip(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	numGossiped := 0
+	sender := &common.SenderTest{T: t}
+	sender.SendAppGossipF = func([]byte) error {
+		numGossiped++
+		return nil
+	}
+	vm.SetAppSender(sender)
+
+	nodeID := ids.GenerateTestShortID()
+
+	// Case: Unparsable tx
+	if err := vm.AppGossip(nodeID, []byte{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if numGossiped != 0 {
+		t.Fatal("shouldn't have gossiped an unparsable tx")
+	}
+
+	lowFeeTx, highFeeTx := newTestCreateSubnetTxs(t, vm)
+
+	// Case: Valid tx is added to the mempool and gossiped
+	if err := vm.AppGossip(nodeID, lowFeeTx.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if !vm.mempool.Has(lowFeeTx.ID()) {
+		t.Fatal("gossiped tx should be in the mempool")
+	}
+	if numGossiped != 1 {
+		t.Fatalf("expected the tx to be gossiped once but was gossiped %d times", numGossiped)
+	}
+
+	// Case: Tx already in the mempool isn't gossiped again
+	if err := vm.AppGossip(nodeID, lowFeeTx.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if numGossiped != 1 {
+		t.Fatalf("expected the tx to be gossiped once but was gossiped %d times", numGossiped)
+	}
+
+	// Case: Invalid tx is dropped
+	invalidTx := &Tx{}
+	if _, err := vm.codec.Unmarshal(highFeeTx.Bytes(), invalidTx); err != nil {
+		t.Fatal(err)
+	}
+	invalidTx.Creds = nil
+	if err := invalidTx.Sign(vm.codec, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.AppGossip(nodeID, invalidTx.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if vm.mempool.Has(invalidTx.ID()) {
+		t.Fatal("invalid tx shouldn't be in the mempool")
+	}
+	if numGossiped != 1 {
+		t.Fatalf("expected the tx to be gossiped once but was gossiped %d times", numGossiped)
+	}
+
+	// Case: Gossip is dropped before the chain is bootstrapped
+	vm.bootstrapped = false
+	if err := vm.AppGossip(nodeID, highFeeTx.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if vm.mempool.Has(highFeeTx.ID()) {
+		t.Fatal("tx gossiped before bootstrapping shouldn't be in the mempool")
+	}
+}
@@ -92,6 +92,8 @@ func (tx *UnsignedImportTx) SemanticVerify(
 	if err := tx.Verify(vm.ctx.XChainID, vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
 		return nil, permError{err}
 	}
+	// Only DJTX can be imported before Apricot Phase 3
+	multiAsset := vm.isApricotPhase3(parentState.GetTimestamp())
 
 	utxos := make([]*djtx.UTXO, len(tx.Ins)+len(tx.ImportedInputs))
 	for index, input := range tx.Ins {
@@ -131,8 +133,17 @@ func (tx *UnsignedImportTx) SemanticVerify(
 		copy(ins, tx.Ins)
 		copy(ins[len(tx.Ins):], tx.ImportedInputs)
 
-		if err := vm.semanticVerifySpendUTXOs(tx, utxos, ins, tx.Outs, stx.Creds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
-			return nil, err
+		if !multiAsset {
+			if err := vm.semanticVerifySpendUTXOs(tx, utxos, ins, tx.Outs, stx.Creds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
+				return nil, err
+			}
+		} else {
+			// Assets other than DJTX may be imported, but the fee is always
+			// paid in DJTX.
+			burned := map[ids.ID]uint64{vm.ctx.DJTXAssetID: vm.TxFee}
+			if err := vm.semanticVerifySpendAssetUTXOs(tx, utxos, ins, tx.Outs, stx.Creds, burned); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -146,7 +157,11 @@ func (tx *UnsignedImportTx) SemanticVerify(
 	consumeInputs(newState, tx.Ins)
 	// Produce the UTXOS
 	txID := tx.ID()
-	produceOutputs(newState, txID, vm.ctx.DJTXAssetID, tx.Outs)
+	if multiAsset {
+		produceAssetOutputs(newState, txID, tx.Outs)
+	} else {
+		produceOutputs(newState, txID, vm.ctx.DJTXAssetID, tx.Outs)
+	}
 	return newState, nil
 }
 
@@ -164,12 +179,23 @@ func (tx *UnsignedImportTx) Accept(ctx *snow.Context, batch database.Batch) erro
 	return ctx.SharedMemory.Remove(tx.SourceChain, utxoIDs, batch)
 }
 
-// Create a new transaction
+// Create a new transaction that imports DJTX
 func (vm *VM) newImportTx(
 	chainID ids.ID, // chain to import from
 	to ids.ShortID, // Address of recipient
 	keys []*crypto.PrivateKeySECP256K1R, // Keys to import the funds
 	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	return vm.newImportAssetsTx(chainID, to, nil, keys, changeAddr)
+}
+
+// Create a new transaction that imports DJTX and the assets in [assetIDs]
+func (vm *VM) newImportAssetsTx(
+	chainID ids.ID, // chain to import from
+	to ids.ShortID, // Address of recipient
+	assetIDs ids.Set, // Assets other than DJTX to import
+	keys []*crypto.PrivateKeySECP256K1R, // Keys to import the funds
+	changeAddr ids.ShortID, // Address to send change to, if there is any
 ) (*Tx, error) {
 	if vm.ctx.XChainID != chainID {
 		return nil, errWrongChainID
@@ -188,10 +214,11 @@ func (vm *VM) newImportTx(
 	importedInputs := []*djtx.TransferableInput{}
 	signers := [][]*crypto.PrivateKeySECP256K1R{}
 
-	importedAmount := uint64(0)
+	importedAmounts := make(map[ids.ID]uint64)
 	now := vm.clock.Unix()
 	for _, utxo := range atomicUTXOs {
-		if utxo.AssetID() != vm.ctx.DJTXAssetID {
+		assetID := utxo.AssetID()
+		if assetID != vm.ctx.DJTXAssetID && !assetIDs.Contains(assetID) {
 			continue
 		}
 		inputIntf, utxoSigners, err := kc.Spend(utxo.Out, now)
@@ -202,7 +229,7 @@ func (vm *VM) newImportTx(
 		if !ok {
 			continue
 		}
-		importedAmount, err = math.Add64(importedAmount, input.Amount())
+		importedAmounts[assetID], err = math.Add64(importedAmounts[assetID], input.Amount())
 		if err != nil {
 			return nil, err
 		}
@@ -215,10 +242,12 @@ func (vm *VM) newImportTx(
 	}
 	djtx.SortTransferableInputsWithSigners(importedInputs, signers)
 
-	if importedAmount == 0 {
+	if len(importedInputs) == 0 {
 		return nil, errNoFunds // No imported UTXOs were spendable
 	}
 
+	importedAmount := importedAmounts[vm.ctx.DJTXAssetID]
+
 	ins := []*djtx.TransferableInput{}
 	outs := []*djtx.TransferableOutput{}
 	if importedAmount < vm.TxFee { // imported amount goes toward paying tx fee
@@ -241,6 +270,24 @@ func (vm *VM) newImportTx(
 			},
 		})
 	}
+	// The imported assets other than DJTX are sent to [to] in full
+	for assetID, amount := range importedAmounts {
+		if assetID == vm.ctx.DJTXAssetID {
+			continue
+		}
+		outs = append(outs, &djtx.TransferableOutput{
+			Asset: djtx.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  0,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{to},
+				},
+			},
+		})
+	}
+	djtx.SortTransferableOutputs(outs, vm.codec)
 
 	// Create the transaction
 	utx := &UnsignedImportTx{
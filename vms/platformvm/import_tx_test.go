@@ -6,6 +6,7 @@ package platformvm
 import (
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
@@ -139,3 +140,85 @@ func TestNewImportTx(t *testing.T) {
 		}
 	}
 }
+
+// Test that assets other than DJTX are only imported when they are requested
+// and that they can only be imported after Apricot Phase 3
+func TestNewImportAssetsTx(t *testing.T) {
+	vm, baseDB := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	recipientKey := keys[0]
+	otherAssetID := ids.GenerateTestID()
+
+	m := &atomic.Memory{}
+	if err := m.Initialize(logging.NoLog{}, prefixdb.New([]byte{0}, baseDB)); err != nil {
+		t.Fatal(err)
+	}
+	vm.ctx.SharedMemory = m.NewSharedMemory(vm.ctx.ChainID)
+	vm.AtomicUTXOManager = djtx.NewAtomicUTXOManager(vm.ctx.SharedMemory, Codec)
+	peerSharedMemory := m.NewSharedMemory(avmID)
+	for _, assetID := range []ids.ID{djtxAssetID, otherAssetID} {
+		utxo := &djtx.UTXO{
+			UTXOID: djtx.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  djtx.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 2 * vm.TxFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Addrs:     []ids.ShortID{recipientKey.PublicKey().Address()},
+					Threshold: 1,
+				},
+			},
+		}
+		utxoBytes, err := Codec.Marshal(codecVersion, utxo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inputID := utxo.InputID()
+		if err := peerSharedMemory.Put(vm.ctx.ChainID, []*atomic.Element{{
+			Key:   inputID[:],
+			Value: utxoBytes,
+			Traits: [][]byte{
+				recipientKey.PublicKey().Address().Bytes(),
+			},
+		}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	to := ids.GenerateTestShortID()
+	importKeys := []*crypto.PrivateKeySECP256K1R{recipientKey}
+
+	// Only DJTX is imported by default
+	tx, err := vm.newImportTx(avmID, to, importKeys, ids.ShortEmpty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range tx.UnsignedTx.(*UnsignedImportTx).ImportedInputs {
+		if in.AssetID() != djtxAssetID {
+			t.Fatalf("shouldn't have imported asset %s", in.AssetID())
+		}
+	}
+
+	tx, err = vm.newImportAssetsTx(avmID, to, ids.Set{otherAssetID: struct{}{}}, importKeys, ids.ShortEmpty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numImported := len(tx.UnsignedTx.(*UnsignedImportTx).ImportedInputs); numImported != 2 {
+		t.Fatalf("should have imported 2 UTXOs but imported %d", numImported)
+	}
+
+	vm.ApricotPhase3Time = vm.internalState.GetTimestamp().Add(time.Second)
+	if _, err := tx.UnsignedTx.(UnsignedAtomicTx).SemanticVerify(vm, vm.internalState, tx); err == nil {
+		t.Fatal("shouldn't be able to import other assets before Apricot Phase 3")
+	}
+	vm.ApricotPhase3Time = time.Time{}
+	if _, err := tx.UnsignedTx.(UnsignedAtomicTx).SemanticVerify(vm, vm.internalState, tx); err != nil {
+		t.Fatal(err)
+	}
+}
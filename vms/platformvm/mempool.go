@@ -11,6 +11,10 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 const (
@@ -19,12 +23,19 @@ const (
 
 	// BatchSize is the number of decision transaction to place into a block
 	BatchSize = 30
+
+	// MaxMempoolSize is the maximum number of bytes of unissued txs that the
+	// mempool will hold
+	MaxMempoolSize = 64 * units.MiB
 )
 
 var (
 	errEndOfTime       = errors.New("program time is suspiciously far in the future. Either this codebase was way more successful than expected, or a critical error has occurred")
 	errNoPendingBlocks = errors.New("no pending blocks")
 	errUnknownTxType   = errors.New("unknown transaction type")
+	errTxTooLarge      = errors.New("transaction is larger than the mempool")
+	errMempoolFull     = errors.New("mempool is full")
+	errConflictingTx   = errors.New("transaction conflicts with a transaction in the mempool that burns at least as much DJTX")
 )
 
 // Mempool implements a simple mempool to convert txs into valid blocks
@@ -66,9 +77,28 @@ type Mempool struct {
 	// Transactions that have not been put into blocks yet
 	dropIncoming        bool
 	unissuedProposalTxs *EventHeap
-	unissuedDecisionTxs []*Tx
-	unissuedAtomicTxs   []*Tx
-	unissuedTxIDs       ids.Set
+	// Proposal txs that aren't bound to a start time, such as
+	// RemoveSubnetValidatorTxs
+	unissuedUntimedProposalTxs []*Tx
+	// Decision and atomic txs ordered by the amount of DJTX they burn, highest
+	// first
+	unissuedDecisionTxs *txHeap
+	unissuedAtomicTxs   *txHeap
+	// All unissued txs ordered by the amount of DJTX they burn, lowest first.
+	// Used to pick which txs to evict when the mempool is full.
+	evictableTxs  *txHeap
+	unissuedTxIDs ids.Set
+	// Key: ID of a UTXO consumed by an unissued tx
+	// Value: ID of the unissued tx that consumes it
+	consumedUTXOs map[ids.ID]ids.ID
+
+	// Number of bytes of unissued txs
+	bytes int
+	// Maximum number of bytes of unissued txs
+	maxBytes int
+	// Number of txs that have been added to the mempool. Used to order txs that
+	// burn the same amount of DJTX.
+	numAdded uint64
 }
 
 // Initialize this mempool.
@@ -80,6 +110,11 @@ func (m *Mempool) Initialize(vm *VM) {
 	// Transactions from clients that have not yet been put into blocks and
 	// added to consensus
 	m.unissuedProposalTxs = &EventHeap{SortByStartTime: true}
+	m.unissuedDecisionTxs = newTxHeap(true)
+	m.unissuedAtomicTxs = newTxHeap(true)
+	m.evictableTxs = newTxHeap(false)
+	m.consumedUTXOs = make(map[ids.ID]ids.ID)
+	m.maxBytes = MaxMempoolSize
 
 	m.timer = timer.NewTimer(func() {
 		m.vm.ctx.Lock.Lock()
@@ -90,7 +125,8 @@ func (m *Mempool) Initialize(vm *VM) {
 	go m.vm.ctx.Log.RecoverAndPanic(m.timer.Dispatch)
 }
 
-// IssueTx enqueues the [tx] to be put into a block
+// IssueTx enqueues the [tx] to be put into a block. If the [tx] wasn't already
+// in the mempool, it is gossiped to our peers.
 func (m *Mempool) IssueTx(tx *Tx) error {
 	if m.dropIncoming {
 		return nil
@@ -100,25 +136,267 @@ func (m *Mempool) IssueTx(tx *Tx) error {
 	if err := tx.Sign(m.vm.codec, nil); err != nil {
 		return err
 	}
-	txID := tx.ID()
-	if m.unissuedTxIDs.Contains(txID) {
+	if m.Has(tx.ID()) {
 		return nil
 	}
+	if err := m.addTx(tx); err != nil {
+		return err
+	}
+	m.vm.gossipTx(tx)
+	m.ResetTimer()
+	return nil
+}
+
+// Has returns true iff the tx with ID [txID] is waiting to be put into a block
+func (m *Mempool) Has(txID ids.ID) bool { return m.unissuedTxIDs.Contains(txID) }
+
+// Txs returns the txs that are waiting to be put into a block
+func (m *Mempool) Txs() []*Tx {
+	txs := make([]*Tx, 0, m.unissuedTxIDs.Len())
+	for _, feeTx := range m.unissuedDecisionTxs.txs {
+		txs = append(txs, feeTx.tx)
+	}
+	for _, feeTx := range m.unissuedAtomicTxs.txs {
+		txs = append(txs, feeTx.tx)
+	}
+	txs = append(txs, m.unissuedUntimedProposalTxs...)
+	txs = append(txs, m.unissuedProposalTxs.Txs...)
+	return txs
+}
+
+// Bytes returns the number of bytes of txs that are waiting to be put into a
+// block
+func (m *Mempool) Bytes() int { return m.bytes }
+
+// MaxBytes returns the maximum number of bytes of txs that can be waiting to be
+// put into a block
+func (m *Mempool) MaxBytes() int { return m.maxBytes }
+
+// addTx adds [tx] to the mempool. If [tx] consumes UTXOs that are consumed by
+// txs in the mempool, it replaces those txs if it burns more DJTX than each of
+// them. Otherwise, it's rejected. If the mempool is full, txs that burn less
+// DJTX than [tx] are evicted to make room for it.
+// Assumes [tx] has been initialized and isn't already in the mempool.
+func (m *Mempool) addTx(tx *Tx) error {
+	size := len(tx.Bytes())
+	if size > m.maxBytes {
+		return errTxTooLarge
+	}
+
+	switch tx.UnsignedTx.(type) {
+	case TimedTx, *UnsignedRemoveSubnetValidatorTx, UnsignedDecisionTx, UnsignedAtomicTx:
+	default:
+		return errUnknownTxType
+	}
+	fee := m.burnedDJTX(tx)
+
+	// Make sure [tx] can replace the txs it conflicts with
+	ins, _ := txInsOuts(tx)
+	conflicts := ids.Set{}
+	for _, in := range ins {
+		if conflictID, ok := m.consumedUTXOs[in.InputID()]; ok {
+			conflicts.Add(conflictID)
+		}
+	}
+	conflictsSize := 0
+	for conflictID := range conflicts {
+		conflict := m.evictableTxs.txIDToEntry[conflictID]
+		if conflict.fee >= fee {
+			return errConflictingTx
+		}
+		conflictsSize += len(conflict.tx.Bytes())
+	}
+
+	// Make sure enough space can be freed before evicting anything
+	if m.bytes-conflictsSize+size > m.maxBytes {
+		freeable := conflictsSize
+		for _, feeTx := range m.evictableTxs.txs {
+			if feeTx.fee < fee && !conflicts.Contains(feeTx.tx.ID()) {
+				freeable += len(feeTx.tx.Bytes())
+			}
+		}
+		if m.bytes-freeable+size > m.maxBytes {
+			return errMempoolFull
+		}
+	}
+
+	for conflictID := range conflicts {
+		m.RemoveTx(conflictID)
+		m.markDropped(conflictID, "replaced by a conflicting tx that burns more DJTX")
+	}
+	for m.bytes+size > m.maxBytes {
+		evictedTx, _ := m.evictableTxs.Peek()
+		evictedTxID := evictedTx.ID()
+		m.RemoveTx(evictedTxID)
+		m.markDropped(evictedTxID, "evicted from the mempool to make room for a higher fee tx")
+	}
+
 	switch tx.UnsignedTx.(type) {
 	case TimedTx:
 		m.unissuedProposalTxs.Add(tx)
+	case *UnsignedRemoveSubnetValidatorTx:
+		m.unissuedUntimedProposalTxs = append(m.unissuedUntimedProposalTxs, tx)
 	case UnsignedDecisionTx:
-		m.unissuedDecisionTxs = append(m.unissuedDecisionTxs, tx)
+		m.unissuedDecisionTxs.Add(tx, fee, m.numAdded)
 	case UnsignedAtomicTx:
-		m.unissuedAtomicTxs = append(m.unissuedAtomicTxs, tx)
-	default:
-		return errUnknownTxType
+		m.unissuedAtomicTxs.Add(tx, fee, m.numAdded)
+	}
+	m.evictableTxs.Add(tx, fee, m.numAdded)
+	m.numAdded++
+
+	txID := tx.ID()
+	for _, in := range ins {
+		m.consumedUTXOs[in.InputID()] = txID
 	}
 	m.unissuedTxIDs.Add(txID)
-	m.ResetTimer()
+	m.bytes += size
+	m.updateMetrics()
 	return nil
 }
 
+// RemoveTx removes the tx with ID [txID] from the mempool, if it's there. This
+// is called when a block containing the tx is verified so that the tx isn't
+// put into another block.
+func (m *Mempool) RemoveTx(txID ids.ID) {
+	if !m.unissuedTxIDs.Contains(txID) {
+		return
+	}
+
+	if tx := m.unissuedDecisionTxs.Remove(txID); tx != nil {
+		m.markRemoved(tx)
+		return
+	}
+	if tx := m.unissuedAtomicTxs.Remove(txID); tx != nil {
+		m.markRemoved(tx)
+		return
+	}
+	for i, tx := range m.unissuedUntimedProposalTxs {
+		if tx.ID() == txID {
+			m.unissuedUntimedProposalTxs = append(m.unissuedUntimedProposalTxs[:i], m.unissuedUntimedProposalTxs[i+1:]...)
+			m.markRemoved(tx)
+			return
+		}
+	}
+	if tx := m.unissuedProposalTxs.RemoveTx(txID); tx != nil {
+		m.markRemoved(tx)
+	}
+}
+
+// markRemoved stops tracking [tx], which has been removed from the mempool's
+// collections of unissued txs
+func (m *Mempool) markRemoved(tx *Tx) {
+	txID := tx.ID()
+	m.evictableTxs.Remove(txID)
+	ins, _ := txInsOuts(tx)
+	for _, in := range ins {
+		inputID := in.InputID()
+		if m.consumedUTXOs[inputID] == txID {
+			delete(m.consumedUTXOs, inputID)
+		}
+	}
+	m.unissuedTxIDs.Remove(txID)
+	m.bytes -= len(tx.Bytes())
+	m.updateMetrics()
+}
+
+// markDropped records that the tx with ID [txID] was removed from the mempool
+// without being put into a block because of [errMsg]
+func (m *Mempool) markDropped(txID ids.ID, errMsg string) {
+	m.vm.droppedTxCache.Put(txID, errMsg) // cache tx as dropped
+	m.vm.ctx.Log.Debug("dropping tx %s: %s", txID, errMsg)
+}
+
+func (m *Mempool) updateMetrics() {
+	m.vm.metrics.mempoolTxs.Set(float64(m.unissuedTxIDs.Len()))
+	m.vm.metrics.mempoolBytes.Set(float64(m.bytes))
+}
+
+// txInsOuts returns the inputs consumed and the outputs produced by [tx],
+// including imported inputs and exported and staked outputs
+func txInsOuts(tx *Tx) ([]*djtx.TransferableInput, []*djtx.TransferableOutput) {
+	var (
+		ins  []*djtx.TransferableInput
+		outs []*djtx.TransferableOutput
+	)
+	switch utx := tx.UnsignedTx.(type) {
+	case *UnsignedCreateChainTx:
+		ins, outs = utx.Ins, utx.Outs
+	case *UnsignedCreateSubnetTx:
+		ins, outs = utx.Ins, utx.Outs
+	case *UnsignedTransferSubnetOwnershipTx:
+		ins, outs = utx.Ins, utx.Outs
+	case *UnsignedTransformSubnetTx:
+		ins, outs = utx.Ins, utx.Outs
+	case *UnsignedImportTx:
+		ins = append(ins, utx.Ins...)
+		ins = append(ins, utx.ImportedInputs...)
+		outs = utx.Outs
+	case *UnsignedExportTx:
+		ins = utx.Ins
+		outs = append(outs, utx.Outs...)
+		outs = append(outs, utx.ExportedOutputs...)
+	case *UnsignedAddValidatorTx:
+		ins = utx.Ins
+		outs = append(outs, utx.Outs...)
+		outs = append(outs, utx.Stake...)
+	case *UnsignedAddRenewableValidatorTx:
+		ins = utx.Ins
+		outs = append(outs, utx.Outs...)
+		outs = append(outs, utx.Stake...)
+	case *UnsignedAddDelegatorTx:
+		ins = utx.Ins
+		outs = append(outs, utx.Outs...)
+		outs = append(outs, utx.Stake...)
+	case *UnsignedAddPermissionlessValidatorTx:
+		ins = utx.Ins
+		outs = append(outs, utx.Outs...)
+		outs = append(outs, utx.Stake...)
+	case *UnsignedAddPermissionlessDelegatorTx:
+		ins = utx.Ins
+		outs = append(outs, utx.Outs...)
+		outs = append(outs, utx.Stake...)
+	case *UnsignedAddSubnetValidatorTx:
+		ins, outs = utx.Ins, utx.Outs
+	case *UnsignedRemoveSubnetValidatorTx:
+		ins, outs = utx.Ins, utx.Outs
+	}
+	return ins, outs
+}
+
+// burnedDJTX returns the amount of DJTX burned by [tx]. If the amount can't be
+// calculated, 0 is returned.
+func (m *Mempool) burnedDJTX(tx *Tx) uint64 {
+	ins, outs := txInsOuts(tx)
+	djtxAssetID := m.vm.ctx.DJTXAssetID
+	consumed := uint64(0)
+	for _, in := range ins {
+		if in.AssetID() != djtxAssetID {
+			continue
+		}
+		newConsumed, err := safemath.Add64(consumed, in.Input().Amount())
+		if err != nil {
+			return 0
+		}
+		consumed = newConsumed
+	}
+	produced := uint64(0)
+	for _, out := range outs {
+		if out.AssetID() != djtxAssetID {
+			continue
+		}
+		newProduced, err := safemath.Add64(produced, out.Output().Amount())
+		if err != nil {
+			return 0
+		}
+		produced = newProduced
+	}
+	burned, err := safemath.Sub64(consumed, produced)
+	if err != nil {
+		return 0
+	}
+	return burned
+}
+
 // BuildBlock builds a block to be added to consensus
 func (m *Mempool) BuildBlock() (snowman.Block, error) {
 	m.dropIncoming = true
@@ -143,17 +421,12 @@ func (m *Mempool) BuildBlock() (snowman.Block, error) {
 	preferredID := preferred.ID()
 	nextHeight := preferred.Height() + 1
 
-	// If there are pending decision txs, build a block with a batch of them
-	if len(m.unissuedDecisionTxs) > 0 {
-		numTxs := BatchSize
-		if numTxs > len(m.unissuedDecisionTxs) {
-			numTxs = len(m.unissuedDecisionTxs)
-		}
-		var txs []*Tx
-		txs, m.unissuedDecisionTxs = m.unissuedDecisionTxs[:numTxs], m.unissuedDecisionTxs[numTxs:]
-		for _, tx := range txs {
-			m.unissuedTxIDs.Remove(tx.ID())
-		}
+	// The state if the preferred block were to be accepted
+	preferredState := preferredDecision.onAccept()
+
+	// If there are pending decision txs, build a block with a batch of the
+	// valid ones that burn the most DJTX
+	if txs := m.removeDecisionTxs(preferredState); len(txs) > 0 {
 		blk, err := m.vm.newStandardBlock(preferredID, nextHeight, txs)
 		if err != nil {
 			m.ResetTimer()
@@ -169,11 +442,13 @@ func (m *Mempool) BuildBlock() (snowman.Block, error) {
 		return blk, m.vm.internalState.Commit()
 	}
 
-	// If there is a pending atomic tx, build a block with it
-	if len(m.unissuedAtomicTxs) > 0 {
-		tx := m.unissuedAtomicTxs[0]
-		m.unissuedAtomicTxs = m.unissuedAtomicTxs[1:]
-		m.unissuedTxIDs.Remove(tx.ID())
+	// If there are pending atomic txs, build a block with the valid one that
+	// burns the most DJTX
+	tx, err := m.removeAtomicTx(preferred, preferredState)
+	if err != nil {
+		return nil, err
+	}
+	if tx != nil {
 		blk, err := m.vm.newAtomicBlock(preferredID, nextHeight, *tx)
 		if err != nil {
 			m.ResetTimer()
@@ -189,9 +464,6 @@ func (m *Mempool) BuildBlock() (snowman.Block, error) {
 		return blk, m.vm.internalState.Commit()
 	}
 
-	// The state if the preferred block were to be accepted
-	preferredState := preferredDecision.onAccept()
-
 	// The chain time if the preferred block were to be committed
 	currentChainTimestamp := preferredState.GetTimestamp()
 	if !currentChainTimestamp.Before(timer.MaxTime) {
@@ -203,7 +475,7 @@ func (m *Mempool) BuildBlock() (snowman.Block, error) {
 	// If the chain time would be the time for the next primary network staker
 	// to leave, then we create a block that removes the staker and proposes
 	// they receive a staker reward
-	tx, _, err := currentStakers.GetNextStaker()
+	tx, _, err = currentStakers.GetNextStaker()
 	if err != nil {
 		return nil, err
 	}
@@ -249,6 +521,26 @@ func (m *Mempool) BuildBlock() (snowman.Block, error) {
 		return blk, m.vm.internalState.Commit()
 	}
 
+	// Propose a proposal tx that can be issued at any time
+	if len(m.unissuedUntimedProposalTxs) > 0 {
+		tx := m.unissuedUntimedProposalTxs[0]
+		m.unissuedUntimedProposalTxs = m.unissuedUntimedProposalTxs[1:]
+		m.markRemoved(tx)
+		blk, err := m.vm.newProposalBlock(preferredID, nextHeight, *tx)
+		if err != nil {
+			m.ResetTimer()
+			return nil, err
+		}
+
+		if err := blk.Verify(); err != nil {
+			m.ResetTimer()
+			return nil, err
+		}
+
+		m.vm.internalState.AddBlock(blk)
+		return blk, m.vm.internalState.Commit()
+	}
+
 	// Propose adding a new validator but only if their start time is in the
 	// future relative to local time (plus Delta)
 	syncTime := localTime.Add(syncBound)
@@ -259,7 +551,7 @@ func (m *Mempool) BuildBlock() (snowman.Block, error) {
 		startTime := utx.StartTime()
 		if startTime.Before(syncTime) {
 			m.unissuedProposalTxs.Remove()
-			m.unissuedTxIDs.Remove(txID)
+			m.markRemoved(tx)
 			errMsg := fmt.Sprintf(
 				"synchrony bound (%s) is later than staker start time (%s)",
 				syncTime,
@@ -275,7 +567,7 @@ func (m *Mempool) BuildBlock() (snowman.Block, error) {
 		// drop the transaction and continue
 		if startTime.After(maxLocalStartTime) {
 			m.unissuedProposalTxs.Remove()
-			m.unissuedTxIDs.Remove(txID)
+			m.markRemoved(tx)
 			continue
 		}
 
@@ -299,7 +591,7 @@ func (m *Mempool) BuildBlock() (snowman.Block, error) {
 
 		// Attempt to issue the transaction
 		m.unissuedProposalTxs.Remove()
-		m.unissuedTxIDs.Remove(txID)
+		m.markRemoved(tx)
 		blk, err := m.vm.newProposalBlock(preferredID, nextHeight, *tx)
 		if err != nil {
 			m.ResetTimer()
@@ -319,12 +611,67 @@ func (m *Mempool) BuildBlock() (snowman.Block, error) {
 	return nil, errNoPendingBlocks
 }
 
+// removeDecisionTxs removes up to BatchSize decision txs from the mempool,
+// starting with the ones that burn the most DJTX. Each tx is verified on top of
+// [parentState] and the txs before it, and the txs that aren't valid are
+// dropped.
+func (m *Mempool) removeDecisionTxs(parentState MutableState) []*Tx {
+	txs := []*Tx(nil)
+	for len(txs) < BatchSize && m.unissuedDecisionTxs.Len() > 0 {
+		tx := m.unissuedDecisionTxs.RemoveTop()
+		m.markRemoved(tx)
+
+		// Verify [tx] on its own state so that a tx that fails verification
+		// doesn't modify the state the following txs are verified on
+		txState := newVersionedState(
+			parentState,
+			parentState.CurrentStakerChainState(),
+			parentState.PendingStakerChainState(),
+		)
+		if _, err := tx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(m.vm, txState, tx); err != nil {
+			m.markDropped(tx.ID(), err.Error())
+			continue
+		}
+		txState.AddTx(tx, Committed)
+		parentState = txState
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+// removeAtomicTx removes atomic txs from the mempool, starting with the one
+// that burns the most DJTX, until one is valid on top of [preferred], whose
+// state is [preferredState]. The txs that aren't valid are dropped. Returns nil
+// if there is no valid atomic tx.
+func (m *Mempool) removeAtomicTx(preferred Block, preferredState MutableState) (*Tx, error) {
+	for m.unissuedAtomicTxs.Len() > 0 {
+		tx := m.unissuedAtomicTxs.RemoveTop()
+		m.markRemoved(tx)
+
+		utx := tx.UnsignedTx.(UnsignedAtomicTx)
+		conflicts, err := preferred.conflicts(utx.InputUTXOs())
+		if err != nil {
+			return nil, err
+		}
+		if conflicts {
+			m.markDropped(tx.ID(), errConflictingParentTxs.Error())
+			continue
+		}
+		if _, err := utx.SemanticVerify(m.vm, preferredState, tx); err != nil {
+			m.markDropped(tx.ID(), err.Error())
+			continue
+		}
+		return tx, nil
+	}
+	return nil, nil
+}
+
 // ResetTimer Check if there is a block ready to be added to consensus. If so, notify the
 // consensus engine.
 func (m *Mempool) ResetTimer() {
 	// If there is a pending transaction, trigger building of a block with that
 	// transaction
-	if len(m.unissuedDecisionTxs) > 0 || len(m.unissuedAtomicTxs) > 0 {
+	if m.unissuedDecisionTxs.Len() > 0 || m.unissuedAtomicTxs.Len() > 0 {
 		m.vm.NotifyBlockReady()
 		return
 	}
@@ -370,6 +717,11 @@ func (m *Mempool) ResetTimer() {
 		return
 	}
 
+	if len(m.unissuedUntimedProposalTxs) > 0 {
+		m.vm.NotifyBlockReady() // Should issue a proposal that isn't timed
+		return
+	}
+
 	syncTime := localTime.Add(syncBound)
 	for m.unissuedProposalTxs.Len() > 0 {
 		startTime := m.unissuedProposalTxs.Peek().UnsignedTx.(TimedTx).StartTime()
@@ -378,8 +730,9 @@ func (m *Mempool) ResetTimer() {
 			return
 		}
 		// If the tx doesn't meet the synchrony bound, drop it
-		txID := m.unissuedProposalTxs.Remove().ID()
-		m.unissuedTxIDs.Remove(txID)
+		tx := m.unissuedProposalTxs.Remove()
+		txID := tx.ID()
+		m.markRemoved(tx)
 		errMsg := fmt.Sprintf(
 			"synchrony bound (%s) is later than staker start time (%s)",
 			syncTime,
@@ -0,0 +1,341 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// newTestCreateSubnetTxs returns two CreateSubnetTxs paid for by different
+// keys. [lowFeeTx] burns [defaultTxFee] and [highFeeTx] burns twice as much.
+func newTestCreateSubnetTxs(t *testing.T, vm *VM) (lowFeeTx *Tx, highFeeTx *Tx) {
+	defer func() { vm.CreationTxFee = defaultTxFee }()
+
+	vm.CreationTxFee = defaultTxFee
+	lowFeeTx, err := vm.newCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vm.CreationTxFee = 2 * defaultTxFee
+	highFeeTx, err = vm.newCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[1].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[1]},
+		keys[1].PublicKey().Address(), // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lowFeeTx, highFeeTx
+}
+
+func TestMempoolBuildsHighestFeeTxsFirst(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	lowFeeTx, highFeeTx := newTestCreateSubnetTxs(t, vm)
+	if fee := vm.mempool.burnedDJTX(lowFeeTx); fee != defaultTxFee {
+		t.Fatalf("expected %d DJTX to be burned but got %d", defaultTxFee, fee)
+	}
+	if fee := vm.mempool.burnedDJTX(highFeeTx); fee != 2*defaultTxFee {
+		t.Fatalf("expected %d DJTX to be burned but got %d", 2*defaultTxFee, fee)
+	}
+
+	if err := vm.mempool.IssueTx(lowFeeTx); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.mempool.IssueTx(highFeeTx); err != nil {
+		t.Fatal(err)
+	}
+	expectedBytes := len(lowFeeTx.Bytes()) + len(highFeeTx.Bytes())
+	if bytes := vm.mempool.Bytes(); bytes != expectedBytes {
+		t.Fatalf("expected the mempool to hold %d bytes but holds %d", expectedBytes, bytes)
+	}
+
+	blkIntf, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk, ok := blkIntf.(*StandardBlock)
+	if !ok {
+		t.Fatalf("expected *StandardBlock but got %T", blkIntf)
+	}
+	if len(blk.Txs) != 2 {
+		t.Fatalf("expected 2 txs but got %d", len(blk.Txs))
+	}
+	if blk.Txs[0].ID() != highFeeTx.ID() || blk.Txs[1].ID() != lowFeeTx.ID() {
+		t.Fatal("expected the txs to be ordered by the amount of DJTX they burn")
+	}
+	if vm.mempool.Has(lowFeeTx.ID()) || vm.mempool.Has(highFeeTx.ID()) {
+		t.Fatal("txs should have been removed from the mempool")
+	}
+	if bytes := vm.mempool.Bytes(); bytes != 0 {
+		t.Fatalf("expected the mempool to be empty but holds %d bytes", bytes)
+	}
+}
+
+func TestMempoolEvictsLowestFeeTxs(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	lowFeeTx, highFeeTx := newTestCreateSubnetTxs(t, vm)
+	vm.mempool.maxBytes = len(lowFeeTx.Bytes()) + len(highFeeTx.Bytes()) - 1
+
+	if err := vm.mempool.IssueTx(lowFeeTx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Case: Room is made for a tx that burns more DJTX
+	if err := vm.mempool.IssueTx(highFeeTx); err != nil {
+		t.Fatal(err)
+	}
+	if vm.mempool.Has(lowFeeTx.ID()) {
+		t.Fatal("lowFeeTx should have been evicted")
+	}
+	if _, dropped := vm.droppedTxCache.Get(lowFeeTx.ID()); !dropped {
+		t.Fatal("lowFeeTx should have been marked as dropped")
+	}
+	if !vm.mempool.Has(highFeeTx.ID()) {
+		t.Fatal("highFeeTx should be in the mempool")
+	}
+
+	// Case: A tx that burns less DJTX doesn't evict anything
+	if err := vm.mempool.IssueTx(lowFeeTx); err != errMempoolFull {
+		t.Fatalf("expected %s but got %v", errMempoolFull, err)
+	}
+	if !vm.mempool.Has(highFeeTx.ID()) {
+		t.Fatal("highFeeTx should still be in the mempool")
+	}
+
+	// Case: Tx can never fit in the mempool
+	vm.mempool.maxBytes = len(lowFeeTx.Bytes()) - 1
+	if err := vm.mempool.IssueTx(lowFeeTx); err != errTxTooLarge {
+		t.Fatalf("expected %s but got %v", errTxTooLarge, err)
+	}
+}
+
+func TestMempoolRemovesTxsInVerifiedBlocks(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	lowFeeTx, _ := newTestCreateSubnetTxs(t, vm)
+	if err := vm.mempool.IssueTx(lowFeeTx); err != nil {
+		t.Fatal(err)
+	}
+
+	// A peer put the tx into a block
+	preferredID := vm.preferred
+	preferred, err := vm.getBlock(preferredID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk, err := vm.newStandardBlock(preferredID, preferred.Height()+1, []*Tx{lowFeeTx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if vm.mempool.Has(lowFeeTx.ID()) {
+		t.Fatal("tx should have been removed from the mempool")
+	}
+	if _, err := vm.BuildBlock(); err != errNoPendingBlocks {
+		t.Fatalf("expected %s but got %v", errNoPendingBlocks, err)
+	}
+}
+
+func TestMempoolDropsInvalidTxsWhenBuildingBlocks(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	lowFeeTx, highFeeTx := newTestCreateSubnetTxs(t, vm)
+	invalidTx, err := vm.newCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[2].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[2]},
+		keys[2].PublicKey().Address(), // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tx := range []*Tx{lowFeeTx, highFeeTx, invalidTx} {
+		if err := vm.mempool.IssueTx(tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The UTXO consumed by [invalidTx] is spent by a tx that isn't in the
+	// mempool
+	for _, in := range invalidTx.UnsignedTx.(*UnsignedCreateSubnetTx).Ins {
+		vm.internalState.DeleteUTXO(in.InputID())
+	}
+
+	blkIntf, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk, ok := blkIntf.(*StandardBlock)
+	if !ok {
+		t.Fatalf("expected *StandardBlock but got %T", blkIntf)
+	}
+	if len(blk.Txs) != 2 {
+		t.Fatalf("expected 2 txs but got %d", len(blk.Txs))
+	}
+	if blk.Txs[0].ID() != highFeeTx.ID() || blk.Txs[1].ID() != lowFeeTx.ID() {
+		t.Fatal("expected the valid txs to be put into the block")
+	}
+	if _, dropped := vm.droppedTxCache.Get(invalidTx.ID()); !dropped {
+		t.Fatal("invalidTx should have been marked as dropped")
+	}
+	if _, dropped := vm.droppedTxCache.Get(lowFeeTx.ID()); dropped {
+		t.Fatal("lowFeeTx shouldn't have been marked as dropped")
+	}
+	if bytes := vm.mempool.Bytes(); bytes != 0 {
+		t.Fatalf("expected the mempool to be empty but holds %d bytes", bytes)
+	}
+}
+
+func TestMempoolReplacesConflictingTxs(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// newTx returns a tx that burns [fee] DJTX and consumes the same UTXO as
+	// every other tx returned by it
+	newTx := func(fee uint64) *Tx {
+		defer func() { vm.CreationTxFee = defaultTxFee }()
+
+		vm.CreationTxFee = fee
+		tx, err := vm.newCreateSubnetTx(
+			1,
+			[]ids.ShortID{keys[0].PublicKey().Address()},
+			[]*crypto.PrivateKeySECP256K1R{keys[0]},
+			keys[0].PublicKey().Address(), // change addr
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+	lowFeeTx := newTx(defaultTxFee)
+	sameFeeTx := newTx(defaultTxFee)
+	sameFeeTx.UnsignedTx.(*UnsignedCreateSubnetTx).Owner.(*secp256k1fx.OutputOwners).Addrs = []ids.ShortID{keys[1].PublicKey().Address()}
+	if err := sameFeeTx.Sign(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}); err != nil {
+		t.Fatal(err)
+	}
+	highFeeTx := newTx(2 * defaultTxFee)
+
+	if err := vm.mempool.IssueTx(lowFeeTx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Case: A conflicting tx that doesn't burn more DJTX is rejected
+	if err := vm.mempool.IssueTx(sameFeeTx); err != errConflictingTx {
+		t.Fatalf("expected %s but got %v", errConflictingTx, err)
+	}
+
+	// Case: A conflicting tx that burns more DJTX replaces the tx
+	if err := vm.mempool.IssueTx(highFeeTx); err != nil {
+		t.Fatal(err)
+	}
+	if vm.mempool.Has(lowFeeTx.ID()) {
+		t.Fatal("lowFeeTx should have been replaced")
+	}
+	if _, dropped := vm.droppedTxCache.Get(lowFeeTx.ID()); !dropped {
+		t.Fatal("lowFeeTx should have been marked as dropped")
+	}
+	if !vm.mempool.Has(highFeeTx.ID()) {
+		t.Fatal("highFeeTx should be in the mempool")
+	}
+	if bytes := vm.mempool.Bytes(); bytes != len(highFeeTx.Bytes()) {
+		t.Fatalf("expected the mempool to hold %d bytes but holds %d", len(highFeeTx.Bytes()), bytes)
+	}
+
+	// Case: The replaced tx can't replace the tx that replaced it
+	if err := vm.mempool.IssueTx(lowFeeTx); err != errConflictingTx {
+		t.Fatalf("expected %s but got %v", errConflictingTx, err)
+	}
+}
+
+func TestMempoolZeroFeeTxsDontEvict(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	lowFeeTx, _ := newTestCreateSubnetTxs(t, vm)
+
+	vm.TxFee = 0
+	zeroFeeTx, err := vm.newAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(defaultValidateStartTime.Add(time.Minute).Unix()),
+		uint64(defaultValidateEndTime.Unix()),
+		keys[0].PublicKey().Address(),
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	vm.TxFee = defaultTxFee
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee := vm.mempool.burnedDJTX(zeroFeeTx); fee != 0 {
+		t.Fatalf("expected no DJTX to be burned but got %d", fee)
+	}
+
+	vm.mempool.maxBytes = len(lowFeeTx.Bytes()) + len(zeroFeeTx.Bytes()) - 1
+	if err := vm.mempool.IssueTx(lowFeeTx); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.mempool.IssueTx(zeroFeeTx); err != errMempoolFull {
+		t.Fatalf("expected %s but got %v", errMempoolFull, err)
+	}
+	if !vm.mempool.Has(lowFeeTx.ID()) {
+		t.Fatal("lowFeeTx shouldn't have been evicted")
+	}
+}
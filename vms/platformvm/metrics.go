@@ -19,6 +19,8 @@ var errUnknownBlockType = errors.New("unknown block type")
 type metrics struct {
 	percentConnected prometheus.Gauge
 	totalStake       prometheus.Gauge
+	mempoolTxs       prometheus.Gauge
+	mempoolBytes     prometheus.Gauge
 
 	numAbortBlocks,
 	numAtomicBlocks,
@@ -27,6 +29,9 @@ type metrics struct {
 	numStandardBlocks prometheus.Counter
 
 	numAddDelegatorTxs,
+	numAddPermissionlessDelegatorTxs,
+	numAddPermissionlessValidatorTxs,
+	numAddRenewableValidatorTxs,
 	numAddSubnetValidatorTxs,
 	numAddValidatorTxs,
 	numAdvanceTimeTxs,
@@ -34,7 +39,10 @@ type metrics struct {
 	numCreateSubnetTxs,
 	numExportTxs,
 	numImportTxs,
-	numRewardValidatorTxs prometheus.Counter
+	numRemoveSubnetValidatorTxs,
+	numRewardValidatorTxs,
+	numTransferSubnetOwnershipTxs,
+	numTransformSubnetTxs prometheus.Counter
 
 	apiRequestMetrics metric.APIInterceptor
 }
@@ -71,6 +79,17 @@ func (m *metrics) Initialize(
 		Help:      "Total amount of DJTX staked",
 	})
 
+	m.mempoolTxs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "mempool_txs",
+		Help:      "Number of txs waiting to be put into a block",
+	})
+	m.mempoolBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "mempool_bytes",
+		Help:      "Number of bytes of txs waiting to be put into a block",
+	})
+
 	m.numAbortBlocks = newBlockMetrics(namespace, "abort")
 	m.numAtomicBlocks = newBlockMetrics(namespace, "atomic")
 	m.numCommitBlocks = newBlockMetrics(namespace, "commit")
@@ -78,6 +97,9 @@ func (m *metrics) Initialize(
 	m.numStandardBlocks = newBlockMetrics(namespace, "standard")
 
 	m.numAddDelegatorTxs = newTxMetrics(namespace, "add_delegator")
+	m.numAddPermissionlessDelegatorTxs = newTxMetrics(namespace, "add_permissionless_delegator")
+	m.numAddPermissionlessValidatorTxs = newTxMetrics(namespace, "add_permissionless_validator")
+	m.numAddRenewableValidatorTxs = newTxMetrics(namespace, "add_renewable_validator")
 	m.numAddSubnetValidatorTxs = newTxMetrics(namespace, "add_subnet_validator")
 	m.numAddValidatorTxs = newTxMetrics(namespace, "add_validator")
 	m.numAdvanceTimeTxs = newTxMetrics(namespace, "advance_time")
@@ -85,7 +107,10 @@ func (m *metrics) Initialize(
 	m.numCreateSubnetTxs = newTxMetrics(namespace, "create_subnet")
 	m.numExportTxs = newTxMetrics(namespace, "export")
 	m.numImportTxs = newTxMetrics(namespace, "import")
+	m.numRemoveSubnetValidatorTxs = newTxMetrics(namespace, "remove_subnet_validator")
 	m.numRewardValidatorTxs = newTxMetrics(namespace, "reward_validator")
+	m.numTransferSubnetOwnershipTxs = newTxMetrics(namespace, "transfer_subnet_ownership")
+	m.numTransformSubnetTxs = newTxMetrics(namespace, "transform_subnet")
 
 	apiRequestMetrics, err := metric.NewAPIInterceptor(namespace, registerer)
 	m.apiRequestMetrics = apiRequestMetrics
@@ -95,6 +120,8 @@ func (m *metrics) Initialize(
 
 		registerer.Register(m.percentConnected),
 		registerer.Register(m.totalStake),
+		registerer.Register(m.mempoolTxs),
+		registerer.Register(m.mempoolBytes),
 
 		registerer.Register(m.numAbortBlocks),
 		registerer.Register(m.numAtomicBlocks),
@@ -103,6 +130,9 @@ func (m *metrics) Initialize(
 		registerer.Register(m.numStandardBlocks),
 
 		registerer.Register(m.numAddDelegatorTxs),
+		registerer.Register(m.numAddPermissionlessDelegatorTxs),
+		registerer.Register(m.numAddPermissionlessValidatorTxs),
+		registerer.Register(m.numAddRenewableValidatorTxs),
 		registerer.Register(m.numAddSubnetValidatorTxs),
 		registerer.Register(m.numAddValidatorTxs),
 		registerer.Register(m.numAdvanceTimeTxs),
@@ -110,7 +140,10 @@ func (m *metrics) Initialize(
 		registerer.Register(m.numCreateSubnetTxs),
 		registerer.Register(m.numExportTxs),
 		registerer.Register(m.numImportTxs),
+		registerer.Register(m.numRemoveSubnetValidatorTxs),
 		registerer.Register(m.numRewardValidatorTxs),
+		registerer.Register(m.numTransferSubnetOwnershipTxs),
+		registerer.Register(m.numTransformSubnetTxs),
 	)
 	return errs.Err
 }
@@ -144,6 +177,12 @@ func (m *metrics) AcceptTx(tx *Tx) error {
 	switch tx.UnsignedTx.(type) {
 	case *UnsignedAddDelegatorTx:
 		m.numAddDelegatorTxs.Inc()
+	case *UnsignedAddPermissionlessDelegatorTx:
+		m.numAddPermissionlessDelegatorTxs.Inc()
+	case *UnsignedAddPermissionlessValidatorTx:
+		m.numAddPermissionlessValidatorTxs.Inc()
+	case *UnsignedAddRenewableValidatorTx:
+		m.numAddRenewableValidatorTxs.Inc()
 	case *UnsignedAddSubnetValidatorTx:
 		m.numAddSubnetValidatorTxs.Inc()
 	case *UnsignedAddValidatorTx:
@@ -158,8 +197,14 @@ func (m *metrics) AcceptTx(tx *Tx) error {
 		m.numImportTxs.Inc()
 	case *UnsignedExportTx:
 		m.numExportTxs.Inc()
+	case *UnsignedRemoveSubnetValidatorTx:
+		m.numRemoveSubnetValidatorTxs.Inc()
 	case *UnsignedRewardValidatorTx:
 		m.numRewardValidatorTxs.Inc()
+	case *UnsignedTransferSubnetOwnershipTx:
+		m.numTransferSubnetOwnershipTxs.Inc()
+	case *UnsignedTransformSubnetTx:
+		m.numTransformSubnetTxs.Inc()
 	default:
 		return errUnknownTxType
 	}
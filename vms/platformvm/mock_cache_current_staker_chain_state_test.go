@@ -42,6 +42,29 @@ func (_m *mockCurrentStakerChainState) DeleteNextStaker() (currentStakerChainSta
 	return r0, r1
 }
 
+// DeleteSubnetValidator provides a mock function with given fields: txID
+func (_m *mockCurrentStakerChainState) DeleteSubnetValidator(txID ids.ID) (currentStakerChainState, error) {
+	ret := _m.Called(txID)
+
+	var r0 currentStakerChainState
+	if rf, ok := ret.Get(0).(func(ids.ID) currentStakerChainState); ok {
+		r0 = rf(txID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(currentStakerChainState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ids.ID) error); ok {
+		r1 = rf(txID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNextStaker provides a mock function with given fields:
 func (_m *mockCurrentStakerChainState) GetNextStaker() (*Tx, uint64, error) {
 	ret := _m.Called()
@@ -125,6 +148,29 @@ func (_m *mockCurrentStakerChainState) GetValidator(nodeID ids.ShortID) (current
 	return r0, r1
 }
 
+// RenewNextStaker provides a mock function with given fields: renewedTx, potentialReward
+func (_m *mockCurrentStakerChainState) RenewNextStaker(renewedTx *Tx, potentialReward uint64) (currentStakerChainState, error) {
+	ret := _m.Called(renewedTx, potentialReward)
+
+	var r0 currentStakerChainState
+	if rf, ok := ret.Get(0).(func(*Tx, uint64) currentStakerChainState); ok {
+		r0 = rf(renewedTx, potentialReward)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(currentStakerChainState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Tx, uint64) error); ok {
+		r1 = rf(renewedTx, potentialReward)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Stakers provides a mock function with given fields:
 func (_m *mockCurrentStakerChainState) Stakers() []*Tx {
 	ret := _m.Called()
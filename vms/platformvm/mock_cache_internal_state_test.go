@@ -6,6 +6,8 @@ import (
 	database "github.com/ava-labs/avalanchego/database"
 	djtx "github.com/ava-labs/avalanchego/vms/components/djtx"
 
+	verify "github.com/ava-labs/avalanchego/vms/components/verify"
+
 	ids "github.com/ava-labs/avalanchego/ids"
 
 	mock "github.com/stretchr/testify/mock"
@@ -55,6 +57,11 @@ func (_m *MockInternalState) AddSubnet(createSubnetTx *Tx) {
 	_m.Called(createSubnetTx)
 }
 
+// AddSubnetTransformation provides a mock function with given fields: transformSubnetTx
+func (_m *MockInternalState) AddSubnetTransformation(transformSubnetTx *Tx) {
+	_m.Called(transformSubnetTx)
+}
+
 // AddTx provides a mock function with given fields: tx, status
 func (_m *MockInternalState) AddTx(tx *Tx, status Status) {
 	_m.Called(tx, status)
@@ -223,6 +230,29 @@ func (_m *MockInternalState) GetLastAccepted() ids.ID {
 	return r0
 }
 
+// GetRewardHistory provides a mock function with given fields: addr
+func (_m *MockInternalState) GetRewardHistory(addr ids.ShortID) ([]rewardRecord, error) {
+	ret := _m.Called(addr)
+
+	var r0 []rewardRecord
+	if rf, ok := ret.Get(0).(func(ids.ShortID) []rewardRecord); ok {
+		r0 = rf(addr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]rewardRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ids.ShortID) error); ok {
+		r1 = rf(addr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRewardUTXOs provides a mock function with given fields: txID
 func (_m *MockInternalState) GetRewardUTXOs(txID ids.ID) ([]*djtx.UTXO, error) {
 	ret := _m.Called(txID)
@@ -246,6 +276,73 @@ func (_m *MockInternalState) GetRewardUTXOs(txID ids.ID) ([]*djtx.UTXO, error) {
 	return r0, r1
 }
 
+// GetSubnetCurrentSupply provides a mock function with given fields: subnetID
+func (_m *MockInternalState) GetSubnetCurrentSupply(subnetID ids.ID) (uint64, error) {
+	ret := _m.Called(subnetID)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(ids.ID) uint64); ok {
+		r0 = rf(subnetID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ids.ID) error); ok {
+		r1 = rf(subnetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubnetOwner provides a mock function with given fields: subnetID
+func (_m *MockInternalState) GetSubnetOwner(subnetID ids.ID) (verify.Verifiable, error) {
+	ret := _m.Called(subnetID)
+
+	var r0 verify.Verifiable
+	if rf, ok := ret.Get(0).(func(ids.ID) verify.Verifiable); ok {
+		r0 = rf(subnetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(verify.Verifiable)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ids.ID) error); ok {
+		r1 = rf(subnetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubnetTransformation provides a mock function with given fields: subnetID
+func (_m *MockInternalState) GetSubnetTransformation(subnetID ids.ID) (*Tx, error) {
+	ret := _m.Called(subnetID)
+
+	var r0 *Tx
+	if rf, ok := ret.Get(0).(func(ids.ID) *Tx); ok {
+		r0 = rf(subnetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Tx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ids.ID) error); ok {
+		r1 = rf(subnetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSubnets provides a mock function with given fields:
 func (_m *MockInternalState) GetSubnets() ([]*Tx, error) {
 	ret := _m.Called()
@@ -364,6 +461,29 @@ func (_m *MockInternalState) GetUptime(nodeID ids.ShortID) (time.Duration, time.
 	return r0, r1, r2
 }
 
+// GetValidatorRenewal provides a mock function with given fields: stakerTxID
+func (_m *MockInternalState) GetValidatorRenewal(stakerTxID ids.ID) (*Tx, error) {
+	ret := _m.Called(stakerTxID)
+
+	var r0 *Tx
+	if rf, ok := ret.Get(0).(func(ids.ID) *Tx); ok {
+		r0 = rf(stakerTxID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Tx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ids.ID) error); ok {
+		r1 = rf(stakerTxID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsMigrated provides a mock function with given fields:
 func (_m *MockInternalState) IsMigrated() (bool, error) {
 	ret := _m.Called()
@@ -435,6 +555,16 @@ func (_m *MockInternalState) SetPendingStakerChainState(_a0 pendingStakerChainSt
 	_m.Called(_a0)
 }
 
+// SetSubnetCurrentSupply provides a mock function with given fields: subnetID, currentSupply
+func (_m *MockInternalState) SetSubnetCurrentSupply(subnetID ids.ID, currentSupply uint64) {
+	_m.Called(subnetID, currentSupply)
+}
+
+// SetSubnetOwner provides a mock function with given fields: subnetID, owner
+func (_m *MockInternalState) SetSubnetOwner(subnetID ids.ID, owner verify.Verifiable) {
+	_m.Called(subnetID, owner)
+}
+
 // SetTimestamp provides a mock function with given fields: _a0
 func (_m *MockInternalState) SetTimestamp(_a0 time.Time) {
 	_m.Called(_a0)
@@ -454,6 +584,11 @@ func (_m *MockInternalState) SetUptime(nodeID ids.ShortID, upDuration time.Durat
 	return r0
 }
 
+// SetValidatorRenewal provides a mock function with given fields: stakerTxID, addRenewableValidatorTx
+func (_m *MockInternalState) SetValidatorRenewal(stakerTxID ids.ID, addRenewableValidatorTx *Tx) {
+	_m.Called(stakerTxID, addRenewableValidatorTx)
+}
+
 // UTXOIDs provides a mock function with given fields: addr, start, limit
 func (_m *MockInternalState) UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, error) {
 	ret := _m.Called(addr, start, limit)
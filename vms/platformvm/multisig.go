@@ -0,0 +1,230 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// A partially signed tx is a tx whose credentials are complete, except that
+// some of their signatures are left empty. Each party that controls some of
+// the addresses that must sign the tx adds its signatures with signTx, on its
+// own node. The partially signed txs are then merged with combineTxs and the
+// result is issued once no signature is missing.
+
+var (
+	errNoTxs               = errors.New("no txs were provided")
+	errDifferentTxs        = errors.New("txs have different unsigned txs")
+	errMissingSignatures   = errors.New("tx is missing signatures")
+	errWrongNumberOfCreds  = errors.New("tx has the wrong number of credentials")
+	errUnknownCredential   = errors.New("unknown credential type")
+	errUnknownInputType    = errors.New("unknown input type")
+	errUnknownOutputType   = errors.New("unknown output type")
+	errUnsupportedSpending = errors.New("tx doesn't spend UTXOs")
+)
+
+// spendingTx is a tx whose inputs consume UTXOs of this chain
+type spendingTx interface {
+	inputs() []*djtx.TransferableInput
+}
+
+// credentialSigners returns the addresses that must sign each credential of
+// [utx], in the order of the signatures of the credential. The UTXOs, and
+// subnet owners, named by [utx] are read from [vs].
+func (vm *VM) credentialSigners(vs MutableState, utx UnsignedTx) ([][]ids.ShortID, error) {
+	spender, ok := utx.(spendingTx)
+	if !ok {
+		return nil, errUnsupportedSpending
+	}
+	ins := spender.inputs()
+
+	utxos := make([]*djtx.UTXO, len(ins))
+	for i, in := range ins {
+		utxo, err := vs.GetUTXO(in.InputID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get UTXO %s: %w", &in.UTXOID, err)
+		}
+		utxos[i] = utxo
+	}
+
+	var (
+		subnetID   ids.ID
+		subnetAuth verify.Verifiable
+	)
+	switch utx := utx.(type) {
+	case *UnsignedImportTx:
+		// Imported UTXOs are read from shared memory, rather than from [vs]
+		utxoIDs := make([][]byte, len(utx.ImportedInputs))
+		for i, in := range utx.ImportedInputs {
+			utxoID := in.InputID()
+			utxoIDs[i] = utxoID[:]
+		}
+		allUTXOBytes, err := vm.ctx.SharedMemory.Get(utx.SourceChain, utxoIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get shared memory: %w", err)
+		}
+		for _, utxoBytes := range allUTXOBytes {
+			utxo := &djtx.UTXO{}
+			if _, err := vm.codec.Unmarshal(utxoBytes, utxo); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal UTXO: %w", err)
+			}
+			utxos = append(utxos, utxo)
+		}
+		ins = append(ins, utx.ImportedInputs...)
+	case *UnsignedAddSubnetValidatorTx:
+		subnetID, subnetAuth = utx.Validator.Subnet, utx.SubnetAuth
+	case *UnsignedRemoveSubnetValidatorTx:
+		subnetID, subnetAuth = utx.Subnet, utx.SubnetAuth
+	case *UnsignedTransferSubnetOwnershipTx:
+		subnetID, subnetAuth = utx.Subnet, utx.SubnetAuth
+	case *UnsignedTransformSubnetTx:
+		subnetID, subnetAuth = utx.Subnet, utx.SubnetAuth
+	case *UnsignedCreateChainTx:
+		subnetID, subnetAuth = utx.SubnetID, utx.SubnetAuth
+	}
+
+	signers := make([][]ids.ShortID, 0, len(ins)+1)
+	for i, in := range ins {
+		inIntf := in.In
+		if lockedIn, ok := inIntf.(*StakeableLockIn); ok {
+			inIntf = lockedIn.TransferableIn
+		}
+		transferIn, ok := inIntf.(*secp256k1fx.TransferInput)
+		if !ok {
+			return nil, errUnknownInputType
+		}
+
+		outIntf := utxos[i].Out
+		if lockedOut, ok := outIntf.(*StakeableLockOut); ok {
+			outIntf = lockedOut.TransferableOut
+		}
+		out, ok := outIntf.(*secp256k1fx.TransferOutput)
+		if !ok {
+			return nil, errUnknownOutputType
+		}
+
+		inSigners, err := transferIn.Signers(&out.OutputOwners)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, inSigners)
+	}
+
+	if subnetAuth != nil {
+		in, ok := subnetAuth.(*secp256k1fx.Input)
+		if !ok {
+			return nil, errUnknownInputType
+		}
+		ownerIntf, err := vs.GetSubnetOwner(subnetID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch owner of subnet %s: %w", subnetID, err)
+		}
+		owner, ok := ownerIntf.(*secp256k1fx.OutputOwners)
+		if !ok {
+			return nil, errUnknownOwners
+		}
+		inSigners, err := in.Signers(owner)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, inSigners)
+	}
+	return signers, nil
+}
+
+// signTx adds the signatures of [tx] that can be produced by the keys in [kc].
+// Returns the number of signatures that were added.
+func (vm *VM) signTx(tx *Tx, kc *secp256k1fx.Keychain) (int, error) {
+	signers, err := vm.credentialSigners(vm.internalState, tx.UnsignedTx)
+	if err != nil {
+		return 0, err
+	}
+	if len(signers) != len(tx.Creds) {
+		return 0, errWrongNumberOfCreds
+	}
+
+	hash := hashing.ComputeHash256(tx.UnsignedBytes())
+	numSigned := 0
+	for i, credIntf := range tx.Creds {
+		cred, ok := credIntf.(*secp256k1fx.Credential)
+		if !ok {
+			return 0, errUnknownCredential
+		}
+		credSigned, err := cred.Sign(hash, signers[i], kc)
+		if err != nil {
+			return 0, err
+		}
+		numSigned += credSigned
+	}
+	// Re-initialize the tx with its new credentials
+	return numSigned, tx.Sign(vm.codec, nil)
+}
+
+// combineTxs returns the tx that has all the signatures added to any of the
+// partially signed [txs]. All of [txs] must have the same unsigned tx.
+func (vm *VM) combineTxs(txs []*Tx) (*Tx, error) {
+	if len(txs) == 0 {
+		return nil, errNoTxs
+	}
+
+	combinedTx := txs[0]
+	unsignedBytes := combinedTx.UnsignedBytes()
+	for _, tx := range txs[1:] {
+		if !bytes.Equal(tx.UnsignedBytes(), unsignedBytes) {
+			return nil, errDifferentTxs
+		}
+		if len(tx.Creds) != len(combinedTx.Creds) {
+			return nil, errWrongNumberOfCreds
+		}
+		for i, credIntf := range tx.Creds {
+			cred, ok := credIntf.(*secp256k1fx.Credential)
+			if !ok {
+				return nil, errUnknownCredential
+			}
+			combinedCred, ok := combinedTx.Creds[i].(*secp256k1fx.Credential)
+			if !ok {
+				return nil, errUnknownCredential
+			}
+			if err := combinedCred.Merge(cred); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// Re-initialize the tx with its new credentials
+	return combinedTx, combinedTx.Sign(vm.codec, nil)
+}
+
+// numMissingSigs returns the number of signatures that haven't been added to
+// [tx] yet
+func numMissingSigs(tx *Tx) int {
+	numMissing := 0
+	for _, credIntf := range tx.Creds {
+		if cred, ok := credIntf.(*secp256k1fx.Credential); ok {
+			numMissing += cred.NumMissingSigs()
+		}
+	}
+	return numMissing
+}
+
+// parseTx returns the tx encoded as [txStr] with [encoding]
+func (vm *VM) parseTx(encoding formatting.Encoding, txStr string) (*Tx, error) {
+	txBytes, err := formatting.Decode(encoding, txStr)
+	if err != nil {
+		return nil, fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx := &Tx{}
+	if _, err := vm.codec.Unmarshal(txBytes, tx); err != nil {
+		return nil, fmt.Errorf("couldn't parse tx: %w", err)
+	}
+	return tx, tx.Sign(vm.codec, nil)
+}
@@ -0,0 +1,192 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// signCopy returns a copy of the partially signed [tx] after it is signed
+// with [keys]
+func signCopy(t *testing.T, vm *VM, tx *Tx, keys ...*crypto.PrivateKeySECP256K1R) *Tx {
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	txCopy, err := vm.parseTx(formatting.Hex, txStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	numSigned, err := vm.signTx(txCopy, newKeychain(keys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numSigned == 0 {
+		t.Fatal("expected signatures to be added")
+	}
+	return txCopy
+}
+
+func TestMultisigExportTx(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Fund a 2-of-2 multisig
+	factory := crypto.FactorySECP256K1R{}
+	owners := secp256k1fx.OutputOwners{Threshold: 2}
+	multisigKeys := make([]*crypto.PrivateKeySECP256K1R, 2)
+	for i := range multisigKeys {
+		key, err := factory.NewPrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		multisigKeys[i] = key.(*crypto.PrivateKeySECP256K1R)
+		owners.Addrs = append(owners.Addrs, multisigKeys[i].PublicKey().Address())
+	}
+	ids.SortShortIDs(owners.Addrs)
+	vm.internalState.AddUTXO(&djtx.UTXO{
+		UTXOID: djtx.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  djtx.Asset{ID: vm.ctx.DJTXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          defaultBalance,
+			OutputOwners: owners,
+		},
+	})
+	if err := vm.internalState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	kc := secp256k1fx.NewKeychain()
+	for _, addr := range owners.Addrs {
+		kc.AddAddress(addr)
+	}
+	tx, err := vm.newExportTxWithKeychain(
+		defaultBalance-vm.TxFee,
+		vm.ctx.XChainID,
+		keys[0].PublicKey().Address(),
+		kc,
+		owners.Addrs[0], // change addr
+		nil,             // memo
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numMissing := numMissingSigs(tx); numMissing != 2 {
+		t.Fatalf("expected 2 missing signatures but got %d", numMissing)
+	}
+
+	signers, err := vm.credentialSigners(vm.internalState, tx.UnsignedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signers) != 1 || len(signers[0]) != 2 {
+		t.Fatalf("expected a credential signed by 2 addresses but got %v", signers)
+	}
+
+	partialTx0 := signCopy(t, vm, tx, multisigKeys[0])
+	partialTx1 := signCopy(t, vm, tx, multisigKeys[1])
+	if numMissing := numMissingSigs(partialTx0); numMissing != 1 {
+		t.Fatalf("expected 1 missing signature but got %d", numMissing)
+	}
+	if _, err := partialTx0.UnsignedTx.(UnsignedAtomicTx).SemanticVerify(vm, vm.internalState, partialTx0); err == nil {
+		t.Fatal("should have failed because a signature is missing")
+	}
+
+	signedTx, err := vm.combineTxs([]*Tx{partialTx0, partialTx1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numMissing := numMissingSigs(signedTx); numMissing != 0 {
+		t.Fatalf("expected no missing signatures but got %d", numMissing)
+	}
+	if _, err := signedTx.UnsignedTx.(UnsignedAtomicTx).SemanticVerify(vm, vm.internalState, signedTx); err != nil {
+		t.Fatal(err)
+	}
+
+	// A different tx can't be combined with the signed tx
+	otherTx, err := vm.newExportTxWithKeychain(
+		defaultBalance-2*vm.TxFee,
+		vm.ctx.XChainID,
+		keys[0].PublicKey().Address(),
+		kc,
+		owners.Addrs[0], // change addr
+		nil,             // memo
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.combineTxs([]*Tx{signedTx, otherTx}); err != errDifferentTxs {
+		t.Fatalf("expected %s but got %v", errDifferentTxs, err)
+	}
+}
+
+func TestMultisigAddSubnetValidatorTx(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	kc := secp256k1fx.NewKeychain()
+	kc.AddAddress(testSubnet1ControlKeys[0].PublicKey().Address())
+	kc.AddAddress(testSubnet1ControlKeys[1].PublicKey().Address())
+
+	// keys[0] is a genesis validator
+	tx, err := vm.newAddSubnetValidatorTxWithKeychain(
+		defaultWeight,
+		uint64(defaultValidateStartTime.Unix()+1),
+		uint64(defaultValidateEndTime.Unix()),
+		keys[0].PublicKey().Address(),
+		testSubnet1.ID(),
+		kc,
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signers, err := vm.credentialSigners(vm.internalState, tx.UnsignedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signers) != len(tx.Creds) {
+		t.Fatalf("expected signers for %d credentials but got %d", len(tx.Creds), len(signers))
+	}
+	// The subnet authorization must be signed by both control keys
+	if subnetSigners := signers[len(signers)-1]; len(subnetSigners) != 2 {
+		t.Fatalf("expected the subnet to be authorized by 2 addresses but got %d", len(subnetSigners))
+	}
+
+	partialTx0 := signCopy(t, vm, tx, testSubnet1ControlKeys[0])
+	if _, _, _, _, err := partialTx0.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.internalState, partialTx0); err == nil {
+		t.Fatal("should have failed because the subnet authorization is missing a signature")
+	}
+	partialTx1 := signCopy(t, vm, tx, testSubnet1ControlKeys[1])
+
+	signedTx, err := vm.combineTxs([]*Tx{partialTx0, partialTx1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numMissing := numMissingSigs(signedTx); numMissing != 0 {
+		t.Fatalf("expected no missing signatures but got %d", numMissing)
+	}
+	if _, _, _, _, err := signedTx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.internalState, signedTx); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -195,6 +195,7 @@ func (pb *ProposalBlock) Verify() error {
 	}
 	pb.onCommitState.AddTx(&pb.Tx, Committed)
 	pb.onAbortState.AddTx(&pb.Tx, Aborted)
+	pb.vm.mempool.RemoveTx(pb.Tx.ID())
 
 	pb.vm.currentBlocks[blkID] = pb
 	parentIntf.addChild(pb)
@@ -0,0 +1,234 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	errRemovePrimaryNetworkValidator = errors.New("can't remove a validator of the primary network")
+
+	_ UnsignedProposalTx = &UnsignedRemoveSubnetValidatorTx{}
+)
+
+// UnsignedRemoveSubnetValidatorTx is an unsigned removeSubnetValidatorTx
+type UnsignedRemoveSubnetValidatorTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// The node to remove from the subnet
+	NodeID ids.ShortID `serialize:"true" json:"nodeID"`
+	// The subnet to remove the node from
+	Subnet ids.ID `serialize:"true" json:"subnet"`
+	// Auth that will be allowing this validator to be removed from the subnet
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+// Verify return nil iff [tx] is valid
+func (tx *UnsignedRemoveSubnetValidatorTx) Verify(
+	ctx *snow.Context,
+	c codec.Manager,
+	feeAmount uint64,
+	feeAssetID ids.ID,
+) error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case tx.syntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errRemovePrimaryNetworkValidator
+	}
+
+	if err := tx.BaseTx.Verify(ctx, c); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	// cache that this is valid
+	tx.syntacticallyVerified = true
+	return nil
+}
+
+// SemanticVerify this transaction is valid.
+func (tx *UnsignedRemoveSubnetValidatorTx) SemanticVerify(
+	vm *VM,
+	parentState MutableState,
+	stx *Tx,
+) (
+	VersionedState,
+	VersionedState,
+	func() error,
+	func() error,
+	TxError,
+) {
+	if err := tx.Verify(
+		vm.ctx,
+		vm.codec,
+		vm.TxFee,
+		vm.ctx.DJTXAssetID,
+	); err != nil {
+		return nil, nil, nil, nil, permError{err}
+	}
+	if !vm.isApricotPhase3(parentState.GetTimestamp()) {
+		return nil, nil, nil, nil, tempError{errNotApricotPhase3}
+	}
+
+	// Verify the tx is well-formed
+	if len(stx.Creds) == 0 {
+		return nil, nil, nil, nil, permError{errWrongNumberOfCredentials}
+	}
+
+	currentStakers := parentState.CurrentStakerChainState()
+	pendingStakers := parentState.PendingStakerChainState()
+
+	var (
+		newCurrentStakers = currentStakers
+		newPendingStakers = pendingStakers
+		isCurrent         bool
+	)
+	currentValidator, err := currentStakers.GetValidator(tx.NodeID)
+	if err != nil && err != database.ErrNotFound {
+		return nil, nil, nil, nil, tempError{
+			fmt.Errorf(
+				"failed to find whether %s is a validator: %w",
+				tx.NodeID.PrefixedString(constants.NodeIDPrefix),
+				err,
+			),
+		}
+	}
+	if err == nil {
+		vdrTx, validates := currentValidator.SubnetValidators()[tx.Subnet]
+		isCurrent = validates
+		if validates {
+			newCurrentStakers, err = currentStakers.DeleteSubnetValidator(vdrTx.ID())
+			if err != nil {
+				return nil, nil, nil, nil, tempError{err}
+			}
+		}
+	}
+	if !isCurrent {
+		// This validator may not have started validating the subnet yet.
+		pendingValidator := pendingStakers.GetValidator(tx.NodeID)
+		vdrTx, validates := pendingValidator.SubnetValidators()[tx.Subnet]
+		if !validates {
+			return nil, nil, nil, nil, permError{
+				fmt.Errorf(
+					"%s isn't validating subnet %s",
+					tx.NodeID.PrefixedString(constants.NodeIDPrefix),
+					tx.Subnet,
+				),
+			}
+		}
+		newPendingStakers, err = pendingStakers.DeleteSubnetValidator(vdrTx.ID())
+		if err != nil {
+			return nil, nil, nil, nil, tempError{err}
+		}
+	}
+
+	if vm.bootstrapped {
+		baseTxCredsLen := len(stx.Creds) - 1
+		baseTxCreds := stx.Creds[:baseTxCredsLen]
+		subnetCred := stx.Creds[baseTxCredsLen]
+
+		subnetOwner, err := parentState.GetSubnetOwner(tx.Subnet)
+		if err != nil {
+			return nil, nil, nil, nil, tempError{
+				fmt.Errorf(
+					"couldn't find owner of subnet %s: %w",
+					tx.Subnet,
+					err,
+				),
+			}
+		}
+
+		if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
+			return nil, nil, nil, nil, permError{err}
+		}
+
+		// Verify the flowcheck
+		if err := vm.semanticVerifySpend(parentState, tx, tx.Ins, tx.Outs, baseTxCreds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	// Set up the state if this tx is committed
+	onCommitState := newVersionedState(parentState, newCurrentStakers, newPendingStakers)
+
+	// Consume the UTXOS
+	consumeInputs(onCommitState, tx.Ins)
+	// Produce the UTXOS
+	txID := tx.ID()
+	produceOutputs(onCommitState, txID, vm.ctx.DJTXAssetID, tx.Outs)
+
+	// Set up the state if this tx is aborted
+	onAbortState := newVersionedState(parentState, currentStakers, pendingStakers)
+	// Consume the UTXOS
+	consumeInputs(onAbortState, tx.Ins)
+	// Produce the UTXOS
+	produceOutputs(onAbortState, txID, vm.ctx.DJTXAssetID, tx.Outs)
+
+	// Only the current validator set needs to be updated after removing a
+	// subnet validator that has already started validating.
+	var onCommitFunc func() error
+	if isCurrent {
+		onCommitFunc = func() error { return vm.updateValidators(false) }
+	}
+	return onCommitState, onAbortState, onCommitFunc, nil, nil
+}
+
+// InitiallyPrefersCommit returns true because the subnet owner explicitly
+// requested the removal of this validator.
+func (tx *UnsignedRemoveSubnetValidatorTx) InitiallyPrefersCommit(*VM) bool {
+	return true
+}
+
+// Create a new transaction
+func (vm *VM) newRemoveSubnetValidatorTx(
+	nodeID ids.ShortID, // ID of the node to remove
+	subnetID ids.ID, // ID of the subnet the validator will stop validating
+	keys []*crypto.PrivateKeySECP256K1R, // Keys to use for removing the validator
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, outs, _, signers, err := vm.stake(keys, 0, vm.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := vm.authorize(vm.internalState, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Create the tx
+	utx := &UnsignedRemoveSubnetValidatorTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    vm.ctx.NetworkID,
+			BlockchainID: vm.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		NodeID:     nodeID,
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(vm.codec, signers); err != nil {
+		return nil, err
+	}
+	return tx, utx.Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID)
+}
@@ -0,0 +1,293 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestRemoveSubnetValidatorTxSyntacticVerify(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	nodeID := keys[0].PublicKey().Address()
+
+	// Case: tx is nil
+	var unsignedTx *UnsignedRemoveSubnetValidatorTx
+	if err := unsignedTx.Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID); err == nil {
+		t.Fatal("should have errored because tx is nil")
+	}
+
+	// Case: Wrong network ID
+	tx, err := vm.newRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.UnsignedTx.(*UnsignedRemoveSubnetValidatorTx).NetworkID++
+	// This tx was syntactically verified when it was created...pretend it wasn't so we don't use cache
+	tx.UnsignedTx.(*UnsignedRemoveSubnetValidatorTx).syntacticallyVerified = false
+	if err := tx.UnsignedTx.(*UnsignedRemoveSubnetValidatorTx).Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID); err == nil {
+		t.Fatal("should have errored because the wrong network ID was used")
+	}
+
+	// Case: Primary network validator
+	tx, err = vm.newRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.UnsignedTx.(*UnsignedRemoveSubnetValidatorTx).Subnet = constants.PrimaryNetworkID
+	// This tx was syntactically verified when it was created...pretend it wasn't so we don't use cache
+	tx.UnsignedTx.(*UnsignedRemoveSubnetValidatorTx).syntacticallyVerified = false
+	if err := tx.UnsignedTx.(*UnsignedRemoveSubnetValidatorTx).Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID); err == nil {
+		t.Fatal("should have errored because a primary network validator can't be removed")
+	}
+
+	// Case: Valid
+	tx, err = vm.newRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.UnsignedTx.(*UnsignedRemoveSubnetValidatorTx).Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRemoveSubnetValidatorTxSemanticVerify(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	nodeID := keys[0].PublicKey().Address()
+
+	// Case: Node isn't validating the subnet
+	tx, err := vm.newRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.internalState, tx); err == nil {
+		t.Fatal("should have failed because the node isn't validating the subnet")
+	}
+
+	// Add [nodeID] as a current validator of the subnet
+	addTx, err := vm.newAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(defaultValidateStartTime.Unix()),
+		uint64(defaultValidateEndTime.Unix()),
+		nodeID,
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	currentStakers, err := vm.internalState.CurrentStakerChainState().UpdateStakers(nil, nil, []*Tx{addTx}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs := newVersionedState(vm.internalState, currentStakers, vm.internalState.PendingStakerChainState())
+
+	// Case: Subnet auth signed by keys that don't own the subnet
+	tx, err = vm.newRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Replace a valid subnet signature with one from keys[3]
+	sig, err := keys[3].SignHash(hashing.ComputeHash256(tx.UnsignedBytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(tx.Creds[len(tx.Creds)-1].(*secp256k1fx.Credential).Sigs[0][:], sig)
+	if _, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vs, tx); err == nil {
+		t.Fatal("should have failed because the subnet auth is invalid")
+	}
+
+	// Case: Valid
+	tx, err = vm.newRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Case: Before Apricot Phase 3
+	vm.ApricotPhase3Time = vs.GetTimestamp().Add(time.Second)
+	if _, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vs, tx); err == nil {
+		t.Fatal("should have failed because Apricot Phase 3 isn't active")
+	} else if !err.Temporary() {
+		t.Fatal("should have failed with a temporary error")
+	}
+	vm.ApricotPhase3Time = time.Time{}
+
+	onCommitState, onAbortState, onCommitFunc, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vs, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if onCommitFunc == nil {
+		t.Fatal("should update the validator set after removing a current validator")
+	}
+
+	vdrs, err := onCommitState.CurrentStakerChainState().ValidatorSet(testSubnet1.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vdrs.Contains(nodeID) {
+		t.Fatal("should have removed the validator on commit")
+	}
+	if _, _, err := onCommitState.CurrentStakerChainState().GetStaker(addTx.ID()); err != database.ErrNotFound {
+		t.Fatalf("expected %s but got %v", database.ErrNotFound, err)
+	}
+	if numStakers := len(onCommitState.CurrentStakerChainState().Stakers()); numStakers != len(vm.internalState.CurrentStakerChainState().Stakers()) {
+		t.Fatalf("expected %d current stakers but got %d", len(vm.internalState.CurrentStakerChainState().Stakers()), numStakers)
+	}
+
+	vdrs, err = onAbortState.CurrentStakerChainState().ValidatorSet(testSubnet1.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !vdrs.Contains(nodeID) {
+		t.Fatal("shouldn't have removed the validator on abort")
+	}
+}
+
+// Remove a subnet validator that hasn't started validating yet
+func TestRemovePendingSubnetValidator(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	startTime := defaultValidateStartTime.Add(syncBound).Add(1 * time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	nodeID := keys[0].PublicKey().Address()
+
+	addTx, err := vm.newAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.mempool.IssueTx(addTx); err != nil {
+		t.Fatal(err)
+	}
+	acceptProposalBlock(t, vm)
+
+	removeTx, err := vm.newRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[1], testSubnet1ControlKeys[2]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.mempool.IssueTx(removeTx); err != nil {
+		t.Fatal(err)
+	}
+	acceptProposalBlock(t, vm)
+
+	if _, status, err := vm.internalState.GetTx(removeTx.ID()); err != nil {
+		t.Fatal(err)
+	} else if status != Committed {
+		t.Fatalf("status should be Committed but is %s", status)
+	}
+
+	pendingStakers := vm.internalState.PendingStakerChainState()
+	vdr := pendingStakers.GetValidator(nodeID)
+	if _, exists := vdr.SubnetValidators()[testSubnet1.ID()]; exists {
+		t.Fatal("should have removed the validator from the pending queue")
+	}
+	for _, staker := range pendingStakers.Stakers() {
+		if staker.ID() == addTx.ID() {
+			t.Fatal("should have removed the validator from the pending stakers")
+		}
+	}
+}
+
+// acceptProposalBlock builds a proposal block and commits it
+func acceptProposalBlock(t *testing.T, vm *VM) {
+	blk, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	block := blk.(*ProposalBlock)
+	options, err := block.Options()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, ok := options[0].(*CommitBlock)
+	if !ok {
+		t.Fatal(errShouldPrefCommit)
+	}
+	if err := block.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if err := commit.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := commit.Accept(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -6,46 +6,73 @@ package platformvm
 import (
 	"math/big"
 	"time"
-)
-
-var (
-	// maxSubMinConsumptionRate is the difference between the maximum
-	// consumption rate of the remaining tokens and the minimum.
-	maxSubMinConsumptionRate = new(big.Int).SetUint64(MaxSubMinConsumptionRate)
 
-	// minConsumptionRate is the consumption rate to use when calculating a
-	// validator period with duration 0.
-	minConsumptionRate = new(big.Int).SetUint64(MinConsumptionRate)
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 
-	// consumptionRateDenominator is the magnitude offset used to emulate
-	// floating point fractions.
-	consumptionRateDenominator = new(big.Int).SetUint64(PercentDenominator)
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
-// reward returns the amount of tokens to reward the staker with.
+// consumptionRateDenominator is the magnitude offset used to emulate floating
+// point fractions.
+var consumptionRateDenominator = new(big.Int).SetUint64(PercentDenominator)
+
+// reward returns the amount of DJTX to reward a primary network staker with.
+func reward(
+	rawDuration time.Duration,
+	rawStakedAmount,
+	rawMaxExistingAmount uint64,
+	rawConsumptionInterval time.Duration,
+) uint64 {
+	return rewardWithConfig(
+		rawDuration,
+		rawStakedAmount,
+		rawMaxExistingAmount,
+		rawConsumptionInterval,
+		MinConsumptionRate,
+		MinConsumptionRate+MaxSubMinConsumptionRate,
+		SupplyCap,
+	)
+}
+
+// rewardWithConfig returns the amount of tokens to reward the staker with.
 //
 // RemainingSupply = SupplyCap - ExistingSupply
 // PortionOfExistingSupply = StakedAmount / ExistingSupply
 // PortionOfStakingDuration = StakingDuration / MaximumStakingDuration
 // MintingRate = MinMintingRate + MaxSubMinMintingRate * PortionOfStakingDuration
 // Reward = RemainingSupply * PortionOfExistingSupply * MintingRate * PortionOfStakingDuration
-func reward(
+//
+// [rawMinConsumptionRate] is the consumption rate to use when calculating a
+// validator period with duration 0. [rawMaxConsumptionRate] is the consumption
+// rate to use when calculating a validator period of [rawConsumptionInterval].
+func rewardWithConfig(
 	rawDuration time.Duration,
 	rawStakedAmount,
 	rawMaxExistingAmount uint64,
 	rawConsumptionInterval time.Duration,
+	rawMinConsumptionRate,
+	rawMaxConsumptionRate,
+	rawSupplyCap uint64,
 ) uint64 {
+	if rawMaxExistingAmount >= rawSupplyCap {
+		// There is nothing left to mint
+		return 0
+	}
+
 	duration := new(big.Int).SetUint64(uint64(rawDuration))
 	stakedAmount := new(big.Int).SetUint64(rawStakedAmount)
 	maxExistingAmount := new(big.Int).SetUint64(rawMaxExistingAmount)
 	consumptionInterval := new(big.Int).SetUint64(uint64(rawConsumptionInterval))
+	minConsumptionRate := new(big.Int).SetUint64(rawMinConsumptionRate)
+	maxSubMinConsumptionRate := new(big.Int).SetUint64(rawMaxConsumptionRate - rawMinConsumptionRate)
 
 	adjustedConsumptionRateNumerator := new(big.Int).Mul(maxSubMinConsumptionRate, duration)
 	adjustedMinConsumptionRateNumerator := new(big.Int).Mul(minConsumptionRate, consumptionInterval)
 	adjustedConsumptionRateNumerator.Add(adjustedConsumptionRateNumerator, adjustedMinConsumptionRateNumerator)
 	adjustedConsumptionRateDenominator := new(big.Int).Mul(consumptionInterval, consumptionRateDenominator)
 
-	reward := new(big.Int).SetUint64(SupplyCap - rawMaxExistingAmount)
+	reward := new(big.Int).SetUint64(rawSupplyCap - rawMaxExistingAmount)
 	reward.Mul(reward, adjustedConsumptionRateNumerator)
 	reward.Mul(reward, stakedAmount)
 	reward.Mul(reward, duration)
@@ -55,3 +82,41 @@ func reward(
 
 	return reward.Uint64()
 }
+
+// splitDelegatorReward splits [stakerReward] between a delegator and the
+// validator it delegated to, which takes [shares] of the reward as its
+// delegation fee.
+func splitDelegatorReward(stakerReward uint64, shares uint32) (delegatorReward uint64, delegateeReward uint64) {
+	delegatorShares := PercentDenominator - uint64(shares)                  // shares <= PercentDenominator so no underflow
+	delegatorReward = delegatorShares * (stakerReward / PercentDenominator) // delegatorShares <= PercentDenominator so no overflow
+	// Delay rounding as long as possible for small numbers
+	if optimisticReward, err := safemath.Mul64(delegatorShares, stakerReward); err == nil {
+		delegatorReward = optimisticReward / PercentDenominator
+	}
+	delegateeReward = stakerReward - delegatorReward // delegatorReward <= reward so no underflow
+	return delegatorReward, delegateeReward
+}
+
+// rewardRecord links a staker to the RewardValidatorTx that removed it from the
+// current staker set
+type rewardRecord struct {
+	StakerTxID ids.ID
+	RewardTxID ids.ID
+}
+
+// stakerRewardsOwner returns the owner of the rewards of [stakerTx], or nil if
+// [stakerTx] can't be rewarded
+func stakerRewardsOwner(stakerTx *Tx) verify.Verifiable {
+	switch utx := stakerTx.UnsignedTx.(type) {
+	case *UnsignedAddValidatorTx:
+		return utx.RewardsOwner
+	case *UnsignedAddDelegatorTx:
+		return utx.RewardsOwner
+	case *UnsignedAddPermissionlessValidatorTx:
+		return utx.RewardsOwner
+	case *UnsignedAddPermissionlessDelegatorTx:
+		return utx.RewardsOwner
+	default:
+		return nil
+	}
+}
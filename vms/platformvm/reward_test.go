@@ -119,3 +119,28 @@ func TestRewards(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitDelegatorReward(t *testing.T) {
+	tests := []struct {
+		stakerReward    uint64
+		shares          uint32
+		delegatorReward uint64
+		delegateeReward uint64
+	}{
+		{stakerReward: 1000, shares: 0, delegatorReward: 1000, delegateeReward: 0},
+		{stakerReward: 1000, shares: PercentDenominator, delegatorReward: 0, delegateeReward: 1000},
+		{stakerReward: 1000, shares: PercentDenominator / 4, delegatorReward: 750, delegateeReward: 250},
+		{stakerReward: 3, shares: PercentDenominator / 2, delegatorReward: 1, delegateeReward: 2},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d/%d", test.stakerReward, test.shares), func(t *testing.T) {
+			delegatorReward, delegateeReward := splitDelegatorReward(test.stakerReward, test.shares)
+			if delegatorReward != test.delegatorReward {
+				t.Fatalf("expected delegator reward %d but got %d", test.delegatorReward, delegatorReward)
+			}
+			if delegateeReward != test.delegateeReward {
+				t.Fatalf("expected delegatee reward %d but got %d", test.delegateeReward, delegateeReward)
+			}
+		})
+	}
+}
@@ -112,17 +112,36 @@ func (tx *UnsignedRewardValidatorTx) SemanticVerify(
 		return nil, nil, nil, nil, permError{err}
 	}
 
+	// If a renewable validator is rewarded, it starts its next staking period
+	// rather than leaving the current stakers.
+	renewal, txErr := vm.renewValidator(parentState, stakerTx, stakerReward)
+	if txErr != nil {
+		return nil, nil, nil, nil, txErr
+	}
+	committedStakers := newlyCurrentStakers
+	if renewal != nil {
+		committedStakers, err = currentStakers.RenewNextStaker(renewal.stakerTx, renewal.potentialReward)
+		if err != nil {
+			return nil, nil, nil, nil, permError{err}
+		}
+	}
+
 	pendingStakers := parentState.PendingStakerChainState()
-	onCommitState := newVersionedState(parentState, newlyCurrentStakers, pendingStakers)
+	onCommitState := newVersionedState(parentState, committedStakers, pendingStakers)
 	onAbortState := newVersionedState(parentState, newlyCurrentStakers, pendingStakers)
 
 	// If the reward is aborted, then the current supply should be decreased.
-	currentSupply := onAbortState.GetCurrentSupply()
-	newSupply, err := safemath.Sub64(currentSupply, stakerReward)
-	if err != nil {
-		return nil, nil, nil, nil, permError{err}
+	// The rewards of stakers on transformed subnets are instead removed from
+	// the supply of their subnet.
+	switch stakerTx.UnsignedTx.(type) {
+	case *UnsignedAddValidatorTx, *UnsignedAddDelegatorTx:
+		currentSupply := onAbortState.GetCurrentSupply()
+		newSupply, err := safemath.Sub64(currentSupply, stakerReward)
+		if err != nil {
+			return nil, nil, nil, nil, permError{err}
+		}
+		onAbortState.SetCurrentSupply(newSupply)
 	}
-	onAbortState.SetCurrentSupply(newSupply)
 
 	var (
 		nodeID    ids.ShortID
@@ -130,22 +149,32 @@ func (tx *UnsignedRewardValidatorTx) SemanticVerify(
 	)
 	switch uStakerTx := stakerTx.UnsignedTx.(type) {
 	case *UnsignedAddValidatorTx:
-		// Refund the stake here
-		for i, out := range uStakerTx.Stake {
-			utxo := &djtx.UTXO{
-				UTXOID: djtx.UTXOID{
-					TxID:        tx.TxID,
-					OutputIndex: uint32(len(uStakerTx.Outs) + i),
-				},
-				Asset: djtx.Asset{ID: vm.ctx.DJTXAssetID},
-				Out:   out.Output(),
+		if renewal == nil {
+			// Refund the stake here
+			for i, out := range uStakerTx.Stake {
+				utxo := &djtx.UTXO{
+					UTXOID: djtx.UTXOID{
+						TxID:        tx.TxID,
+						OutputIndex: uint32(len(uStakerTx.Outs) + i),
+					},
+					Asset: djtx.Asset{ID: vm.ctx.DJTXAssetID},
+					Out:   out.Output(),
+				}
+				onCommitState.AddUTXO(utxo)
+				onAbortState.AddUTXO(utxo)
 			}
-			onCommitState.AddUTXO(utxo)
-			onAbortState.AddUTXO(utxo)
+		} else {
+			// The stake is only refunded if the validator isn't renewed
+			refundStake(tx.TxID, len(uStakerTx.Outs), uStakerTx.Stake, onAbortState)
+
+			renewedID := renewal.stakerTx.ID()
+			onCommitState.AddTx(renewal.stakerTx, Committed)
+			onCommitState.SetValidatorRenewal(renewedID, renewal.registrationTx)
+			onCommitState.SetCurrentSupply(renewal.currentSupply)
 		}
 
 		// Provide the reward here
-		if stakerReward > 0 {
+		if stakerReward > 0 && (renewal == nil || !renewal.restakedReward) {
 			outIntf, err := vm.fx.CreateOutput(stakerReward, uStakerTx.RewardsOwner)
 			if err != nil {
 				return nil, nil, nil, nil, permError{
@@ -204,13 +233,7 @@ func (tx *UnsignedRewardValidatorTx) SemanticVerify(
 
 		// Calculate split of reward between delegator/delegatee
 		// The delegator gives stake to the validatee
-		delegatorShares := PercentDenominator - uint64(vdrTx.Shares)             // parentTx.Shares <= PercentDenominator so no underflow
-		delegatorReward := delegatorShares * (stakerReward / PercentDenominator) // delegatorShares <= PercentDenominator so no overflow
-		// Delay rounding as long as possible for small numbers
-		if optimisticReward, err := safemath.Mul64(delegatorShares, stakerReward); err == nil {
-			delegatorReward = optimisticReward / PercentDenominator
-		}
-		delegateeReward := stakerReward - delegatorReward // delegatorReward <= reward so no underflow
+		delegatorReward, delegateeReward := splitDelegatorReward(stakerReward, vdrTx.Shares)
 
 		offset := 0
 
@@ -266,6 +289,115 @@ func (tx *UnsignedRewardValidatorTx) SemanticVerify(
 			onCommitState.AddRewardUTXO(tx.TxID, utxo)
 		}
 
+		nodeID = uStakerTx.Validator.ID()
+		startTime = vdrTx.StartTime()
+	case *UnsignedAddPermissionlessValidatorTx:
+		transformSubnetTx, txErr := getSubnetTransformation(parentState, uStakerTx.Validator.Subnet)
+		if txErr != nil {
+			return nil, nil, nil, nil, txErr
+		}
+		if txErr := decreaseSubnetSupply(onAbortState, uStakerTx.Validator.Subnet, stakerReward); txErr != nil {
+			return nil, nil, nil, nil, txErr
+		}
+
+		// Refund the stake here
+		refundStake(tx.TxID, len(uStakerTx.Outs), uStakerTx.Stake, onCommitState, onAbortState)
+
+		// Provide the reward here
+		if stakerReward > 0 {
+			utxo, txErr := vm.newRewardUTXO(
+				tx.TxID,
+				len(uStakerTx.Outs)+len(uStakerTx.Stake),
+				transformSubnetTx.AssetID,
+				stakerReward,
+				uStakerTx.RewardsOwner,
+			)
+			if txErr != nil {
+				return nil, nil, nil, nil, txErr
+			}
+
+			onCommitState.AddUTXO(utxo)
+			onCommitState.AddRewardUTXO(tx.TxID, utxo)
+		}
+
+		nodeID = uStakerTx.Validator.ID()
+		startTime = uStakerTx.StartTime()
+	case *UnsignedAddPermissionlessDelegatorTx:
+		transformSubnetTx, txErr := getSubnetTransformation(parentState, uStakerTx.Validator.Subnet)
+		if txErr != nil {
+			return nil, nil, nil, nil, txErr
+		}
+		if txErr := decreaseSubnetSupply(onAbortState, uStakerTx.Validator.Subnet, stakerReward); txErr != nil {
+			return nil, nil, nil, nil, txErr
+		}
+
+		// Refund the stake here
+		refundStake(tx.TxID, len(uStakerTx.Outs), uStakerTx.Stake, onCommitState, onAbortState)
+
+		// We're removing a delegator, so we need to fetch the validator they
+		// are delgated to.
+		vdr, err := currentStakers.GetValidator(uStakerTx.Validator.NodeID)
+		if err != nil {
+			return nil, nil, nil, nil, tempError{
+				fmt.Errorf(
+					"failed to get whether %s is a validator: %w",
+					uStakerTx.Validator.NodeID,
+					err,
+				),
+			}
+		}
+		vdrTx, exists := vdr.PermissionlessValidators()[uStakerTx.Validator.Subnet]
+		if !exists {
+			return nil, nil, nil, nil, tempError{
+				fmt.Errorf(
+					"%s isn't validating subnet %s",
+					uStakerTx.Validator.NodeID,
+					uStakerTx.Validator.Subnet,
+				),
+			}
+		}
+
+		// Calculate split of reward between delegator/delegatee
+		delegatorReward, delegateeReward := splitDelegatorReward(stakerReward, vdrTx.Shares)
+
+		offset := 0
+
+		// Reward the delegator here
+		if delegatorReward > 0 {
+			utxo, txErr := vm.newRewardUTXO(
+				tx.TxID,
+				len(uStakerTx.Outs)+len(uStakerTx.Stake),
+				transformSubnetTx.AssetID,
+				delegatorReward,
+				uStakerTx.RewardsOwner,
+			)
+			if txErr != nil {
+				return nil, nil, nil, nil, txErr
+			}
+
+			onCommitState.AddUTXO(utxo)
+			onCommitState.AddRewardUTXO(tx.TxID, utxo)
+
+			offset++
+		}
+
+		// Reward the delegatee here
+		if delegateeReward > 0 {
+			utxo, txErr := vm.newRewardUTXO(
+				tx.TxID,
+				len(uStakerTx.Outs)+len(uStakerTx.Stake)+offset,
+				transformSubnetTx.AssetID,
+				delegateeReward,
+				vdrTx.RewardsOwner,
+			)
+			if txErr != nil {
+				return nil, nil, nil, nil, txErr
+			}
+
+			onCommitState.AddUTXO(utxo)
+			onCommitState.AddRewardUTXO(tx.TxID, utxo)
+		}
+
 		nodeID = uStakerTx.Validator.ID()
 		startTime = vdrTx.StartTime()
 	default:
@@ -306,3 +438,64 @@ func (vm *VM) newRewardValidatorTx(txID ids.ID) (*Tx, error) {
 	}}
 	return tx, tx.Sign(vm.codec, nil)
 }
+
+// refundStake adds the [stake] of the staker added by [txID] back to the UTXO
+// sets of [states]. The first stake output has index [offset].
+func refundStake(txID ids.ID, offset int, stake []*djtx.TransferableOutput, states ...UTXOAdder) {
+	for i, out := range stake {
+		utxo := &djtx.UTXO{
+			UTXOID: djtx.UTXOID{
+				TxID:        txID,
+				OutputIndex: uint32(offset + i),
+			},
+			Asset: out.Asset,
+			Out:   out.Output(),
+		}
+		for _, state := range states {
+			state.AddUTXO(utxo)
+		}
+	}
+}
+
+// newRewardUTXO returns a UTXO that pays [amount] of [assetID] to [owner]
+func (vm *VM) newRewardUTXO(
+	txID ids.ID,
+	outputIndex int,
+	assetID ids.ID,
+	amount uint64,
+	owner verify.Verifiable,
+) (*djtx.UTXO, TxError) {
+	outIntf, err := vm.fx.CreateOutput(amount, owner)
+	if err != nil {
+		return nil, permError{
+			fmt.Errorf("failed to create output: %w", err),
+		}
+	}
+	out, ok := outIntf.(verify.State)
+	if !ok {
+		return nil, permError{errInvalidState}
+	}
+	return &djtx.UTXO{
+		UTXOID: djtx.UTXOID{
+			TxID:        txID,
+			OutputIndex: uint32(outputIndex),
+		},
+		Asset: djtx.Asset{ID: assetID},
+		Out:   out,
+	}, nil
+}
+
+// decreaseSubnetSupply removes the unpaid [reward] from the supply of the
+// transformed subnet [subnetID]
+func decreaseSubnetSupply(vs MutableState, subnetID ids.ID, reward uint64) TxError {
+	currentSupply, err := vs.GetSubnetCurrentSupply(subnetID)
+	if err != nil {
+		return tempError{err}
+	}
+	newSupply, err := safemath.Sub64(currentSupply, reward)
+	if err != nil {
+		return permError{err}
+	}
+	vs.SetSubnetCurrentSupply(subnetID, newSupply)
+	return nil
+}
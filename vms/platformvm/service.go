@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
@@ -45,12 +47,17 @@ var (
 	errNoSubnetID            = errors.New("argument 'subnetID' not provided")
 	errNoRewardAddress       = errors.New("argument 'rewardAddress' not provided")
 	errInvalidDelegationRate = errors.New("argument 'delegationFeeRate' must be between 0 and 100, inclusive")
+	errInvalidDelegationFee  = errors.New("argument 'delegationFee' must be between 0 and 100, inclusive")
+	errNoStakeAmount         = errors.New("argument 'stakeAmount' must be positive")
 	errNoAddresses           = errors.New("no addresses provided")
 	errNoKeys                = errors.New("user has no keys or funds")
 	errNoPrimaryValidators   = errors.New("no default subnet validators")
 	errCorruptedReason       = errors.New("tx validity corrupted")
 	errStartTimeTooSoon      = fmt.Errorf("start time must be at least %s in the future", minAddStakerDelay)
 	errStartTimeTooLate      = errors.New("start time is too far in the future")
+	errNoChangeAddress       = errors.New("argument 'changeAddr' not provided")
+	errNoTxDescribed         = errors.New("no tx was described")
+	errMultipleTxsDescribed  = errors.New("more than one tx was described")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -208,6 +215,11 @@ func (service *Service) GetBalance(_ *http.Request, args *api.JSONAddress, respo
 
 utxoFor:
 	for _, utxo := range utxos {
+		if utxo.AssetID() != service.vm.ctx.DJTXAssetID {
+			// The balance is denominated in DJTX. Other assets, such as the
+			// staking assets of transformed subnets, are ignored.
+			continue
+		}
 		switch out := utxo.Out.(type) {
 		case *secp256k1fx.TransferOutput:
 			if out.Locktime <= currentTime {
@@ -513,8 +525,15 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 
 		response.Subnets = make([]APISubnet, len(subnets)+1)
 		for i, subnet := range subnets {
-			unsignedTx := subnet.UnsignedTx.(*UnsignedCreateSubnetTx)
-			owner := unsignedTx.Owner.(*secp256k1fx.OutputOwners)
+			subnetID := subnet.ID()
+			subnetOwner, err := service.vm.internalState.GetSubnetOwner(subnetID)
+			if err != nil {
+				return fmt.Errorf("couldn't get owner of subnet %s: %w", subnetID, err)
+			}
+			owner, ok := subnetOwner.(*secp256k1fx.OutputOwners)
+			if !ok {
+				return errUnknownOwners
+			}
 			controlAddrs := []string{}
 			for _, controlKeyID := range owner.Addrs {
 				addr, err := service.vm.FormatLocalAddress(controlKeyID)
@@ -524,7 +543,7 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 				controlAddrs = append(controlAddrs, addr)
 			}
 			response.Subnets[i] = APISubnet{
-				ID:          subnet.ID(),
+				ID:          subnetID,
 				ControlKeys: controlAddrs,
 				Threshold:   json.Uint32(owner.Threshold),
 			}
@@ -564,11 +583,14 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 			return err
 		}
 
-		subnet, ok := subnetTx.UnsignedTx.(*UnsignedCreateSubnetTx)
-		if !ok {
+		if _, ok := subnetTx.UnsignedTx.(*UnsignedCreateSubnetTx); !ok {
 			return errWrongTxType
 		}
-		owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+		subnetOwner, err := service.vm.internalState.GetSubnetOwner(subnetID)
+		if err != nil {
+			return fmt.Errorf("couldn't get owner of subnet %s: %w", subnetID, err)
+		}
+		owner, ok := subnetOwner.(*secp256k1fx.OutputOwners)
 		if !ok {
 			return errUnknownOwners
 		}
@@ -584,7 +606,7 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 
 		response.Subnets = append(response.Subnets,
 			APISubnet{
-				ID:          subnet.ID(),
+				ID:          subnetID,
 				ControlKeys: controlAddrs,
 				Threshold:   json.Uint32(owner.Threshold),
 			},
@@ -608,12 +630,18 @@ type GetStakingAssetIDResponse struct {
 func (service *Service) GetStakingAssetID(_ *http.Request, args *GetStakingAssetIDArgs, response *GetStakingAssetIDResponse) error {
 	service.vm.ctx.Log.Debug("Platform: GetStakingAssetID called")
 
-	if args.SubnetID != constants.PrimaryNetworkID {
+	if args.SubnetID == constants.PrimaryNetworkID {
+		response.AssetID = service.vm.ctx.DJTXAssetID
+		return nil
+	}
+
+	transformSubnetTx, txErr := getSubnetTransformation(service.vm.internalState, args.SubnetID)
+	if txErr != nil {
 		return fmt.Errorf("Subnet %s doesn't have a valid staking token",
 			args.SubnetID)
 	}
 
-	response.AssetID = service.vm.ctx.DJTXAssetID
+	response.AssetID = transformSubnetTx.AssetID
 	return nil
 }
 
@@ -775,6 +803,73 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 				EndTime:   json.Uint64(staker.EndTime().Unix()),
 				Weight:    &weight,
 			})
+		case *UnsignedAddPermissionlessDelegatorTx:
+			if args.SubnetID != staker.Validator.Subnet {
+				continue
+			}
+			if !includeAllNodes && !nodeIDs.Contains(staker.Validator.ID()) {
+				continue
+			}
+
+			rewardOwner, err := service.getAPIOwner(staker.RewardsOwner)
+			if err != nil {
+				return err
+			}
+
+			weight := json.Uint64(staker.Validator.Weight())
+			potentialReward := json.Uint64(reward)
+			delegator := APIPrimaryDelegator{
+				APIStaker: APIStaker{
+					TxID:        tx.ID(),
+					StartTime:   json.Uint64(staker.StartTime().Unix()),
+					EndTime:     json.Uint64(staker.EndTime().Unix()),
+					StakeAmount: &weight,
+					NodeID:      staker.Validator.ID().PrefixedString(constants.NodeIDPrefix),
+				},
+				RewardOwner:     rewardOwner,
+				PotentialReward: &potentialReward,
+			}
+			vdrToDelegators[delegator.NodeID] = append(vdrToDelegators[delegator.NodeID], delegator)
+		case *UnsignedAddPermissionlessValidatorTx:
+			if args.SubnetID != staker.Validator.Subnet {
+				continue
+			}
+			if !includeAllNodes && !nodeIDs.Contains(staker.Validator.ID()) {
+				continue
+			}
+
+			nodeID := staker.Validator.ID()
+			startTime := staker.StartTime()
+			weight := json.Uint64(staker.Validator.Weight())
+			potentialReward := json.Uint64(reward)
+			delegationFee := json.Float32(100 * float32(staker.Shares) / float32(PercentDenominator))
+			rawUptime, err := service.vm.CalculateUptimePercent(nodeID, startTime)
+			if err != nil {
+				return err
+			}
+			uptime := json.Float32(rawUptime)
+
+			connected := service.vm.IsConnected(nodeID)
+
+			rewardOwner, err := service.getAPIOwner(staker.RewardsOwner)
+			if err != nil {
+				return err
+			}
+
+			reply.Validators = append(reply.Validators, APIPrimaryValidator{
+				APIStaker: APIStaker{
+					TxID:        tx.ID(),
+					NodeID:      nodeID.PrefixedString(constants.NodeIDPrefix),
+					StartTime:   json.Uint64(startTime.Unix()),
+					EndTime:     json.Uint64(staker.EndTime().Unix()),
+					StakeAmount: &weight,
+				},
+				Uptime:          &uptime,
+				Connected:       &connected,
+				PotentialReward: &potentialReward,
+				RewardOwner:     rewardOwner,
+				DelegationFee:   delegationFee,
+			})
 		default:
 			return fmt.Errorf("expected validator but got %T", tx.UnsignedTx)
 		}
@@ -794,6 +889,135 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 	return nil
 }
 
+// GetObservedUptimesArgs are the arguments for calling GetObservedUptimes
+type GetObservedUptimesArgs struct {
+	// NodeIDs of validators to request. If [NodeIDs] is empty, it fetches all
+	// current validators that can be rewarded. If some nodeIDs are not
+	// currently validators, they will be omitted from the response.
+	NodeIDs []string `json:"nodeIDs"`
+}
+
+// APIObservedUptime is the uptime of a validator that can be rewarded, as
+// observed by this node
+type APIObservedUptime struct {
+	NodeID string `json:"nodeID"`
+	// Primary network, or the transformed subnet the validator validates
+	SubnetID  ids.ID      `json:"subnetID"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	// Number of seconds this node has observed the validator to be online
+	// since it started validating
+	UpDuration json.Uint64 `json:"upDuration"`
+	// Unix time at which [UpDuration] was calculated
+	LastUpdated json.Uint64 `json:"lastUpdated"`
+	// True iff the validator is currently connected to this node
+	Connected bool `json:"connected"`
+	// Fraction of the validation period so far that the validator was online
+	Uptime json.Float32 `json:"uptime"`
+	// Fraction of the validation period that the validator would be online
+	// if it stays connected until its end time
+	MaxPossibleUptime json.Float32 `json:"maxPossibleUptime"`
+	// Minimum uptime this node requires to vote for rewarding the validator
+	RequiredUptime json.Float32 `json:"requiredUptime"`
+	// True iff this node would vote to reward the validator if its
+	// validation period ended now
+	EligibleForReward bool `json:"eligibleForReward"`
+	// True iff this node can still vote to reward the validator at the end of
+	// its validation period
+	CanBeRewarded bool `json:"canBeRewarded"`
+}
+
+// GetObservedUptimesReply is the response from calling GetObservedUptimes
+type GetObservedUptimesReply struct {
+	Uptimes []APIObservedUptime `json:"uptimes"`
+}
+
+// GetObservedUptimes returns the uptimes this node has observed for the
+// current validators that can be rewarded, and whether this node would vote to
+// reward them. These are the primary network validators, including renewable
+// validators during their current staking period, and the validators of
+// transformed subnets. A node validating several of these has an entry for
+// each of them.
+func (service *Service) GetObservedUptimes(_ *http.Request, args *GetObservedUptimesArgs, reply *GetObservedUptimesReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetObservedUptimes called")
+
+	nodeIDs := ids.ShortSet{}
+	for _, nodeID := range args.NodeIDs {
+		nID, err := ids.ShortFromPrefixedString(nodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return err
+		}
+		nodeIDs.Add(nID)
+	}
+	includeAllNodes := nodeIDs.Len() == 0
+
+	reply.Uptimes = []APIObservedUptime{}
+	currentValidators := service.vm.internalState.CurrentStakerChainState()
+	for _, tx := range currentValidators.Stakers() { // Iterates in order of increasing stop time
+		var (
+			nodeID    ids.ShortID
+			subnetID  ids.ID
+			startTime time.Time
+			endTime   time.Time
+		)
+		// Renewable validators are current stakers through the
+		// AddValidatorTx of their current staking period.
+		switch staker := tx.UnsignedTx.(type) {
+		case *UnsignedAddValidatorTx:
+			nodeID = staker.Validator.ID()
+			subnetID = constants.PrimaryNetworkID
+			startTime = staker.StartTime()
+			endTime = staker.EndTime()
+		case *UnsignedAddPermissionlessValidatorTx:
+			nodeID = staker.Validator.ID()
+			subnetID = staker.Validator.Subnet
+			startTime = staker.StartTime()
+			endTime = staker.EndTime()
+		default:
+			// Delegators share the uptime of their validator, and permissioned
+			// subnet validators aren't rewarded.
+			continue
+		}
+		if !includeAllNodes && !nodeIDs.Contains(nodeID) {
+			continue
+		}
+
+		upDuration, lastUpdated, err := service.vm.CalculateUptime(nodeID)
+		if err != nil {
+			return fmt.Errorf("couldn't calculate uptime of %s: %w", nodeID, err)
+		}
+
+		uptime := float64(1)
+		if elapsed := lastUpdated.Sub(startTime); elapsed > 0 {
+			uptime = float64(upDuration) / float64(elapsed)
+		}
+		maxPossibleUptime := float64(1)
+		if period := endTime.Sub(startTime); period > 0 {
+			maxUpDuration := upDuration
+			if remaining := endTime.Sub(lastUpdated); remaining > 0 {
+				maxUpDuration += remaining
+			}
+			maxPossibleUptime = float64(maxUpDuration) / float64(period)
+		}
+
+		reply.Uptimes = append(reply.Uptimes, APIObservedUptime{
+			NodeID:            nodeID.PrefixedString(constants.NodeIDPrefix),
+			SubnetID:          subnetID,
+			StartTime:         json.Uint64(startTime.Unix()),
+			EndTime:           json.Uint64(endTime.Unix()),
+			UpDuration:        json.Uint64(upDuration / time.Second),
+			LastUpdated:       json.Uint64(lastUpdated.Unix()),
+			Connected:         service.vm.IsConnected(nodeID),
+			Uptime:            json.Float32(uptime),
+			MaxPossibleUptime: json.Float32(maxPossibleUptime),
+			RequiredUptime:    json.Float32(service.vm.UptimePercentage),
+			EligibleForReward: uptime >= service.vm.UptimePercentage,
+			CanBeRewarded:     maxPossibleUptime >= service.vm.UptimePercentage,
+		})
+	}
+	return nil
+}
+
 // GetPendingValidatorsArgs are the arguments for calling GetPendingValidators
 type GetPendingValidatorsArgs struct {
 	// Subnet we're getting the pending validators of
@@ -891,6 +1115,46 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 				EndTime:   json.Uint64(staker.EndTime().Unix()),
 				Weight:    &weight,
 			})
+		case *UnsignedAddPermissionlessDelegatorTx:
+			if args.SubnetID != staker.Validator.Subnet {
+				continue
+			}
+			if !includeAllNodes && !nodeIDs.Contains(staker.Validator.ID()) {
+				continue
+			}
+
+			weight := json.Uint64(staker.Validator.Weight())
+			reply.Delegators = append(reply.Delegators, APIStaker{
+				TxID:        tx.ID(),
+				NodeID:      staker.Validator.ID().PrefixedString(constants.NodeIDPrefix),
+				StartTime:   json.Uint64(staker.StartTime().Unix()),
+				EndTime:     json.Uint64(staker.EndTime().Unix()),
+				StakeAmount: &weight,
+			})
+		case *UnsignedAddPermissionlessValidatorTx:
+			if args.SubnetID != staker.Validator.Subnet {
+				continue
+			}
+			if !includeAllNodes && !nodeIDs.Contains(staker.Validator.ID()) {
+				continue
+			}
+
+			nodeID := staker.Validator.ID()
+			weight := json.Uint64(staker.Validator.Weight())
+			delegationFee := json.Float32(100 * float32(staker.Shares) / float32(PercentDenominator))
+
+			connected := service.vm.IsConnected(nodeID)
+			reply.Validators = append(reply.Validators, APIPrimaryValidator{
+				APIStaker: APIStaker{
+					TxID:        tx.ID(),
+					NodeID:      nodeID.PrefixedString(constants.NodeIDPrefix),
+					StartTime:   json.Uint64(staker.StartTime().Unix()),
+					EndTime:     json.Uint64(staker.EndTime().Unix()),
+					StakeAmount: &weight,
+				},
+				DelegationFee: delegationFee,
+				Connected:     &connected,
+			})
 		default:
 			return fmt.Errorf("expected validator but got %T", tx.UnsignedTx)
 		}
@@ -898,6 +1162,27 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 	return nil
 }
 
+// getAPIOwner returns the API representation of [owner], or nil if [owner]
+// isn't a set of secp256k1fx output owners
+func (service *Service) getAPIOwner(owner verify.Verifiable) (*APIOwner, error) {
+	outputOwners, ok := owner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, nil
+	}
+	apiOwner := &APIOwner{
+		Locktime:  json.Uint64(outputOwners.Locktime),
+		Threshold: json.Uint32(outputOwners.Threshold),
+	}
+	for _, addr := range outputOwners.Addrs {
+		addrStr, err := service.vm.FormatLocalAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		apiOwner.Addresses = append(apiOwner.Addresses, addrStr)
+	}
+	return apiOwner, nil
+}
+
 // GetCurrentSupplyReply are the results from calling GetCurrentSupply
 type GetCurrentSupplyReply struct {
 	Supply json.Uint64 `json:"supply"`
@@ -970,6 +1255,11 @@ type AddValidatorArgs struct {
 	// The address the staking reward, if applicable, will go to
 	RewardAddress     string       `json:"rewardAddress"`
 	DelegationFeeRate json.Float32 `json:"delegationFeeRate"`
+	// Number of times the staking period is renewed after it ends. If
+	// non-zero, the validator's stake rolls into each renewal.
+	Renewals json.Uint32 `json:"renewals,omitempty"`
+	// If true, the staking rewards are added to the stake on each renewal
+	RestakeRewards bool `json:"restakeRewards,omitempty"`
 }
 
 // AddValidator creates and signs and issues a transaction to add a
@@ -1065,16 +1355,32 @@ func (service *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, re
 	}
 
 	// Create the transaction
-	tx, err := service.vm.newAddValidatorTx(
-		args.weight(),                        // Stake amount
-		uint64(args.StartTime),               // Start time
-		uint64(args.EndTime),                 // End time
-		nodeID,                               // Node ID
-		rewardAddress,                        // Reward Address
-		uint32(10000*args.DelegationFeeRate), // Shares
-		filteredPrivKeys,                     // Private keys
-		changeAddr,                           // Change address
-	)
+	var tx *Tx
+	if args.Renewals == 0 {
+		tx, err = service.vm.newAddValidatorTx(
+			args.weight(),                        // Stake amount
+			uint64(args.StartTime),               // Start time
+			uint64(args.EndTime),                 // End time
+			nodeID,                               // Node ID
+			rewardAddress,                        // Reward Address
+			uint32(10000*args.DelegationFeeRate), // Shares
+			filteredPrivKeys,                     // Private keys
+			changeAddr,                           // Change address
+		)
+	} else {
+		tx, err = service.vm.newAddRenewableValidatorTx(
+			args.weight(),                        // Stake amount
+			uint64(args.StartTime),               // Start time
+			uint64(args.EndTime),                 // End time of the first staking period
+			nodeID,                               // Node ID
+			rewardAddress,                        // Reward Address
+			uint32(10000*args.DelegationFeeRate), // Shares
+			uint32(args.Renewals),                // Renewals
+			args.RestakeRewards,                  // Restake rewards
+			filteredPrivKeys,                     // Private keys
+			changeAddr,                           // Change address
+		)
+	}
 	if err != nil {
 		return fmt.Errorf("couldn't create tx: %w", err)
 	}
@@ -1430,35 +1736,41 @@ func (service *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, re
 	return errs.Err
 }
 
-// ExportDJTXArgs are the arguments to ExportDJTX
-type ExportDJTXArgs struct {
+// RemoveSubnetValidatorArgs are the arguments to RemoveSubnetValidator
+type RemoveSubnetValidatorArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
-
-	// Amount of DJTX to send
-	Amount json.Uint64 `json:"amount"`
-
-	// ID of the address that will receive the DJTX. This address includes the
-	// chainID, which is used to determine what the destination chain is.
-	To string `json:"to"`
+	// Node ID of the validator to remove
+	NodeID string `json:"nodeID"`
+	// ID of the subnet the validator is removed from
+	SubnetID string `json:"subnetID"`
 }
 
-// ExportDJTX exports DJTX from the P-Chain to the X-Chain
-// It must be imported on the X-Chain to complete the transfer
-func (service *Service) ExportDJTX(_ *http.Request, args *ExportDJTXArgs, response *api.JSONTxIDChangeAddr) error {
-	service.vm.ctx.Log.Debug("Platform: ExportDJTX called")
+// RemoveSubnetValidator creates and signs and issues a transaction to remove a
+// validator from a subnet other than the primary network before its end time
+func (service *Service) RemoveSubnetValidator(_ *http.Request, args *RemoveSubnetValidatorArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: RemoveSubnetValidator called")
 
-	if args.Amount == 0 {
-		return errors.New("argument 'amount' must be > 0")
+	if args.SubnetID == "" {
+		return errNoSubnetID
 	}
 
-	// Parse the to address
-	chainID, to, err := service.vm.ParseAddress(args.To)
+	// Parse the node ID
+	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
 	if err != nil {
-		return err
+		return fmt.Errorf("error parsing nodeID: %q: %w", args.NodeID, err)
 	}
 
-	// Get this user's data
+	// Parse the subnet ID
+	subnetID, err := ids.FromString(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("problem parsing subnetID %q: %w", args.SubnetID, err)
+	}
+	if subnetID == constants.PrimaryNetworkID {
+		return errRemovePrimaryNetworkValidator
+	}
+
+	// Get the keys controlled by the user
 	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
 	if err != nil {
 		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
@@ -1466,17 +1778,16 @@ func (service *Service) ExportDJTX(_ *http.Request, args *ExportDJTXArgs, respon
 	defer db.Close()
 
 	user := user{db: db}
-	privKeys, err := user.getKeys()
+	keys, err := user.getKeys()
 	if err != nil {
 		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
 	}
 
-	// Parse the change address. Assumes that if the user has no keys,
-	// this operation will fail so the change address can be anything.
-	if len(privKeys) == 0 {
+	// Parse the change address.
+	if len(keys) == 0 {
 		return errNoKeys
 	}
-	changeAddr := privKeys[0].PublicKey().Address() // By default, use a key controlled by the user
+	changeAddr := keys[0].PublicKey().Address() // By default, use a key controlled by the user
 	if args.ChangeAddr != "" {
 		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
 		if err != nil {
@@ -1497,9 +1808,9 @@ func (service *Service) ExportDJTX(_ *http.Request, args *ExportDJTXArgs, respon
 	// If fromAddrs given, only use those addrs to pay fee
 	filteredPrivKeys := []*crypto.PrivateKeySECP256K1R{}
 	if fromAddrs.Len() == 0 {
-		filteredPrivKeys = privKeys
+		filteredPrivKeys = keys
 	} else {
-		for _, key := range privKeys {
+		for _, key := range keys {
 			if fromAddrs.Contains(key.PublicKey().Address()) {
 				filteredPrivKeys = append(filteredPrivKeys, key)
 			}
@@ -1507,12 +1818,11 @@ func (service *Service) ExportDJTX(_ *http.Request, args *ExportDJTXArgs, respon
 	}
 
 	// Create the transaction
-	tx, err := service.vm.newExportTx(
-		uint64(args.Amount), // Amount
-		chainID,             // ID of the chain to send the funds to
-		to,                  // Address
-		filteredPrivKeys,    // Private keys
-		changeAddr,          // Change address
+	tx, err := service.vm.newRemoveSubnetValidatorTx(
+		nodeID,           // Node ID
+		subnetID,         // Subnet ID
+		filteredPrivKeys, // Keys
+		changeAddr,       // Change address
 	)
 	if err != nil {
 		return fmt.Errorf("couldn't create tx: %w", err)
@@ -1530,46 +1840,681 @@ func (service *Service) ExportDJTX(_ *http.Request, args *ExportDJTXArgs, respon
 	return errs.Err
 }
 
-// ImportDJTXArgs are the arguments to ImportDJTX
-type ImportDJTXArgs struct {
+// TransferSubnetOwnershipArgs are the arguments to TransferSubnetOwnership
+type TransferSubnetOwnershipArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
-
-	// Chain the funds are coming from
-	SourceChain string `json:"sourceChain"`
-
-	// The address that will receive the imported funds
-	To string `json:"to"`
+	// ID of the subnet to transfer
+	SubnetID string `json:"subnetID"`
+	// The ID member of APISubnet is ignored. The control keys and threshold
+	// define the new owner of the subnet.
+	APISubnet
 }
 
-// ImportDJTX issues a transaction to import DJTX from the X-chain. The DJTX
-// must have already been exported from the X-Chain.
-func (service *Service) ImportDJTX(_ *http.Request, args *ImportDJTXArgs, response *api.JSONTxIDChangeAddr) error {
-	service.vm.ctx.Log.Debug("Platform: ImportDJTX called")
-
-	// Parse the sourceCHain
-	chainID, err := service.vm.ctx.BCLookup.Lookup(args.SourceChain)
-	if err != nil {
-		return fmt.Errorf("problem parsing chainID %q: %w", args.SourceChain, err)
-	}
+// TransferSubnetOwnership creates and signs and issues a transaction to replace
+// the control keys of a subnet
+func (service *Service) TransferSubnetOwnership(_ *http.Request, args *TransferSubnetOwnershipArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: TransferSubnetOwnership called")
 
-	// Parse the to address
-	to, err := service.vm.ParseLocalAddress(args.To)
-	if err != nil { // Parse address
-		return fmt.Errorf("couldn't parse argument 'to' to an address: %w", err)
+	if args.SubnetID == "" {
+		return errNoSubnetID
 	}
 
-	// Get the user's info
-	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	// Parse the subnet ID
+	subnetID, err := ids.FromString(args.SubnetID)
 	if err != nil {
-		return fmt.Errorf("couldn't get user %q: %w", args.Username, err)
+		return fmt.Errorf("problem parsing subnetID %q: %w", args.SubnetID, err)
 	}
-	defer db.Close()
-
-	user := user{db: db}
-	privKeys, err := user.getKeys()
-	if err != nil { // Get keys
-		return fmt.Errorf("couldn't get keys controlled by the user: %w", err)
+	if subnetID == constants.PrimaryNetworkID {
+		return errTransferPrimaryNetwork
+	}
+
+	// Parse the control keys
+	controlKeys := []ids.ShortID{}
+	for _, controlKey := range args.ControlKeys {
+		controlKeyID, err := service.vm.ParseLocalAddress(controlKey)
+		if err != nil {
+			return fmt.Errorf("problem parsing control key %q: %w", controlKey, err)
+		}
+		controlKeys = append(controlKeys, controlKeyID)
+	}
+
+	// Get the keys controlled by the user
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+	}
+	defer db.Close()
+
+	user := user{db: db}
+	privKeys, err := user.getKeys()
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address. Assumes that if the user has no keys,
+	// this operation will fail so the change address can be anything.
+	if len(privKeys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := privKeys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// If fromAddrs given, only use those addrs to pay fee
+	filteredPrivKeys := []*crypto.PrivateKeySECP256K1R{}
+	if fromAddrs.Len() == 0 {
+		filteredPrivKeys = privKeys
+	} else {
+		for _, key := range privKeys {
+			if fromAddrs.Contains(key.PublicKey().Address()) {
+				filteredPrivKeys = append(filteredPrivKeys, key)
+			}
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.newTransferSubnetOwnershipTx(
+		subnetID,               // Subnet ID
+		uint32(args.Threshold), // Threshold
+		controlKeys,            // Control Addresses
+		filteredPrivKeys,       // Private keys
+		changeAddr,             // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.mempool.IssueTx(tx),
+		db.Close(),
+	)
+	return errs.Err
+}
+
+// TransformSubnetArgs are the arguments to TransformSubnet
+type TransformSubnetArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// ID of the subnet to transform
+	SubnetID string `json:"subnetID"`
+	// ID of the asset that will be staked on the subnet
+	AssetID string `json:"assetID"`
+	// Amount of [AssetID] that is initially in circulation
+	InitialSupply json.Uint64 `json:"initialSupply"`
+	// Amount of [AssetID] that will be in circulation once all staking rewards
+	// have been minted
+	MaximumSupply json.Uint64 `json:"maximumSupply"`
+	// Reward consumption rates, times 1,000,000
+	MinConsumptionRate json.Uint64 `json:"minConsumptionRate"`
+	MaxConsumptionRate json.Uint64 `json:"maxConsumptionRate"`
+	// Bounds on the amount staked on a single validator
+	MinValidatorStake json.Uint64 `json:"minValidatorStake"`
+	MaxValidatorStake json.Uint64 `json:"maxValidatorStake"`
+	// Bounds on the staking period, in seconds
+	MinStakeDuration json.Uint32 `json:"minStakeDuration"`
+	MaxStakeDuration json.Uint32 `json:"maxStakeDuration"`
+	// Minimum delegation fee as a percentage
+	MinDelegationFeeRate json.Float32 `json:"minDelegationFeeRate"`
+	// Minimum amount a delegator can stake
+	MinDelegatorStake json.Uint64 `json:"minDelegatorStake"`
+}
+
+// TransformSubnet creates and signs and issues a transaction to make a subnet
+// validated by anyone who stakes the provided asset
+func (service *Service) TransformSubnet(_ *http.Request, args *TransformSubnetArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: TransformSubnet called")
+
+	switch {
+	case args.SubnetID == "":
+		return errNoSubnetID
+	case args.MinDelegationFeeRate < 0 || args.MinDelegationFeeRate > 100:
+		return errInvalidDelegationRate
+	}
+
+	// Parse the subnet ID
+	subnetID, err := ids.FromString(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("problem parsing subnetID %q: %w", args.SubnetID, err)
+	}
+	if subnetID == constants.PrimaryNetworkID {
+		return errTransformPrimaryNetwork
+	}
+
+	// Parse the asset ID
+	assetID, err := ids.FromString(args.AssetID)
+	if err != nil {
+		return fmt.Errorf("problem parsing assetID %q: %w", args.AssetID, err)
+	}
+
+	// Get the keys controlled by the user
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+	}
+	defer db.Close()
+
+	user := user{db: db}
+	privKeys, err := user.getKeys()
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// If fromAddrs given, only use those addrs to pay fee
+	filteredPrivKeys := []*crypto.PrivateKeySECP256K1R{}
+	if fromAddrs.Len() == 0 {
+		filteredPrivKeys = privKeys
+	} else {
+		for _, key := range privKeys {
+			if fromAddrs.Contains(key.PublicKey().Address()) {
+				filteredPrivKeys = append(filteredPrivKeys, key)
+			}
+		}
+	}
+
+	// Parse the change address.
+	if len(filteredPrivKeys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := filteredPrivKeys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.newTransformSubnetTx(
+		subnetID,                                         // Subnet ID
+		assetID,                                          // Staking asset ID
+		uint64(args.InitialSupply),                       // Initial supply
+		uint64(args.MaximumSupply),                       // Maximum supply
+		uint64(args.MinConsumptionRate),                  // Min consumption rate
+		uint64(args.MaxConsumptionRate),                  // Max consumption rate
+		uint64(args.MinValidatorStake),                   // Min validator stake
+		uint64(args.MaxValidatorStake),                   // Max validator stake
+		time.Duration(args.MinStakeDuration)*time.Second, // Min stake duration
+		time.Duration(args.MaxStakeDuration)*time.Second, // Max stake duration
+		uint32(10000*args.MinDelegationFeeRate),          // Min delegation fee
+		uint64(args.MinDelegatorStake),                   // Min delegator stake
+		filteredPrivKeys,                                 // Private keys
+		changeAddr,                                       // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.mempool.IssueTx(tx),
+		db.Close(),
+	)
+	return errs.Err
+}
+
+// AddPermissionlessValidatorArgs are the arguments to AddPermissionlessValidator
+type AddPermissionlessValidatorArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	APIStaker
+	// ID of the transformed subnet to validate
+	SubnetID string `json:"subnetID"`
+	// The address the staking reward, if applicable, will go to
+	RewardAddress     string       `json:"rewardAddress"`
+	DelegationFeeRate json.Float32 `json:"delegationFeeRate"`
+}
+
+// AddPermissionlessValidator creates and signs and issues a transaction to add
+// a validator to a transformed subnet
+func (service *Service) AddPermissionlessValidator(_ *http.Request, args *AddPermissionlessValidatorArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: AddPermissionlessValidator called")
+
+	now := service.vm.clock.Time()
+	minAddStakerTime := now.Add(minAddStakerDelay)
+	minAddStakerUnix := json.Uint64(minAddStakerTime.Unix())
+	maxAddStakerTime := now.Add(maxFutureStartTime)
+	maxAddStakerUnix := json.Uint64(maxAddStakerTime.Unix())
+
+	if args.StartTime == 0 {
+		args.StartTime = minAddStakerUnix
+	}
+
+	switch {
+	case args.SubnetID == "":
+		return errNoSubnetID
+	case args.RewardAddress == "":
+		return errNoRewardAddress
+	case args.StartTime < minAddStakerUnix:
+		return errStartTimeTooSoon
+	case args.StartTime > maxAddStakerUnix:
+		return errStartTimeTooLate
+	case args.DelegationFeeRate < 0 || args.DelegationFeeRate > 100:
+		return errInvalidDelegationRate
+	}
+
+	// Parse the subnet ID
+	subnetID, err := ids.FromString(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("problem parsing subnetID %q: %w", args.SubnetID, err)
+	}
+
+	// Parse the node ID
+	var nodeID ids.ShortID
+	if args.NodeID == "" {
+		nodeID = service.vm.ctx.NodeID // If omitted, use this node's ID
+	} else {
+		nID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return err
+		}
+		nodeID = nID
+	}
+
+	// Parse the reward address
+	rewardAddress, err := service.vm.ParseLocalAddress(args.RewardAddress)
+	if err != nil {
+		return fmt.Errorf("problem while parsing reward address: %w", err)
+	}
+
+	// Get the keys controlled by the user
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+	}
+	defer db.Close()
+
+	user := user{db: db}
+	privKeys, err := user.getKeys()
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// If fromAddrs given, only use those addrs to pay fee
+	filteredPrivKeys := []*crypto.PrivateKeySECP256K1R{}
+	if fromAddrs.Len() == 0 {
+		filteredPrivKeys = privKeys
+	} else {
+		for _, key := range privKeys {
+			if fromAddrs.Contains(key.PublicKey().Address()) {
+				filteredPrivKeys = append(filteredPrivKeys, key)
+			}
+		}
+	}
+
+	// Parse the change address.
+	if len(filteredPrivKeys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := filteredPrivKeys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.newAddPermissionlessValidatorTx(
+		args.weight(),                        // Stake amount
+		uint64(args.StartTime),               // Start time
+		uint64(args.EndTime),                 // End time
+		nodeID,                               // Node ID
+		subnetID,                             // Subnet ID
+		rewardAddress,                        // Reward Address
+		uint32(10000*args.DelegationFeeRate), // Shares
+		filteredPrivKeys,                     // Private keys
+		changeAddr,                           // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.mempool.IssueTx(tx),
+		db.Close(),
+	)
+	return errs.Err
+}
+
+// AddPermissionlessDelegatorArgs are the arguments to AddPermissionlessDelegator
+type AddPermissionlessDelegatorArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	APIStaker
+	// ID of the transformed subnet the node validates
+	SubnetID string `json:"subnetID"`
+	// The address the staking reward, if applicable, will go to
+	RewardAddress string `json:"rewardAddress"`
+}
+
+// AddPermissionlessDelegator creates and signs and issues a transaction to
+// delegate to a validator of a transformed subnet
+func (service *Service) AddPermissionlessDelegator(_ *http.Request, args *AddPermissionlessDelegatorArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: AddPermissionlessDelegator called")
+
+	now := service.vm.clock.Time()
+	minAddStakerTime := now.Add(minAddStakerDelay)
+	minAddStakerUnix := json.Uint64(minAddStakerTime.Unix())
+	maxAddStakerTime := now.Add(maxFutureStartTime)
+	maxAddStakerUnix := json.Uint64(maxAddStakerTime.Unix())
+
+	if args.StartTime == 0 {
+		args.StartTime = minAddStakerUnix
+	}
+
+	switch {
+	case args.SubnetID == "":
+		return errNoSubnetID
+	case args.RewardAddress == "":
+		return errNoRewardAddress
+	case args.StartTime < minAddStakerUnix:
+		return errStartTimeTooSoon
+	case args.StartTime > maxAddStakerUnix:
+		return errStartTimeTooLate
+	}
+
+	// Parse the subnet ID
+	subnetID, err := ids.FromString(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("problem parsing subnetID %q: %w", args.SubnetID, err)
+	}
+
+	// Parse the node ID
+	var nodeID ids.ShortID
+	if args.NodeID == "" {
+		nodeID = service.vm.ctx.NodeID // If omitted, use this node's ID
+	} else {
+		nID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return err
+		}
+		nodeID = nID
+	}
+
+	// Parse the reward address
+	rewardAddress, err := service.vm.ParseLocalAddress(args.RewardAddress)
+	if err != nil {
+		return fmt.Errorf("problem while parsing reward address: %w", err)
+	}
+
+	// Get the keys controlled by the user
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+	}
+	defer db.Close()
+
+	user := user{db: db}
+	privKeys, err := user.getKeys()
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// If fromAddrs given, only use those addrs to pay fee
+	filteredPrivKeys := []*crypto.PrivateKeySECP256K1R{}
+	if fromAddrs.Len() == 0 {
+		filteredPrivKeys = privKeys
+	} else {
+		for _, key := range privKeys {
+			if fromAddrs.Contains(key.PublicKey().Address()) {
+				filteredPrivKeys = append(filteredPrivKeys, key)
+			}
+		}
+	}
+
+	// Parse the change address.
+	if len(filteredPrivKeys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := filteredPrivKeys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.newAddPermissionlessDelegatorTx(
+		args.weight(),          // Stake amount
+		uint64(args.StartTime), // Start time
+		uint64(args.EndTime),   // End time
+		nodeID,                 // Node ID
+		subnetID,               // Subnet ID
+		rewardAddress,          // Reward Address
+		filteredPrivKeys,       // Private keys
+		changeAddr,             // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.mempool.IssueTx(tx),
+		db.Close(),
+	)
+	return errs.Err
+}
+
+// ExportDJTXArgs are the arguments to ExportDJTX
+type ExportDJTXArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+
+	// Amount of DJTX to send
+	Amount json.Uint64 `json:"amount"`
+
+	// ID of the address that will receive the DJTX. This address includes the
+	// chainID, which is used to determine what the destination chain is.
+	To string `json:"to"`
+
+	api.JSONMemo
+}
+
+// ExportDJTX exports DJTX from the P-Chain to the X-Chain
+// It must be imported on the X-Chain to complete the transfer
+func (service *Service) ExportDJTX(_ *http.Request, args *ExportDJTXArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: ExportDJTX called")
+
+	if args.Amount == 0 {
+		return errors.New("argument 'amount' must be > 0")
+	}
+
+	// Parse the to address
+	chainID, to, err := service.vm.ParseAddress(args.To)
+	if err != nil {
+		return err
+	}
+
+	// Validate the memo field
+	recipients := ids.NewShortSet(1)
+	recipients.Add(to)
+	memo, err := djtx.ParseMemo(args.Memo, args.MemoPublicKey, recipients)
+	if err != nil {
+		return err
+	}
+
+	// Get this user's data
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+	}
+	defer db.Close()
+
+	user := user{db: db}
+	privKeys, err := user.getKeys()
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address. Assumes that if the user has no keys,
+	// this operation will fail so the change address can be anything.
+	if len(privKeys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := privKeys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// If fromAddrs given, only use those addrs to pay fee
+	filteredPrivKeys := []*crypto.PrivateKeySECP256K1R{}
+	if fromAddrs.Len() == 0 {
+		filteredPrivKeys = privKeys
+	} else {
+		for _, key := range privKeys {
+			if fromAddrs.Contains(key.PublicKey().Address()) {
+				filteredPrivKeys = append(filteredPrivKeys, key)
+			}
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.newExportTxWithKeychain(
+		uint64(args.Amount),           // Amount
+		chainID,                       // ID of the chain to send the funds to
+		to,                            // Address
+		newKeychain(filteredPrivKeys), // Private keys
+		changeAddr,                    // Change address
+		memo,                          // Memo
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.mempool.IssueTx(tx),
+		db.Close(),
+	)
+	return errs.Err
+}
+
+// ImportDJTXArgs are the arguments to ImportDJTX
+type ImportDJTXArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+
+	// Chain the funds are coming from
+	SourceChain string `json:"sourceChain"`
+
+	// The address that will receive the imported funds
+	To string `json:"to"`
+
+	// Assets other than DJTX that are imported along with the DJTX. If empty,
+	// only DJTX is imported.
+	AssetIDs []ids.ID `json:"assetIDs"`
+}
+
+// ImportDJTX issues a transaction to import DJTX, and optionally other assets,
+// from the X-chain. The funds must have already been exported from the X-Chain.
+func (service *Service) ImportDJTX(_ *http.Request, args *ImportDJTXArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: ImportDJTX called")
+
+	// Parse the sourceCHain
+	chainID, err := service.vm.ctx.BCLookup.Lookup(args.SourceChain)
+	if err != nil {
+		return fmt.Errorf("problem parsing chainID %q: %w", args.SourceChain, err)
+	}
+
+	// Parse the to address
+	to, err := service.vm.ParseLocalAddress(args.To)
+	if err != nil { // Parse address
+		return fmt.Errorf("couldn't parse argument 'to' to an address: %w", err)
+	}
+
+	// Get the user's info
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("couldn't get user %q: %w", args.Username, err)
+	}
+	defer db.Close()
+
+	user := user{db: db}
+	privKeys, err := user.getKeys()
+	if err != nil { // Get keys
+		return fmt.Errorf("couldn't get keys controlled by the user: %w", err)
 	}
 
 	// Parse the change address. Assumes that if the user has no keys,
@@ -1607,7 +2552,9 @@ func (service *Service) ImportDJTX(_ *http.Request, args *ImportDJTXArgs, respon
 		}
 	}
 
-	tx, err := service.vm.newImportTx(chainID, to, filteredPrivKeys, changeAddr)
+	assetIDs := ids.NewSet(len(args.AssetIDs))
+	assetIDs.Add(args.AssetIDs...)
+	tx, err := service.vm.newImportAssetsTx(chainID, to, assetIDs, filteredPrivKeys, changeAddr)
 	if err != nil {
 		return err
 	}
@@ -2030,6 +2977,9 @@ func (service *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response
 	if _, err := service.vm.codec.Unmarshal(txBytes, tx); err != nil {
 		return fmt.Errorf("couldn't parse tx: %w", err)
 	}
+	if numMissing := numMissingSigs(tx); numMissing > 0 {
+		return fmt.Errorf("%w: %d signatures are missing", errMissingSignatures, numMissing)
+	}
 	if err := service.vm.mempool.IssueTx(tx); err != nil {
 		return fmt.Errorf("couldn't issue tx: %w", err)
 	}
@@ -2038,6 +2988,285 @@ func (service *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response
 	return nil
 }
 
+// SimulateTx verifies a tx against the state the preferred block would
+// produce, without issuing it
+func (service *Service) SimulateTx(_ *http.Request, args *api.FormattedTx, response *api.SimulateTxReply) error {
+	service.vm.ctx.Log.Debug("Platform: SimulateTx called")
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx := &Tx{}
+	if _, err := service.vm.codec.Unmarshal(txBytes, tx); err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+	return service.vm.simulateTx(tx, response)
+}
+
+// UnsignedExportDJTXArgs describes an exportTx to create with CreateUnsignedTx
+type UnsignedExportDJTXArgs struct {
+	// Amount of DJTX to send
+	Amount json.Uint64 `json:"amount"`
+
+	// ID of the address that will receive the DJTX. This address includes the
+	// chainID, which is used to determine what the destination chain is.
+	To string `json:"to"`
+}
+
+// UnsignedAddSubnetValidatorArgs describes an addSubnetValidatorTx to create
+// with CreateUnsignedTx
+type UnsignedAddSubnetValidatorArgs struct {
+	APIStaker
+	// ID of subnet to validate
+	SubnetID string `json:"subnetID"`
+}
+
+// CreateUnsignedTxArgs are the arguments to CreateUnsignedTx. Exactly one of
+// the txs must be described.
+type CreateUnsignedTxArgs struct {
+	// Addresses that fund the tx and, if needed, authorize it on behalf of a
+	// subnet. Their keys don't need to be held by this node.
+	api.JSONFromAddrs
+	// Address to send change to
+	api.JSONChangeAddr
+
+	ExportDJTX         *UnsignedExportDJTXArgs         `json:"exportDJTX"`
+	AddSubnetValidator *UnsignedAddSubnetValidatorArgs `json:"addSubnetValidator"`
+
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// CreateUnsignedTxReply is the response from CreateUnsignedTx
+type CreateUnsignedTxReply struct {
+	// Tx with all of its signatures left empty
+	api.FormattedTx
+	// Addresses that must sign each credential of the tx
+	Signers [][]string `json:"signers"`
+}
+
+// CreateUnsignedTx creates a tx that spends UTXOs, and uses subnet
+// authorizations, of the provided addresses without signing it. The returned
+// tx is signed by each party with SignTx and the results are combined with
+// CombineSignatures.
+func (service *Service) CreateUnsignedTx(_ *http.Request, args *CreateUnsignedTxArgs, response *CreateUnsignedTxReply) error {
+	service.vm.ctx.Log.Debug("Platform: CreateUnsignedTx called")
+
+	if len(args.From) == 0 {
+		return errNoAddresses
+	}
+	kc := secp256k1fx.NewKeychain()
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		kc.AddAddress(addr)
+	}
+
+	if args.ChangeAddr == "" {
+		return errNoChangeAddress
+	}
+	changeAddr, err := service.vm.ParseLocalAddress(args.ChangeAddr)
+	if err != nil {
+		return fmt.Errorf("couldn't parse changeAddr: %w", err)
+	}
+
+	var tx *Tx
+	switch {
+	case args.ExportDJTX != nil && args.AddSubnetValidator != nil:
+		return errMultipleTxsDescribed
+	case args.ExportDJTX != nil:
+		if args.ExportDJTX.Amount == 0 {
+			return errors.New("argument 'amount' must be > 0")
+		}
+
+		// Parse the to address
+		chainID, to, err := service.vm.ParseAddress(args.ExportDJTX.To)
+		if err != nil {
+			return err
+		}
+
+		tx, err = service.vm.newExportTxWithKeychain(
+			uint64(args.ExportDJTX.Amount), // Amount
+			chainID,                        // ID of the chain to send the funds to
+			to,                             // Address
+			kc,                             // Addresses
+			changeAddr,                     // Change address
+			nil,                            // Memo
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't create tx: %w", err)
+		}
+	case args.AddSubnetValidator != nil:
+		staker := args.AddSubnetValidator
+		now := service.vm.clock.Time()
+		minAddStakerUnix := json.Uint64(now.Add(minAddStakerDelay).Unix())
+		maxAddStakerUnix := json.Uint64(now.Add(maxFutureStartTime).Unix())
+
+		if staker.StartTime == 0 {
+			staker.StartTime = minAddStakerUnix
+		}
+
+		switch {
+		case staker.SubnetID == "":
+			return errNoSubnetID
+		case staker.StartTime < minAddStakerUnix:
+			return errStartTimeTooSoon
+		case staker.StartTime > maxAddStakerUnix:
+			return errStartTimeTooLate
+		}
+
+		// Parse the node ID
+		nodeID, err := ids.ShortFromPrefixedString(staker.NodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return fmt.Errorf("error parsing nodeID: %q: %w", staker.NodeID, err)
+		}
+
+		// Parse the subnet ID
+		subnetID, err := ids.FromString(staker.SubnetID)
+		if err != nil {
+			return fmt.Errorf("problem parsing subnetID %q: %w", staker.SubnetID, err)
+		}
+		if subnetID == constants.PrimaryNetworkID {
+			return errors.New("subnet validator attempts to validate primary network")
+		}
+
+		tx, err = service.vm.newAddSubnetValidatorTxWithKeychain(
+			staker.weight(),          // Stake amount
+			uint64(staker.StartTime), // Start time
+			uint64(staker.EndTime),   // End time
+			nodeID,                   // Node ID
+			subnetID,                 // Subnet ID
+			kc,                       // Addresses
+			changeAddr,               // Change address
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't create tx: %w", err)
+		}
+	default:
+		return errNoTxDescribed
+	}
+
+	signers, err := service.vm.credentialSigners(service.vm.internalState, tx.UnsignedTx)
+	if err != nil {
+		return fmt.Errorf("couldn't get signers of tx: %w", err)
+	}
+	response.Signers = make([][]string, len(signers))
+	for i, credSigners := range signers {
+		response.Signers[i] = make([]string, len(credSigners))
+		for j, signer := range credSigners {
+			response.Signers[i][j], err = service.vm.FormatLocalAddress(signer)
+			if err != nil {
+				return fmt.Errorf("couldn't format address %s: %w", signer, err)
+			}
+		}
+	}
+
+	response.Tx, err = formatting.Encode(args.Encoding, tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode tx as string: %w", err)
+	}
+	response.Encoding = args.Encoding
+	return nil
+}
+
+// SignTxArgs are the arguments to SignTx
+type SignTxArgs struct {
+	api.UserPass
+	// Partially signed tx
+	api.FormattedTx
+}
+
+// SignTxReply is the response from SignTx
+type SignTxReply struct {
+	api.FormattedTx
+	// Number of signatures added by the user
+	SignaturesAdded json.Uint32 `json:"signaturesAdded"`
+	// Number of signatures that are still missing from the tx
+	SignaturesMissing json.Uint32 `json:"signaturesMissing"`
+}
+
+// SignTx adds the signatures of a partially signed tx that can be produced by
+// the keys of the user
+func (service *Service) SignTx(_ *http.Request, args *SignTxArgs, response *SignTxReply) error {
+	service.vm.ctx.Log.Debug("Platform: SignTx called")
+
+	tx, err := service.vm.parseTx(args.Encoding, args.Tx)
+	if err != nil {
+		return err
+	}
+
+	// Get the keys controlled by the user
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+	}
+	defer db.Close()
+
+	user := user{db: db}
+	keys, err := user.getKeys()
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	numSigned, err := service.vm.signTx(tx, newKeychain(keys))
+	if err != nil {
+		return fmt.Errorf("couldn't sign tx: %w", err)
+	}
+
+	response.Tx, err = formatting.Encode(args.Encoding, tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode tx as string: %w", err)
+	}
+	response.Encoding = args.Encoding
+	response.SignaturesAdded = json.Uint32(numSigned)
+	response.SignaturesMissing = json.Uint32(numMissingSigs(tx))
+	return db.Close()
+}
+
+// CombineSignaturesArgs are the arguments to CombineSignatures
+type CombineSignaturesArgs struct {
+	// Partially signed versions of the same tx
+	Txs      []string            `json:"txs"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// CombineSignaturesReply is the response from CombineSignatures
+type CombineSignaturesReply struct {
+	api.FormattedTx
+	// Number of signatures that are still missing from the tx
+	SignaturesMissing json.Uint32 `json:"signaturesMissing"`
+}
+
+// CombineSignatures merges the signatures of partially signed versions of the
+// same tx
+func (service *Service) CombineSignatures(_ *http.Request, args *CombineSignaturesArgs, response *CombineSignaturesReply) error {
+	service.vm.ctx.Log.Debug("Platform: CombineSignatures called")
+
+	txs := make([]*Tx, len(args.Txs))
+	for i, txStr := range args.Txs {
+		tx, err := service.vm.parseTx(args.Encoding, txStr)
+		if err != nil {
+			return err
+		}
+		txs[i] = tx
+	}
+
+	tx, err := service.vm.combineTxs(txs)
+	if err != nil {
+		return fmt.Errorf("couldn't combine signatures: %w", err)
+	}
+
+	response.Tx, err = formatting.Encode(args.Encoding, tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode tx as string: %w", err)
+	}
+	response.Encoding = args.Encoding
+	response.SignaturesMissing = json.Uint32(numMissingSigs(tx))
+	return nil
+}
+
 // GetTx gets a tx
 func (service *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.FormattedTx) error {
 	service.vm.ctx.Log.Debug("Platform: GetTx called")
@@ -2142,6 +3371,46 @@ func (service *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, resp
 	return nil
 }
 
+// GetMempoolArgs are the arguments for calling GetMempool
+type GetMempoolArgs struct {
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetMempoolReply is the response from calling GetMempool
+type GetMempoolReply struct {
+	// IDs of the txs waiting to be put into a block
+	TxIDs []ids.ID `json:"txIDs"`
+	// String representation of the txs waiting to be put into a block
+	Txs []string `json:"txs"`
+	// Encoding of [Txs]
+	Encoding formatting.Encoding `json:"encoding"`
+	// Number of bytes of txs in the mempool
+	Bytes json.Uint64 `json:"bytes"`
+	// Maximum number of bytes of txs the mempool will hold
+	MaxBytes json.Uint64 `json:"maxBytes"`
+}
+
+// GetMempool returns the txs that are waiting to be put into a block
+func (service *Service) GetMempool(_ *http.Request, args *GetMempoolArgs, response *GetMempoolReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetMempool called")
+
+	txs := service.vm.mempool.Txs()
+	response.TxIDs = make([]ids.ID, len(txs))
+	response.Txs = make([]string, len(txs))
+	for i, tx := range txs {
+		response.TxIDs[i] = tx.ID()
+		txStr, err := formatting.Encode(args.Encoding, tx.Bytes())
+		if err != nil {
+			return fmt.Errorf("couldn't encode tx %s as a string: %w", tx.ID(), err)
+		}
+		response.Txs[i] = txStr
+	}
+	response.Encoding = args.Encoding
+	response.Bytes = json.Uint64(service.vm.mempool.Bytes())
+	response.MaxBytes = json.Uint64(service.vm.mempool.MaxBytes())
+	return nil
+}
+
 type GetStakeArgs struct {
 	api.JSONAddresses
 	Encoding formatting.Encoding `json:"encoding"`
@@ -2168,7 +3437,8 @@ func (service *Service) getStakeHelper(tx *Tx, addrs ids.ShortSet) (uint64, []dj
 		outs = staker.Stake
 	case *UnsignedAddValidatorTx:
 		outs = staker.Stake
-	case *UnsignedAddSubnetValidatorTx:
+	case *UnsignedAddSubnetValidatorTx, *UnsignedAddPermissionlessValidatorTx, *UnsignedAddPermissionlessDelegatorTx:
+		// These stakers don't stake DJTX
 		return 0, nil, nil
 	default:
 		err := fmt.Errorf("expected *UnsignedAddDelegatorTx, *UnsignedAddValidatorTx or *UnsignedAddSubnetValidatorTx but got %T", tx.UnsignedTx)
@@ -2394,3 +3664,211 @@ func (service *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, rep
 	reply.Encoding = args.Encoding
 	return nil
 }
+
+// EstimateRewardArgs are the arguments for calling EstimateReward
+type EstimateRewardArgs struct {
+	// Amount of nDJTX to stake
+	StakeAmount json.Uint64 `json:"stakeAmount"`
+	// Number of seconds to stake for
+	Duration json.Uint64 `json:"duration"`
+	// Percent of the reward that is paid to the validator as a delegation fee.
+	// Ignored if [NodeID] is provided.
+	DelegationFee json.Float32 `json:"delegationFee"`
+	// If provided, estimate the reward for delegating to this primary network
+	// validator, using the delegation fee it charges
+	NodeID string `json:"nodeID"`
+}
+
+// EstimateRewardReply is the response from calling EstimateReward
+type EstimateRewardReply struct {
+	// Total amount of nDJTX that would be minted as a reward
+	Reward json.Uint64 `json:"reward"`
+	// Amount of the reward that the staker would receive
+	StakerReward json.Uint64 `json:"stakerReward"`
+	// Amount of the reward that would be paid to the validator as a
+	// delegation fee
+	DelegationFee json.Uint64 `json:"delegationFee"`
+	// The current supply of nDJTX that the estimate is based on
+	CurrentSupply json.Uint64 `json:"currentSupply"`
+}
+
+// EstimateReward returns the reward that staking [args.StakeAmount] nDJTX on
+// the primary network for [args.Duration] would earn, if the staker was
+// sufficiently online. The estimate uses the current supply, so the actual
+// reward may differ if the supply changes before the staker starts staking.
+func (service *Service) EstimateReward(_ *http.Request, args *EstimateRewardArgs, reply *EstimateRewardReply) error {
+	service.vm.ctx.Log.Debug("Platform: EstimateReward called")
+
+	duration := time.Duration(args.Duration) * time.Second
+	switch {
+	case args.StakeAmount == 0:
+		return errNoStakeAmount
+	case duration < service.vm.MinStakeDuration:
+		return errStakeTooShort
+	case duration > service.vm.MaxStakeDuration:
+		return errStakeTooLong
+	case args.DelegationFee < 0 || args.DelegationFee > 100:
+		return errInvalidDelegationFee
+	}
+
+	shares := uint32(10000 * args.DelegationFee)
+	if args.NodeID != "" {
+		nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return fmt.Errorf("failed to parse nodeID %q due to: %w", args.NodeID, err)
+		}
+		vdrTx, err := service.vm.getPrimaryValidatorTx(nodeID)
+		if err != nil {
+			return fmt.Errorf("couldn't get validator %s: %w", args.NodeID, err)
+		}
+		shares = vdrTx.Shares
+	}
+
+	currentSupply := service.vm.internalState.GetCurrentSupply()
+	stakerReward := reward(
+		duration,
+		uint64(args.StakeAmount),
+		currentSupply,
+		service.vm.StakeMintingPeriod,
+	)
+	delegatorReward, delegationFee := splitDelegatorReward(stakerReward, shares)
+
+	reply.Reward = json.Uint64(stakerReward)
+	reply.StakerReward = json.Uint64(delegatorReward)
+	reply.DelegationFee = json.Uint64(delegationFee)
+	reply.CurrentSupply = json.Uint64(currentSupply)
+	return nil
+}
+
+// getPrimaryValidatorTx returns the tx that added [nodeID] as a current or
+// pending primary network validator
+func (vm *VM) getPrimaryValidatorTx(nodeID ids.ShortID) (*UnsignedAddValidatorTx, error) {
+	vdr, err := vm.internalState.CurrentStakerChainState().GetValidator(nodeID)
+	if err == nil {
+		return vdr.AddValidatorTx(), nil
+	}
+	if err != database.ErrNotFound {
+		return nil, err
+	}
+	return vm.internalState.PendingStakerChainState().GetValidatorTx(nodeID)
+}
+
+// RewardHistoryEntry describes the outcome of a staker's staking period
+type RewardHistoryEntry struct {
+	// ID of the tx that added the staker
+	TxID ids.ID `json:"txID"`
+	// ID of the tx that removed the staker
+	RewardTxID ids.ID `json:"rewardTxID"`
+	// Either "validator" or "delegator"
+	Type        string      `json:"type"`
+	NodeID      string      `json:"nodeID"`
+	SubnetID    ids.ID      `json:"subnetID"`
+	StartTime   json.Uint64 `json:"startTime"`
+	EndTime     json.Uint64 `json:"endTime"`
+	StakeAmount json.Uint64 `json:"stakeAmount"`
+	// True iff the staker was sufficiently online to be rewarded
+	Rewarded bool `json:"rewarded"`
+	// Amount of the reward that was paid to the requested address
+	RewardAmount json.Uint64 `json:"rewardAmount"`
+	// Asset the reward was paid in
+	AssetID ids.ID `json:"assetID"`
+}
+
+// GetRewardHistoryReply is the response from calling GetRewardHistory
+type GetRewardHistoryReply struct {
+	// Sorted by increasing end time
+	Rewards []RewardHistoryEntry `json:"rewards"`
+}
+
+// GetRewardHistory returns the outcomes of the staking periods whose rewards
+// were, or would have been, paid to [args.Address].
+func (service *Service) GetRewardHistory(_ *http.Request, args *api.JSONAddress, reply *GetRewardHistoryReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetRewardHistory called")
+
+	addr, err := service.vm.ParseLocalAddress(args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+	}
+
+	records, err := service.vm.internalState.GetRewardHistory(addr)
+	if err != nil {
+		return fmt.Errorf("couldn't get reward history: %w", err)
+	}
+
+	reply.Rewards = make([]RewardHistoryEntry, len(records))
+	for i, record := range records {
+		entry := &reply.Rewards[i]
+		entry.TxID = record.StakerTxID
+		entry.RewardTxID = record.RewardTxID
+
+		stakerTx, _, err := service.vm.internalState.GetTx(record.StakerTxID)
+		if err != nil {
+			return fmt.Errorf("couldn't get staker tx %s: %w", record.StakerTxID, err)
+		}
+		var vdr *Validator
+		switch utx := stakerTx.UnsignedTx.(type) {
+		case *UnsignedAddValidatorTx:
+			entry.Type = "validator"
+			entry.SubnetID = constants.PrimaryNetworkID
+			entry.AssetID = service.vm.ctx.DJTXAssetID
+			vdr = &utx.Validator
+		case *UnsignedAddDelegatorTx:
+			entry.Type = "delegator"
+			entry.SubnetID = constants.PrimaryNetworkID
+			entry.AssetID = service.vm.ctx.DJTXAssetID
+			vdr = &utx.Validator
+		case *UnsignedAddPermissionlessValidatorTx:
+			entry.Type = "validator"
+			entry.SubnetID = utx.Validator.Subnet
+			vdr = &utx.Validator.Validator
+		case *UnsignedAddPermissionlessDelegatorTx:
+			entry.Type = "delegator"
+			entry.SubnetID = utx.Validator.Subnet
+			vdr = &utx.Validator.Validator
+		default:
+			return fmt.Errorf("expected tx %s to be a staker tx but got %T", record.StakerTxID, utx)
+		}
+		if entry.SubnetID != constants.PrimaryNetworkID {
+			transformSubnetTx, err := getSubnetTransformation(service.vm.internalState, entry.SubnetID)
+			if err != nil {
+				return fmt.Errorf("couldn't get subnet transformation: %w", err)
+			}
+			entry.AssetID = transformSubnetTx.AssetID
+		}
+		entry.NodeID = vdr.ID().PrefixedString(constants.NodeIDPrefix)
+		entry.StartTime = json.Uint64(vdr.Start)
+		entry.EndTime = json.Uint64(vdr.End)
+		entry.StakeAmount = json.Uint64(vdr.Wght)
+
+		_, status, err := service.vm.internalState.GetTx(record.RewardTxID)
+		if err != nil {
+			return fmt.Errorf("couldn't get reward tx %s: %w", record.RewardTxID, err)
+		}
+		entry.Rewarded = status == Committed
+
+		utxos, err := service.vm.internalState.GetRewardUTXOs(record.StakerTxID)
+		if err != nil {
+			return fmt.Errorf("couldn't get reward UTXOs: %w", err)
+		}
+		rewardAmount := uint64(0)
+		for _, utxo := range utxos {
+			out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+			if !ok {
+				continue
+			}
+			if addrs := out.AddressesSet(); !addrs.Contains(addr) {
+				continue
+			}
+			rewardAmount, err = math.Add64(rewardAmount, out.Amount())
+			if err != nil {
+				return err
+			}
+		}
+		entry.RewardAmount = json.Uint64(rewardAmount)
+	}
+
+	sort.SliceStable(reply.Rewards, func(i, j int) bool {
+		return reply.Rewards[i].EndTime < reply.Rewards[j].EndTime
+	})
+	return nil
+}
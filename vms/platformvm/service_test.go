@@ -10,15 +10,19 @@ import (
 	"math/rand"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -26,6 +30,7 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/uptime"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	cjson "github.com/ava-labs/avalanchego/utils/json"
@@ -750,3 +755,427 @@ func TestGetCurrentValidators(t *testing.T) {
 		t.Fatalf("didnt find delegator")
 	}
 }
+
+func TestGetMempool(t *testing.T) {
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	tx, _ := newTestCreateSubnetTxs(t, service.vm)
+	if err := service.vm.mempool.IssueTx(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	reply := GetMempoolReply{}
+	if err := service.GetMempool(nil, &GetMempoolArgs{Encoding: formatting.Hex}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.TxIDs) != 1 || reply.TxIDs[0] != tx.ID() {
+		t.Fatalf("expected the mempool to contain only %s but got %v", tx.ID(), reply.TxIDs)
+	}
+	txBytes, err := formatting.Decode(reply.Encoding, reply.Txs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(txBytes, tx.Bytes()) {
+		t.Fatal("wrong tx bytes returned")
+	}
+	if uint64(reply.Bytes) != uint64(len(tx.Bytes())) {
+		t.Fatalf("expected %d bytes but got %d", len(tx.Bytes()), reply.Bytes)
+	}
+	if uint64(reply.MaxBytes) != MaxMempoolSize {
+		t.Fatalf("expected %d max bytes but got %d", MaxMempoolSize, reply.MaxBytes)
+	}
+}
+
+func TestEstimateReward(t *testing.T) {
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	stakeAmount := service.vm.MinValidatorStake
+	duration := defaultMinStakingDuration
+	currentSupply := service.vm.internalState.GetCurrentSupply()
+	expectedReward := reward(duration, stakeAmount, currentSupply, service.vm.StakeMintingPeriod)
+
+	// Case: Staking period too short
+	reply := EstimateRewardReply{}
+	if err := service.EstimateReward(nil, &EstimateRewardArgs{
+		StakeAmount: cjson.Uint64(stakeAmount),
+		Duration:    cjson.Uint64((duration - time.Second) / time.Second),
+	}, &reply); err != errStakeTooShort {
+		t.Fatalf("expected %s but got %v", errStakeTooShort, err)
+	}
+
+	// Case: Delegation fee is paid out of the reward
+	if err := service.EstimateReward(nil, &EstimateRewardArgs{
+		StakeAmount:   cjson.Uint64(stakeAmount),
+		Duration:      cjson.Uint64(duration / time.Second),
+		DelegationFee: 25,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	expectedStakerReward, expectedFee := splitDelegatorReward(expectedReward, PercentDenominator/4)
+	switch {
+	case uint64(reply.Reward) != expectedReward:
+		t.Fatalf("expected reward %d but got %d", expectedReward, reply.Reward)
+	case uint64(reply.StakerReward) != expectedStakerReward:
+		t.Fatalf("expected staker reward %d but got %d", expectedStakerReward, reply.StakerReward)
+	case uint64(reply.DelegationFee) != expectedFee:
+		t.Fatalf("expected delegation fee %d but got %d", expectedFee, reply.DelegationFee)
+	case uint64(reply.CurrentSupply) != currentSupply:
+		t.Fatalf("expected current supply %d but got %d", currentSupply, reply.CurrentSupply)
+	}
+
+	// Case: Delegating to a genesis validator, which charges the maximum fee
+	nodeID := keys[0].PublicKey().Address()
+	vdrTx, err := service.vm.getPrimaryValidatorTx(nodeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.EstimateReward(nil, &EstimateRewardArgs{
+		StakeAmount:   cjson.Uint64(stakeAmount),
+		Duration:      cjson.Uint64(duration / time.Second),
+		DelegationFee: 25,
+		NodeID:        nodeID.PrefixedString(constants.NodeIDPrefix),
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	expectedStakerReward, expectedFee = splitDelegatorReward(expectedReward, vdrTx.Shares)
+	if uint64(reply.StakerReward) != expectedStakerReward || uint64(reply.DelegationFee) != expectedFee {
+		t.Fatalf("expected the validator's delegation fee to be used")
+	}
+
+	// Case: Unknown validator
+	if err := service.EstimateReward(nil, &EstimateRewardArgs{
+		StakeAmount: cjson.Uint64(stakeAmount),
+		Duration:    cjson.Uint64(duration / time.Second),
+		NodeID:      ids.GenerateTestShortID().PrefixedString(constants.NodeIDPrefix),
+	}, &reply); err == nil {
+		t.Fatal("should have failed because the node isn't a validator")
+	}
+}
+
+func TestGetRewardHistory(t *testing.T) {
+	service := defaultService(t)
+	vm := service.vm
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	startTime := defaultValidateStartTime.Add(syncBound).Add(time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	nodeID := ids.GenerateTestShortID()
+	rewardAddr := ids.GenerateTestShortID()
+
+	vdrTx, err := vm.newAddValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		rewardAddr,
+		PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.mempool.IssueTx(vdrTx); err != nil {
+		t.Fatal(err)
+	}
+	acceptProposalBlock(t, vm) // add the validator to the pending set
+
+	vm.clock.Set(startTime)
+	acceptProposalBlock(t, vm) // move the validator to the current set
+	_, potentialReward, err := vm.internalState.CurrentStakerChainState().GetStaker(vdrTx.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rewardAddrStr, err := vm.FormatLocalAddress(rewardAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply := GetRewardHistoryReply{}
+	if err := service.GetRewardHistory(nil, &api.JSONAddress{Address: rewardAddrStr}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Rewards) != 0 {
+		t.Fatalf("expected no rewards before the staking period ended but got %d", len(reply.Rewards))
+	}
+
+	vm.clock.Set(endTime)
+	acceptProposalBlock(t, vm) // advance time
+	acceptProposalBlock(t, vm) // reward the validator
+
+	if err := service.GetRewardHistory(nil, &api.JSONAddress{Address: rewardAddrStr}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Rewards) != 1 {
+		t.Fatalf("expected 1 reward but got %d", len(reply.Rewards))
+	}
+	entry := reply.Rewards[0]
+	switch {
+	case entry.TxID != vdrTx.ID():
+		t.Fatalf("expected staker tx %s but got %s", vdrTx.ID(), entry.TxID)
+	case entry.Type != "validator":
+		t.Fatalf("expected a validator but got %q", entry.Type)
+	case entry.NodeID != nodeID.PrefixedString(constants.NodeIDPrefix):
+		t.Fatalf("expected node %s but got %s", nodeID, entry.NodeID)
+	case !entry.Rewarded:
+		t.Fatal("expected the validator to have been rewarded")
+	case uint64(entry.RewardAmount) != potentialReward:
+		t.Fatalf("expected reward %d but got %d", potentialReward, entry.RewardAmount)
+	case entry.AssetID != vm.ctx.DJTXAssetID:
+		t.Fatalf("expected reward in %s but got %s", vm.ctx.DJTXAssetID, entry.AssetID)
+	}
+
+	// The reward tx was committed
+	if _, status, err := vm.internalState.GetTx(entry.RewardTxID); err != nil {
+		t.Fatal(err)
+	} else if status != Committed {
+		t.Fatalf("expected reward tx to be %s but was %s", Committed, status)
+	}
+
+	// Rewards accepted before the index existed are indexed when the state is
+	// loaded
+	is := vm.internalState.(*internalStateImpl)
+	vdrTxID := vdrTx.ID()
+	if err := prefixdb.New(rewardAddr[:], is.rewardHistoryDB).Delete(vdrTxID[:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := is.singletonDB.Delete(rewardHistoryIndexedKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.GetRewardHistory(nil, &api.JSONAddress{Address: rewardAddrStr}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Rewards) != 0 {
+		t.Fatalf("expected the reward history to have been cleared but got %d rewards", len(reply.Rewards))
+	}
+	if err := is.indexRewardHistory(); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.GetRewardHistory(nil, &api.JSONAddress{Address: rewardAddrStr}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Rewards) != 1 || reply.Rewards[0] != entry {
+		t.Fatalf("expected the reward history to be restored to %v but got %v", entry, reply.Rewards)
+	}
+
+	// Other addresses have no reward history
+	otherAddrStr, err := vm.FormatLocalAddress(keys[1].PublicKey().Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.GetRewardHistory(nil, &api.JSONAddress{Address: otherAddrStr}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Rewards) != 0 {
+		t.Fatalf("expected no rewards but got %d", len(reply.Rewards))
+	}
+}
+
+func TestGetObservedUptimes(t *testing.T) {
+	_, genesisBytes := defaultGenesis()
+	vm := &VM{Factory: Factory{
+		Chains:             chains.MockManager{},
+		UptimePercentage:   .45,
+		StakeMintingPeriod: defaultMaxStakingDuration,
+		Validators:         validators.NewManager(),
+	}}
+	ctx := defaultContext()
+	ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	msgChan := make(chan common.Message, 1)
+	if err := vm.Initialize(ctx, manager.NewMemDB(version.DefaultVersion1_0_0), genesisBytes, nil, nil, msgChan, nil); err != nil {
+		t.Fatal(err)
+	}
+	vm.clock.Set(defaultGenesisTime)
+	vm.Manager.(uptime.TestManager).SetTime(defaultGenesisTime)
+	if err := vm.Bootstrapping(); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only [connectedID] is online, and only for the sixth day of the genesis
+	// validators' ten day validation period
+	connectedID := keys[0].PublicKey().Address()
+	vm.Manager.(uptime.TestManager).SetTime(defaultValidateStartTime.Add(5 * 24 * time.Hour))
+	if err := vm.Connected(connectedID); err != nil {
+		t.Fatal(err)
+	}
+	vm.Manager.(uptime.TestManager).SetTime(defaultValidateStartTime.Add(6 * 24 * time.Hour))
+
+	// [connectedID] also validates a transformed subnet from the fourth day
+	// until the eighth day
+	permissionlessTx := &Tx{UnsignedTx: &UnsignedAddPermissionlessValidatorTx{
+		Validator: SubnetValidator{
+			Validator: Validator{
+				NodeID: connectedID,
+				Start:  uint64(defaultValidateStartTime.Add(4 * 24 * time.Hour).Unix()),
+				End:    uint64(defaultValidateStartTime.Add(8 * 24 * time.Hour).Unix()),
+				Wght:   defaultWeight,
+			},
+			Subnet: testSubnet1.ID(),
+		},
+		RewardsOwner: &secp256k1fx.OutputOwners{},
+	}}
+	if err := permissionlessTx.Sign(vm.codec, nil); err != nil {
+		t.Fatal(err)
+	}
+	currentStakers, err := vm.internalState.CurrentStakerChainState().UpdateStakers(
+		[]*validatorReward{{addStakerTx: permissionlessTx}},
+		nil,
+		nil,
+		0,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm.internalState.SetCurrentStakerChainState(currentStakers)
+
+	service := &Service{vm: vm}
+	reply := GetObservedUptimesReply{}
+	if err := service.GetObservedUptimes(nil, &GetObservedUptimesArgs{}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Uptimes) != len(keys)+1 {
+		t.Fatalf("expected %d uptimes but got %d", len(keys)+1, len(reply.Uptimes))
+	}
+	for _, uptime := range reply.Uptimes {
+		if uptime.SubnetID == testSubnet1.ID() {
+			switch {
+			case uptime.NodeID != connectedID.PrefixedString(constants.NodeIDPrefix):
+				t.Fatalf("expected %s to validate the subnet but got %s", connectedID, uptime.NodeID)
+			case uptime.Uptime != .5:
+				t.Fatalf("expected uptime .5 but got %f", uptime.Uptime)
+			case !uptime.EligibleForReward:
+				t.Fatal("should be eligible for a reward")
+			case uptime.MaxPossibleUptime != .75:
+				t.Fatalf("expected max possible uptime .75 but got %f", uptime.MaxPossibleUptime)
+			}
+			continue
+		}
+		if uptime.SubnetID != constants.PrimaryNetworkID {
+			t.Fatalf("unexpected subnet %s", uptime.SubnetID)
+		}
+		if uptime.RequiredUptime != .45 {
+			t.Fatalf("expected required uptime .45 but got %f", uptime.RequiredUptime)
+		}
+		if uptime.EligibleForReward {
+			t.Fatalf("%s shouldn't be eligible for a reward", uptime.NodeID)
+		}
+		if uptime.NodeID != connectedID.PrefixedString(constants.NodeIDPrefix) {
+			if uptime.Connected || uptime.UpDuration != 0 || uptime.CanBeRewarded {
+				t.Fatalf("%s should be offline and unable to be rewarded", uptime.NodeID)
+			}
+			continue
+		}
+		switch {
+		case !uptime.Connected:
+			t.Fatal("should be connected")
+		case uptime.UpDuration != cjson.Uint64(24*time.Hour/time.Second):
+			t.Fatalf("expected to be up for a day but was up for %d seconds", uptime.UpDuration)
+		case uptime.MaxPossibleUptime != .5:
+			t.Fatalf("expected max possible uptime .5 but got %f", uptime.MaxPossibleUptime)
+		case !uptime.CanBeRewarded:
+			t.Fatal("should still be able to be rewarded")
+		}
+	}
+
+	// Filter by node ID
+	if err := service.GetObservedUptimes(nil, &GetObservedUptimesArgs{
+		NodeIDs: []string{connectedID.PrefixedString(constants.NodeIDPrefix)},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Uptimes) != 2 {
+		t.Fatalf("expected 2 uptimes but got %d", len(reply.Uptimes))
+	}
+}
+
+func TestExportDJTXEncryptedMemo(t *testing.T) {
+	service := defaultService(t)
+	defaultAddress(t, service)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	factory := crypto.FactorySECP256K1R{}
+	recipientKeyIntf, err := factory.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipientKey := recipientKeyIntf.(*crypto.PrivateKeySECP256K1R)
+	to, err := service.vm.FormatAddress(service.vm.ctx.XChainID, recipientKey.PublicKey().Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	memoPublicKey, err := formatting.Encode(formatting.CB58, recipientKey.PublicKey().Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply := &api.JSONTxIDChangeAddr{}
+	err = service.ExportDJTX(nil, &ExportDJTXArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: testUsername,
+				Password: testPassword,
+			},
+		},
+		Amount:        100,
+		To:            to,
+		JSONMemo: api.JSONMemo{
+			Memo:          "invoice #1234",
+			MemoPublicKey: memoPublicKey,
+		},
+	}, reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tx := range service.vm.mempool.Txs() {
+		if tx.ID() != reply.TxID {
+			continue
+		}
+		memo := tx.UnsignedTx.(*UnsignedExportTx).Memo
+		plaintext, err := crypto.DecryptMemo(recipientKey, memo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(plaintext) != "invoice #1234" {
+			t.Fatalf("expected the memo to be decrypted but got %q", plaintext)
+		}
+		return
+	}
+	t.Fatal("expected the export tx to be in the mempool")
+}
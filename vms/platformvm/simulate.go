@@ -0,0 +1,146 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+)
+
+// transferringTx is a tx that consumes and produces UTXOs of this chain
+type transferringTx interface {
+	spendingTx
+	outputs() []*djtx.TransferableOutput
+}
+
+// simulateTx verifies [tx] against the state the preferred block would
+// produce if accepted, without issuing [tx], and describes the result in
+// [reply]. As the syntactic checks of a tx are part of its semantic
+// verification, failing either is reported as failing the semantic check.
+func (vm *VM) simulateTx(tx *Tx, reply *api.SimulateTxReply) error {
+	// Initialize the transaction
+	if err := tx.Sign(vm.codec, nil); err != nil {
+		return err
+	}
+	reply.TxID = tx.ID()
+
+	ins, outs, stake := transfers(tx.UnsignedTx)
+	reply.Consumed = make([]api.SimulatedUTXO, len(ins))
+	for i, in := range ins {
+		reply.Consumed[i] = api.SimulatedUTXO{
+			UTXOID:  in.UTXOID.String(),
+			AssetID: in.AssetID(),
+			Amount:  json.Uint64(in.Input().Amount()),
+		}
+	}
+	reply.Produced = make([]api.SimulatedUTXO, len(outs))
+	for i, out := range outs {
+		utxoID := djtx.UTXOID{
+			TxID:        reply.TxID,
+			OutputIndex: uint32(i),
+		}
+		reply.Produced[i] = api.SimulatedUTXO{
+			UTXOID:  utxoID.String(),
+			AssetID: out.AssetID(),
+			Amount:  json.Uint64(out.Output().Amount()),
+		}
+	}
+
+	// Staked funds are locked rather than burned
+	unburnedOuts := make([]*djtx.TransferableOutput, 0, len(outs)+len(stake))
+	unburnedOuts = append(unburnedOuts, outs...)
+	unburnedOuts = append(unburnedOuts, stake...)
+	burned, err := djtx.Burned(ins, unburnedOuts)
+	if err != nil {
+		return fmt.Errorf("problem calculating the burned amounts: %w", err)
+	}
+	burnedAssetIDs := make([]ids.ID, 0, len(burned))
+	for assetID := range burned {
+		burnedAssetIDs = append(burnedAssetIDs, assetID)
+	}
+	ids.SortIDs(burnedAssetIDs)
+	reply.Burned = make([]api.BurnedAsset, len(burnedAssetIDs))
+	for i, assetID := range burnedAssetIDs {
+		reply.Burned[i] = api.BurnedAsset{
+			AssetID: assetID,
+			Amount:  json.Uint64(burned[assetID]),
+		}
+	}
+
+	if numMissing := numMissingSigs(tx); numMissing > 0 {
+		reply.FailedCheck = api.SignaturesCheck
+		reply.Error = fmt.Sprintf("%s: %d signatures are missing", errMissingSignatures, numMissing)
+		return nil
+	}
+
+	preferred, err := vm.Preferred()
+	if err != nil {
+		return fmt.Errorf("couldn't get preferred block: %w", err)
+	}
+	preferredDecision, ok := preferred.(decision)
+	if !ok {
+		// The preferred block should always be a decision block
+		return errInvalidBlockType
+	}
+	preferredState := preferredDecision.onAccept()
+
+	var txErr TxError
+	switch utx := tx.UnsignedTx.(type) {
+	case UnsignedDecisionTx:
+		// Decision txs modify the state they're verified against
+		onAcceptState := newVersionedState(
+			preferredState,
+			preferredState.CurrentStakerChainState(),
+			preferredState.PendingStakerChainState(),
+		)
+		_, txErr = utx.SemanticVerify(vm, onAcceptState, tx)
+	case UnsignedProposalTx:
+		_, _, _, _, txErr = utx.SemanticVerify(vm, preferredState, tx)
+	case UnsignedAtomicTx:
+		_, txErr = utx.SemanticVerify(vm, preferredState, tx)
+	default:
+		return errUnknownTxType
+	}
+	if txErr != nil {
+		reply.FailedCheck = api.SemanticCheck
+		reply.Error = txErr.Error()
+		return nil
+	}
+	reply.Valid = true
+	return nil
+}
+
+// transfers returns the inputs that [utx] consumes, the outputs it produces,
+// including those exported to other chains, and the outputs it stakes
+func transfers(utx UnsignedTx) ([]*djtx.TransferableInput, []*djtx.TransferableOutput, []*djtx.TransferableOutput) {
+	transferrer, ok := utx.(transferringTx)
+	if !ok {
+		return nil, nil, nil
+	}
+	ins := transferrer.inputs()
+	outs := transferrer.outputs()
+
+	var stake []*djtx.TransferableOutput
+	switch utx := utx.(type) {
+	case *UnsignedImportTx:
+		ins = append(append([]*djtx.TransferableInput(nil), ins...), utx.ImportedInputs...)
+	case *UnsignedExportTx:
+		outs = append(append([]*djtx.TransferableOutput(nil), outs...), utx.ExportedOutputs...)
+	case *UnsignedAddValidatorTx:
+		stake = utx.Stake
+	case *UnsignedAddRenewableValidatorTx:
+		stake = utx.Stake
+	case *UnsignedAddDelegatorTx:
+		stake = utx.Stake
+	case *UnsignedAddPermissionlessValidatorTx:
+		stake = utx.Stake
+	case *UnsignedAddPermissionlessDelegatorTx:
+		stake = utx.Stake
+	}
+	return ins, outs, stake
+}
@@ -0,0 +1,101 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+)
+
+func TestSimulateTx(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+	vm.CreationTxFee = defaultTxFee
+
+	// newTx returns a tx creating a subnet, paid for by keys[0] and signed by
+	// [signer]
+	newTx := func(signer *crypto.PrivateKeySECP256K1R) *Tx {
+		tx, err := vm.newCreateSubnetTx(
+			1, // threshold
+			[]ids.ShortID{keys[0].PublicKey().Address()}, // control keys
+			[]*crypto.PrivateKeySECP256K1R{keys[0]},      // payer
+			keys[0].PublicKey().Address(),                // change addr
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers := make([][]*crypto.PrivateKeySECP256K1R, len(tx.Creds))
+		for i := range signers {
+			signers[i] = []*crypto.PrivateKeySECP256K1R{signer}
+		}
+		tx.Creds = nil
+		if err := tx.Sign(vm.codec, signers); err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+
+	tx := newTx(keys[0])
+	reply := api.SimulateTxReply{}
+	if err := vm.simulateTx(tx, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply.Valid {
+		t.Fatalf("expected the tx to be valid but it failed the %s check: %s", reply.FailedCheck, reply.Error)
+	}
+	if reply.TxID != tx.ID() {
+		t.Fatalf("expected tx ID %s but got %s", tx.ID(), reply.TxID)
+	}
+	if len(reply.Consumed) == 0 || len(reply.Produced) == 0 {
+		t.Fatal("expected the tx to consume and produce UTXOs")
+	}
+	if len(reply.Burned) != 1 ||
+		reply.Burned[0].AssetID != vm.ctx.DJTXAssetID ||
+		uint64(reply.Burned[0].Amount) != vm.CreationTxFee {
+		t.Fatalf("expected %d DJTX to be burned but got %v", vm.CreationTxFee, reply.Burned)
+	}
+	if vm.mempool.Has(tx.ID()) {
+		t.Fatal("simulating a tx shouldn't issue it")
+	}
+
+	tests := []struct {
+		description string
+		tx          *Tx
+		failedCheck string
+	}{
+		{
+			description: "missing signature",
+			tx:          newTx(nil),
+			failedCheck: api.SignaturesCheck,
+		},
+		{
+			description: "wrong signer",
+			tx:          newTx(keys[1]),
+			failedCheck: api.SemanticCheck,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reply := api.SimulateTxReply{}
+			if err := vm.simulateTx(test.tx, &reply); err != nil {
+				t.Fatal(err)
+			}
+			if reply.Valid {
+				t.Fatal("expected the tx to be invalid")
+			}
+			if reply.FailedCheck != test.failedCheck {
+				t.Fatalf("expected the %s check to fail but the %s check failed: %s", test.failedCheck, reply.FailedCheck, reply.Error)
+			}
+		})
+	}
+}
@@ -21,7 +21,16 @@ var (
 	errCantSign                     = errors.New("can't sign")
 )
 
-// stake the provided amount while deducting the provided fee.
+// newKeychain returns a keychain that holds [keys]
+func newKeychain(keys []*crypto.PrivateKeySECP256K1R) *secp256k1fx.Keychain {
+	kc := secp256k1fx.NewKeychain()
+	for _, key := range keys {
+		kc.Add(key)
+	}
+	return kc
+}
+
+// stake the provided amount of DJTX while deducting the provided fee.
 // Arguments:
 // - [keys] are the owners of the funds
 // - [amount] is the amount of funds that are trying to be staked
@@ -46,20 +55,48 @@ func (vm *VM) stake(
 	[][]*crypto.PrivateKeySECP256K1R, // signers
 	error,
 ) {
-	addrs := ids.NewShortSet(len(keys)) // The addresses controlled by [keys]
-	for _, key := range keys {
-		addrs.Add(key.PublicKey().Address())
-	}
-	utxos, err := vm.getAllUTXOs(addrs) // The UTXOs controlled by [keys]
+	return vm.stakeAsset(keys, vm.ctx.DJTXAssetID, amount, fee, changeAddr)
+}
+
+// stakeAsset is the same as stake, except that the [amount] that is staked is
+// denominated in [stakeAssetID]. The [fee] is always paid in DJTX.
+func (vm *VM) stakeAsset(
+	keys []*crypto.PrivateKeySECP256K1R,
+	stakeAssetID ids.ID,
+	amount uint64,
+	fee uint64,
+	changeAddr ids.ShortID,
+) (
+	[]*djtx.TransferableInput, // inputs
+	[]*djtx.TransferableOutput, // returnedOutputs
+	[]*djtx.TransferableOutput, // stakedOutputs
+	[][]*crypto.PrivateKeySECP256K1R, // signers
+	error,
+) {
+	return vm.stakeAssetWithKeychain(newKeychain(keys), stakeAssetID, amount, fee, changeAddr)
+}
+
+// stakeAssetWithKeychain is the same as stakeAsset, except that the funds are
+// owned by the addresses in [kc]. If [kc] doesn't hold the key of an address
+// that must sign, a nil key is returned in its place.
+func (vm *VM) stakeAssetWithKeychain(
+	kc *secp256k1fx.Keychain,
+	stakeAssetID ids.ID,
+	amount uint64,
+	fee uint64,
+	changeAddr ids.ShortID,
+) (
+	[]*djtx.TransferableInput, // inputs
+	[]*djtx.TransferableOutput, // returnedOutputs
+	[]*djtx.TransferableOutput, // stakedOutputs
+	[][]*crypto.PrivateKeySECP256K1R, // signers
+	error,
+) {
+	utxos, err := vm.getAllUTXOs(kc.Addresses()) // The UTXOs controlled by [kc]
 	if err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("couldn't get UTXOs: %w", err)
 	}
 
-	kc := secp256k1fx.NewKeychain() // Keychain consumes UTXOs and creates new ones
-	for _, key := range keys {
-		kc.Add(key)
-	}
-
 	// Minimum time this transaction will be issued at
 	now := uint64(vm.clock.Time().Unix())
 
@@ -68,19 +105,22 @@ func (vm *VM) stake(
 	stakedOuts := []*djtx.TransferableOutput{}
 	signers := [][]*crypto.PrivateKeySECP256K1R{}
 
-	// Amount of DJTX that has been staked
+	// Amount of [stakeAssetID] that has been staked
 	amountStaked := uint64(0)
 
-	// Consume locked UTXOs
+	// lockedSpendable is a locked UTXO that can fund the stake
+	type lockedSpendable struct {
+		utxo    *djtx.UTXO
+		out     *StakeableLockOut
+		inner   *secp256k1fx.TransferOutput
+		in      djtx.TransferableIn
+		signers []*crypto.PrivateKeySECP256K1R
+	}
+	lockedSpendables := []lockedSpendable{}
+	lockedAmounts := []uint64{}
 	for _, utxo := range utxos {
-		// If we have consumed more DJTX than we are trying to stake, then we
-		// have no need to consume more locked DJTX
-		if amountStaked >= amount {
-			break
-		}
-
-		if assetID := utxo.AssetID(); assetID != vm.ctx.DJTXAssetID {
-			continue // We only care about staking DJTX, so ignore other assets
+		if assetID := utxo.AssetID(); assetID != stakeAssetID {
+			continue // We only care about staking [stakeAssetID], so ignore other assets
 		}
 
 		out, ok := utxo.Out.(*StakeableLockOut)
@@ -111,9 +151,23 @@ func (vm *VM) stake(
 			vm.ctx.Log.Warn("expected input to be djtx.TransferableIn but is %T", inIntf)
 			continue
 		}
+		lockedSpendables = append(lockedSpendables, lockedSpendable{
+			utxo:    utxo,
+			out:     out,
+			inner:   inner,
+			in:      in,
+			signers: inSigners,
+		})
+		lockedAmounts = append(lockedAmounts, in.Amount())
+	}
+
+	// Consume locked UTXOs
+	for _, i := range vm.coinSelector.Select(lockedAmounts, amount) {
+		spendable := lockedSpendables[i]
+		out := spendable.out
 
 		// The remaining value is initially the full value of the input
-		remainingValue := in.Amount()
+		remainingValue := spendable.in.Amount()
 
 		// Stake any value that should be staked
 		amountToStake := safemath.Min64(
@@ -125,22 +179,22 @@ func (vm *VM) stake(
 
 		// Add the input to the consumed inputs
 		ins = append(ins, &djtx.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  djtx.Asset{ID: vm.ctx.DJTXAssetID},
+			UTXOID: spendable.utxo.UTXOID,
+			Asset:  djtx.Asset{ID: stakeAssetID},
 			In: &StakeableLockIn{
 				Locktime:       out.Locktime,
-				TransferableIn: in,
+				TransferableIn: spendable.in,
 			},
 		})
 
 		// Add the output to the staked outputs
 		stakedOuts = append(stakedOuts, &djtx.TransferableOutput{
-			Asset: djtx.Asset{ID: vm.ctx.DJTXAssetID},
+			Asset: djtx.Asset{ID: stakeAssetID},
 			Out: &StakeableLockOut{
 				Locktime: out.Locktime,
 				TransferableOut: &secp256k1fx.TransferOutput{
 					Amt:          amountToStake,
-					OutputOwners: inner.OutputOwners,
+					OutputOwners: spendable.inner.OutputOwners,
 				},
 			},
 		})
@@ -149,34 +203,44 @@ func (vm *VM) stake(
 			// This input provided more value than was needed to be locked.
 			// Some of it must be returned
 			returnedOuts = append(returnedOuts, &djtx.TransferableOutput{
-				Asset: djtx.Asset{ID: vm.ctx.DJTXAssetID},
+				Asset: djtx.Asset{ID: stakeAssetID},
 				Out: &StakeableLockOut{
 					Locktime: out.Locktime,
 					TransferableOut: &secp256k1fx.TransferOutput{
 						Amt:          remainingValue,
-						OutputOwners: inner.OutputOwners,
+						OutputOwners: spendable.inner.OutputOwners,
 					},
 				},
 			})
 		}
 
 		// Add the signers needed for this input to the set of signers
-		signers = append(signers, inSigners)
+		signers = append(signers, spendable.signers)
 	}
 
-	// Amount of DJTX that has been burned
-	amountBurned := uint64(0)
+	// Amount of each asset that must still be spent from unlocked UTXOs
+	targets := map[ids.ID]uint64{
+		vm.ctx.DJTXAssetID: fee,
+	}
+	targets[stakeAssetID], err = safemath.Add64(targets[stakeAssetID], amount-amountStaked)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
 
+	// unlockedSpendable is an unlocked UTXO that can fund the fee or the stake
+	type unlockedSpendable struct {
+		utxo    *djtx.UTXO
+		in      djtx.TransferableIn
+		signers []*crypto.PrivateKeySECP256K1R
+	}
+	unlockedSpendables := map[ids.ID][]unlockedSpendable{}
+	unlockedAmounts := map[ids.ID][]uint64{}
 	for _, utxo := range utxos {
-		// If we have consumed more DJTX than we are trying to stake, and we
-		// have burned more DJTX then we need to, then we have no need to
-		// consume more DJTX
-		if amountBurned >= fee && amountStaked >= amount {
-			break
-		}
-
-		if assetID := utxo.AssetID(); assetID != vm.ctx.DJTXAssetID {
-			continue // We only care about burning DJTX, so ignore other assets
+		// We only care about burning DJTX and staking [stakeAssetID], so
+		// ignore other assets
+		assetID := utxo.AssetID()
+		if targets[assetID] == 0 {
+			continue
 		}
 
 		out := utxo.Out
@@ -202,65 +266,91 @@ func (vm *VM) stake(
 			// happen
 			continue
 		}
+		unlockedSpendables[assetID] = append(unlockedSpendables[assetID], unlockedSpendable{
+			utxo:    utxo,
+			in:      in,
+			signers: inSigners,
+		})
+		unlockedAmounts[assetID] = append(unlockedAmounts[assetID], in.Amount())
+	}
 
-		// The remaining value is initially the full value of the input
-		remainingValue := in.Amount()
+	// Amount of DJTX that has been burned
+	amountBurned := uint64(0)
 
-		// Burn any value that should be burned
-		amountToBurn := safemath.Min64(
-			fee-amountBurned, // Amount we still need to burn
-			remainingValue,   // Amount available to burn
-		)
-		amountBurned += amountToBurn
-		remainingValue -= amountToBurn
+	// The fee is burned before the stake is funded, so DJTX is consumed first
+	assetIDs := []ids.ID{vm.ctx.DJTXAssetID}
+	if stakeAssetID != vm.ctx.DJTXAssetID {
+		assetIDs = append(assetIDs, stakeAssetID)
+	}
+	for _, assetID := range assetIDs {
+		for _, i := range vm.coinSelector.Select(unlockedAmounts[assetID], targets[assetID]) {
+			spendable := unlockedSpendables[assetID][i]
+			in := spendable.in
+
+			// The remaining value is initially the full value of the input
+			remainingValue := in.Amount()
+
+			if assetID == vm.ctx.DJTXAssetID {
+				// Burn any value that should be burned
+				amountToBurn := safemath.Min64(
+					fee-amountBurned, // Amount we still need to burn
+					remainingValue,   // Amount available to burn
+				)
+				amountBurned += amountToBurn
+				remainingValue -= amountToBurn
+			}
 
-		// Stake any value that should be staked
-		amountToStake := safemath.Min64(
-			amount-amountStaked, // Amount we still need to stake
-			remainingValue,      // Amount available to stake
-		)
-		amountStaked += amountToStake
-		remainingValue -= amountToStake
+			amountToStake := uint64(0)
+			if assetID == stakeAssetID {
+				// Stake any value that should be staked
+				amountToStake = safemath.Min64(
+					amount-amountStaked, // Amount we still need to stake
+					remainingValue,      // Amount available to stake
+				)
+				amountStaked += amountToStake
+				remainingValue -= amountToStake
+			}
 
-		// Add the input to the consumed inputs
-		ins = append(ins, &djtx.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  djtx.Asset{ID: vm.ctx.DJTXAssetID},
-			In:     in,
-		})
+			// Add the input to the consumed inputs
+			ins = append(ins, &djtx.TransferableInput{
+				UTXOID: spendable.utxo.UTXOID,
+				Asset:  djtx.Asset{ID: assetID},
+				In:     in,
+			})
 
-		if amountToStake > 0 {
-			// Some of this input was put for staking
-			stakedOuts = append(stakedOuts, &djtx.TransferableOutput{
-				Asset: djtx.Asset{ID: vm.ctx.DJTXAssetID},
-				Out: &secp256k1fx.TransferOutput{
-					Amt: amountToStake,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-						Addrs:     []ids.ShortID{changeAddr},
+			if amountToStake > 0 {
+				// Some of this input was put for staking
+				stakedOuts = append(stakedOuts, &djtx.TransferableOutput{
+					Asset: djtx.Asset{ID: assetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt: amountToStake,
+						OutputOwners: secp256k1fx.OutputOwners{
+							Locktime:  0,
+							Threshold: 1,
+							Addrs:     []ids.ShortID{changeAddr},
+						},
 					},
-				},
-			})
-		}
+				})
+			}
 
-		if remainingValue > 0 {
-			// This input had extra value, so some of it must be returned
-			returnedOuts = append(returnedOuts, &djtx.TransferableOutput{
-				Asset: djtx.Asset{ID: vm.ctx.DJTXAssetID},
-				Out: &secp256k1fx.TransferOutput{
-					Amt: remainingValue,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-						Addrs:     []ids.ShortID{changeAddr},
+			if remainingValue > 0 {
+				// This input had extra value, so some of it must be returned
+				returnedOuts = append(returnedOuts, &djtx.TransferableOutput{
+					Asset: djtx.Asset{ID: assetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt: remainingValue,
+						OutputOwners: secp256k1fx.OutputOwners{
+							Locktime:  0,
+							Threshold: 1,
+							Addrs:     []ids.ShortID{changeAddr},
+						},
 					},
-				},
-			})
-		}
+				})
+			}
 
-		// Add the signers needed for this input to the set of signers
-		signers = append(signers, inSigners)
+			// Add the signers needed for this input to the set of signers
+			signers = append(signers, spendable.signers)
+		}
 	}
 
 	if amountBurned < fee || amountStaked < amount {
@@ -286,31 +376,36 @@ func (vm *VM) authorize(
 	[]*crypto.PrivateKeySECP256K1R, // Keys that prove ownership
 	error,
 ) {
-	subnetTx, _, err := vs.GetTx(subnetID)
+	return vm.authorizeWithKeychain(vs, subnetID, newKeychain(keys))
+}
+
+// authorizeWithKeychain is the same as authorize, except that the operation is
+// authorized by the addresses in [kc]. If [kc] doesn't hold the key of an
+// address that must sign, a nil key is returned in its place.
+func (vm *VM) authorizeWithKeychain(
+	vs MutableState,
+	subnetID ids.ID,
+	kc *secp256k1fx.Keychain,
+) (
+	verify.Verifiable, // Input that names owners
+	[]*crypto.PrivateKeySECP256K1R, // Keys that prove ownership
+	error,
+) {
+	subnetOwner, err := vs.GetSubnetOwner(subnetID)
 	if err != nil {
 		return nil, nil, fmt.Errorf(
-			"failed to fetch subnet %s: %w",
+			"failed to fetch owner of subnet %s: %w",
 			subnetID,
 			err,
 		)
 	}
-	subnet, ok := subnetTx.UnsignedTx.(*UnsignedCreateSubnetTx)
-	if !ok {
-		return nil, nil, errWrongTxType
-	}
 
 	// Make sure the owners of the subnet match the provided keys
-	owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+	owner, ok := subnetOwner.(*secp256k1fx.OutputOwners)
 	if !ok {
 		return nil, nil, errUnknownOwners
 	}
 
-	// Add the keys to a keychain
-	kc := secp256k1fx.NewKeychain()
-	for _, key := range keys {
-		kc.Add(key)
-	}
-
 	// Make sure that the operation is valid after a minimum time
 	now := uint64(vm.clock.Time().Unix())
 
@@ -355,6 +450,93 @@ func (vm *VM) semanticVerifySpend(
 	return vm.semanticVerifySpendUTXOs(tx, utxos, ins, outs, creds, feeAmount, feeAssetID)
 }
 
+// Verify that [tx] is semantically valid when it may spend multiple assets.
+// The flowcheck is performed independently for each asset.
+// [burned] maps an assetID to the amount of that asset that must be burned.
+// Precondition: [tx] has already been syntactically verified
+func (vm *VM) semanticVerifySpendAssets(
+	utxoDB UTXOGetter,
+	tx UnsignedTx,
+	ins []*djtx.TransferableInput,
+	outs []*djtx.TransferableOutput,
+	creds []verify.Verifiable,
+	burned map[ids.ID]uint64,
+) TxError {
+	utxos := make([]*djtx.UTXO, len(ins))
+	for index, input := range ins {
+		utxo, err := utxoDB.GetUTXO(input.InputID())
+		if err != nil {
+			return tempError{
+				fmt.Errorf(
+					"failed to read consumed UTXO %s due to: %w",
+					&input.UTXOID,
+					err,
+				),
+			}
+		}
+		utxos[index] = utxo
+	}
+
+	return vm.semanticVerifySpendAssetUTXOs(tx, utxos, ins, outs, creds, burned)
+}
+
+// semanticVerifySpendAssetUTXOs is the same as semanticVerifySpendAssets,
+// except that [utxos[i]] is the UTXO being consumed by [ins[i]].
+func (vm *VM) semanticVerifySpendAssetUTXOs(
+	tx UnsignedTx,
+	utxos []*djtx.UTXO,
+	ins []*djtx.TransferableInput,
+	outs []*djtx.TransferableOutput,
+	creds []verify.Verifiable,
+	burned map[ids.ID]uint64,
+) TxError {
+	if len(ins) != len(creds) {
+		return permError{fmt.Errorf("there are %d inputs but %d credentials. Should be same number",
+			len(ins), len(creds))}
+	}
+	if len(ins) != len(utxos) {
+		return permError{fmt.Errorf("there are %d inputs but %d utxos. Should be same number",
+			len(ins), len(utxos))}
+	}
+
+	assetIDs := ids.Set{}
+	for assetID := range burned {
+		assetIDs.Add(assetID)
+	}
+	for _, in := range ins {
+		assetIDs.Add(in.AssetID())
+	}
+	for _, out := range outs {
+		assetIDs.Add(out.AssetID())
+	}
+
+	for assetID := range assetIDs {
+		var (
+			assetUTXOs []*djtx.UTXO
+			assetIns   []*djtx.TransferableInput
+			assetCreds []verify.Verifiable
+			assetOuts  []*djtx.TransferableOutput
+		)
+		for index, in := range ins {
+			if in.AssetID() == assetID {
+				assetUTXOs = append(assetUTXOs, utxos[index])
+				assetIns = append(assetIns, in)
+				assetCreds = append(assetCreds, creds[index])
+			}
+		}
+		for _, out := range outs {
+			if out.AssetID() == assetID {
+				assetOuts = append(assetOuts, out)
+			}
+		}
+
+		if err := vm.semanticVerifySpendUTXOs(tx, assetUTXOs, assetIns, assetOuts, assetCreds, burned[assetID], assetID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Verify that [tx] is semantically valid.
 // [db] should not be committed if an error is returned
 // [ins] and [outs] are the inputs and outputs of [tx].
@@ -571,3 +753,23 @@ func produceOutputs(
 		})
 	}
 }
+
+// Adds the UTXOs created by [outs] to the UTXO set. Unlike produceOutputs,
+// each UTXO keeps the asset of the output that created it.
+// [txID] is the ID of the tx that created [outs].
+func produceAssetOutputs(
+	utxoDB UTXOAdder,
+	txID ids.ID,
+	outs []*djtx.TransferableOutput,
+) {
+	for index, out := range outs {
+		utxoDB.AddUTXO(&djtx.UTXO{
+			UTXOID: djtx.UTXOID{
+				TxID:        txID,
+				OutputIndex: uint32(index),
+			},
+			Asset: out.Asset,
+			Out:   out.Output(),
+		})
+	}
+}
@@ -118,6 +118,11 @@ func (sb *StandardBlock) Verify() error {
 		}
 	}
 
+	// These txs no longer need to be put into a block
+	for _, tx := range sb.Txs {
+		sb.vm.mempool.RemoveTx(tx.ID())
+	}
+
 	sb.vm.currentBlocks[blkID] = sb
 	parentIntf.addChild(sb)
 	return nil
@@ -0,0 +1,176 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errTransferPrimaryNetwork = errors.New("can't transfer ownership of the primary network")
+
+	_ UnsignedDecisionTx = &UnsignedTransferSubnetOwnershipTx{}
+)
+
+// UnsignedTransferSubnetOwnershipTx is an unsigned proposal to replace the
+// owner of a subnet
+type UnsignedTransferSubnetOwnershipTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet this tx is modifying
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// Proves that the issuer has the right to modify the subnet
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+	// Who is now authorized to manage this subnet
+	Owner verify.Verifiable `serialize:"true" json:"newOwner"`
+}
+
+// Verify this transaction is well-formed
+func (tx *UnsignedTransferSubnetOwnershipTx) Verify(
+	ctx *snow.Context,
+	c codec.Manager,
+	feeAmount uint64,
+	feeAssetID ids.ID,
+) error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case tx.syntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errTransferPrimaryNetwork
+	}
+
+	if err := tx.BaseTx.Verify(ctx, c); err != nil {
+		return err
+	}
+	if err := verify.All(tx.SubnetAuth, tx.Owner); err != nil {
+		return err
+	}
+
+	tx.syntacticallyVerified = true
+	return nil
+}
+
+// SemanticVerify returns nil if [tx] is valid given the state in [db]
+func (tx *UnsignedTransferSubnetOwnershipTx) SemanticVerify(
+	vm *VM,
+	vs VersionedState,
+	stx *Tx,
+) (
+	func() error,
+	TxError,
+) {
+	// Make sure this transaction is well formed.
+	if len(stx.Creds) == 0 {
+		return nil, permError{errWrongNumberOfCredentials}
+	}
+	if err := tx.Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
+		return nil, permError{err}
+	}
+	if !vm.isApricotPhase3(vs.GetTimestamp()) {
+		return nil, tempError{errNotApricotPhase3}
+	}
+
+	// Select the credentials for each purpose
+	baseTxCredsLen := len(stx.Creds) - 1
+	baseTxCreds := stx.Creds[:baseTxCredsLen]
+	subnetCred := stx.Creds[baseTxCredsLen]
+
+	// Verify the flowcheck
+	if err := vm.semanticVerifySpend(vs, tx, tx.Ins, tx.Outs, baseTxCreds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
+		return nil, err
+	}
+
+	subnetIntf, _, err := vs.GetTx(tx.Subnet)
+	if err == database.ErrNotFound {
+		return nil, permError{
+			fmt.Errorf("%s isn't a known subnet", tx.Subnet),
+		}
+	}
+	if err != nil {
+		return nil, tempError{err}
+	}
+
+	if _, ok := subnetIntf.UnsignedTx.(*UnsignedCreateSubnetTx); !ok {
+		return nil, permError{
+			fmt.Errorf("%s isn't a subnet", tx.Subnet),
+		}
+	}
+
+	subnetOwner, err := vs.GetSubnetOwner(tx.Subnet)
+	if err != nil {
+		return nil, tempError{err}
+	}
+
+	// Verify that this transfer is authorized by the current owner
+	if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
+		return nil, permError{err}
+	}
+
+	// Consume the UTXOS
+	consumeInputs(vs, tx.Ins)
+	// Produce the UTXOS
+	txID := tx.ID()
+	produceOutputs(vs, txID, vm.ctx.DJTXAssetID, tx.Outs)
+	// Replace the owner of the subnet
+	vs.SetSubnetOwner(tx.Subnet, tx.Owner)
+
+	return nil, nil
+}
+
+// [ownerAddrs] must be unique. They will be sorted by this method.
+func (vm *VM) newTransferSubnetOwnershipTx(
+	subnetID ids.ID, // ID of the subnet to transfer
+	threshold uint32, // [threshold] of [ownerAddrs] needed to manage this subnet
+	ownerAddrs []ids.ShortID, // new control addresses for the subnet
+	keys []*crypto.PrivateKeySECP256K1R, // pay the fee and prove the current ownership
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, outs, _, signers, err := vm.stake(keys, 0, vm.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := vm.authorize(vm.internalState, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Sort control addresses
+	ids.SortShortIDs(ownerAddrs)
+
+	// Create the tx
+	utx := &UnsignedTransferSubnetOwnershipTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    vm.ctx.NetworkID,
+			BlockchainID: vm.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+		Owner: &secp256k1fx.OutputOwners{
+			Threshold: threshold,
+			Addrs:     ownerAddrs,
+		},
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(vm.codec, signers); err != nil {
+		return nil, err
+	}
+	return tx, utx.Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID)
+}
@@ -0,0 +1,172 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestTransferSubnetOwnershipTxSyntacticVerify(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	newOwners := []ids.ShortID{keys[3].PublicKey().Address()}
+
+	// Case: tx is nil
+	var unsignedTx *UnsignedTransferSubnetOwnershipTx
+	if err := unsignedTx.Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID); err == nil {
+		t.Fatal("should have errored because tx is nil")
+	}
+
+	// Case: Primary network
+	tx, err := vm.newTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		newOwners,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.UnsignedTx.(*UnsignedTransferSubnetOwnershipTx).Subnet = constants.PrimaryNetworkID
+	// This tx was syntactically verified when it was created...pretend it wasn't so we don't use cache
+	tx.UnsignedTx.(*UnsignedTransferSubnetOwnershipTx).syntacticallyVerified = false
+	if err := tx.UnsignedTx.(*UnsignedTransferSubnetOwnershipTx).Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID); err == nil {
+		t.Fatal("should have errored because the primary network can't be transferred")
+	}
+
+	// Case: Invalid new owner
+	tx, err = vm.newTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		newOwners,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.UnsignedTx.(*UnsignedTransferSubnetOwnershipTx).Owner.(*secp256k1fx.OutputOwners).Threshold = 2
+	// This tx was syntactically verified when it was created...pretend it wasn't so we don't use cache
+	tx.UnsignedTx.(*UnsignedTransferSubnetOwnershipTx).syntacticallyVerified = false
+	if err := tx.UnsignedTx.(*UnsignedTransferSubnetOwnershipTx).Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID); err == nil {
+		t.Fatal("should have errored because the threshold is larger than the number of owners")
+	}
+}
+
+func TestTransferSubnetOwnership(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	newOwnerKey := keys[3]
+	newOwners := []ids.ShortID{newOwnerKey.PublicKey().Address()}
+
+	// Case: Signed by keys that don't own the subnet
+	if _, err := vm.newTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		newOwners,
+		[]*crypto.PrivateKeySECP256K1R{newOwnerKey},
+		ids.ShortEmpty, // change addr
+	); err == nil {
+		t.Fatal("should have failed because the keys don't own the subnet")
+	}
+
+	tx, err := vm.newTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		newOwners,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Case: Before Apricot Phase 3
+	vm.ApricotPhase3Time = vm.internalState.GetTimestamp().Add(time.Second)
+	vs := newVersionedState(vm.internalState, vm.internalState.CurrentStakerChainState(), vm.internalState.PendingStakerChainState())
+	if _, err := tx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(vm, vs, tx); err == nil {
+		t.Fatal("should have failed because Apricot Phase 3 isn't active")
+	}
+	vm.ApricotPhase3Time = time.Time{}
+
+	if err := vm.mempool.IssueTx(tx); err != nil {
+		t.Fatal(err)
+	}
+	blk, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Accept(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, status, err := vm.internalState.GetTx(tx.ID()); err != nil {
+		t.Fatal(err)
+	} else if status != Committed {
+		t.Fatalf("status should be Committed but is %s", status)
+	}
+
+	ownerIntf, err := vm.internalState.GetSubnetOwner(testSubnet1.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, ok := ownerIntf.(*secp256k1fx.OutputOwners)
+	if !ok {
+		t.Fatalf("expected *secp256k1fx.OutputOwners but got %T", ownerIntf)
+	}
+	if owner.Threshold != 1 || len(owner.Addrs) != 1 || owner.Addrs[0] != newOwners[0] {
+		t.Fatalf("unexpected subnet owner %v", owner)
+	}
+
+	// The previous owners can no longer manage the subnet
+	if _, err := vm.newRemoveSubnetValidatorTx(
+		keys[0].PublicKey().Address(),
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	); err == nil {
+		t.Fatal("should have failed because the keys no longer own the subnet")
+	}
+
+	// The new owner is persisted
+	is, err := NewInternalState(vm, vm.dbManager.Current().Database, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownerIntf, err = is.GetSubnetOwner(testSubnet1.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, ok = ownerIntf.(*secp256k1fx.OutputOwners)
+	if !ok {
+		t.Fatalf("expected *secp256k1fx.OutputOwners but got %T", ownerIntf)
+	}
+	if owner.Threshold != 1 || len(owner.Addrs) != 1 || owner.Addrs[0] != newOwners[0] {
+		t.Fatalf("unexpected persisted subnet owner %v", owner)
+	}
+}
@@ -0,0 +1,316 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	errTransformPrimaryNetwork      = errors.New("can't transform the primary network")
+	errEmptyAssetID                 = errors.New("staking asset ID can't be empty")
+	errDJTXStakingAsset             = errors.New("staking asset ID can't be DJTX")
+	errInitialSupplyZero            = errors.New("initial supply must be non-0")
+	errInitialSupplyGreaterThanMax  = errors.New("initial supply can't be greater than maximum supply")
+	errMinConsumptionRateTooLarge   = errors.New("min consumption rate can't be greater than max consumption rate")
+	errMaxConsumptionRateTooLarge   = fmt.Errorf("max consumption rate can't be greater than %d", PercentDenominator)
+	errMinValidatorStakeZero        = errors.New("min validator stake must be non-0")
+	errMinValidatorStakeAboveSupply = errors.New("min validator stake can't be greater than initial supply")
+	errMinValidatorStakeAboveMax    = errors.New("min validator stake can't be greater than max validator stake")
+	errMaxValidatorStakeTooLarge    = errors.New("max validator stake can't be greater than maximum supply")
+	errMinStakeDurationZero         = errors.New("min stake duration must be non-0")
+	errMinStakeDurationTooLarge     = errors.New("min stake duration can't be greater than max stake duration")
+	errMinDelegationFeeTooLarge     = fmt.Errorf("min delegation fee can't be greater than %d", PercentDenominator)
+	errMinDelegatorStakeZero        = errors.New("min delegator stake must be non-0")
+	errSubnetAlreadyTransformed     = errors.New("subnet has already been transformed")
+	errMaxStakeDurationTooLarge     = fmt.Errorf("max stake duration can't be greater than %d seconds", uint32(math.MaxUint32))
+
+	_ UnsignedDecisionTx = &UnsignedTransformSubnetTx{}
+)
+
+// UnsignedTransformSubnetTx is an unsigned proposal to convert a permissioned
+// subnet into one that is validated by anyone who stakes the subnet's own
+// staking asset.
+type UnsignedTransformSubnetTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet this tx is modifying
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// Asset to use when staking on the subnet
+	AssetID ids.ID `serialize:"true" json:"assetID"`
+	// Amount to initially specify as the current supply
+	InitialSupply uint64 `serialize:"true" json:"initialSupply"`
+	// Amount to specify as the maximum token supply. The difference between
+	// the maximum supply and the initial supply is burned by this tx so that it
+	// can later be minted as staking rewards.
+	MaximumSupply uint64 `serialize:"true" json:"maximumSupply"`
+	// MinConsumptionRate is the rate to allocate funds if the validator's stake
+	// duration is 0, times 1,000,000
+	MinConsumptionRate uint64 `serialize:"true" json:"minConsumptionRate"`
+	// MaxConsumptionRate is the rate to allocate funds if the validator's stake
+	// duration is equal to the maximum stake duration, times 1,000,000
+	MaxConsumptionRate uint64 `serialize:"true" json:"maxConsumptionRate"`
+	// MinValidatorStake is the minimum amount of funds required to become a
+	// validator
+	MinValidatorStake uint64 `serialize:"true" json:"minValidatorStake"`
+	// MaxValidatorStake is the maximum amount of funds a single validator can
+	// be allocated, including delegated funds
+	MaxValidatorStake uint64 `serialize:"true" json:"maxValidatorStake"`
+	// MinStakeDuration is the minimum number of seconds a staker can stake for
+	MinStakeDuration uint32 `serialize:"true" json:"minStakeDuration"`
+	// MaxStakeDuration is the maximum number of seconds a staker can stake for
+	MaxStakeDuration uint32 `serialize:"true" json:"maxStakeDuration"`
+	// MinDelegationFee is the minimum percentage a validator must charge a
+	// delegator for delegating, times 10,000
+	MinDelegationFee uint32 `serialize:"true" json:"minDelegationFee"`
+	// MinDelegatorStake is the minimum amount of funds required to become a
+	// delegator
+	MinDelegatorStake uint64 `serialize:"true" json:"minDelegatorStake"`
+	// Proves that the issuer has the right to modify the subnet
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+// MinStakeDurationTime is the minimum duration a staker can stake for
+func (tx *UnsignedTransformSubnetTx) MinStakeDurationTime() time.Duration {
+	return time.Duration(tx.MinStakeDuration) * time.Second
+}
+
+// MaxStakeDurationTime is the maximum duration a staker can stake for
+func (tx *UnsignedTransformSubnetTx) MaxStakeDurationTime() time.Duration {
+	return time.Duration(tx.MaxStakeDuration) * time.Second
+}
+
+// Verify this transaction is well-formed
+func (tx *UnsignedTransformSubnetTx) Verify(
+	ctx *snow.Context,
+	c codec.Manager,
+	feeAmount uint64,
+	feeAssetID ids.ID,
+) error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case tx.syntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errTransformPrimaryNetwork
+	case tx.AssetID == ids.Empty:
+		return errEmptyAssetID
+	case tx.AssetID == ctx.DJTXAssetID:
+		return errDJTXStakingAsset
+	case tx.InitialSupply == 0:
+		return errInitialSupplyZero
+	case tx.InitialSupply > tx.MaximumSupply:
+		return errInitialSupplyGreaterThanMax
+	case tx.MinConsumptionRate > tx.MaxConsumptionRate:
+		return errMinConsumptionRateTooLarge
+	case tx.MaxConsumptionRate > PercentDenominator:
+		return errMaxConsumptionRateTooLarge
+	case tx.MinValidatorStake == 0:
+		return errMinValidatorStakeZero
+	case tx.MinValidatorStake > tx.InitialSupply:
+		return errMinValidatorStakeAboveSupply
+	case tx.MinValidatorStake > tx.MaxValidatorStake:
+		return errMinValidatorStakeAboveMax
+	case tx.MaxValidatorStake > tx.MaximumSupply:
+		return errMaxValidatorStakeTooLarge
+	case tx.MinStakeDuration == 0:
+		return errMinStakeDurationZero
+	case tx.MinStakeDuration > tx.MaxStakeDuration:
+		return errMinStakeDurationTooLarge
+	case tx.MinDelegationFee > PercentDenominator:
+		return errMinDelegationFeeTooLarge
+	case tx.MinDelegatorStake == 0:
+		return errMinDelegatorStakeZero
+	}
+
+	if err := tx.BaseTx.Verify(ctx, c); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.syntacticallyVerified = true
+	return nil
+}
+
+// SemanticVerify returns nil if [tx] is valid given the state in [db]
+func (tx *UnsignedTransformSubnetTx) SemanticVerify(
+	vm *VM,
+	vs VersionedState,
+	stx *Tx,
+) (
+	func() error,
+	TxError,
+) {
+	// Make sure this transaction is well formed.
+	if len(stx.Creds) == 0 {
+		return nil, permError{errWrongNumberOfCredentials}
+	}
+	if err := tx.Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
+		return nil, permError{err}
+	}
+	if !vm.isApricotPhase3(vs.GetTimestamp()) {
+		return nil, tempError{errNotApricotPhase3}
+	}
+
+	// Select the credentials for each purpose
+	baseTxCredsLen := len(stx.Creds) - 1
+	baseTxCreds := stx.Creds[:baseTxCredsLen]
+	subnetCred := stx.Creds[baseTxCredsLen]
+
+	// The tokens that will later be minted as staking rewards are burned now
+	burned := map[ids.ID]uint64{
+		vm.ctx.DJTXAssetID: vm.TxFee,
+		tx.AssetID:         tx.MaximumSupply - tx.InitialSupply,
+	}
+
+	// Verify the flowcheck
+	if err := vm.semanticVerifySpendAssets(vs, tx, tx.Ins, tx.Outs, baseTxCreds, burned); err != nil {
+		return nil, err
+	}
+
+	subnetIntf, _, err := vs.GetTx(tx.Subnet)
+	if err == database.ErrNotFound {
+		return nil, permError{
+			fmt.Errorf("%s isn't a known subnet", tx.Subnet),
+		}
+	}
+	if err != nil {
+		return nil, tempError{err}
+	}
+
+	if _, ok := subnetIntf.UnsignedTx.(*UnsignedCreateSubnetTx); !ok {
+		return nil, permError{
+			fmt.Errorf("%s isn't a subnet", tx.Subnet),
+		}
+	}
+
+	switch _, err := vs.GetSubnetTransformation(tx.Subnet); err {
+	case nil:
+		return nil, permError{errSubnetAlreadyTransformed}
+	case database.ErrNotFound:
+	default:
+		return nil, tempError{err}
+	}
+
+	subnetOwner, err := vs.GetSubnetOwner(tx.Subnet)
+	if err != nil {
+		return nil, tempError{err}
+	}
+
+	// Verify that this transformation is authorized by the current owner
+	if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
+		return nil, permError{err}
+	}
+
+	// Consume the UTXOS
+	consumeInputs(vs, tx.Ins)
+	// Produce the UTXOS
+	txID := tx.ID()
+	produceAssetOutputs(vs, txID, tx.Outs)
+	// Record the staking configuration of the subnet
+	vs.AddSubnetTransformation(stx)
+	vs.SetSubnetCurrentSupply(tx.Subnet, tx.InitialSupply)
+
+	return nil, nil
+}
+
+// Create a new transaction
+func (vm *VM) newTransformSubnetTx(
+	subnetID ids.ID, // ID of the subnet to transform
+	assetID ids.ID, // Asset to stake on the subnet
+	initialSupply uint64, // Supply of [assetID] in circulation
+	maximumSupply uint64, // Supply of [assetID] after all rewards are minted
+	minConsumptionRate uint64, // Consumption rate for a 0 length stake
+	maxConsumptionRate uint64, // Consumption rate for a stake of [maxStakeDuration]
+	minValidatorStake uint64, // Minimum amount a validator must stake
+	maxValidatorStake uint64, // Maximum amount of stake on a single validator
+	minStakeDuration time.Duration, // Minimum stake duration
+	maxStakeDuration time.Duration, // Maximum stake duration
+	minDelegationFee uint32, // Minimum fee a validator can charge delegators
+	minDelegatorStake uint64, // Minimum amount a delegator must stake
+	keys []*crypto.PrivateKeySECP256K1R, // Pay the fee, burn the rewards and prove the ownership
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	switch {
+	case initialSupply > maximumSupply:
+		return nil, errInitialSupplyGreaterThanMax
+	case maxStakeDuration/time.Second > math.MaxUint32:
+		return nil, errMaxStakeDurationTooLarge
+	}
+	// The staked outputs are dropped, which burns the future rewards
+	ins, outs, _, signers, err := vm.stakeAsset(keys, assetID, maximumSupply-initialSupply, vm.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := vm.authorize(vm.internalState, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Create the tx
+	utx := &UnsignedTransformSubnetTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    vm.ctx.NetworkID,
+			BlockchainID: vm.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		Subnet:             subnetID,
+		AssetID:            assetID,
+		InitialSupply:      initialSupply,
+		MaximumSupply:      maximumSupply,
+		MinConsumptionRate: minConsumptionRate,
+		MaxConsumptionRate: maxConsumptionRate,
+		MinValidatorStake:  minValidatorStake,
+		MaxValidatorStake:  maxValidatorStake,
+		MinStakeDuration:   uint32(minStakeDuration / time.Second),
+		MaxStakeDuration:   uint32(maxStakeDuration / time.Second),
+		MinDelegationFee:   minDelegationFee,
+		MinDelegatorStake:  minDelegatorStake,
+		SubnetAuth:         subnetAuth,
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(vm.codec, signers); err != nil {
+		return nil, err
+	}
+	return tx, utx.Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID)
+}
+
+// getSubnetTransformation returns the tx that transformed [subnetID] in [vs].
+// A permError is returned if [subnetID] hasn't been transformed.
+func getSubnetTransformation(vs MutableState, subnetID ids.ID) (*UnsignedTransformSubnetTx, TxError) {
+	transformSubnetTxIntf, err := vs.GetSubnetTransformation(subnetID)
+	if err == database.ErrNotFound {
+		return nil, permError{
+			fmt.Errorf("%s hasn't been transformed", subnetID),
+		}
+	}
+	if err != nil {
+		return nil, tempError{
+			fmt.Errorf("couldn't find transformation of subnet %s: %w", subnetID, err),
+		}
+	}
+	transformSubnetTx, ok := transformSubnetTxIntf.UnsignedTx.(*UnsignedTransformSubnetTx)
+	if !ok {
+		return nil, tempError{errWrongTxType}
+	}
+	return transformSubnetTx, nil
+}
@@ -0,0 +1,260 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/djtx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const (
+	testSubnetInitialSupply = 1000 * defaultWeight
+	testSubnetMaximumSupply = 2000 * defaultWeight
+)
+
+// addTestAssetUTXO gives [key] a UTXO of [amount] units of [assetID]
+func addTestAssetUTXO(t *testing.T, vm *VM, assetID ids.ID, amount uint64, key *crypto.PrivateKeySECP256K1R) {
+	vm.internalState.AddUTXO(&djtx.UTXO{
+		UTXOID: djtx.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  djtx.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{key.PublicKey().Address()},
+			},
+		},
+	})
+	if err := vm.internalState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestTransformSubnetTx transforms [testSubnet1] to be staked with [assetID]
+func newTestTransformSubnetTx(vm *VM, assetID ids.ID) (*Tx, error) {
+	return vm.newTransformSubnetTx(
+		testSubnet1.ID(),
+		assetID,
+		testSubnetInitialSupply,
+		testSubnetMaximumSupply,
+		MinConsumptionRate,
+		MinConsumptionRate+MaxSubMinConsumptionRate,
+		defaultWeight,
+		10*defaultWeight,
+		defaultMinStakingDuration,
+		defaultMaxStakingDuration,
+		20000,
+		defaultWeight,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		testSubnet1ControlKeys[0].PublicKey().Address(), // change addr
+	)
+}
+
+func TestTransformSubnetTxSyntacticVerify(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := ids.GenerateTestID()
+	addTestAssetUTXO(t, vm, assetID, testSubnetMaximumSupply, testSubnet1ControlKeys[0])
+
+	// Case: tx is nil
+	var unsignedTx *UnsignedTransformSubnetTx
+	if err := unsignedTx.Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID); err == nil {
+		t.Fatal("should have errored because tx is nil")
+	}
+
+	tests := []struct {
+		description string
+		modify      func(*UnsignedTransformSubnetTx)
+		expectedErr error
+	}{
+		{
+			description: "primary network",
+			modify:      func(tx *UnsignedTransformSubnetTx) { tx.Subnet = constants.PrimaryNetworkID },
+			expectedErr: errTransformPrimaryNetwork,
+		},
+		{
+			description: "empty asset",
+			modify:      func(tx *UnsignedTransformSubnetTx) { tx.AssetID = ids.Empty },
+			expectedErr: errEmptyAssetID,
+		},
+		{
+			description: "DJTX asset",
+			modify:      func(tx *UnsignedTransformSubnetTx) { tx.AssetID = vm.ctx.DJTXAssetID },
+			expectedErr: errDJTXStakingAsset,
+		},
+		{
+			description: "initial supply above maximum",
+			modify:      func(tx *UnsignedTransformSubnetTx) { tx.InitialSupply = tx.MaximumSupply + 1 },
+			expectedErr: errInitialSupplyGreaterThanMax,
+		},
+		{
+			description: "min consumption rate above max",
+			modify:      func(tx *UnsignedTransformSubnetTx) { tx.MinConsumptionRate = tx.MaxConsumptionRate + 1 },
+			expectedErr: errMinConsumptionRateTooLarge,
+		},
+		{
+			description: "max consumption rate too large",
+			modify:      func(tx *UnsignedTransformSubnetTx) { tx.MaxConsumptionRate = PercentDenominator + 1 },
+			expectedErr: errMaxConsumptionRateTooLarge,
+		},
+		{
+			description: "min validator stake above max",
+			modify:      func(tx *UnsignedTransformSubnetTx) { tx.MinValidatorStake = tx.MaxValidatorStake + 1 },
+			expectedErr: errMinValidatorStakeAboveMax,
+		},
+		{
+			description: "max validator stake above maximum supply",
+			modify:      func(tx *UnsignedTransformSubnetTx) { tx.MaxValidatorStake = tx.MaximumSupply + 1 },
+			expectedErr: errMaxValidatorStakeTooLarge,
+		},
+		{
+			description: "min stake duration above max",
+			modify:      func(tx *UnsignedTransformSubnetTx) { tx.MinStakeDuration = tx.MaxStakeDuration + 1 },
+			expectedErr: errMinStakeDurationTooLarge,
+		},
+		{
+			description: "min delegation fee too large",
+			modify:      func(tx *UnsignedTransformSubnetTx) { tx.MinDelegationFee = PercentDenominator + 1 },
+			expectedErr: errMinDelegationFeeTooLarge,
+		},
+		{
+			description: "zero min delegator stake",
+			modify:      func(tx *UnsignedTransformSubnetTx) { tx.MinDelegatorStake = 0 },
+			expectedErr: errMinDelegatorStakeZero,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tx, err := newTestTransformSubnetTx(vm, assetID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			utx := tx.UnsignedTx.(*UnsignedTransformSubnetTx)
+			test.modify(utx)
+			// This tx was syntactically verified when it was created...pretend it wasn't so we don't use cache
+			utx.syntacticallyVerified = false
+			if err := utx.Verify(vm.ctx, vm.codec, vm.TxFee, vm.ctx.DJTXAssetID); err != test.expectedErr {
+				t.Fatalf("expected %v but got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestTransformSubnetTxSemanticVerify(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Case: Not enough of the staking asset to burn the future rewards
+	assetID := ids.GenerateTestID()
+	if _, err := newTestTransformSubnetTx(vm, assetID); err == nil {
+		t.Fatal("should have failed because the rewards can't be burned")
+	}
+
+	addTestAssetUTXO(t, vm, assetID, testSubnetMaximumSupply, testSubnet1ControlKeys[0])
+
+	// Case: Subnet auth signed by keys that don't own the subnet
+	if _, err := vm.newTransformSubnetTx(
+		testSubnet1.ID(),
+		assetID,
+		testSubnetInitialSupply,
+		testSubnetMaximumSupply,
+		MinConsumptionRate,
+		MinConsumptionRate+MaxSubMinConsumptionRate,
+		defaultWeight,
+		10*defaultWeight,
+		defaultMinStakingDuration,
+		defaultMaxStakingDuration,
+		20000,
+		defaultWeight,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0]},
+		ids.ShortEmpty, // change addr
+	); err == nil {
+		t.Fatal("should have failed because the threshold of the subnet isn't met")
+	}
+
+	// Case: Valid
+	tx, err := newTestTransformSubnetTx(vm, assetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs := newVersionedState(
+		vm.internalState,
+		vm.internalState.CurrentStakerChainState(),
+		vm.internalState.PendingStakerChainState(),
+	)
+
+	// Case: Before Apricot Phase 3
+	vm.ApricotPhase3Time = vs.GetTimestamp().Add(time.Second)
+	if _, err := tx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(vm, vs, tx); err == nil {
+		t.Fatal("should have failed because Apricot Phase 3 isn't active")
+	}
+	vm.ApricotPhase3Time = time.Time{}
+
+	if _, err := tx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(vm, vs, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	transformSubnetTx, txErr := getSubnetTransformation(vs, testSubnet1.ID())
+	if txErr != nil {
+		t.Fatal(txErr)
+	}
+	if transformSubnetTx.AssetID != assetID {
+		t.Fatalf("expected staking asset %s but got %s", assetID, transformSubnetTx.AssetID)
+	}
+	if supply, err := vs.GetSubnetCurrentSupply(testSubnet1.ID()); err != nil {
+		t.Fatal(err)
+	} else if supply != testSubnetInitialSupply {
+		t.Fatalf("expected current supply %d but got %d", testSubnetInitialSupply, supply)
+	}
+
+	// Case: Subnet has already been transformed
+	tx, err = newTestTransformSubnetTx(vm, assetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(vm, vs, tx); err == nil {
+		t.Fatal("should have failed because the subnet was already transformed")
+	}
+
+	// Case: Subnet validators can no longer be added by the subnet owner
+	addTx, err := vm.newAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(defaultValidateStartTime.Add(syncBound).Add(time.Second).Unix()),
+		uint64(defaultValidateEndTime.Unix()),
+		keys[0].PublicKey().Address(),
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, _, err := addTx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vs, addTx); err == nil {
+		t.Fatal("should have failed because the subnet is permissionless")
+	}
+
+	if _, err := vm.internalState.GetSubnetTransformation(testSubnet1.ID()); err != database.ErrNotFound {
+		t.Fatalf("expected %s but got %v", database.ErrNotFound, err)
+	}
+}
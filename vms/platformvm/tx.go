@@ -86,6 +86,10 @@ func (tx *Tx) Sign(c codec.Manager, signers [][]*crypto.PrivateKeySECP256K1R) er
 			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(keys)),
 		}
 		for i, key := range keys {
+			if key == nil {
+				// The signature is added later, by the holder of the key
+				continue
+			}
 			sig, err := key.SignHash(hash) // Sign hash
 			if err != nil {
 				return fmt.Errorf("problem generating credential: %w", err)
@@ -0,0 +1,108 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"container/heap"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// feeTx is a transaction in a txHeap along with the amount of DJTX it burns
+type feeTx struct {
+	tx *Tx
+	// amount of DJTX burned by [tx]
+	fee uint64
+	// order in which [tx] was added to the mempool. Used to break fee ties.
+	seq uint64
+	// index of this entry in the heap
+	index int
+}
+
+// txHeap is a collection of txs ordered by the amount of DJTX they burn. If
+// [maxHeap] is true, the first element is the tx that burns the most DJTX,
+// with ties broken in favor of the tx that was added first. Otherwise, the
+// first element is the tx that burns the least DJTX, with ties broken in favor
+// of the tx that was added last. This struct implements the heap interface.
+type txHeap struct {
+	maxHeap bool
+	txs     []*feeTx
+	// Key: Tx ID
+	// Value: entry of the tx in this heap
+	txIDToEntry map[ids.ID]*feeTx
+}
+
+func newTxHeap(maxHeap bool) *txHeap {
+	return &txHeap{
+		maxHeap:     maxHeap,
+		txIDToEntry: make(map[ids.ID]*feeTx),
+	}
+}
+
+func (h *txHeap) Len() int { return len(h.txs) }
+func (h *txHeap) Less(i, j int) bool {
+	iTx := h.txs[i]
+	jTx := h.txs[j]
+	if iTx.fee == jTx.fee {
+		return (iTx.seq < jTx.seq) == h.maxHeap
+	}
+	return (iTx.fee > jTx.fee) == h.maxHeap
+}
+func (h *txHeap) Swap(i, j int) {
+	h.txs[i], h.txs[j] = h.txs[j], h.txs[i]
+	h.txs[i].index = i
+	h.txs[j].index = j
+}
+
+// Add [tx], which burns [fee] DJTX, to the heap
+func (h *txHeap) Add(tx *Tx, fee, seq uint64) {
+	heap.Push(h, &feeTx{
+		tx:  tx,
+		fee: fee,
+		seq: seq,
+	})
+}
+
+// Has returns true iff the tx with ID [txID] is in the heap
+func (h *txHeap) Has(txID ids.ID) bool {
+	_, ok := h.txIDToEntry[txID]
+	return ok
+}
+
+// Peek returns the first tx in the heap and the amount of DJTX it burns
+func (h *txHeap) Peek() (*Tx, uint64) {
+	top := h.txs[0]
+	return top.tx, top.fee
+}
+
+// RemoveTop removes and returns the first tx in the heap
+func (h *txHeap) RemoveTop() *Tx { return heap.Pop(h).(*feeTx).tx }
+
+// Remove the tx with ID [txID] from the heap. Returns nil if the tx isn't in
+// the heap.
+func (h *txHeap) Remove(txID ids.ID) *Tx {
+	entry, ok := h.txIDToEntry[txID]
+	if !ok {
+		return nil
+	}
+	return heap.Remove(h, entry.index).(*feeTx).tx
+}
+
+// Push implements the heap interface
+func (h *txHeap) Push(x interface{}) {
+	entry := x.(*feeTx)
+	entry.index = len(h.txs)
+	h.txs = append(h.txs, entry)
+	h.txIDToEntry[entry.tx.ID()] = entry
+}
+
+// Pop implements the heap interface
+func (h *txHeap) Pop() interface{} {
+	newLen := len(h.txs) - 1
+	entry := h.txs[newLen]
+	h.txs[newLen] = nil
+	h.txs = h.txs[:newLen]
+	delete(h.txIDToEntry, entry.tx.ID())
+	return entry
+}
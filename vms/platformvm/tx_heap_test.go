@@ -0,0 +1,94 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+)
+
+func newTestFeeTx(seed byte) *Tx {
+	utx := &UnsignedCreateSubnetTx{}
+	utx.Initialize(nil, []byte{seed})
+	return &Tx{UnsignedTx: utx}
+}
+
+func TestTxHeapMaxFee(t *testing.T) {
+	h := newTxHeap(true)
+
+	tx0 := newTestFeeTx(0)
+	tx1 := newTestFeeTx(1)
+	tx2 := newTestFeeTx(2)
+	tx3 := newTestFeeTx(3)
+
+	h.Add(tx0, 1, 0)
+	h.Add(tx1, 3, 1)
+	h.Add(tx2, 2, 2)
+	h.Add(tx3, 3, 3)
+
+	if !h.Has(tx2.ID()) {
+		t.Fatal("should have tx2")
+	}
+	if tx, fee := h.Peek(); tx.ID() != tx1.ID() || fee != 3 {
+		t.Fatalf("expected tx1 with fee 3 but got %s with fee %d", tx.ID(), fee)
+	}
+
+	// Ties are broken in favor of the first tx added
+	for i, expectedTx := range []*Tx{tx1, tx3, tx2, tx0} {
+		if tx := h.RemoveTop(); tx.ID() != expectedTx.ID() {
+			t.Fatalf("expected tx %d to be %s but got %s", i, expectedTx.ID(), tx.ID())
+		}
+	}
+	if h.Len() != 0 {
+		t.Fatalf("expected the heap to be empty but has %d txs", h.Len())
+	}
+}
+
+func TestTxHeapMinFee(t *testing.T) {
+	h := newTxHeap(false)
+
+	tx0 := newTestFeeTx(0)
+	tx1 := newTestFeeTx(1)
+	tx2 := newTestFeeTx(2)
+	tx3 := newTestFeeTx(3)
+
+	h.Add(tx0, 2, 0)
+	h.Add(tx1, 1, 1)
+	h.Add(tx2, 3, 2)
+	h.Add(tx3, 1, 3)
+
+	// Ties are broken in favor of the last tx added
+	for i, expectedTx := range []*Tx{tx3, tx1, tx0, tx2} {
+		if tx := h.RemoveTop(); tx.ID() != expectedTx.ID() {
+			t.Fatalf("expected tx %d to be %s but got %s", i, expectedTx.ID(), tx.ID())
+		}
+	}
+}
+
+func TestTxHeapRemove(t *testing.T) {
+	h := newTxHeap(true)
+
+	tx0 := newTestFeeTx(0)
+	tx1 := newTestFeeTx(1)
+	tx2 := newTestFeeTx(2)
+
+	h.Add(tx0, 1, 0)
+	h.Add(tx1, 2, 1)
+	h.Add(tx2, 3, 2)
+
+	if tx := h.Remove(tx2.ID()); tx == nil || tx.ID() != tx2.ID() {
+		t.Fatal("should have removed tx2")
+	}
+	if tx := h.Remove(tx2.ID()); tx != nil {
+		t.Fatal("shouldn't have removed tx2 twice")
+	}
+	if h.Has(tx2.ID()) {
+		t.Fatal("shouldn't have tx2")
+	}
+	if tx := h.RemoveTop(); tx.ID() != tx1.ID() {
+		t.Fatalf("expected %s but got %s", tx1.ID(), tx.ID())
+	}
+	if tx := h.RemoveTop(); tx.ID() != tx0.ID() {
+		t.Fatalf("expected %s but got %s", tx0.ID(), tx.ID())
+	}
+}
@@ -70,9 +70,11 @@ var (
 	errDSCantValidate    = errors.New("new blockchain can't be validated by primary network")
 	errStartTimeTooEarly = errors.New("start time is before the current chain time")
 	errStartAfterEndTime = errors.New("start time is after the end time")
+	errNotApricotPhase3  = errors.New("transaction isn't valid before the Apricot Phase 3 upgrade")
 
 	_ block.ChainVM        = &VM{}
 	_ validators.Connector = &VM{}
+	_ common.AppVM         = &VM{}
 	_ secp256k1fx.VM       = &VM{}
 	_ Fx                   = &secp256k1fx.Fx{}
 )
@@ -100,6 +102,9 @@ type VM struct {
 	// channel to send messages to the consensus engine
 	toEngine chan<- common.Message
 
+	// Used to gossip mempool txs to our peers
+	appSender common.AppSender
+
 	internalState InternalState
 
 	// ID of the preferred block
@@ -127,6 +132,9 @@ type VM struct {
 	currentBlocks map[ids.ID]Block
 
 	lastVdrUpdate time.Time
+
+	// Chooses the UTXOs that fund the txs built by the APIs
+	coinSelector djtx.CoinSelector
 }
 
 // Initialize this blockchain.
@@ -142,6 +150,15 @@ func (vm *VM) Initialize(
 ) error {
 	ctx.Log.Verbo("initializing platform chain")
 
+	config, err := ParseConfig(configBytes)
+	if err != nil {
+		return err
+	}
+	vm.coinSelector, err = djtx.NewCoinSelector(config.CoinSelection)
+	if err != nil {
+		return err
+	}
+
 	// Initialize metrics as soon as possible
 	if err := vm.metrics.Initialize(ctx.Namespace, ctx.Metrics); err != nil {
 		return err
@@ -521,6 +538,12 @@ func (vm *VM) nextStakerChangeTime(vs MutableState) (time.Time, error) {
 	return earliest, nil
 }
 
+// isApricotPhase3 returns true if the rules introduced by Apricot Phase 3
+// apply to a block built on a chain whose timestamp is [timestamp]
+func (vm *VM) isApricotPhase3(timestamp time.Time) bool {
+	return !timestamp.Before(vm.ApricotPhase3Time)
+}
+
 func (vm *VM) Codec() codec.Manager { return vm.codec }
 
 func (vm *VM) CodecRegistry() codec.Registry { return vm.codecRegistry }
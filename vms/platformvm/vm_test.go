@@ -1118,6 +1118,80 @@ func TestRewardValidatorAccept(t *testing.T) {
 	}
 }
 
+type removedValidatorsListener struct {
+	removed ids.ShortSet
+}
+
+func (l *removedValidatorsListener) OnValidatorAdded(ids.ShortID, uint64) {}
+
+func (l *removedValidatorsListener) OnValidatorRemoved(vdrID ids.ShortID, _ uint64) {
+	l.removed.Add(vdrID)
+}
+
+func (l *removedValidatorsListener) OnValidatorWeightChanged(ids.ShortID, uint64, uint64) {}
+
+// Test that the listeners of the validator set are notified when a staker
+// change is committed
+func TestValidatorSetCallbackListener(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	vdrs, ok := vm.Validators.GetValidators(constants.PrimaryNetworkID)
+	if !ok {
+		t.Fatal("missing primary network validators")
+	}
+	listener := &removedValidatorsListener{}
+	vdrs.RegisterCallbackListener(listener)
+
+	// Fast forward clock to time for genesis validators to leave
+	vm.clock.Set(defaultValidateEndTime)
+
+	// Advance the timestamp and then reward the first genesis validator
+	for i := 0; i < 2; i++ {
+		blk, err := vm.BuildBlock()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := blk.Verify(); err != nil {
+			t.Fatal(err)
+		}
+		block := blk.(*ProposalBlock)
+		options, err := block.Options()
+		if err != nil {
+			t.Fatal(err)
+		}
+		commit := options[0].(*CommitBlock)
+		if err := block.Accept(); err != nil {
+			t.Fatal(err)
+		}
+		if err := commit.Verify(); err != nil {
+			t.Fatal(err)
+		}
+		if listener.removed.Len() != 0 {
+			t.Fatal("validators shouldn't be removed before the staker change is committed")
+		}
+		if err := commit.Accept(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if listener.removed.Len() != 1 {
+		t.Fatalf("expected 1 validator to be removed but got %d", listener.removed.Len())
+	}
+	currentStakers := vm.internalState.CurrentStakerChainState()
+	for vdrID := range listener.removed {
+		if _, err := currentStakers.GetValidator(vdrID); err == nil {
+			t.Fatalf("validator %s should have been removed", vdrID)
+		}
+	}
+}
+
 // Test case where primary network validator not rewarded
 func TestRewardValidatorReject(t *testing.T) {
 	vm, _ := defaultVM()
@@ -2159,6 +2233,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 		&engine,
 		vdrs,
 		msgChan,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -7,8 +7,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/go-plugin"
 
@@ -25,6 +29,9 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/chain"
 	"github.com/ava-labs/avalanchego/vms/components/missing"
@@ -43,7 +50,8 @@ import (
 var (
 	errUnsupportedFXs = errors.New("unsupported feature extensions")
 
-	_ block.ChainVM = &VMClient{}
+	_ block.ChainVM                  = &VMClient{}
+	_ validators.SetCallbackListener = &VMClient{}
 )
 
 const (
@@ -51,6 +59,11 @@ const (
 	missingCacheSize    = 256
 	unverifiedCacheSize = 256
 	bytesToIDCacheSize  = 512
+
+	// validatorChangeTimeout bounds the time spent forwarding a change of the
+	// validator set, so that an unresponsive remote VM doesn't hold up the
+	// changes queued after it.
+	validatorChangeTimeout = 5 * time.Second
 )
 
 // VMClient is an implementation of VM that talks over RPC.
@@ -71,13 +84,41 @@ type VMClient struct {
 	conns        []*grpc.ClientConn
 
 	ctx *snow.Context
+
+	// shutdown is set once the remote VM has been shut down, after which
+	// validator set changes are no longer forwarded to it.
+	shutdown utils.AtomicBool
+	// validatorsUnsupported is set once the remote VM reports that it doesn't
+	// track the validator set, after which the changes are no longer
+	// forwarded to it.
+	validatorsUnsupported utils.AtomicBool
+
+	// Changes to the validator set are queued by the set's callbacks and
+	// forwarded to the remote VM in the background, so that the node isn't
+	// blocked on the remote VM while it updates its validator set.
+	validatorChangesLock sync.Mutex
+	validatorChanges     []validatorChange
+	// validatorChangesQueued is signalled when a change is queued
+	validatorChangesQueued chan struct{}
+	// closed is closed once the VM has been shut down
+	closed chan struct{}
+}
+
+// validatorChange is a change of the weight of a validator that hasn't been
+// forwarded to the remote VM yet. A weight of 0 means the validator isn't in
+// the set.
+type validatorChange struct {
+	validatorID          ids.ShortID
+	oldWeight, newWeight uint64
 }
 
 // NewClient returns a VM connected to a remote VM
 func NewClient(client vmproto.VMClient, broker *plugin.GRPCBroker) *VMClient {
 	return &VMClient{
-		client: client,
-		broker: broker,
+		client:                 client,
+		broker:                 broker,
+		validatorChangesQueued: make(chan struct{}, 1),
+		closed:                 make(chan struct{}),
 	}
 }
 
@@ -126,6 +167,12 @@ func (vm *VMClient) Initialize(
 	vm.bcLookup = galiaslookup.NewServer(ctx.BCLookup)
 	vm.snLookup = gsubnetlookup.NewServer(ctx.SNLookup)
 
+	// queue the changes to the validator set, they are forwarded to the remote
+	// VM once it has been initialized
+	if ctx.Validators != nil {
+		ctx.Validators.RegisterCallbackListener(vm)
+	}
+
 	// start the db server
 	dbBrokerID := vm.broker.NextId()
 	go vm.broker.AcceptAndServe(dbBrokerID, vm.startDBServer)
@@ -173,6 +220,8 @@ func (vm *VMClient) Initialize(
 		return err
 	}
 
+	go vm.ctx.Log.RecoverAndPanic(vm.dispatchValidatorChanges)
+
 	id, err := ids.ToID(resp.LastAcceptedID)
 	if err != nil {
 		return err
@@ -278,6 +327,12 @@ func (vm *VMClient) Bootstrapped() error {
 }
 
 func (vm *VMClient) Shutdown() error {
+	vm.shutdown.SetValue(true)
+	if vm.ctx != nil && vm.ctx.Validators != nil {
+		vm.ctx.Validators.UnregisterCallbackListener(vm)
+	}
+	close(vm.closed)
+
 	errs := wrappers.Errs{}
 	_, err := vm.client.Shutdown(context.Background(), &vmproto.ShutdownRequest{})
 	errs.Add(err)
@@ -493,3 +548,114 @@ func (vm *VMClient) Connected(id ids.ShortID) error {
 func (vm *VMClient) Disconnected(id ids.ShortID) error {
 	return nil // noop
 }
+
+func (vm *VMClient) OnValidatorAdded(validatorID ids.ShortID, weight uint64) {
+	vm.queueValidatorChange(validatorID, 0, weight)
+}
+
+func (vm *VMClient) OnValidatorRemoved(validatorID ids.ShortID, weight uint64) {
+	vm.queueValidatorChange(validatorID, weight, 0)
+}
+
+func (vm *VMClient) OnValidatorWeightChanged(validatorID ids.ShortID, oldWeight, newWeight uint64) {
+	vm.queueValidatorChange(validatorID, oldWeight, newWeight)
+}
+
+// queueValidatorChange queues a change of the validator set to be forwarded to
+// the remote VM. It never blocks on the remote VM.
+func (vm *VMClient) queueValidatorChange(validatorID ids.ShortID, oldWeight, newWeight uint64) {
+	if !vm.forwardValidatorChanges() {
+		return
+	}
+
+	vm.validatorChangesLock.Lock()
+	vm.validatorChanges = append(vm.validatorChanges, validatorChange{
+		validatorID: validatorID,
+		oldWeight:   oldWeight,
+		newWeight:   newWeight,
+	})
+	vm.validatorChangesLock.Unlock()
+
+	select {
+	case vm.validatorChangesQueued <- struct{}{}:
+	default:
+	}
+}
+
+// dispatchValidatorChanges forwards the queued changes of the validator set to
+// the remote VM until the VM is shut down
+func (vm *VMClient) dispatchValidatorChanges() {
+	for {
+		select {
+		case <-vm.validatorChangesQueued:
+			vm.sendValidatorChanges()
+		case <-vm.closed:
+			return
+		}
+	}
+}
+
+// sendValidatorChanges forwards the currently queued changes of the validator
+// set to the remote VM, in the order they were made
+func (vm *VMClient) sendValidatorChanges() {
+	vm.validatorChangesLock.Lock()
+	changes := vm.validatorChanges
+	vm.validatorChanges = nil
+	vm.validatorChangesLock.Unlock()
+
+	for _, change := range changes {
+		if !vm.forwardValidatorChanges() {
+			return
+		}
+		vm.sendValidatorChange(change)
+	}
+}
+
+func (vm *VMClient) sendValidatorChange(change validatorChange) {
+	ctx, cancel := context.WithTimeout(context.Background(), validatorChangeTimeout)
+	defer cancel()
+
+	var err error
+	switch {
+	case change.oldWeight == 0:
+		_, err = vm.client.ValidatorAdded(ctx, &vmproto.ValidatorAddedRequest{
+			ValidatorID: change.validatorID.Bytes(),
+			Weight:      change.newWeight,
+		})
+		vm.handleValidatorChangeErr("addition", change.validatorID, err)
+	case change.newWeight == 0:
+		_, err = vm.client.ValidatorRemoved(ctx, &vmproto.ValidatorRemovedRequest{
+			ValidatorID: change.validatorID.Bytes(),
+			Weight:      change.oldWeight,
+		})
+		vm.handleValidatorChangeErr("removal", change.validatorID, err)
+	default:
+		_, err = vm.client.ValidatorWeightChanged(ctx, &vmproto.ValidatorWeightChangedRequest{
+			ValidatorID: change.validatorID.Bytes(),
+			OldWeight:   change.oldWeight,
+			NewWeight:   change.newWeight,
+		})
+		vm.handleValidatorChangeErr("weight change", change.validatorID, err)
+	}
+}
+
+// forwardValidatorChanges returns true if the changes to the validator set
+// should be forwarded to the remote VM.
+func (vm *VMClient) forwardValidatorChanges() bool {
+	return !vm.shutdown.GetValue() && !vm.validatorsUnsupported.GetValue()
+}
+
+// handleValidatorChangeErr logs the failure to forward a change of the
+// validator set. If the remote VM was built before it tracked the validator
+// set, the changes stop being forwarded to it.
+func (vm *VMClient) handleValidatorChangeErr(change string, validatorID ids.ShortID, err error) {
+	if err == nil {
+		return
+	}
+	if status.Code(err) == codes.Unimplemented {
+		vm.validatorsUnsupported.SetValue(true)
+		vm.ctx.Log.Info("the remote VM doesn't track the validator set, no longer forwarding its changes")
+		return
+	}
+	vm.ctx.Log.Error("failed to forward the %s of validator %s: %s", change, validatorID.PrefixedString(constants.NodeIDPrefix), err)
+}
@@ -0,0 +1,133 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/vmproto"
+)
+
+// legacyVMClient is a remote VM that doesn't track the validator set
+type legacyVMClient struct {
+	vmproto.VMClient
+
+	validatorCalls int
+}
+
+func (c *legacyVMClient) ValidatorAdded(context.Context, *vmproto.ValidatorAddedRequest, ...grpc.CallOption) (*vmproto.ValidatorAddedResponse, error) {
+	c.validatorCalls++
+	return nil, status.Error(codes.Unimplemented, "method ValidatorAdded not implemented")
+}
+
+func (c *legacyVMClient) ValidatorWeightChanged(context.Context, *vmproto.ValidatorWeightChangedRequest, ...grpc.CallOption) (*vmproto.ValidatorWeightChangedResponse, error) {
+	c.validatorCalls++
+	return nil, status.Error(codes.Unimplemented, "method ValidatorWeightChanged not implemented")
+}
+
+func TestValidatorChangesUnimplemented(t *testing.T) {
+	client := &legacyVMClient{}
+	vm := NewClient(client, nil)
+	vm.ctx = snow.DefaultContextTest()
+	vdrs := validators.NewSet()
+	vm.ctx.Validators = vdrs
+
+	vdrs.RegisterCallbackListener(vm)
+	err := vdrs.AddWeight(ids.ShortID{1}, 1)
+	assert.NoError(t, err)
+	err = vdrs.AddWeight(ids.ShortID{1}, 1)
+	assert.NoError(t, err)
+	vm.sendValidatorChanges()
+	assert.Equal(t, 1, client.validatorCalls)
+	assert.True(t, vm.validatorsUnsupported.GetValue())
+
+	// The changes are no longer forwarded to the remote VM
+	err = vdrs.AddWeight(ids.ShortID{2}, 1)
+	assert.NoError(t, err)
+	vm.sendValidatorChanges()
+	assert.Equal(t, 1, client.validatorCalls)
+}
+
+// blockingVMClient is a remote VM that doesn't respond to a change of the
+// validator set until it is released
+type blockingVMClient struct {
+	vmproto.VMClient
+
+	release chan struct{}
+	changes chan string
+}
+
+func (c *blockingVMClient) ValidatorAdded(_ context.Context, req *vmproto.ValidatorAddedRequest, _ ...grpc.CallOption) (*vmproto.ValidatorAddedResponse, error) {
+	<-c.release
+	c.changes <- fmt.Sprintf("added %x %d", req.ValidatorID, req.Weight)
+	return &vmproto.ValidatorAddedResponse{}, nil
+}
+
+func (c *blockingVMClient) ValidatorRemoved(_ context.Context, req *vmproto.ValidatorRemovedRequest, _ ...grpc.CallOption) (*vmproto.ValidatorRemovedResponse, error) {
+	<-c.release
+	c.changes <- fmt.Sprintf("removed %x %d", req.ValidatorID, req.Weight)
+	return &vmproto.ValidatorRemovedResponse{}, nil
+}
+
+func (c *blockingVMClient) ValidatorWeightChanged(_ context.Context, req *vmproto.ValidatorWeightChangedRequest, _ ...grpc.CallOption) (*vmproto.ValidatorWeightChangedResponse, error) {
+	<-c.release
+	c.changes <- fmt.Sprintf("changed %x %d %d", req.ValidatorID, req.OldWeight, req.NewWeight)
+	return &vmproto.ValidatorWeightChangedResponse{}, nil
+}
+
+func TestValidatorChangesDontBlock(t *testing.T) {
+	client := &blockingVMClient{
+		release: make(chan struct{}),
+		changes: make(chan string, 4),
+	}
+	vm := NewClient(client, nil)
+	vm.ctx = snow.DefaultContextTest()
+	vdrs := validators.NewSet()
+	vm.ctx.Validators = vdrs
+
+	// The existing validators are queued when the listener is registered
+	vdr0 := ids.ShortID{1}
+	err := vdrs.AddWeight(vdr0, 1)
+	assert.NoError(t, err)
+	vdrs.RegisterCallbackListener(vm)
+	go vm.dispatchValidatorChanges()
+	defer close(vm.closed)
+
+	// Changing the set doesn't wait for the remote VM
+	vdr1 := ids.ShortID{2}
+	err = vdrs.AddWeight(vdr1, 2)
+	assert.NoError(t, err)
+	err = vdrs.AddWeight(vdr1, 3)
+	assert.NoError(t, err)
+	err = vdrs.RemoveWeight(vdr0, 1)
+	assert.NoError(t, err)
+
+	close(client.release)
+	expectedChanges := []string{
+		fmt.Sprintf("added %x 1", vdr0.Bytes()),
+		fmt.Sprintf("added %x 2", vdr1.Bytes()),
+		fmt.Sprintf("changed %x 2 5", vdr1.Bytes()),
+		fmt.Sprintf("removed %x 1", vdr0.Bytes()),
+	}
+	for _, expectedChange := range expectedChanges {
+		select {
+		case change := <-client.changes:
+			assert.Equal(t, expectedChange, change)
+		case <-time.After(time.Second):
+			t.Fatalf("should have forwarded %q", expectedChange)
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
@@ -52,13 +53,18 @@ type VMServer struct {
 
 	ctx      *snow.Context
 	toEngine chan common.Message
+
+	// validators mirrors the validator set of the node, which is kept up to
+	// date by the node forwarding the changes to its set.
+	validators validators.Set
 }
 
 // NewServer returns a vm instance connected to a remote vm instance
 func NewServer(vm block.ChainVM, broker *plugin.GRPCBroker) *VMServer {
 	return &VMServer{
-		vm:     vm,
-		broker: broker,
+		vm:         vm,
+		broker:     broker,
+		validators: validators.NewSet(),
 	}
 }
 
@@ -189,6 +195,7 @@ func (vm *VMServer) Initialize(_ context.Context, req *vmproto.InitializeRequest
 		SNLookup:             snLookupClient,
 		EpochFirstTransition: epochFirstTransition,
 		EpochDuration:        time.Duration(req.EpochDuration),
+		Validators:           vm.validators,
 	}
 
 	if err := vm.vm.Initialize(vm.ctx, dbManager, req.GenesisBytes, req.UpgradeBytes, req.ConfigBytes, toEngine, nil); err != nil {
@@ -422,3 +429,32 @@ func (vm *VMServer) BlockReject(_ context.Context, req *vmproto.BlockRejectReque
 	}
 	return &vmproto.BlockRejectResponse{}, nil
 }
+
+func (vm *VMServer) ValidatorAdded(_ context.Context, req *vmproto.ValidatorAddedRequest) (*vmproto.ValidatorAddedResponse, error) {
+	validatorID, err := ids.ToShortID(req.ValidatorID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.ValidatorAddedResponse{}, vm.validators.AddWeight(validatorID, req.Weight)
+}
+
+func (vm *VMServer) ValidatorRemoved(_ context.Context, req *vmproto.ValidatorRemovedRequest) (*vmproto.ValidatorRemovedResponse, error) {
+	validatorID, err := ids.ToShortID(req.ValidatorID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.ValidatorRemovedResponse{}, vm.validators.RemoveWeight(validatorID, req.Weight)
+}
+
+func (vm *VMServer) ValidatorWeightChanged(_ context.Context, req *vmproto.ValidatorWeightChangedRequest) (*vmproto.ValidatorWeightChangedResponse, error) {
+	validatorID, err := ids.ToShortID(req.ValidatorID)
+	if err != nil {
+		return nil, err
+	}
+	if req.NewWeight > req.OldWeight {
+		err = vm.validators.AddWeight(validatorID, req.NewWeight-req.OldWeight)
+	} else {
+		err = vm.validators.RemoveWeight(validatorID, req.OldWeight-req.NewWeight)
+	}
+	return &vmproto.ValidatorWeightChangedResponse{}, err
+}
@@ -33,6 +33,9 @@ type VMClient interface {
 	BlockVerify(ctx context.Context, in *BlockVerifyRequest, opts ...grpc.CallOption) (*BlockVerifyResponse, error)
 	BlockAccept(ctx context.Context, in *BlockAcceptRequest, opts ...grpc.CallOption) (*BlockAcceptResponse, error)
 	BlockReject(ctx context.Context, in *BlockRejectRequest, opts ...grpc.CallOption) (*BlockRejectResponse, error)
+	ValidatorAdded(ctx context.Context, in *ValidatorAddedRequest, opts ...grpc.CallOption) (*ValidatorAddedResponse, error)
+	ValidatorRemoved(ctx context.Context, in *ValidatorRemovedRequest, opts ...grpc.CallOption) (*ValidatorRemovedResponse, error)
+	ValidatorWeightChanged(ctx context.Context, in *ValidatorWeightChangedRequest, opts ...grpc.CallOption) (*ValidatorWeightChangedResponse, error)
 }
 
 type vMClient struct {
@@ -178,6 +181,33 @@ func (c *vMClient) BlockReject(ctx context.Context, in *BlockRejectRequest, opts
 	return out, nil
 }
 
+func (c *vMClient) ValidatorAdded(ctx context.Context, in *ValidatorAddedRequest, opts ...grpc.CallOption) (*ValidatorAddedResponse, error) {
+	out := new(ValidatorAddedResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/ValidatorAdded", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) ValidatorRemoved(ctx context.Context, in *ValidatorRemovedRequest, opts ...grpc.CallOption) (*ValidatorRemovedResponse, error) {
+	out := new(ValidatorRemovedResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/ValidatorRemoved", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) ValidatorWeightChanged(ctx context.Context, in *ValidatorWeightChangedRequest, opts ...grpc.CallOption) (*ValidatorWeightChangedResponse, error) {
+	out := new(ValidatorWeightChangedResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/ValidatorWeightChanged", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VMServer is the server API for VM service.
 // All implementations must embed UnimplementedVMServer
 // for forward compatibility
@@ -197,6 +227,9 @@ type VMServer interface {
 	BlockVerify(context.Context, *BlockVerifyRequest) (*BlockVerifyResponse, error)
 	BlockAccept(context.Context, *BlockAcceptRequest) (*BlockAcceptResponse, error)
 	BlockReject(context.Context, *BlockRejectRequest) (*BlockRejectResponse, error)
+	ValidatorAdded(context.Context, *ValidatorAddedRequest) (*ValidatorAddedResponse, error)
+	ValidatorRemoved(context.Context, *ValidatorRemovedRequest) (*ValidatorRemovedResponse, error)
+	ValidatorWeightChanged(context.Context, *ValidatorWeightChangedRequest) (*ValidatorWeightChangedResponse, error)
 	mustEmbedUnimplementedVMServer()
 }
 
@@ -249,6 +282,15 @@ func (UnimplementedVMServer) BlockAccept(context.Context, *BlockAcceptRequest) (
 func (UnimplementedVMServer) BlockReject(context.Context, *BlockRejectRequest) (*BlockRejectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BlockReject not implemented")
 }
+func (UnimplementedVMServer) ValidatorAdded(context.Context, *ValidatorAddedRequest) (*ValidatorAddedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatorAdded not implemented")
+}
+func (UnimplementedVMServer) ValidatorRemoved(context.Context, *ValidatorRemovedRequest) (*ValidatorRemovedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatorRemoved not implemented")
+}
+func (UnimplementedVMServer) ValidatorWeightChanged(context.Context, *ValidatorWeightChangedRequest) (*ValidatorWeightChangedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatorWeightChanged not implemented")
+}
 func (UnimplementedVMServer) mustEmbedUnimplementedVMServer() {}
 
 // UnsafeVMServer may be embedded to opt out of forward compatibility for this service.
@@ -532,6 +574,60 @@ func _VM_BlockReject_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VM_ValidatorAdded_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatorAddedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).ValidatorAdded(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/ValidatorAdded",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).ValidatorAdded(ctx, req.(*ValidatorAddedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_ValidatorRemoved_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatorRemovedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).ValidatorRemoved(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/ValidatorRemoved",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).ValidatorRemoved(ctx, req.(*ValidatorRemovedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_ValidatorWeightChanged_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatorWeightChangedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).ValidatorWeightChanged(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/ValidatorWeightChanged",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).ValidatorWeightChanged(ctx, req.(*ValidatorWeightChangedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VM_ServiceDesc is the grpc.ServiceDesc for VM service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -599,6 +695,18 @@ var VM_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BlockReject",
 			Handler:    _VM_BlockReject_Handler,
 		},
+		{
+			MethodName: "ValidatorAdded",
+			Handler:    _VM_ValidatorAdded_Handler,
+		},
+		{
+			MethodName: "ValidatorRemoved",
+			Handler:    _VM_ValidatorRemoved_Handler,
+		},
+		{
+			MethodName: "ValidatorWeightChanged",
+			Handler:    _VM_ValidatorWeightChanged_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vm.proto",
@@ -0,0 +1,57 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	errNilBurnOperation = errors.New("nil burn operation")
+	errNoBurnedAmount   = errors.New("burn operation must burn a positive amount")
+	errTooManyChanges   = errors.New("burn operation can have at most one change output")
+)
+
+// BurnOperation destroys funds of a transfer output. The funds that aren't
+// burned, if any, are sent to a change output.
+type BurnOperation struct {
+	Input `serialize:"true"`
+
+	// Amount of funds that are destroyed
+	Amt uint64 `serialize:"true" json:"amount"`
+
+	// Change holds the funds that aren't burned. Empty if all of the funds are
+	// burned.
+	Change []*TransferOutput `serialize:"true" json:"change"`
+}
+
+func (op *BurnOperation) Outs() []verify.State {
+	outs := make([]verify.State, len(op.Change))
+	for i, out := range op.Change {
+		outs[i] = out
+	}
+	return outs
+}
+
+func (op *BurnOperation) Verify() error {
+	switch {
+	case op == nil:
+		return errNilBurnOperation
+	case op.Amt == 0:
+		return errNoBurnedAmount
+	case len(op.Change) > 1:
+		return errTooManyChanges
+	}
+	if err := op.Input.Verify(); err != nil {
+		return err
+	}
+	for _, out := range op.Change {
+		if err := out.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+func TestBurnOperationVerify(t *testing.T) {
+	change := &TransferOutput{
+		Amt: 1,
+		OutputOwners: OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		},
+	}
+	tests := []struct {
+		description string
+		op          *BurnOperation
+		expectedErr error
+	}{
+		{
+			description: "nil operation",
+			op:          nil,
+			expectedErr: errNilBurnOperation,
+		},
+		{
+			description: "nothing burned",
+			op:          &BurnOperation{},
+			expectedErr: errNoBurnedAmount,
+		},
+		{
+			description: "several change outputs",
+			op: &BurnOperation{
+				Amt:    1,
+				Change: []*TransferOutput{change, change},
+			},
+			expectedErr: errTooManyChanges,
+		},
+		{
+			description: "invalid change output",
+			op: &BurnOperation{
+				Amt:    1,
+				Change: []*TransferOutput{{}},
+			},
+			expectedErr: errNoValueOutput,
+		},
+		{
+			description: "unsorted signature indices",
+			op: &BurnOperation{
+				Input: Input{SigIndices: []uint32{1, 0}},
+				Amt:   1,
+			},
+			expectedErr: errNotSortedUnique,
+		},
+		{
+			description: "valid",
+			op: &BurnOperation{
+				Input:  Input{SigIndices: []uint32{0}},
+				Amt:    1,
+				Change: []*TransferOutput{change},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			if err := test.op.Verify(); err != test.expectedErr {
+				t.Fatalf("expected %v but got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestBurnOperationOuts(t *testing.T) {
+	op := &BurnOperation{Amt: 1}
+	if outs := op.Outs(); len(outs) != 0 {
+		t.Fatalf("expected no outputs but got %d", len(outs))
+	}
+	op.Change = []*TransferOutput{{Amt: 1}}
+	if outs := op.Outs(); len(outs) != 1 {
+		t.Fatalf("expected 1 output but got %d", len(outs))
+	}
+}
+
+func TestBurnOperationState(t *testing.T) {
+	intf := interface{}(&BurnOperation{})
+	if _, ok := intf.(verify.State); ok {
+		t.Fatalf("shouldn't be marked as state")
+	}
+}
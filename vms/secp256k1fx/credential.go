@@ -8,16 +8,25 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 )
 
-var errNilCredential = errors.New("nil credential")
+var (
+	errNilCredential         = errors.New("nil credential")
+	errWrongNumberOfSigners  = errors.New("wrong number of signers")
+	errWrongNumberOfSigs     = errors.New("credentials have different numbers of signatures")
+	errConflictingSignatures = errors.New("credentials have conflicting signatures")
+)
 
 const (
 	defaultEncoding = formatting.Hex
 )
 
+// emptySig is the value of a signature that hasn't been added yet
+var emptySig [crypto.SECP256K1RSigLen]byte
+
 type Credential struct {
 	Sigs [][crypto.SECP256K1RSigLen]byte `serialize:"true" json:"signatures"`
 }
@@ -48,3 +57,59 @@ func (cr *Credential) Verify() error {
 		return nil
 	}
 }
+
+// NumMissingSigs returns the number of signatures that haven't been added to
+// this credential yet. A missing signature is left empty.
+func (cr *Credential) NumMissingSigs() int {
+	numMissing := 0
+	for _, sig := range cr.Sigs {
+		if sig == emptySig {
+			numMissing++
+		}
+	}
+	return numMissing
+}
+
+// Sign adds the missing signatures over [hash] that can be produced by the keys
+// in [kc]. [signers] are the addresses that must sign this credential, in the
+// order of the signatures. Returns the number of signatures that were added.
+func (cr *Credential) Sign(hash []byte, signers []ids.ShortID, kc *Keychain) (int, error) {
+	if len(signers) != len(cr.Sigs) {
+		return 0, errWrongNumberOfSigners
+	}
+	numSigned := 0
+	for i, signer := range signers {
+		if cr.Sigs[i] != emptySig {
+			continue
+		}
+		key, exists := kc.Get(signer)
+		if !exists {
+			continue
+		}
+		sig, err := key.SignHash(hash)
+		if err != nil {
+			return numSigned, fmt.Errorf("problem generating credential: %w", err)
+		}
+		copy(cr.Sigs[i][:], sig)
+		numSigned++
+	}
+	return numSigned, nil
+}
+
+// Merge adds the signatures of [other] that are missing from this credential.
+// Both credentials must be for the same input of the same tx.
+func (cr *Credential) Merge(other *Credential) error {
+	if len(cr.Sigs) != len(other.Sigs) {
+		return errWrongNumberOfSigs
+	}
+	for i, sig := range other.Sigs {
+		switch {
+		case sig == emptySig:
+		case cr.Sigs[i] == emptySig:
+			cr.Sigs[i] = sig
+		case cr.Sigs[i] != sig:
+			return errConflictingSignatures
+		}
+	}
+	return nil
+}
@@ -9,7 +9,9 @@ import (
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 )
 
@@ -107,3 +109,78 @@ func TestCredentialNotState(t *testing.T) {
 		t.Fatalf("shouldn't be marked as state")
 	}
 }
+
+func TestCredentialPartialSigning(t *testing.T) {
+	hash := hashing.ComputeHash256([]byte{1, 2, 3})
+
+	kc0 := NewKeychain()
+	sk0, err := kc0.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	kc1 := NewKeychain()
+	sk1, err := kc1.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signers := []ids.ShortID{
+		sk0.PublicKey().Address(),
+		sk1.PublicKey().Address(),
+	}
+
+	cred0 := &Credential{Sigs: make([][crypto.SECP256K1RSigLen]byte, len(signers))}
+	cred1 := &Credential{Sigs: make([][crypto.SECP256K1RSigLen]byte, len(signers))}
+	if numMissing := cred0.NumMissingSigs(); numMissing != 2 {
+		t.Fatalf("expected 2 missing signatures but got %d", numMissing)
+	}
+
+	if _, err := cred0.Sign(hash, signers[:1], kc0); err != errWrongNumberOfSigners {
+		t.Fatalf("expected %s but got %v", errWrongNumberOfSigners, err)
+	}
+	if numSigned, err := cred0.Sign(hash, signers, kc0); err != nil {
+		t.Fatal(err)
+	} else if numSigned != 1 {
+		t.Fatalf("expected 1 signature to be added but got %d", numSigned)
+	}
+	if numSigned, err := cred1.Sign(hash, signers, kc1); err != nil {
+		t.Fatal(err)
+	} else if numSigned != 1 {
+		t.Fatalf("expected 1 signature to be added but got %d", numSigned)
+	}
+	if numMissing := cred0.NumMissingSigs(); numMissing != 1 {
+		t.Fatalf("expected 1 missing signature but got %d", numMissing)
+	}
+
+	// Signing again doesn't add any signatures
+	if numSigned, err := cred0.Sign(hash, signers, kc0); err != nil {
+		t.Fatal(err)
+	} else if numSigned != 0 {
+		t.Fatalf("expected no signatures to be added but got %d", numSigned)
+	}
+
+	if err := cred0.Merge(cred1); err != nil {
+		t.Fatal(err)
+	}
+	if numMissing := cred0.NumMissingSigs(); numMissing != 0 {
+		t.Fatalf("expected no missing signatures but got %d", numMissing)
+	}
+
+	factory := crypto.FactorySECP256K1R{}
+	for i, sig := range cred0.Sigs {
+		pk, err := factory.RecoverHashPublicKey(hash, sig[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pk.Address() != signers[i] {
+			t.Fatalf("expected signature %d from %s but got from %s", i, signers[i], pk.Address())
+		}
+	}
+
+	cred1.Sigs[0][0]++
+	if err := cred0.Merge(cred1); err != errConflictingSignatures {
+		t.Fatalf("expected %s but got %v", errConflictingSignatures, err)
+	}
+	if err := cred0.Merge(&Credential{}); err != errWrongNumberOfSigs {
+		t.Fatalf("expected %s but got %v", errWrongNumberOfSigs, err)
+	}
+}
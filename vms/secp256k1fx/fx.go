@@ -8,8 +8,10 @@ import (
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/hashing"
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 )
@@ -28,6 +30,7 @@ var (
 	errWrongOwnerType                 = errors.New("wrong owner type")
 	errWrongNumberOfUTXOs             = errors.New("wrong number of utxos for the operation")
 	errWrongMintCreated               = errors.New("wrong mint output created from the operation")
+	errWrongBurnedAmount              = errors.New("burned and change amounts don't add up to the utxo amount")
 	errTimelocked                     = errors.New("output is time locked")
 	errTooManySigners                 = errors.New("input has more signers than expected")
 	errTooFewSigners                  = errors.New("input has less signers than expected")
@@ -65,6 +68,27 @@ func (fx *Fx) Initialize(vmIntf interface{}) error {
 	return errs.Err
 }
 
+// InitializeVesting registers the vesting types of this fx with [c]. They are
+// registered separately from the other types of this fx so that a VM can
+// register them after the types of all of its fxs, which leaves the type IDs
+// of the existing types unchanged.
+func (fx *Fx) InitializeVesting(c codec.Registry) error {
+	return c.RegisterType(&VestingOutput{})
+}
+
+// InitializeBurning registers the burn and renounce mint operations of this
+// fx with [c]. Like the vesting types, they are registered after the types of
+// all of the fxs, and after the vesting types, so that the type IDs of the
+// existing types are unchanged.
+func (fx *Fx) InitializeBurning(c codec.Registry) error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&BurnOperation{}),
+		c.RegisterType(&RenounceMintOperation{}),
+	)
+	return errs.Err
+}
+
 func (fx *Fx) InitializeVM(vmIntf interface{}) error {
 	vm, ok := vmIntf.(VM)
 	if !ok {
@@ -107,10 +131,6 @@ func (fx *Fx) VerifyOperation(txIntf, opIntf, credIntf interface{}, utxosIntf []
 	if !ok {
 		return errWrongTxType
 	}
-	op, ok := opIntf.(*MintOperation)
-	if !ok {
-		return errWrongOpType
-	}
 	cred, ok := credIntf.(*Credential)
 	if !ok {
 		return errWrongCredentialType
@@ -118,11 +138,28 @@ func (fx *Fx) VerifyOperation(txIntf, opIntf, credIntf interface{}, utxosIntf []
 	if len(utxosIntf) != 1 {
 		return errWrongNumberOfUTXOs
 	}
-	out, ok := utxosIntf[0].(*MintOutput)
-	if !ok {
-		return errWrongUTXOType
+	switch op := opIntf.(type) {
+	case *MintOperation:
+		out, ok := utxosIntf[0].(*MintOutput)
+		if !ok {
+			return errWrongUTXOType
+		}
+		return fx.verifyOperation(tx, op, cred, out)
+	case *BurnOperation:
+		out, ok := utxosIntf[0].(*TransferOutput)
+		if !ok {
+			return errWrongUTXOType
+		}
+		return fx.verifyBurnOperation(tx, op, cred, out)
+	case *RenounceMintOperation:
+		out, ok := utxosIntf[0].(*MintOutput)
+		if !ok {
+			return errWrongUTXOType
+		}
+		return fx.verifyRenounceMintOperation(tx, op, cred, out)
+	default:
+		return errWrongOpType
 	}
-	return fx.verifyOperation(tx, op, cred, out)
 }
 
 func (fx *Fx) verifyOperation(tx Tx, op *MintOperation, cred *Credential, utxo *MintOutput) error {
@@ -135,6 +172,31 @@ func (fx *Fx) verifyOperation(tx Tx, op *MintOperation, cred *Credential, utxo *
 	return fx.VerifyCredentials(tx, &op.MintInput, cred, &utxo.OutputOwners)
 }
 
+func (fx *Fx) verifyBurnOperation(tx Tx, op *BurnOperation, cred *Credential, utxo *TransferOutput) error {
+	if err := verify.All(op, cred, utxo); err != nil {
+		return err
+	}
+	amount := op.Amt
+	for _, out := range op.Change {
+		newAmount, err := safemath.Add64(amount, out.Amt)
+		if err != nil {
+			return err
+		}
+		amount = newAmount
+	}
+	if amount != utxo.Amt {
+		return errWrongBurnedAmount
+	}
+	return fx.VerifyCredentials(tx, &op.Input, cred, &utxo.OutputOwners)
+}
+
+func (fx *Fx) verifyRenounceMintOperation(tx Tx, op *RenounceMintOperation, cred *Credential, utxo *MintOutput) error {
+	if err := verify.All(op, cred, utxo); err != nil {
+		return err
+	}
+	return fx.VerifyCredentials(tx, &op.MintInput, cred, &utxo.OutputOwners)
+}
+
 func (fx *Fx) VerifyTransfer(txIntf, inIntf, credIntf, utxoIntf interface{}) error {
 	tx, ok := txIntf.(Tx)
 	if !ok {
@@ -148,11 +210,19 @@ func (fx *Fx) VerifyTransfer(txIntf, inIntf, credIntf, utxoIntf interface{}) err
 	if !ok {
 		return errWrongCredentialType
 	}
-	out, ok := utxoIntf.(*TransferOutput)
-	if !ok {
+	switch out := utxoIntf.(type) {
+	case *TransferOutput:
+		return fx.VerifySpend(tx, in, cred, out)
+	case *VestingOutput:
+		// The VM ensures that the funds that are still locked are sent to a
+		// new vesting output
+		if err := out.VestingSchedule.Verify(); err != nil {
+			return err
+		}
+		return fx.VerifySpend(tx, in, cred, &out.TransferOutput)
+	default:
 		return errWrongUTXOType
 	}
-	return fx.VerifySpend(tx, in, cred, out)
 }
 
 // VerifySpend ensures that the utxo can be sent to any address
@@ -1141,3 +1141,251 @@ func TestVerifyPermission(t *testing.T) {
 		}
 	}
 }
+
+func TestFxVerifyTransferVesting(t *testing.T) {
+	vm := TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	date := time.Date(2019, time.January, 19, 16, 25, 17, 3, time.UTC)
+	vm.CLK.Set(date)
+	fx := Fx{}
+	if err := fx.Initialize(&vm); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.InitializeVesting(vm.CodecRegistry()); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.Bootstrapping(); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+	tx := &TestTx{Bytes: txBytes}
+	out := &VestingOutput{
+		VestingSchedule: VestingSchedule{
+			StartTime:      uint64(date.Unix()),
+			PeriodDuration: 1,
+			NumPeriods:     10,
+			TotalAmount:    10,
+		},
+		TransferOutput: TransferOutput{
+			Amt: 10,
+			OutputOwners: OutputOwners{
+				Locktime:  0,
+				Threshold: 1,
+				Addrs: []ids.ShortID{
+					addr,
+				},
+			},
+		},
+	}
+	in := &TransferInput{
+		Amt: 10,
+		Input: Input{
+			SigIndices: []uint32{0},
+		},
+	}
+	cred := &Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{
+			sigBytes,
+		},
+	}
+
+	// The fx only verifies the spend. The VM verifies that the locked funds
+	// are sent to a new vesting output.
+	if err := fx.VerifyTransfer(tx, in, cred, out); err != nil {
+		t.Fatal(err)
+	}
+
+	in.Amt = 9
+	if err := fx.VerifyTransfer(tx, in, cred, out); err == nil {
+		t.Fatal("should have errored due to an input amount mismatch")
+	}
+
+	in.Amt = 10
+	out.NumPeriods = 0
+	if err := fx.VerifyTransfer(tx, in, cred, out); err == nil {
+		t.Fatal("should have errored due to an invalid vesting schedule")
+	}
+}
+
+func TestFxVerifyBurnOperation(t *testing.T) {
+	vm := TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	date := time.Date(2019, time.January, 19, 16, 25, 17, 3, time.UTC)
+	vm.CLK.Set(date)
+	fx := Fx{}
+	if err := fx.Initialize(&vm); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.InitializeBurning(vm.CodecRegistry()); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+	tx := &TestTx{Bytes: txBytes}
+	owners := OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}
+	cred := &Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{
+			sigBytes,
+		},
+	}
+	tests := []struct {
+		description string
+		utxo        interface{}
+		op          *BurnOperation
+		shouldErr   bool
+	}{
+		{
+			description: "burn everything",
+			utxo: &TransferOutput{
+				Amt:          3,
+				OutputOwners: owners,
+			},
+			op: &BurnOperation{
+				Input: Input{SigIndices: []uint32{0}},
+				Amt:   3,
+			},
+		},
+		{
+			description: "burn with change",
+			utxo: &TransferOutput{
+				Amt:          3,
+				OutputOwners: owners,
+			},
+			op: &BurnOperation{
+				Input: Input{SigIndices: []uint32{0}},
+				Amt:   1,
+				Change: []*TransferOutput{{
+					Amt:          2,
+					OutputOwners: owners,
+				}},
+			},
+		},
+		{
+			description: "funds created",
+			utxo: &TransferOutput{
+				Amt:          3,
+				OutputOwners: owners,
+			},
+			op: &BurnOperation{
+				Input: Input{SigIndices: []uint32{0}},
+				Amt:   2,
+				Change: []*TransferOutput{{
+					Amt:          2,
+					OutputOwners: owners,
+				}},
+			},
+			shouldErr: true,
+		},
+		{
+			description: "funds unaccounted for",
+			utxo: &TransferOutput{
+				Amt:          3,
+				OutputOwners: owners,
+			},
+			op: &BurnOperation{
+				Input: Input{SigIndices: []uint32{0}},
+				Amt:   2,
+			},
+			shouldErr: true,
+		},
+		{
+			description: "wrong signer",
+			utxo: &TransferOutput{
+				Amt: 3,
+				OutputOwners: OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr2},
+				},
+			},
+			op: &BurnOperation{
+				Input: Input{SigIndices: []uint32{0}},
+				Amt:   3,
+			},
+			shouldErr: true,
+		},
+		{
+			description: "mint output",
+			utxo: &MintOutput{
+				OutputOwners: owners,
+			},
+			op: &BurnOperation{
+				Input: Input{SigIndices: []uint32{0}},
+				Amt:   3,
+			},
+			shouldErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := fx.VerifyOperation(tx, test.op, cred, []interface{}{test.utxo})
+			if test.shouldErr && err == nil {
+				t.Fatal("should have failed verification")
+			} else if !test.shouldErr && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestFxVerifyRenounceMintOperation(t *testing.T) {
+	vm := TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	date := time.Date(2019, time.January, 19, 16, 25, 17, 3, time.UTC)
+	vm.CLK.Set(date)
+	fx := Fx{}
+	if err := fx.Initialize(&vm); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.InitializeBurning(vm.CodecRegistry()); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+	tx := &TestTx{Bytes: txBytes}
+	op := &RenounceMintOperation{
+		MintInput: Input{SigIndices: []uint32{0}},
+	}
+	cred := &Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{
+			sigBytes,
+		},
+	}
+	owners := OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}
+
+	utxos := []interface{}{&MintOutput{OutputOwners: owners}}
+	if err := fx.VerifyOperation(tx, op, cred, utxos); err != nil {
+		t.Fatal(err)
+	}
+
+	utxos = []interface{}{&MintOutput{OutputOwners: OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr2},
+	}}}
+	if err := fx.VerifyOperation(tx, op, cred, utxos); err == nil {
+		t.Fatal("should have failed to renounce another minter's output")
+	}
+
+	utxos = []interface{}{&TransferOutput{
+		Amt:          1,
+		OutputOwners: owners,
+	}}
+	if err := fx.VerifyOperation(tx, op, cred, utxos); err != errWrongUTXOType {
+		t.Fatalf("expected %s but got %v", errWrongUTXOType, err)
+	}
+}
@@ -6,6 +6,7 @@ package secp256k1fx
 import (
 	"errors"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 )
 
@@ -32,3 +33,16 @@ func (in *Input) Verify() error {
 		return nil
 	}
 }
+
+// Signers returns the addresses of [owners] that must sign this input, in the
+// order of the signatures
+func (in *Input) Signers(owners *OutputOwners) ([]ids.ShortID, error) {
+	signers := make([]ids.ShortID, len(in.SigIndices))
+	for i, index := range in.SigIndices {
+		if index >= uint32(len(owners.Addrs)) {
+			return nil, errInputOutputIndexOutOfBounds
+		}
+		signers[i] = owners.Addrs[index]
+	}
+	return signers, nil
+}
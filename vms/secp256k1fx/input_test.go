@@ -5,6 +5,8 @@ package secp256k1fx
 
 import (
 	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
 )
 
 func TestInputVerifyNil(t *testing.T) {
@@ -13,3 +15,28 @@ func TestInputVerifyNil(t *testing.T) {
 		t.Fatalf("Input.Verify should have returned an error due to an nil input")
 	}
 }
+
+func TestInputSigners(t *testing.T) {
+	owners := &OutputOwners{
+		Threshold: 2,
+		Addrs: []ids.ShortID{
+			ids.GenerateTestShortID(),
+			ids.GenerateTestShortID(),
+			ids.GenerateTestShortID(),
+		},
+	}
+
+	in := &Input{SigIndices: []uint32{0, 2}}
+	signers, err := in.Signers(owners)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signers) != 2 || signers[0] != owners.Addrs[0] || signers[1] != owners.Addrs[2] {
+		t.Fatalf("wrong signers %v", signers)
+	}
+
+	in = &Input{SigIndices: []uint32{3}}
+	if _, err := in.Signers(owners); err != errInputOutputIndexOutOfBounds {
+		t.Fatalf("expected %s but got %v", errInputOutputIndexOutOfBounds, err)
+	}
+}
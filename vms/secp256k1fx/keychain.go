@@ -20,6 +20,8 @@ var errCantSpend = errors.New("unable to spend this UTXO")
 type Keychain struct {
 	factory        *crypto.FactorySECP256K1R
 	addrToKeyIndex map[ids.ShortID]int
+	// Addresses whose keys aren't held by this keychain
+	watchedAddrs ids.ShortSet
 
 	// These can be used to iterate over. However, they should not be modified externally.
 	Addrs ids.ShortSet
@@ -38,12 +40,24 @@ func NewKeychain() *Keychain {
 func (kc *Keychain) Add(key *crypto.PrivateKeySECP256K1R) {
 	addr := key.PublicKey().Address()
 	if _, ok := kc.addrToKeyIndex[addr]; !ok {
+		kc.watchedAddrs.Remove(addr)
 		kc.addrToKeyIndex[addr] = len(kc.Keys)
 		kc.Keys = append(kc.Keys, key)
 		kc.Addrs.Add(addr)
 	}
 }
 
+// AddAddress adds an address, whose key is held elsewhere, to the keychain.
+// Outputs owned by the address can be matched, but a nil key is returned in
+// place of the address's key. The address's signature must be added to the tx
+// separately.
+func (kc *Keychain) AddAddress(addr ids.ShortID) {
+	if _, ok := kc.addrToKeyIndex[addr]; !ok {
+		kc.watchedAddrs.Add(addr)
+		kc.Addrs.Add(addr)
+	}
+}
+
 // Get a key from the keychain. If the key is unknown, the
 func (kc Keychain) Get(id ids.ShortID) (*crypto.PrivateKeySECP256K1R, bool) {
 	if i, ok := kc.addrToKeyIndex[id]; ok {
@@ -99,9 +113,13 @@ func (kc *Keychain) Match(owners *OutputOwners, time uint64) ([]uint32, []*crypt
 	sigs := make([]uint32, 0, owners.Threshold)
 	keys := make([]*crypto.PrivateKeySECP256K1R, 0, owners.Threshold)
 	for i := uint32(0); i < uint32(len(owners.Addrs)) && uint32(len(keys)) < owners.Threshold; i++ {
-		if key, exists := kc.Get(owners.Addrs[i]); exists {
+		addr := owners.Addrs[i]
+		if key, exists := kc.Get(addr); exists {
 			sigs = append(sigs, i)
 			keys = append(keys, key)
+		} else if kc.watchedAddrs.Contains(addr) {
+			sigs = append(sigs, i)
+			keys = append(keys, nil)
 		}
 	}
 	return sigs, keys, uint32(len(keys)) == owners.Threshold
@@ -345,3 +345,52 @@ func TestKeychainPrefixedString(t *testing.T) {
 		t.Fatalf(`Keychain.PrefixedString("xD") returned:\n%s\nexpected:\n%s`, result, expected)
 	}
 }
+
+func TestKeychainMatchWatchedAddress(t *testing.T) {
+	kc := NewKeychain()
+	sk, err := kc.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	watchedAddr := ids.GenerateTestShortID()
+
+	owners := OutputOwners{
+		Threshold: 2,
+		Addrs: []ids.ShortID{
+			watchedAddr,
+			sk.PublicKey().Address(),
+		},
+	}
+	ids.SortShortIDs(owners.Addrs)
+	if err := owners.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, ok := kc.Match(&owners, 0); ok {
+		t.Fatalf("Shouldn't have been able to match with the owners")
+	}
+
+	kc.AddAddress(watchedAddr)
+	if !kc.Addrs.Contains(watchedAddr) {
+		t.Fatalf("Should manage the watched address")
+	}
+	if _, exists := kc.Get(watchedAddr); exists {
+		t.Fatalf("Shouldn't have a key for the watched address")
+	}
+
+	indices, keys, ok := kc.Match(&owners, 0)
+	if !ok {
+		t.Fatalf("Should have been able to match with the owners")
+	} else if len(indices) != 2 || len(keys) != 2 {
+		t.Fatalf("Should have returned two indices and two keys")
+	}
+	for i, index := range indices {
+		addr := owners.Addrs[index]
+		switch {
+		case addr == watchedAddr && keys[i] != nil:
+			t.Fatalf("Should have returned a nil key for the watched address")
+		case addr != watchedAddr && keys[i] != sk:
+			t.Fatalf("Returned wrong key")
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var errNilRenounceMintOperation = errors.New("nil renounce mint operation")
+
+// RenounceMintOperation consumes a mint output without replacing it, which
+// permanently revokes the minting rights that the output granted
+type RenounceMintOperation struct {
+	MintInput Input `serialize:"true" json:"mintInput"`
+}
+
+func (op *RenounceMintOperation) Outs() []verify.State { return nil }
+
+func (op *RenounceMintOperation) Verify() error {
+	if op == nil {
+		return errNilRenounceMintOperation
+	}
+	return op.MintInput.Verify()
+}
@@ -0,0 +1,36 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+func TestRenounceMintOperationVerify(t *testing.T) {
+	if err := (*RenounceMintOperation)(nil).Verify(); err != errNilRenounceMintOperation {
+		t.Fatalf("expected %s but got %v", errNilRenounceMintOperation, err)
+	}
+	op := &RenounceMintOperation{
+		MintInput: Input{SigIndices: []uint32{1, 0}},
+	}
+	if err := op.Verify(); err != errNotSortedUnique {
+		t.Fatalf("expected %s but got %v", errNotSortedUnique, err)
+	}
+	op.MintInput.SigIndices = []uint32{0}
+	if err := op.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if outs := op.Outs(); len(outs) != 0 {
+		t.Fatalf("expected no outputs but got %d", len(outs))
+	}
+}
+
+func TestRenounceMintOperationState(t *testing.T) {
+	intf := interface{}(&RenounceMintOperation{})
+	if _, ok := intf.(verify.State); ok {
+		t.Fatalf("shouldn't be marked as state")
+	}
+}
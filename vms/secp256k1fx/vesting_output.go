@@ -0,0 +1,124 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errNoVestingPeriods      = errors.New("vesting schedule has no periods")
+	errZeroPeriodDuration    = errors.New("vesting schedule has a zero period duration")
+	errNoVestingAmount       = errors.New("vesting schedule vests no funds")
+	errVestingOverflow       = errors.New("vesting schedule ends after the maximum time")
+	errAmountExceedsSchedule = errors.New("output amount is greater than the amount vested by its schedule")
+
+	_ verify.State = &VestingOutput{}
+)
+
+// VestingSchedule unlocks [TotalAmount] linearly over [NumPeriods] periods
+// that start at [StartTime]. No funds are unlocked before the cliff, at which
+// point the funds of all the elapsed periods are unlocked at once.
+type VestingSchedule struct {
+	// Unix time that the first period starts at
+	StartTime uint64 `serialize:"true" json:"startTime"`
+	// Number of seconds after [StartTime] before any funds are unlocked
+	Cliff uint64 `serialize:"true" json:"cliff"`
+	// Number of seconds each period lasts
+	PeriodDuration uint64 `serialize:"true" json:"periodDuration"`
+	// Number of periods the funds are unlocked over
+	NumPeriods uint32 `serialize:"true" json:"numPeriods"`
+	// Amount of funds vested by this schedule
+	TotalAmount uint64 `serialize:"true" json:"totalAmount"`
+}
+
+// Verify returns nil iff this schedule is well formed
+func (s *VestingSchedule) Verify() error {
+	switch {
+	case s.NumPeriods == 0:
+		return errNoVestingPeriods
+	case s.PeriodDuration == 0:
+		return errZeroPeriodDuration
+	case s.TotalAmount == 0:
+		return errNoVestingAmount
+	}
+	if _, err := safemath.Add64(s.StartTime, s.Cliff); err != nil {
+		return errVestingOverflow
+	}
+	duration, err := safemath.Mul64(s.PeriodDuration, uint64(s.NumPeriods))
+	if err != nil {
+		return errVestingOverflow
+	}
+	if _, err := safemath.Add64(s.StartTime, duration); err != nil {
+		return errVestingOverflow
+	}
+	return nil
+}
+
+// Unlocked returns the amount of funds of this schedule that are unlocked at
+// [time]. Assumes the schedule is valid.
+func (s *VestingSchedule) Unlocked(time uint64) uint64 {
+	if time < s.StartTime+s.Cliff {
+		return 0
+	}
+	elapsedPeriods := (time - s.StartTime) / s.PeriodDuration
+	if elapsedPeriods >= uint64(s.NumPeriods) {
+		return s.TotalAmount
+	}
+	// [elapsedPeriods] < [NumPeriods] so the quotient fits in a uint64
+	hi, lo := bits.Mul64(s.TotalAmount, elapsedPeriods)
+	unlocked, _ := bits.Div64(hi, lo, uint64(s.NumPeriods))
+	return unlocked
+}
+
+// Locked returns the amount of funds of this schedule that are still locked
+// at [time]. Assumes the schedule is valid.
+func (s *VestingSchedule) Locked(time uint64) uint64 {
+	return s.TotalAmount - s.Unlocked(time)
+}
+
+// VestingOutput is a TransferOutput whose funds are unlocked by a
+// VestingSchedule. The funds that are still locked when the output is spent
+// must be sent to a new VestingOutput with the same schedule and owners.
+type VestingOutput struct {
+	VestingSchedule `serialize:"true"`
+	TransferOutput  `serialize:"true"`
+}
+
+// LockedAmount returns the amount of this output that is still locked at
+// [time]. Assumes the output is valid.
+func (out *VestingOutput) LockedAmount(time uint64) uint64 {
+	locked := out.VestingSchedule.Locked(time)
+	if locked > out.Amt {
+		// Part of the funds of the schedule have already been spent
+		return out.Amt
+	}
+	return locked
+}
+
+// Equals returns true iff [other] has the same schedule and owners
+func (out *VestingOutput) Equals(other *VestingOutput) bool {
+	return out.VestingSchedule == other.VestingSchedule &&
+		out.OutputOwners.Equals(&other.OutputOwners)
+}
+
+func (out *VestingOutput) Verify() error {
+	switch {
+	case out == nil:
+		return errNilOutput
+	case out.Amt > out.TotalAmount:
+		return errAmountExceedsSchedule
+	}
+	if err := out.VestingSchedule.Verify(); err != nil {
+		return err
+	}
+	return out.TransferOutput.Verify()
+}
+
+func (out *VestingOutput) VerifyState() error { return out.Verify() }
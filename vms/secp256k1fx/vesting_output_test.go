@@ -0,0 +1,222 @@
+// (c) 2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+func TestVestingScheduleUnlocked(t *testing.T) {
+	schedule := VestingSchedule{
+		StartTime:      100,
+		Cliff:          25,
+		PeriodDuration: 10,
+		NumPeriods:     4,
+		TotalAmount:    1000,
+	}
+	if err := schedule.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		time     uint64
+		unlocked uint64
+	}{
+		{time: 0, unlocked: 0},
+		{time: 110, unlocked: 0},   // before the cliff
+		{time: 124, unlocked: 0},   // before the cliff
+		{time: 125, unlocked: 500}, // the 2 elapsed periods unlock at the cliff
+		{time: 129, unlocked: 500},
+		{time: 130, unlocked: 750},
+		{time: 140, unlocked: 1000},
+		{time: math.MaxUint64, unlocked: 1000},
+	}
+	for _, test := range tests {
+		if unlocked := schedule.Unlocked(test.time); unlocked != test.unlocked {
+			t.Fatalf("at time %d expected %d to be unlocked but got %d", test.time, test.unlocked, unlocked)
+		}
+		if locked := schedule.Locked(test.time); locked != 1000-test.unlocked {
+			t.Fatalf("at time %d expected %d to be locked but got %d", test.time, 1000-test.unlocked, locked)
+		}
+	}
+}
+
+func TestVestingScheduleUnlockedLargeAmount(t *testing.T) {
+	schedule := VestingSchedule{
+		PeriodDuration: 1,
+		NumPeriods:     3,
+		TotalAmount:    math.MaxUint64,
+	}
+	if unlocked := schedule.Unlocked(2); unlocked != math.MaxUint64/3*2 {
+		t.Fatalf("expected %d to be unlocked but got %d", uint64(math.MaxUint64/3*2), unlocked)
+	}
+}
+
+func TestVestingScheduleVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule VestingSchedule
+		err      error
+	}{
+		{
+			name: "no periods",
+			schedule: VestingSchedule{
+				PeriodDuration: 1,
+				TotalAmount:    1,
+			},
+			err: errNoVestingPeriods,
+		},
+		{
+			name: "zero period duration",
+			schedule: VestingSchedule{
+				NumPeriods:  1,
+				TotalAmount: 1,
+			},
+			err: errZeroPeriodDuration,
+		},
+		{
+			name: "no amount",
+			schedule: VestingSchedule{
+				PeriodDuration: 1,
+				NumPeriods:     1,
+			},
+			err: errNoVestingAmount,
+		},
+		{
+			name: "cliff overflow",
+			schedule: VestingSchedule{
+				StartTime:      1,
+				Cliff:          math.MaxUint64,
+				PeriodDuration: 1,
+				NumPeriods:     1,
+				TotalAmount:    1,
+			},
+			err: errVestingOverflow,
+		},
+		{
+			name: "duration overflow",
+			schedule: VestingSchedule{
+				PeriodDuration: math.MaxUint64,
+				NumPeriods:     2,
+				TotalAmount:    1,
+			},
+			err: errVestingOverflow,
+		},
+		{
+			name: "end overflow",
+			schedule: VestingSchedule{
+				StartTime:      math.MaxUint64,
+				PeriodDuration: 1,
+				NumPeriods:     1,
+				TotalAmount:    1,
+			},
+			err: errVestingOverflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.schedule.Verify(); err != test.err {
+				t.Fatalf("expected %s but got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestVestingOutputLockedAmount(t *testing.T) {
+	out := VestingOutput{
+		VestingSchedule: VestingSchedule{
+			PeriodDuration: 1,
+			NumPeriods:     4,
+			TotalAmount:    100,
+		},
+		TransferOutput: TransferOutput{
+			Amt: 60,
+			OutputOwners: OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.ShortEmpty},
+			},
+		},
+	}
+	if err := out.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	// 75 of the schedule is locked but only 60 remain in the output
+	if locked := out.LockedAmount(1); locked != 60 {
+		t.Fatalf("expected 60 to be locked but got %d", locked)
+	}
+	if locked := out.LockedAmount(2); locked != 50 {
+		t.Fatalf("expected 50 to be locked but got %d", locked)
+	}
+	if locked := out.LockedAmount(4); locked != 0 {
+		t.Fatalf("expected nothing to be locked but got %d", locked)
+	}
+}
+
+func TestVestingOutputVerify(t *testing.T) {
+	out := (*VestingOutput)(nil)
+	if err := out.Verify(); err != errNilOutput {
+		t.Fatalf("expected %s but got %v", errNilOutput, err)
+	}
+
+	out = &VestingOutput{
+		VestingSchedule: VestingSchedule{
+			PeriodDuration: 1,
+			NumPeriods:     1,
+			TotalAmount:    1,
+		},
+		TransferOutput: TransferOutput{
+			Amt: 2,
+			OutputOwners: OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.ShortEmpty},
+			},
+		},
+	}
+	if err := out.Verify(); err != errAmountExceedsSchedule {
+		t.Fatalf("expected %s but got %v", errAmountExceedsSchedule, err)
+	}
+
+	out.Amt = 1
+	out.Threshold = 2
+	if err := out.VerifyState(); err == nil {
+		t.Fatal("should have errored due to an invalid threshold")
+	}
+}
+
+func TestVestingOutputEquals(t *testing.T) {
+	out := &VestingOutput{
+		VestingSchedule: VestingSchedule{
+			PeriodDuration: 1,
+			NumPeriods:     1,
+			TotalAmount:    2,
+		},
+		TransferOutput: TransferOutput{
+			Amt: 2,
+			OutputOwners: OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.ShortEmpty},
+			},
+		},
+	}
+	other := *out
+	other.Amt = 1
+	if !out.Equals(&other) {
+		t.Fatal("outputs with the same schedule and owners should be equal")
+	}
+	other.StartTime = 1
+	if out.Equals(&other) {
+		t.Fatal("outputs with different schedules shouldn't be equal")
+	}
+}
+
+func TestVestingOutputState(t *testing.T) {
+	intf := interface{}(&VestingOutput{})
+	if _, ok := intf.(verify.State); !ok {
+		t.Fatalf("should be marked as state")
+	}
+}